@@ -61,7 +61,7 @@ if exist "!EXE_PATH!~" del /F /Q "!EXE_PATH!~" >NUL 2>&1
 
 REM Build the executable
 echo Building executable...
-go build -o "!EXE_PATH!" .
+go build -ldflags "-X ue-git-plugin-manager/internal/selfupdate.Version=!VERSION!" -o "!EXE_PATH!" .
 
 if %ERRORLEVEL% EQU 0 (
     echo.
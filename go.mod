@@ -3,13 +3,13 @@ module ue-git-plugin-manager
 go 1.21
 
 require (
+	github.com/chzyer/readline v1.5.1
 	github.com/fatih/color v1.16.0
 	github.com/manifoldco/promptui v0.9.0
+	golang.org/x/sys v0.14.0
 )
 
 require (
-	github.com/chzyer/readline v1.5.1 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.14.0 // indirect
 )
@@ -0,0 +1,194 @@
+package backup
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+)
+
+// CurrentVersion is the backup format version this tool writes
+const CurrentVersion = 1
+
+// Entry names inside a backup archive
+const (
+	MetadataEntry = "backup.json"
+	ConfigEntry   = "config.json"
+	BundleEntry   = "origin.bundle"
+	EnginesDir    = "engines" // engines/<worktree subdir>/... holds each worktree's built plugin files
+	DataDir       = "data"    // data/... holds files kept in the data directory, such as local patches
+)
+
+// Metadata describes what a backup holds
+type Metadata struct {
+	Version      int             `json:"version"`
+	Machine      string          `json:"machine"`
+	CreatedUTC   string          `json:"created_utc"`
+	OriginBranch string          `json:"origin_branch"` // Branch checked out in the origin repository
+	Engines      []config.Engine `json:"engines"`
+}
+
+// Writer adds files to a backup archive
+type Writer struct {
+	file *os.File
+	zip  *zip.Writer
+}
+
+// Create starts a backup archive at path
+func Create(path string) (*Writer, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{file: file, zip: zip.NewWriter(file)}, nil
+}
+
+// AddJSON stores a value as an indented JSON entry
+func (w *Writer) AddJSON(name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	dst, err := w.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = dst.Write(data)
+	return err
+}
+
+// AddFile stores the file at src under name
+func (w *Writer) AddFile(name, src string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dst, err := w.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, file); err != nil {
+		return fmt.Errorf("failed to add %s: %v", src, err)
+	}
+	return nil
+}
+
+// AddDir stores every file below src under the prefix name
+func (w *Writer) AddDir(name, src string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		return w.AddFile(path.Join(name, filepath.ToSlash(rel)), p)
+	})
+}
+
+// Close finishes the archive
+func (w *Writer) Close() error {
+	if err := w.zip.Close(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// Extract unpacks a backup archive into dir and returns its metadata
+func Extract(archivePath, dir string) (Metadata, error) {
+	var meta Metadata
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return meta, fmt.Errorf("failed to open %s: %v", archivePath, err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		target := filepath.Join(dir, filepath.FromSlash(file.Name))
+		// Entries must stay inside dir, whatever the archive says
+		if rel, err := filepath.Rel(dir, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return meta, fmt.Errorf("%s contains an invalid entry: %s", archivePath, file.Name)
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if err := extractFile(file, target); err != nil {
+			return meta, fmt.Errorf("failed to extract %s: %v", file.Name, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, MetadataEntry))
+	if err != nil {
+		return meta, fmt.Errorf("%s is not a backup made by this tool", archivePath)
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("failed to parse %s: %w", MetadataEntry, err)
+	}
+	if meta.Version > CurrentVersion {
+		return meta, fmt.Errorf("%s was written by a newer version of this tool (backup version %d)", archivePath, meta.Version)
+	}
+	return meta, nil
+}
+
+// extractFile writes one archive entry to target
+func extractFile(file *zip.File, target string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	dst, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// CopyEntry copies the extracted entries below name in dir into dst, and does nothing when the
+// backup has none
+func CopyEntry(dir, name, dst string) error {
+	src := filepath.Join(dir, filepath.FromSlash(name))
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+}
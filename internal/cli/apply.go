@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/menu"
+)
+
+// runApply reproduces the setup recorded in a machine manifest
+func runApply(app Application, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: apply <manifest.json>")
+		return ExitError
+	}
+	if err := menu.ApplyManifest(app, args[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
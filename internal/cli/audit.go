@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/plugin"
+)
+
+// auditedEngine is one engine in the JSON output of the audit command
+type auditedEngine struct {
+	Version    string                 `json:"version"`
+	Path       string                 `json:"path"`
+	Plugins    []plugin.AuditedPlugin `json:"plugins"`
+	Unapproved []string               `json:"unapproved,omitempty"`
+}
+
+// runAudit lists the non-stock plugins in every detected engine and, with --check, exits with
+// ExitBroken when any of them is missing from the approved list
+func runAudit(app Application, args []string) int {
+	flags := flag.NewFlagSet("audit", flag.ContinueOnError)
+	check := flags.Bool("check", false, "exit with a non-zero code when an engine has a plugin that isn't approved")
+	jsonOutput := flags.Bool("json", false, "print the audit as JSON")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+
+	cfg, err := loadConfig(app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	engines, err := app.GetEngine().DiscoverEngines(cfg.CustomEngineRoots)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to discover engines: %v\n", err)
+		return ExitError
+	}
+
+	var audited []auditedEngine
+	unapproved := 0
+	for _, eng := range engines {
+		entry := auditedEngine{Version: eng.Version, Path: eng.Path, Plugins: app.GetPlugin().AuditEnginePlugins(eng.Path)}
+		for _, p := range entry.Plugins {
+			if !cfg.PluginApproved(p.Name) {
+				entry.Unapproved = append(entry.Unapproved, p.Name)
+			}
+		}
+		unapproved += len(entry.Unapproved)
+		audited = append(audited, entry)
+	}
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(audited, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, eng := range audited {
+			fmt.Printf("UE %s (%s)\n", eng.Version, eng.Path)
+			if len(eng.Plugins) == 0 {
+				fmt.Println("  No non-stock plugins")
+			}
+			for _, p := range eng.Plugins {
+				marker := " "
+				if !cfg.PluginApproved(p.Name) {
+					marker = "!"
+				}
+				fmt.Printf(" %s %s %s — %s, %s\n", marker, p.Name, p.Version, p.Source, p.Dir)
+			}
+		}
+		if unapproved > 0 {
+			fmt.Printf("\n%d plugin(s) not on the approved list (marked !)\n", unapproved)
+		}
+	}
+
+	if *check && unapproved > 0 {
+		return ExitBroken
+	}
+	return ExitOK
+}
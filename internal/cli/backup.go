@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"ue-git-plugin-manager/internal/menu"
+)
+
+// runBackup writes the managed state to an archive for restoring after a reimage
+func runBackup(app Application, args []string) int {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	out := flags.String("out", defaultBackupName(), "archive to write")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if err := menu.CreateBackup(app, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+// runRestore reconstructs the managed state from a backup archive
+func runRestore(app Application, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: restore <backup.zip>")
+		return ExitError
+	}
+	if err := menu.RestoreBackup(app, args[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+// defaultBackupName names a backup after the machine and the day it was made
+func defaultBackupName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("uegpm-backup-%s-%s.zip", host, time.Now().Format("2006-01-02"))
+}
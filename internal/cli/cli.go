@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/menu"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// Exit codes returned by subcommands
+const (
+	ExitOK               = 0
+	ExitBroken           = 1
+	ExitUpdatesAvailable = 2
+	ExitError            = 3
+)
+
+// Application interface for dependency injection
+type Application interface {
+	GetConfig() *config.Manager
+	GetGit() *git.Manager
+	GetEngine() *engine.Manager
+	GetPlugin() *plugin.Manager
+	GetUtils() *utils.Manager
+	GetDetection() *detection.Detector
+}
+
+// readOnly refuses commands that change the machine
+var readOnly bool
+
+// SetReadOnly turns the viewer mode on or off
+func SetReadOnly(enabled bool) {
+	readOnly = enabled
+}
+
+// mutatingCommands are the subcommands that change the machine, refused in viewer mode
+var mutatingCommands = map[string]bool{
+	"context-menu": true, "setup": true, "repair": true, "update": true,
+	"restore": true, "repoint": true, "apply": true, "uninstall": true,
+}
+
+// requireWritable refuses commands that change the machine in viewer mode, set with --viewer
+// or in the configuration. A configuration that can't be read may have it on, so it refuses then too.
+func requireWritable(app Application) error {
+	if readOnly {
+		return fmt.Errorf("viewer mode is on")
+	}
+	cfg, err := loadConfig(app)
+	if err != nil {
+		return fmt.Errorf("viewer mode can't be ruled out: %v", err)
+	}
+	if cfg.ViewerMode {
+		return fmt.Errorf("viewer mode is on")
+	}
+	return nil
+}
+
+// Run executes a subcommand and returns the process exit code
+func Run(app Application, args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return ExitError
+	}
+	if mutatingCommands[args[0]] {
+		if err := requireWritable(app); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s is not available: %v\n", args[0], err)
+			return ExitError
+		}
+	}
+
+	switch args[0] {
+	case "status":
+		return runStatus(app, args[1:])
+	case "ping":
+		return runPing(app, args[1:])
+	case "metrics":
+		return runMetrics(app, args[1:])
+	case "report":
+		return runReport(app, args[1:])
+	case "compare":
+		return runCompare(app, args[1:])
+	case "audit":
+		return runAudit(app, args[1:])
+	case "doctor":
+		return runDoctor(app, args[1:])
+	case "serve":
+		return runServe(app, args[1:])
+	case "context-menu":
+		return runContextMenu(args[1:])
+	case "setup":
+		return runSetup(app, args[1:])
+	case "repair":
+		return runRepair(app, args[1:])
+	case "update":
+		return runUpdate(app, args[1:])
+	case "backup":
+		return runBackup(app, args[1:])
+	case "restore":
+		return runRestore(app, args[1:])
+	case "repoint":
+		if err := menu.RepointJunctions(app); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitBroken
+		}
+		return ExitOK
+	case "apply":
+		return runApply(app, args[1:])
+	case "uninstall":
+		return runUninstall(app, args[1:])
+	case "selfupdate":
+		return runSelfUpdate(app, args[1:])
+	case "help", "-h", "--help", "/?":
+		printUsage()
+		return ExitOK
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", args[0])
+		printUsage()
+		return ExitError
+	}
+}
+
+// IsCommand reports whether an argument names a subcommand rather than a path
+func IsCommand(arg string) bool {
+	switch arg {
+	case "status", "ping", "metrics", "report", "compare", "audit", "doctor", "serve", "setup", "repair", "update", "backup", "restore", "repoint", "context-menu", "apply", "uninstall", "selfupdate", "help", "-h", "--help", "/?":
+		return true
+	}
+	return false
+}
+
+// printUsage prints the list of available subcommands
+func printUsage() {
+	name := filepath.Base(os.Args[0])
+	fmt.Printf("Usage: %s [command] [flags]\n", name)
+	fmt.Println()
+	fmt.Println("Run without a command to open the interactive menu, or pass a project or")
+	fmt.Println("engine folder (or drop one onto the exe) to open its setup directly.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  status     Show the setup status of every detected engine")
+	fmt.Println("             --check  exit 0 if all managed engines are set up and up to date,")
+	fmt.Println("                      1 if any is broken, 2 if updates are available")
+	fmt.Println("             --fetch  fetch from the remote before checking for updates")
+	fmt.Println("             --json [--out <file>]  write this machine's status as JSON")
+	fmt.Println("             --engine <versions|folders>  only these engines, as with --check;")
+	fmt.Println("                      one that was never set up counts as broken")
+	fmt.Println("  ping       Report one engine's plugin health from the last status check, for launcher scripts")
+	fmt.Println("             --engine <version|folder>  exit 0 if healthy, 1 if broken, 3 if unknown")
+	fmt.Println("             --max-age <duration>       treat an older check (e.g. 24h) as unknown")
+	fmt.Println("  metrics    Export status metrics in the Prometheus text format")
+	fmt.Println("             --textfile <path>  write to a node_exporter textfile collector file")
+	fmt.Println("             --push <url>       push to a Prometheus Pushgateway")
+	fmt.Println("  report     Summarize machine status files collected from several workstations")
+	fmt.Println("             --input <folder> [--format html|markdown] [--out <file>]")
+	fmt.Println("  compare    Report machines that differ from an approved machine manifest")
+	fmt.Println("             --golden <manifest.json> [--input <folder>] [--out <file>]")
+	fmt.Println("             exits 1 when any machine has a missing, broken or drifted engine")
+	fmt.Println("  audit      List non-stock plugins in every detected engine's Plugins folder")
+	fmt.Println("             --check  exit 1 if any plugin is missing from the approved list")
+	fmt.Println("             --json   print the audit as JSON")
+	fmt.Println("  doctor     Check Git, Git LFS, long paths and, per engine, .NET, the C++ toolchain and")
+	fmt.Println("             write access, printing how to fix what is missing; exits 1 if a check fails")
+	fmt.Println("             --engine <versions|folders>  only these engines  --json  print the checks as JSON")
+	fmt.Println("  serve      Serve a read-only status page with engines, commits behind and recent operations")
+	fmt.Println("             --dashboard  required; the page is at http://127.0.0.1:8765/ by default")
+	fmt.Println("             --addr <host:port>  e.g. :8765 to reach it from other machines")
+	fmt.Println("             --refresh <duration>  how often status is collected (default 1m)")
+	fmt.Println("             --fetch  fetch before each collection so commits behind stay current")
+	fmt.Println("  setup      Set up engines without the menus")
+	fmt.Println("             --engine <versions|folders>  e.g. --engine 5.3,5.4 or a folder for a version")
+	fmt.Println("                                          installed more than once")
+	fmt.Println("             --all    every detected engine that was never set up")
+	fmt.Println("  repair     Repair broken engines without the menus")
+	fmt.Println("             --engine <versions|folders> | --all (every broken engine)")
+	fmt.Println("  update     Update and rebuild every managed engine without prompts (for Task Scheduler)")
+	fmt.Println("             --scheduled  only run inside maintenance_window from config.json")
+	fmt.Println("             --engine <versions|folders>  only update these engines")
+	fmt.Println("             never rebuilds while an Unreal editor is running; exits 2 when deferred")
+	fmt.Println("  backup     Save the configuration, plugin repository and built plugins to one archive")
+	fmt.Println("             --out <file>  defaults to uegpm-backup-<machine>-<date>.zip")
+	fmt.Println("  restore    Rebuild worktrees and plugin links from a backup on a reimaged machine")
+	fmt.Println("             <backup.zip>  the data directory must not have a plugin repository yet")
+	fmt.Println("  repoint    Point every managed engine's plugin link at its worktree in the current")
+	fmt.Println("             data directory, after moving it or restoring it from a backup")
+	fmt.Println("  apply      Reproduce the setup recorded in a machine manifest")
+	fmt.Println("             <manifest.json>  exported from Settings → \"Export Machine Manifest\"")
+	fmt.Println("  context-menu install|uninstall")
+	fmt.Println("             Add or remove \"Configure Unreal project for Git\" on folder right-click")
+	fmt.Println("  uninstall  Remove plugin links, worktrees, settings, scheduled tasks and the context menu entry")
+	fmt.Println("             --machine  also remove the shared data directory in ProgramData")
+	fmt.Println("             --silent   don't ask for confirmation (for SCCM / Intune)")
+	fmt.Println("             --log <file>  defaults to %ProgramData%\\" + uninstallLogName)
+	fmt.Println("             --engine <versions|folders> | --all  only remove these engines' setups and")
+	fmt.Println("                      keep the tool's data; combine with --silent or --yes for scripts")
+	fmt.Println("  selfupdate Replace this exe with the latest release once its checksum is verified")
+	fmt.Println("             --check  only report whether a newer version exists; exits 2 when one does")
+	fmt.Println("  help       Show this help")
+	fmt.Println()
+	fmt.Println("Add --viewer to open a read-only menu that only shows status and diagnostics.")
+	fmt.Println("Add --json-events to write progress to stderr as JSON lines instead of printing it.")
+	fmt.Println("Add --verbose to print every diagnostic step; they are always in the log file.")
+	fmt.Println("Add --quiet to only print warnings and errors; --verbose is ignored with it.")
+	fmt.Println("Add --yes to answer every confirmation with yes, including the destructive ones.")
+	fmt.Println("Add --plain for numbered prompts a screen reader can follow instead of arrow-key lists.")
+}
+
+// loadConfig loads the configuration, falling back to defaults when none exists yet
+func loadConfig(app Application) (*config.Config, error) {
+	configMgr := app.GetConfig()
+	if !configMgr.Exists() {
+		return configMgr.CreateDefault(), nil
+	}
+	cfg, err := configMgr.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %v", err)
+	}
+	if cfg.VerboseOutput {
+		events.SetVerbose(true)
+	}
+	utils.SetConfirmPolicy(cfg.Confirmations)
+	utils.SetPromptDefaults(cfg.PromptDefaults)
+	plugin.SetLinkNames(cfg.LinkNames())
+	runner.SetTimeout(runner.KindGit, cfg.GitTimeout())
+	runner.SetTimeout(runner.KindBuild, cfg.BuildTimeout())
+	return cfg, nil
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"ue-git-plugin-manager/internal/manifest"
+	"ue-git-plugin-manager/internal/report"
+)
+
+// runCompare reports how machines differ from a golden manifest and exits with ExitBroken on any drift
+func runCompare(app Application, args []string) int {
+	flags := flag.NewFlagSet("compare", flag.ContinueOnError)
+	golden := flags.String("golden", "", "approved machine manifest written by \"Export Machine Manifest\"")
+	input := flags.String("input", "", "folder of machine status files written by 'status --json --out'; this machine when empty")
+	out := flags.String("out", "", "write the drift report to this file instead of the console")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if *golden == "" {
+		fmt.Fprintln(os.Stderr, "Error: --golden is required")
+		return ExitError
+	}
+
+	approved, err := manifest.Load(*golden)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	var machines []report.MachineStatus
+	if *input != "" {
+		machines, err = report.LoadDir(*input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to read machine status files: %v\n", err)
+			return ExitError
+		}
+	} else {
+		cfg, err := loadConfig(app)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		statuses, err := app.GetDetection().DetectSetupStatus(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		machines = []report.MachineStatus{collectMachineStatus(app, cfg, statuses)}
+	}
+
+	drifts := report.CompareToGolden(approved, machines)
+	rendered := report.RenderDriftMarkdown(approved, machines, drifts, time.Now())
+	if *out == "" {
+		fmt.Print(rendered)
+	} else if err := os.WriteFile(*out, []byte(rendered), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write %s: %v\n", *out, err)
+		return ExitError
+	} else {
+		fmt.Printf("Drift report for %d machine(s) written to %s\n", len(machines), *out)
+	}
+
+	if len(drifts) > 0 {
+		return ExitBroken
+	}
+	return ExitOK
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/explorer"
+)
+
+// runContextMenu installs or removes the Explorer folder context menu entry
+func runContextMenu(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: context-menu install|uninstall")
+		return ExitError
+	}
+
+	switch args[0] {
+	case "install":
+		exePath, err := os.Executable()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		if err := explorer.Install(exePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		fmt.Printf("Installed \"%s\" for %s\n", explorer.MenuLabel, exePath)
+	case "uninstall":
+		if err := explorer.Uninstall(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		fmt.Println("Context menu entry removed")
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown context-menu action: %s\n", args[0])
+		return ExitError
+	}
+	return ExitOK
+}
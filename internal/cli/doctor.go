@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/doctor"
+	"ue-git-plugin-manager/internal/engine"
+)
+
+// doctorLabels are the markers printed before each check
+var doctorLabels = map[string]string{
+	doctor.StatusOK:   "OK  ",
+	doctor.StatusWarn: "WARN",
+	doctor.StatusFail: "FAIL",
+}
+
+// runDoctor checks everything setting up and building needs, on the machine and for each
+// detected engine, and prints how to fix what is missing. It exits with ExitBroken when a check
+// failed, so a provisioning script can stop before a setup fails half way.
+func runDoctor(app Application, args []string) int {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	engines := addEngineFlags(flags, "every detected engine (the default)")
+	jsonOutput := flags.Bool("json", false, "print the checks as JSON")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+
+	// Only the engines' folders are needed, so their setups aren't detected
+	cfg, err := loadConfig(app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	discovered, err := app.GetEngine().DiscoverEngines(cfg.CustomEngineRoots)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	var statuses []detection.SetupStatus
+	for _, eng := range discovered {
+		statuses = append(statuses, detection.SetupStatus{EngineVersion: eng.Version, EnginePath: eng.Path})
+	}
+	picked := statuses
+	if engines.given() {
+		if picked, err = engines.pick(statuses, func(detection.SetupStatus) bool { return true }); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+	}
+
+	sections := []doctor.Section{doctor.Machine(app.GetGit())}
+	for _, status := range picked {
+		eng := engine.EngineInfo{Version: status.EngineVersion, Path: status.EnginePath}
+		sections = append(sections, doctor.Engine(app.GetEngine(), app.GetPlugin(), eng))
+	}
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(sections, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		fmt.Println(string(data))
+	} else {
+		for i, section := range sections {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(section.Title)
+			for _, check := range section.Checks {
+				fmt.Printf("  %s %s: %s\n", doctorLabels[check.Status], check.Name, check.Detail)
+				if check.Fix != "" {
+					fmt.Printf("       Fix: %s\n", check.Fix)
+				}
+			}
+		}
+		if len(picked) == 0 {
+			fmt.Println()
+			fmt.Println("No engines were detected; add their folders in Settings → \"Manage Custom Engine Paths\".")
+		}
+	}
+
+	if doctor.Failed(sections) {
+		return ExitBroken
+	}
+	return ExitOK
+}
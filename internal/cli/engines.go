@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/issues"
+	"ue-git-plugin-manager/internal/menu"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// engineFlags are the --engine and --all flags of the commands that act on chosen engines
+type engineFlags struct {
+	engines *string
+	all     *bool
+}
+
+// addEngineFlags registers --engine and --all; allHelp says which engines --all picks
+func addEngineFlags(flags *flag.FlagSet, allHelp string) engineFlags {
+	return engineFlags{
+		engines: flags.String("engine", "", "engine versions (e.g. 5.3) or folders, separated by commas"),
+		all:     flags.Bool("all", false, allHelp),
+	}
+}
+
+// given reports whether either flag was passed
+func (f engineFlags) given() bool {
+	return *f.engines != "" || *f.all
+}
+
+// pick returns the detected engines named by --engine or, with --all, those for which all
+// returns true. A version installed more than once has to be named by its folder.
+func (f engineFlags) pick(statuses []detection.SetupStatus, all func(detection.SetupStatus) bool) ([]detection.SetupStatus, error) {
+	if *f.all && *f.engines != "" {
+		return nil, fmt.Errorf("pass either --engine or --all, not both")
+	}
+	if *f.all {
+		var picked []detection.SetupStatus
+		for _, status := range statuses {
+			if all(status) {
+				picked = append(picked, status)
+			}
+		}
+		return picked, nil
+	}
+	if *f.engines == "" {
+		return nil, fmt.Errorf("pass --engine <version|folder> or --all")
+	}
+
+	var picked []detection.SetupStatus
+	for _, name := range strings.Split(*f.engines, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		var matches []detection.SetupStatus
+		for _, status := range statuses {
+			if status.EngineVersion == name || utils.SamePath(status.EnginePath, name) {
+				matches = append(matches, status)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("UE %s was not found; check \"status\" for the detected engines", name)
+		case 1:
+			picked = append(picked, matches[0])
+		default:
+			paths := make([]string, len(matches))
+			for i, match := range matches {
+				paths[i] = match.EnginePath
+			}
+			return nil, fmt.Errorf("UE %s is installed more than once; pass one of its folders: %s", name, strings.Join(paths, ", "))
+		}
+	}
+	return picked, nil
+}
+
+// detectForCommand loads the configuration and detects every engine's setup
+func detectForCommand(app Application) (*config.Config, []detection.SetupStatus, error) {
+	cfg, err := loadConfig(app)
+	if err != nil {
+		return nil, nil, err
+	}
+	statuses, err := app.GetDetection().DetectSetupStatus(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, statuses, nil
+}
+
+// runSetup sets up the chosen engines without the menus, for provisioning scripts
+func runSetup(app Application, args []string) int {
+	flags := flag.NewFlagSet("setup", flag.ContinueOnError)
+	engines := addEngineFlags(flags, "set up every detected engine that was never set up")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	cfg, statuses, err := detectForCommand(app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	picked, err := engines.pick(statuses, func(status detection.SetupStatus) bool { return status.IsNeverSetUp })
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	failed := 0
+	var toSetUp []detection.SetupStatus
+	for _, status := range picked {
+		switch {
+		case status.IsSetupComplete:
+			events.Printf("✅ UE %s (%s) is already set up\n", status.EngineVersion, status.EnginePath)
+			continue
+		case status.IsBroken:
+			fmt.Printf("❌ UE %s (%s) was set up before and is broken; use \"repair\"\n", status.EngineVersion, status.EnginePath)
+			failed++
+			continue
+		}
+		toSetUp = append(toSetUp, status)
+	}
+	// The engines' plugins are built side by side, up to the configured number at a time
+	for i, err := range menu.SetupEngines(app, cfg, toSetUp) {
+		if err != nil {
+			fmt.Printf("❌ UE %s: %v\n", toSetUp[i].EngineVersion, err)
+			printIssueCode(cfg, err)
+			failed++
+		}
+	}
+	return engineResult(picked, failed, "set up")
+}
+
+// runRepair repairs the chosen engines without the menus
+func runRepair(app Application, args []string) int {
+	flags := flag.NewFlagSet("repair", flag.ContinueOnError)
+	engines := addEngineFlags(flags, "repair every broken engine")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	cfg, statuses, err := detectForCommand(app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	picked, err := engines.pick(statuses, func(status detection.SetupStatus) bool { return status.IsBroken })
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	failed := 0
+	for _, status := range picked {
+		switch {
+		case status.IsNeverSetUp:
+			fmt.Printf("❌ UE %s (%s) was never set up; use \"setup\"\n", status.EngineVersion, status.EnginePath)
+			failed++
+			continue
+		case status.IsSetupComplete:
+			events.Printf("✅ UE %s (%s) needs no repair\n", status.EngineVersion, status.EnginePath)
+			continue
+		}
+		if err := menu.RepairEngine(app, cfg, status.EnginePath, status.EngineVersion, status.WorktreeSubdir); err != nil {
+			fmt.Printf("❌ UE %s: %v\n", status.EngineVersion, err)
+			printIssueCode(cfg, err)
+			failed++
+		}
+	}
+	return engineResult(picked, failed, "repaired")
+}
+
+// uninstallSelected removes the setup of the chosen engines only, leaving the tool installed
+func uninstallSelected(app Application, engines engineFlags, silent bool) int {
+	cfg, statuses, err := detectForCommand(app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	picked, err := engines.pick(statuses, func(status detection.SetupStatus) bool { return isManaged(cfg, status) })
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	failed := 0
+	for _, status := range picked {
+		if !isManaged(cfg, status) {
+			events.Printf("✅ UE %s (%s) is not set up\n", status.EngineVersion, status.EnginePath)
+			continue
+		}
+		if !silent && !utils.ConfirmDestructive(fmt.Sprintf("Remove the plugin setup of UE %s (%s)?", status.EngineVersion, status.EnginePath)) {
+			continue
+		}
+		if err := menu.UninstallEngine(app, cfg, status.EnginePath, status.EngineVersion, status.WorktreeSubdir); err != nil {
+			fmt.Printf("❌ UE %s: %v\n", status.EngineVersion, err)
+			failed++
+		}
+	}
+	return engineResult(picked, failed, "uninstalled")
+}
+
+// printIssueCode prints the error code of a failure and its documentation link
+func printIssueCode(cfg *config.Config, err error) {
+	if line := issues.Describe(cfg.SupportDocsURL, err); line != "" {
+		fmt.Println(line)
+	}
+}
+
+// engineResult reports the outcome of a command over several engines as its exit code
+func engineResult(picked []detection.SetupStatus, failed int, done string) int {
+	if len(picked) == 0 {
+		events.Println("No engines to act on.")
+		return ExitOK
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d of %d engine(s) could not be %s\n", failed, len(picked), done)
+		return ExitError
+	}
+	return ExitOK
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/metrics"
+)
+
+// runMetrics collects status metrics and writes them to a textfile, a pushgateway or stdout
+func runMetrics(app Application, args []string) int {
+	flags := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	textfile := flags.String("textfile", "", "write metrics to this .prom file for the node_exporter textfile collector")
+	pushURL := flags.String("push", "", "push metrics to this Prometheus Pushgateway URL")
+	job := flags.String("job", "ue_git_plugin_manager", "job name used when pushing")
+	fetch := flags.Bool("fetch", false, "fetch from the remote before counting commits behind")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+
+	cfg, err := loadConfig(app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	if *textfile == "" {
+		*textfile = cfg.MetricsTextfilePath
+	}
+	if *pushURL == "" {
+		*pushURL = cfg.MetricsPushgatewayURL
+	}
+
+	if *fetch && app.GetGit().IsOriginCloned() {
+		if err := app.GetGit().FetchAll(cfg.MirrorURL); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch updates: %v\n", err)
+		}
+	}
+
+	statuses, err := app.GetDetection().DetectSetupStatus(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	snapshot := collectMetrics(app, cfg, statuses)
+
+	if *textfile == "" && *pushURL == "" {
+		fmt.Print(snapshot.Render())
+		return ExitOK
+	}
+
+	exitCode := ExitOK
+	if *textfile != "" {
+		if err := metrics.WriteTextfile(*textfile, snapshot); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write metrics to %s: %v\n", *textfile, err)
+			exitCode = ExitError
+		} else {
+			fmt.Printf("Metrics written to %s\n", *textfile)
+		}
+	}
+	if *pushURL != "" {
+		if err := metrics.Push(*pushURL, *job, snapshot); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to push metrics to %s: %v\n", *pushURL, err)
+			exitCode = ExitError
+		} else {
+			fmt.Printf("Metrics pushed to %s\n", *pushURL)
+		}
+	}
+	return exitCode
+}
+
+// collectMetrics builds a metrics snapshot from the detected status of managed engines
+func collectMetrics(app Application, cfg *config.Config, statuses []detection.SetupStatus) *metrics.Snapshot {
+	snapshot := &metrics.Snapshot{CollectedAt: time.Now()}
+	for _, status := range statuses {
+		if !isManaged(cfg, status) {
+			continue
+		}
+
+		em := metrics.EngineMetrics{
+			Version:       status.EngineVersion,
+			Path:          status.EnginePath,
+			SetupComplete: status.IsSetupComplete,
+		}
+		if status.WorktreeExists {
+			if updateInfo, err := app.GetGit().GetUpdateInfo(status.WorktreeSubdir, cfg.TrackedBranch(status.WorktreeSubdir), cfg.TrackedPin(status.WorktreeSubdir)); err == nil {
+				em.CommitsBehind = updateInfo.CommitsAhead
+			}
+		}
+		if eng := app.GetConfig().GetEngineByPath(cfg, status.EnginePath); eng != nil && eng.LastUpdatedUTC != "" {
+			if t, err := time.Parse(time.RFC3339, eng.LastUpdatedUTC); err == nil {
+				em.LastUpdated = t
+			}
+		}
+		snapshot.Engines = append(snapshot.Engines, em)
+	}
+	return snapshot
+}
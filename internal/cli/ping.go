@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// runPing reports an engine's plugin health from the status cache of the last full check, plus a
+// look at the plugin DLL, without running git. It is meant for launcher scripts that gate editor
+// startup and can't wait for a full status check. The config is loaded for the engines' plugin
+// link names.
+func runPing(app Application, args []string) int {
+	flags := flag.NewFlagSet("ping", flag.ContinueOnError)
+	engineArg := flags.String("engine", "", "engine version (e.g. 5.4) or engine folder")
+	maxAge := flags.Duration("max-age", 0, "treat a cached status older than this (e.g. 24h) as unknown")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if *engineArg == "" {
+		fmt.Fprintln(os.Stderr, "Error: --engine is required")
+		return ExitError
+	}
+
+	if _, err := loadConfig(app); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	cache, err := detection.ReadStatusCache(app.GetConfig().GetBaseDir())
+	if err != nil {
+		fmt.Println("UNKNOWN  no cached status; run the tool or \"status\" once")
+		return ExitError
+	}
+	age := time.Since(cache.CheckedUTC)
+	if *maxAge > 0 && age > *maxAge {
+		fmt.Printf("UNKNOWN  cached status is %s old\n", age.Round(time.Minute))
+		return ExitError
+	}
+
+	var matches []detection.CachedStatus
+	setUp := false
+	for _, entry := range cache.Engines {
+		if entry.EngineVersion == *engineArg || utils.SamePath(entry.EnginePath, *engineArg) {
+			matches = append(matches, entry)
+			setUp = setUp || !entry.IsNeverSetUp
+		}
+	}
+	if len(matches) == 0 {
+		fmt.Printf("UNKNOWN  UE %s was not found by the last check\n", *engineArg)
+		return ExitError
+	}
+
+	exitCode := ExitOK
+	for _, entry := range matches {
+		// Other installs of the same version that were never set up don't matter to the launcher
+		if setUp && entry.IsNeverSetUp {
+			continue
+		}
+		dll := filepath.Join(app.GetPlugin().GetPluginLinkPath(entry.EnginePath), "Binaries", "Win64", "UnrealEditor-GitSourceControl.dll")
+		switch {
+		case !entry.IsSetupComplete:
+			fmt.Printf("BROKEN   UE %s (%s): %s\n", entry.EngineVersion, entry.EnginePath, strings.Join(entry.Issues, "; "))
+			exitCode = ExitBroken
+		case !fileExists(dll):
+			fmt.Printf("BROKEN   UE %s (%s): plugin binaries missing since the last check\n", entry.EngineVersion, entry.EnginePath)
+			exitCode = ExitBroken
+		default:
+			fmt.Printf("OK       UE %s (%s), checked %s ago\n", entry.EngineVersion, entry.EnginePath, age.Round(time.Minute))
+		}
+	}
+	return exitCode
+}
+
+// fileExists reports whether a file can be found, following links
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ue-git-plugin-manager/internal/report"
+)
+
+// runReport renders collected machine status files into an HTML or Markdown summary
+func runReport(app Application, args []string) int {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	input := flags.String("input", "", "folder containing machine status files written by 'status --json --out'")
+	format := flags.String("format", "", "html or markdown (default: from the --out extension, else markdown)")
+	out := flags.String("out", "", "write the report to this file instead of the console")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+
+	if *input == "" {
+		fmt.Fprintln(os.Stderr, "Error: --input is required")
+		return ExitError
+	}
+
+	if *format == "" {
+		*format = "markdown"
+		if ext := filepath.Ext(*out); ext == ".html" || ext == ".htm" {
+			*format = "html"
+		}
+	}
+
+	machines, err := report.LoadDir(*input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read machine status files: %v\n", err)
+		return ExitError
+	}
+
+	now := time.Now()
+	var rendered string
+	switch *format {
+	case "html":
+		rendered, err = report.RenderHTML(machines, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to render report: %v\n", err)
+			return ExitError
+		}
+	case "markdown", "md":
+		rendered = report.RenderMarkdown(machines, now)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use html or markdown)\n", *format)
+		return ExitError
+	}
+
+	if *out == "" {
+		fmt.Print(rendered)
+		return ExitOK
+	}
+	if err := os.WriteFile(*out, []byte(rendered), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write %s: %v\n", *out, err)
+		return ExitError
+	}
+	fmt.Printf("Report for %d machine(s) written to %s\n", len(machines), *out)
+	return ExitOK
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/selfupdate"
+)
+
+// runSelfUpdate replaces the exe with the latest release's once its checksum is verified. With
+// --check it only reports whether a newer version exists, exiting 2 when one does, like update.
+func runSelfUpdate(app Application, args []string) int {
+	flags := flag.NewFlagSet("selfupdate", flag.ContinueOnError)
+	check := flags.Bool("check", false, "only report whether a newer version is available")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if !*check {
+		if cfg, err := loadConfig(app); readOnly || (err == nil && cfg.ViewerMode) {
+			fmt.Fprintln(os.Stderr, "Error: selfupdate is not available in viewer mode; use --check")
+			return ExitError
+		}
+	}
+
+	if selfupdate.Version == "dev" {
+		fmt.Println("This is a development build; it isn't updated from releases.")
+		return ExitOK
+	}
+	release, err := selfupdate.Latest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to check for tool updates: %v\n", err)
+		return ExitError
+	}
+	if !release.IsNewer() {
+		fmt.Printf("Up to date (%s)\n", selfupdate.Version)
+		return ExitOK
+	}
+	fmt.Printf("Version %s is available (this is %s): %s\n", release.Version, selfupdate.Version, release.PageURL)
+	if *check {
+		return ExitUpdatesAvailable
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	if err := selfupdate.CanApply(exePath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	if err := release.Apply(exePath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	fmt.Printf("Updated to %s; it is used from the next start.\n", release.Version)
+	return ExitOK
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"ue-git-plugin-manager/internal/dashboard"
+)
+
+// recentOperations is how many finished steps the dashboard lists
+const recentOperations = 25
+
+// runServe serves a read-only status dashboard over HTTP, for build machines reached over RDP or SSH
+func runServe(app Application, args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	showDashboard := flags.Bool("dashboard", false, "serve the status dashboard")
+	addr := flags.String("addr", "127.0.0.1:8765", "address to listen on; use :8765 to allow other machines")
+	refresh := flags.Duration("refresh", time.Minute, "how often the status is collected again and the page reloads")
+	fetch := flags.Bool("fetch", false, "fetch from the remote before each collection, to keep commits behind current")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if !*showDashboard {
+		fmt.Fprintln(os.Stderr, "Error: nothing to serve; pass --dashboard")
+		return ExitError
+	}
+	if *refresh < 10*time.Second {
+		*refresh = 10 * time.Second
+	}
+
+	// Fetching can take minutes, so it runs on its own schedule instead of while a page waits
+	if *fetch {
+		go fetchPeriodically(app, *refresh)
+	}
+
+	collect := func() (dashboard.Data, error) {
+		cfg, err := loadConfig(app)
+		if err != nil {
+			return dashboard.Data{}, err
+		}
+		statuses, err := app.GetDetection().ReadSetupStatus(cfg)
+		if err != nil {
+			return dashboard.Data{}, err
+		}
+		return dashboard.Data{
+			Machine:    collectMachineStatus(app, cfg, statuses),
+			Operations: dashboard.ReadOperations(app.GetConfig().GetLogFile(), recentOperations),
+		}, nil
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	if host, _, err := net.SplitHostPort(listener.Addr().String()); err == nil && !net.ParseIP(host).IsLoopback() {
+		fmt.Println("⚠️  The dashboard has no login; anyone who can reach this port can see engine paths and status.")
+	}
+	fmt.Printf("Dashboard at http://%s/ (Ctrl+C to stop)\n", displayAddr(listener.Addr()))
+
+	if err := http.Serve(listener, dashboard.Handler(collect, *refresh)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+// fetchPeriodically fetches from the remote every interval so commits behind stay current
+func fetchPeriodically(app Application, interval time.Duration) {
+	for {
+		if cfg, err := loadConfig(app); err == nil && app.GetGit().IsOriginCloned() {
+			if err := app.GetGit().FetchAll(cfg.MirrorURL); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to fetch updates: %v\n", err)
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// displayAddr returns a listening address as it can be typed into a browser on this machine
+func displayAddr(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
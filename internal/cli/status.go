@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/report"
+	"ue-git-plugin-manager/internal/timing"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// runStatus prints the setup status and, with --check, reports it through the exit code
+func runStatus(app Application, args []string) int {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	check := flags.Bool("check", false, "exit with a non-zero code when an engine is broken or out of date")
+	fetch := flags.Bool("fetch", false, "fetch from the remote before checking for updates")
+	jsonOutput := flags.Bool("json", false, "print a machine status document as JSON")
+	out := flags.String("out", "", "with --json, write the document to this file (e.g. a shared folder for reports)")
+	engines := engineFlags{
+		engines: flags.String("engine", "", "only check these engine versions or folders, separated by commas"),
+		all:     new(bool),
+	}
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+
+	cfg, err := loadConfig(app)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	if *jsonOutput {
+		return writeMachineStatus(app, cfg, *fetch, *out)
+	}
+
+	if !*check && *engines.engines == "" {
+		summary, err := app.GetDetection().GetSetupSummary(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		fmt.Print(summary)
+		return ExitOK
+	}
+
+	if *fetch && app.GetGit().IsOriginCloned() {
+		if err := app.GetGit().FetchAll(cfg.MirrorURL); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch updates: %v\n", err)
+		}
+	}
+
+	statuses, err := app.GetDetection().DetectSetupStatus(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	if *engines.engines == "" {
+		return checkStatuses(app, cfg, statuses)
+	}
+
+	// Engines asked for by name are reported even when they were never set up
+	picked, err := engines.pick(statuses, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	exitCode := ExitOK
+	var managed []detection.SetupStatus
+	for _, status := range picked {
+		if isManaged(cfg, status) {
+			managed = append(managed, status)
+			continue
+		}
+		fmt.Printf("NOT SET UP UE %s (%s)\n", status.EngineVersion, status.EnginePath)
+		exitCode = ExitBroken
+	}
+	if len(managed) > 0 {
+		if code := checkStatuses(app, cfg, managed); code == ExitBroken || exitCode == ExitOK {
+			exitCode = code
+		}
+	}
+	return exitCode
+}
+
+// checkStatuses prints one line per managed engine and returns the worst exit code found
+func checkStatuses(app Application, cfg *config.Config, statuses []detection.SetupStatus) int {
+	exitCode := ExitOK
+	managed := 0
+	for _, status := range statuses {
+		if !isManaged(cfg, status) {
+			continue
+		}
+		managed++
+
+		if !status.IsSetupComplete {
+			fmt.Printf("BROKEN   UE %s (%s): %v\n", status.EngineVersion, status.EnginePath, status.Issues)
+			if len(status.Projects) > 0 {
+				fmt.Printf("         affects projects: %s\n", strings.Join(status.Projects, ", "))
+			}
+			exitCode = ExitBroken
+			continue
+		}
+
+		updateInfo, err := app.GetGit().GetUpdateInfo(status.WorktreeSubdir, cfg.TrackedBranch(status.WorktreeSubdir), cfg.TrackedPin(status.WorktreeSubdir))
+		if err != nil {
+			fmt.Printf("BROKEN   UE %s (%s): could not check for updates: %v\n", status.EngineVersion, status.EnginePath, err)
+			exitCode = ExitBroken
+			continue
+		}
+		if updateInfo.CommitsAhead > 0 {
+			fmt.Printf("UPDATES  UE %s (%s): %d commit(s) behind\n", status.EngineVersion, status.EnginePath, updateInfo.CommitsAhead)
+			if exitCode == ExitOK {
+				exitCode = ExitUpdatesAvailable
+			}
+			continue
+		}
+
+		fmt.Printf("OK       UE %s (%s)\n", status.EngineVersion, status.EnginePath)
+	}
+
+	if managed == 0 {
+		fmt.Println("No managed engines found.")
+	}
+
+	return exitCode
+}
+
+// isManaged reports whether an engine is recorded in config or has any setup artifacts
+func isManaged(cfg *config.Config, status detection.SetupStatus) bool {
+	for _, eng := range cfg.Engines {
+		if utils.SamePath(eng.EnginePath, status.EnginePath) {
+			return true
+		}
+	}
+	return !status.IsNeverSetUp
+}
+
+// writeMachineStatus prints or saves this machine's status document for fleet reports
+func writeMachineStatus(app Application, cfg *config.Config, fetch bool, out string) int {
+	if fetch && app.GetGit().IsOriginCloned() {
+		if err := app.GetGit().FetchAll(cfg.MirrorURL); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch updates: %v\n", err)
+		}
+	}
+
+	statuses, err := app.GetDetection().DetectSetupStatus(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	machine := collectMachineStatus(app, cfg, statuses)
+
+	if out != "" {
+		if err := report.WriteFile(out, machine); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write %s: %v\n", out, err)
+			return ExitError
+		}
+		fmt.Printf("Status written to %s\n", out)
+		return ExitOK
+	}
+
+	data, err := json.MarshalIndent(machine, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	fmt.Println(string(data))
+	return ExitOK
+}
+
+// collectMachineStatus converts detection results into a machine status document
+func collectMachineStatus(app Application, cfg *config.Config, statuses []detection.SetupStatus) report.MachineStatus {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	machine := report.MachineStatus{
+		Machine:      host,
+		User:         os.Getenv("USERNAME"),
+		CollectedUTC: time.Now().UTC().Format(time.RFC3339),
+		Engines:      []report.EngineStatus{},
+	}
+	if store, err := timing.Load(app.GetConfig().GetTimingsFile()); err == nil && len(store) > 0 {
+		machine.StepTimings = store
+	}
+
+	for _, status := range statuses {
+		eng := report.EngineStatus{
+			Version: status.EngineVersion,
+			Path:    status.EnginePath,
+			State:   report.StateComplete,
+		}
+		switch {
+		case status.IsNeverSetUp:
+			eng.State = report.StateNotSetUp
+		case !status.IsSetupComplete:
+			eng.State = report.StateBroken
+			eng.Issues = status.Issues
+		}
+		if status.WorktreeExists {
+			if updateInfo, err := app.GetGit().GetUpdateInfo(status.WorktreeSubdir, cfg.TrackedBranch(status.WorktreeSubdir), cfg.TrackedPin(status.WorktreeSubdir)); err == nil {
+				eng.CommitsBehind = updateInfo.CommitsAhead
+				eng.LocalSHA = updateInfo.LocalSHA
+				eng.BaseSHA = updateInfo.BaseSHA
+			}
+		}
+		if !status.IsNeverSetUp {
+			eng.StockPluginEnabled = app.GetEngine().CheckPluginCollision(status.EnginePath)
+		}
+		if managed := app.GetConfig().GetEngineByPath(cfg, status.EnginePath); managed != nil {
+			eng.LastUpdatedUTC = managed.LastUpdatedUTC
+		}
+		machine.Engines = append(machine.Engines, eng)
+	}
+	return machine
+}
@@ -0,0 +1,268 @@
+package cli
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/explorer"
+	"ue-git-plugin-manager/internal/logging"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// uninstallLogName is the log of the last uninstall. It is kept outside the data directories,
+// which the uninstall deletes, so deployment tools can collect it afterwards.
+const uninstallLogName = "ue-git-plugin-manager-uninstall.log"
+
+// runUninstall removes everything the tool set up: plugin links and copies, the stock plugin
+// changes, the data directories with their worktrees and config, scheduled tasks running this
+// exe and the Explorer entry. The exe itself is left for the installer to remove.
+func runUninstall(app Application, args []string) int {
+	flags := flag.NewFlagSet("uninstall", flag.ContinueOnError)
+	machine := flags.Bool("machine", false, "also remove the shared data directory in ProgramData")
+	silent := flags.Bool("silent", false, "don't ask for confirmation")
+	logPath := flags.String("log", defaultUninstallLog(), "file to log the uninstall to")
+	engines := addEngineFlags(flags, "remove the setup of every managed engine, keeping the tool's data")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+	if engines.given() {
+		return uninstallSelected(app, engines, *silent)
+	}
+
+	baseDirs := []string{app.GetConfig().GetBaseDir()}
+	if *machine {
+		for _, dir := range config.GetPossibleBaseDirs() {
+			if !containsPath(baseDirs, dir) {
+				baseDirs = append(baseDirs, dir)
+			}
+		}
+	}
+
+	if !*silent {
+		fmt.Println("This removes all plugin links, worktrees and settings of UE Git Plugin Manager from:")
+		for _, dir := range baseDirs {
+			fmt.Printf("  %s\n", dir)
+		}
+		if !utils.ConfirmDestructive("Uninstall?") {
+			return ExitOK
+		}
+	}
+
+	// The regular log lives in a data directory that is about to be deleted
+	if err := logging.Open(*logPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	failed := false
+	report := func(err error, format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		if err != nil {
+			failed = true
+			message = fmt.Sprintf("%s: %v", message, err)
+			fmt.Printf("❌ %s\n", message)
+		} else {
+			fmt.Printf("✅ %s\n", message)
+		}
+		logging.Printf("uninstall: %s", message)
+	}
+
+	// Engines are taken from every config and from a fresh scan, so links survive a lost config
+	var configs []*config.Config
+	for _, dir := range baseDirs {
+		mgr := config.NewWithBaseDir(app.GetConfig().GetExeDir(), dir)
+		if !mgr.Exists() {
+			continue
+		}
+		cfg, err := mgr.Load()
+		if err != nil {
+			report(err, "Read %s", filepath.Join(dir, "config.json"))
+			continue
+		}
+		configs = append(configs, cfg)
+		for enginePath, name := range cfg.LinkNames() {
+			plugin.SetLinkName(enginePath, name)
+		}
+	}
+
+	for _, enginePath := range uninstallEngines(app, configs) {
+		linkPath := app.GetPlugin().GetPluginLinkPath(enginePath)
+		link := app.GetPlugin().InspectLink(linkPath)
+		owned := disabledByTool(configs, enginePath)
+		if link.IsLink || link.IsCopy {
+			// A link into another user's data directory is theirs even when this user manages
+			// the engine, and so is the stock plugin setting it relies on
+			owner := app.GetDetection().LinkOwner(enginePath)
+			switch {
+			case underAny(link.Target, baseDirs) || (owner == "" && managedEngine(configs, enginePath)):
+				report(app.GetPlugin().RemoveJunction(linkPath), "Remove the plugin from %s", enginePath)
+				owned = true
+			case owner != "":
+				logging.Printf("uninstall: left %s's plugin link in %s", owner, enginePath)
+				fmt.Printf("ℹ️  Left %s's plugin link in %s\n", owner, enginePath)
+				owned = false
+			}
+		}
+		if owned && app.GetEngine().IsStockPluginDisabled(enginePath) {
+			report(app.GetEngine().EnableStockPlugin(enginePath), "Restore the stock Git plugin of %s", enginePath)
+		}
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		exePath = os.Args[0]
+	}
+	for _, task := range scheduledTasksRunning(exePath) {
+		_, err := runner.CombinedOutput("schtasks", "/Delete", "/TN", task, "/F")
+		report(err, "Delete scheduled task %s", task)
+	}
+
+	if explorer.IsInstalled() {
+		report(explorer.Uninstall(), "Remove the Explorer context menu entry")
+	}
+
+	// Worktrees are inside the data directories, so they go with them after their links are gone
+	for _, dir := range baseDirs {
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		report(os.RemoveAll(dir), "Remove %s", dir)
+	}
+
+	logging.Close()
+	if failed {
+		fmt.Printf("Uninstall finished with errors; see %s\n", *logPath)
+		return ExitError
+	}
+	fmt.Printf("Uninstall completed; log written to %s\n", *logPath)
+	return ExitOK
+}
+
+// defaultUninstallLog returns the known path the uninstall logs to
+func defaultUninstallLog() string {
+	programData := os.Getenv("ProgramData")
+	if programData == "" {
+		programData = filepath.Join("C:\\", "ProgramData")
+	}
+	return filepath.Join(programData, uninstallLogName)
+}
+
+// uninstallEngines returns every engine folder a config manages or a scan finds, once each
+func uninstallEngines(app Application, configs []*config.Config) []string {
+	var paths []string
+	add := func(path string) {
+		if path != "" && !containsPath(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	for _, cfg := range configs {
+		for _, eng := range cfg.Engines {
+			add(eng.EnginePath)
+		}
+		if engines, err := app.GetEngine().DiscoverEngines(cfg.CustomEngineRoots); err == nil {
+			for _, eng := range engines {
+				add(eng.Path)
+			}
+		}
+	}
+	if len(configs) == 0 {
+		if engines, err := app.GetEngine().DiscoverEngines(nil); err == nil {
+			for _, eng := range engines {
+				add(eng.Path)
+			}
+		}
+	}
+	return paths
+}
+
+// managedEngine reports whether any config has the engine set up
+func managedEngine(configs []*config.Config, enginePath string) bool {
+	for _, cfg := range configs {
+		for _, eng := range cfg.Engines {
+			if utils.SamePath(eng.EnginePath, enginePath) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// disabledByTool reports whether a config records that the tool disabled the engine's stock plugin
+func disabledByTool(configs []*config.Config, enginePath string) bool {
+	for _, cfg := range configs {
+		for _, eng := range cfg.Engines {
+			if utils.SamePath(eng.EnginePath, enginePath) && eng.StockPluginDisabledByTool {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// underAny reports whether path is inside one of the directories
+func underAny(path string, dirs []string) bool {
+	if path == "" {
+		return false
+	}
+	key := utils.PathKey(path)
+	for _, dir := range dirs {
+		if strings.HasPrefix(key, utils.PathKey(dir)+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPath reports whether paths holds a spelling of path
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if utils.SamePath(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduledTasksRunning returns the scheduled tasks whose action starts the given exe, such as
+// the "update --scheduled" task from the README
+func scheduledTasksRunning(exePath string) []string {
+	output, err := runner.Output("schtasks", "/Query", "/FO", "CSV", "/V", "/NH")
+	if err != nil {
+		return nil
+	}
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil
+	}
+
+	exeName := strings.ToLower(filepath.Base(exePath))
+	var tasks []string
+	for _, record := range records {
+		// HostName, TaskName, Next Run Time, Status, Logon Mode, Last Run Time, Last Result, Author, Task To Run, ...
+		if len(record) < 9 {
+			continue
+		}
+		name, action := record[1], strings.ToLower(record[8])
+		if strings.Contains(action, exeName) && !containsTask(tasks, name) {
+			tasks = append(tasks, name)
+		}
+	}
+	return tasks
+}
+
+// containsTask reports whether a task name is already listed; schtasks lists a task once per trigger
+func containsTask(tasks []string, name string) bool {
+	for _, task := range tasks {
+		if task == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/maintenance"
+	"ue-git-plugin-manager/internal/menu"
+)
+
+// runUpdate updates every managed engine, or those named by --engine, without prompts, for
+// scheduled tasks
+func runUpdate(app Application, args []string) int {
+	flags := flag.NewFlagSet("update", flag.ContinueOnError)
+	scheduled := flags.Bool("scheduled", false, "only update inside the configured maintenance window")
+	engines := addEngineFlags(flags, "update every managed engine (the default)")
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+
+	var enginePaths []string
+	if *engines.engines != "" {
+		_, statuses, err := detectForCommand(app)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		picked, err := engines.pick(statuses, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		for _, status := range picked {
+			enginePaths = append(enginePaths, status.EnginePath)
+		}
+	}
+
+	err := menu.UpdateUnattended(app, *scheduled, enginePaths)
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, maintenance.ErrDeferred):
+		fmt.Printf("⏸️  %v\n", err)
+		return ExitUpdatesAvailable
+	default:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+}
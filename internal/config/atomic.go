@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+const (
+	lockTimeout  = 10 * time.Second
+	lockStaleAge = 60 * time.Second
+)
+
+// acquireLock takes an exclusive lock next to path so concurrent instances don't interleave
+// writes. A lock left behind by a crashed process is taken over once it is stale.
+func acquireLock(path string) (func(), error) {
+	return utils.AcquireLock(path, lockTimeout, lockStaleAge)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it over path, so a crash
+// mid-write never leaves a truncated file
+func writeFileAtomic(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,25 +11,162 @@ import (
 	"strings"
 	"time"
 
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/logging"
+	"ue-git-plugin-manager/internal/projectconfig"
+	"ue-git-plugin-manager/internal/secret"
 	"ue-git-plugin-manager/internal/utils"
 )
 
 const (
 	defaultRemoteBranch = "dev"
 	defaultPinnedCommit = "40d8a5438e654927934c14d6836a67363fbe0495"
+	candidateSuffix     = "-candidate"
+)
+
+// DefaultRepoMaintenanceDays is how often the plugin repository is compacted unless configured
+const DefaultRepoMaintenanceDays = 30
+
+// How long git commands and plugin builds may run unless configured, in minutes
+const (
+	DefaultGitTimeoutMinutes   = 30
+	DefaultBuildTimeoutMinutes = 120
+)
+
+// Bounds of how many engines' plugins are built at the same time
+const (
+	DefaultParallelBuilds = 2
+	MaxParallelBuilds     = 4
 )
 
 // Config represents the application configuration
 type Config struct {
-	Version             int      `json:"version"`
-	BaseDir             string   `json:"base_dir"`
-	OriginDir           string   `json:"origin_dir"`
-	WorktreesDir        string   `json:"worktrees_dir"`
-	DefaultRemoteBranch string   `json:"default_remote_branch"`
-	PinnedCommitSHA     string   `json:"pinned_commit_sha"`
-	Engines             []Engine `json:"engines"`
-	CustomEngineRoots   []string `json:"custom_engine_roots"`
-	LastRunUTC          string   `json:"last_run_utc"`
+	Version             int        `json:"version"`
+	BaseDir             string     `json:"base_dir"`
+	OriginDir           string     `json:"origin_dir"`
+	WorktreesDir        string     `json:"worktrees_dir"`
+	DefaultRemoteBranch string     `json:"default_remote_branch"`
+	PinnedCommitSHA     string     `json:"pinned_commit_sha"`
+	Engines             []Engine   `json:"engines"`
+	CustomEngineRoots   []ScanRoot `json:"custom_engine_roots"` // Plain paths or objects with scan options
+	Projects            []Project  `json:"projects,omitempty"`
+	LastRunUTC          string     `json:"last_run_utc"`
+
+	// MirrorURL is a studio-local mirror of the plugin repository used for clone and fetch,
+	// falling back to GitHub when it can't be reached
+	MirrorURL string `json:"mirror_url,omitempty"`
+
+	// Remotes are additional remotes of the plugin repository, such as a studio fork next to
+	// upstream. An engine tracks a branch on one of them with an upstream_branch of the form
+	// "<remote>/<branch>".
+	Remotes []Remote `json:"remotes,omitempty"`
+
+	// Local patches applied on top of upstream in every worktree during each update. Relative
+	// patch file paths are resolved against the data directory.
+	PatchFiles    []string `json:"patch_files,omitempty"`
+	PatchesBranch string   `json:"patches_branch,omitempty"`
+
+	// BranchCompatibility maps a UE version such as "4.27" to the plugin branch to suggest when
+	// the tracked branch's descriptor doesn't support it. Without an entry, the remote branches'
+	// descriptors are searched for one made for that version.
+	BranchCompatibility map[string]string `json:"branch_compatibility,omitempty"`
+
+	// PluginIniOptions adds studio-specific ini keys, such as the ones a project reads its status
+	// branch names from, to the plugin settings screen
+	PluginIniOptions []projectconfig.IniOption `json:"plugin_ini_options,omitempty"`
+
+	// KeptPluginOverrides lists copies of the plugin inside projects that the user chose to keep
+	// over the engine's plugin, so they aren't reported as issues
+	KeptPluginOverrides []string `json:"kept_plugin_overrides,omitempty"`
+
+	// OtherUserSetups records engines whose plugin link points into another Windows user's data
+	// directory, and whether this user chose to adopt that setup or coexist with it
+	OtherUserSetups []OtherUserSetup `json:"other_user_setups,omitempty"`
+
+	// ViewerMode limits the tool to status and diagnostics, e.g. for producers' machines
+	ViewerMode bool `json:"viewer_mode,omitempty"`
+
+	// ApprovedEnginePlugins names the non-stock engine plugins expected on this machine; the
+	// engine plugin audit flags any others. Empty turns the check off.
+	ApprovedEnginePlugins []string `json:"approved_engine_plugins,omitempty"`
+
+	// ProjectLevelOnly forbids modifying engine installs, leaving project-level installation and
+	// project configuration; IT can also enforce it machine-wide in the registry
+	ProjectLevelOnly bool `json:"project_level_only,omitempty"`
+
+	// SkipPluginCacheCleanup turns off clearing stale plugin Intermediate folders during setup and update
+	SkipPluginCacheCleanup bool `json:"skip_plugin_cache_cleanup,omitempty"`
+	// ParallelBuilds is how many engines' plugins are compiled at the same time when several
+	// engines are set up or updated together; zero uses DefaultParallelBuilds
+	ParallelBuilds int `json:"parallel_builds,omitempty"`
+	// RepoMaintenanceDays is how many days pass between compactions of the plugin repository,
+	// run after an update's fetch; zero uses DefaultRepoMaintenanceDays and negative turns it off
+	RepoMaintenanceDays int `json:"repo_maintenance_days,omitempty"`
+	// LastRepoMaintenanceUTC is when the plugin repository was last compacted
+	LastRepoMaintenanceUTC string `json:"last_repo_maintenance_utc,omitempty"`
+	// GitTimeoutMinutes and BuildTimeoutMinutes stop a git command or plugin build that runs
+	// longer, e.g. on a stalled network; zero uses the defaults and negative means no limit
+	GitTimeoutMinutes   int `json:"git_timeout_minutes,omitempty"`
+	BuildTimeoutMinutes int `json:"build_timeout_minutes,omitempty"`
+
+	// ColorTheme selects the status color palette: "default", "colorblind" or "none"
+	ColorTheme string `json:"color_theme,omitempty"`
+	// TextStatusSymbols shows [OK]/[XX]-style status markers instead of colored emoji
+	TextStatusSymbols bool `json:"text_status_symbols,omitempty"`
+	// VerboseOutput prints every diagnostic step of linking and building instead of one line per
+	// result; the log file always has them
+	VerboseOutput bool `json:"verbose_output,omitempty"`
+	// Confirmations is "always" (or empty) to confirm every step of an update, repair, uninstall
+	// or project configuration, or "destructive" to only confirm deleting or discarding things
+	Confirmations string `json:"confirmations,omitempty"`
+	// PlainPrompts replaces the arrow-key lists with numbered prompts read as a line of text and
+	// never clears the screen, for screen readers; --plain turns it on for one run
+	PlainPrompts bool `json:"plain_prompts,omitempty"`
+	// PromptDefaults preselects answers, keyed by the prompt's text (* matches any text), so a
+	// distributed config turns the interactive flow into pressing Enter. Yes/no questions take
+	// "yes" or "no"; choices take the option's text or its beginning.
+	PromptDefaults map[string]string `json:"prompt_defaults,omitempty"`
+
+	// MaintenanceWindow limits unattended updates ("update --scheduled") to a daily local time
+	// range such as "02:00-05:00". Empty allows them at any time.
+	MaintenanceWindow string `json:"maintenance_window,omitempty"`
+
+	// Metrics export defaults used by the metrics command when no flags are given
+	MetricsTextfilePath   string `json:"metrics_textfile_path,omitempty"`
+	MetricsPushgatewayURL string `json:"metrics_pushgateway_url,omitempty"`
+
+	// TelemetryEnabled is the user's opt-in to sending anonymous operation outcomes (engine
+	// versions, step durations, failure categories) to TelemetryURL, an endpoint the studio runs.
+	// Nothing is sent unless both are set.
+	TelemetryEnabled bool   `json:"telemetry_enabled,omitempty"`
+	TelemetryURL     string `json:"telemetry_url,omitempty"`
+
+	// SupportDocsURL is where error codes printed with failures link to, e.g. a page of the
+	// studio's wiki; {code} is replaced by the code. Empty links to this tool's own docs.
+	SupportDocsURL string `json:"support_docs_url,omitempty"`
+}
+
+// Choices for an engine whose plugin link another Windows user's setup owns
+const (
+	// OtherUserAdopt uses the other user's setup as this user's: the editor keeps loading their
+	// build, and this user's own worktree for the engine is removed
+	OtherUserAdopt = "adopt"
+	// OtherUserCoexist keeps the other user's link and this user's own worktree side by side; the
+	// link can be pointed at either later
+	OtherUserCoexist = "coexist"
+)
+
+// OtherUserSetup is the choice made about an engine linked to another user's setup
+type OtherUserSetup struct {
+	EnginePath string `json:"engine_path"`
+	Owner      string `json:"owner"` // Windows account name the link's target belongs to
+	Choice     string `json:"choice"`
+}
+
+// Remote is an additional remote of the plugin repository
+type Remote struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 // Engine represents a managed Unreal Engine installation
@@ -35,9 +174,44 @@ type Engine struct {
 	EnginePath                string `json:"engine_path"`
 	EngineVersion             string `json:"engine_version"`
 	WorktreeSubdir            string `json:"worktree_subdir"`
-	Branch                    string `json:"branch"`
+	Branch                    string `json:"branch"`                      // Local engine-<version> branch checked out in the worktree
+	UpstreamBranch            string `json:"upstream_branch,omitempty"`   // Overrides default_remote_branch for this engine; "<remote>/<branch>" for an additional remote
+	PinnedCommitSHA           string `json:"pinned_commit_sha,omitempty"` // Overrides the global pin for this engine
 	PluginLinkPath            string `json:"plugin_link_path"`
+	PluginLinkName            string `json:"plugin_link_name,omitempty"` // Folder name of the link under Engine/Plugins; empty is UEGitPlugin_PB
 	StockPluginDisabledByTool bool   `json:"stock_plugin_disabled_by_tool"`
+	LastUpdatedUTC            string `json:"last_updated_utc,omitempty"`
+	BuildFingerprint          string `json:"build_fingerprint,omitempty"`         // Build.version identity, used to find the engine if it moves
+	InstallID                 string `json:"install_id,omitempty"`                // Volume and folder index of the engine, recognises the install at a new drive letter
+	UpdatesSnoozedUntilUTC    string `json:"updates_snoozed_until_utc,omitempty"` // "Remind me later" hides available updates until then
+
+	// A second, separately built worktree the plugin link can be switched to, for comparing a
+	// candidate plugin version with the stable one. Switching swaps it with the fields above.
+	AlternateWorktreeSubdir  string `json:"alternate_worktree_subdir,omitempty"`
+	AlternateUpstreamBranch  string `json:"alternate_upstream_branch,omitempty"`
+	AlternatePinnedCommitSHA string `json:"alternate_pinned_commit_sha,omitempty"`
+}
+
+// Project represents an Unreal project registered so status can show which engine it uses
+type Project struct {
+	Path               string              `json:"path"`
+	Name               string              `json:"name"`
+	SourceControlCheck *SourceControlCheck `json:"source_control_check,omitempty"` // Last editor source control smoke test
+}
+
+// SourceControlCheck is the result of running the editor against a project to confirm the
+// Git provider initializes and the project's remote is reachable
+type SourceControlCheck struct {
+	CheckedUTC    string `json:"checked_utc"`
+	EngineVersion string `json:"engine_version"`
+	ProviderOK    bool   `json:"provider_ok"`
+	RemoteOK      bool   `json:"remote_ok"`
+	Detail        string `json:"detail,omitempty"` // Why the check failed
+}
+
+// Passed reports whether both the provider and the remote checks succeeded
+func (c SourceControlCheck) Passed() bool {
+	return c.ProviderOK && c.RemoteOK
 }
 
 // Manager handles configuration operations
@@ -45,11 +219,23 @@ type Manager struct {
 	exeDir     string
 	baseDir    string
 	configPath string
+	portable   bool
 }
 
 // New creates a new configuration manager
 func New(exeDir string) *Manager {
 	baseDir := getUserConfigDir()
+	return &Manager{
+		exeDir:     exeDir,
+		baseDir:    baseDir,
+		configPath: filepath.Join(baseDir, "config.json"),
+		portable:   !utils.IsPackagedApp(exeDir) && utils.IsWritableDir(exeDir),
+	}
+}
+
+// NewWithBaseDir creates a configuration manager for the data directory at baseDir, such as the
+// other of GetPossibleBaseDirs
+func NewWithBaseDir(exeDir, baseDir string) *Manager {
 	return &Manager{
 		exeDir:     exeDir,
 		baseDir:    baseDir,
@@ -80,10 +266,10 @@ func getUserConfigDir() string {
 		// Use fallback location: C:\ProgramData\ue-git-plugin-manager
 		// Use filepath.Join with "C:\\" to ensure absolute path on Windows
 		fallbackConfigDir := filepath.Join("C:\\", "ProgramData", "ue-git-plugin-manager")
-		fmt.Printf("⚠️  Warning: Username contains non-ASCII characters.\n")
-		fmt.Printf("   Default path: %s\n", defaultConfigDir)
-		fmt.Printf("   Using fallback path: %s\n", fallbackConfigDir)
-		fmt.Printf("   (This is required to prevent build failures with UBT/MSVC)\n\n")
+		events.Warn("Username contains non-ASCII characters.")
+		events.Info("Default path: %s", defaultConfigDir)
+		events.Info("Using fallback path: %s", fallbackConfigDir)
+		events.Info("(This is required to prevent build failures with UBT/MSVC)")
 
 		// Create the directory if it doesn't exist
 		os.MkdirAll(fallbackConfigDir, 0755)
@@ -106,6 +292,77 @@ func (m *Manager) GetBaseDir() string {
 	return m.baseDir
 }
 
+// IsPortable reports whether the exe runs from a folder the user can write to, as an unzipped
+// download does. Installed and MSIX-packaged copies are not portable: nothing may be written
+// next to the exe, so every file goes to the base directory or a folder the user picks.
+func (m *Manager) IsPortable() bool {
+	return m.portable
+}
+
+// GetLogsDir returns the folder all logs are written to: the main log, and reports written
+// during project configuration in a subfolder per project
+func (m *Manager) GetLogsDir() string {
+	return filepath.Join(m.baseDir, "logs")
+}
+
+// GetRunLogsDir returns the folder holding a log file per run of the tool
+func (m *Manager) GetRunLogsDir() string {
+	return filepath.Join(m.GetLogsDir(), "runs")
+}
+
+// GetUserFilesDir returns the folder offered for files the user exports: next to a portable
+// exe, or the user's Documents folder for an installed one
+func (m *Manager) GetUserFilesDir() string {
+	if m.portable {
+		return m.exeDir
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, "Documents")
+	}
+	return m.baseDir
+}
+
+// GetHistoryFile returns the file used to remember previously entered values of a prompt
+func (m *Manager) GetHistoryFile(name string) string {
+	return filepath.Join(m.baseDir, name+"_history.txt")
+}
+
+// GetTimingsFile returns the file holding this machine's step timing aggregates
+func (m *Manager) GetTimingsFile() string {
+	return filepath.Join(m.baseDir, "timings.json")
+}
+
+// GetSetupJournalFile returns the file recording the progress of engine setups that haven't finished
+func (m *Manager) GetSetupJournalFile() string {
+	return filepath.Join(m.baseDir, "setup-journal.json")
+}
+
+// GetTelemetryQueueFile returns the file holding telemetry events not sent yet
+func (m *Manager) GetTelemetryQueueFile() string {
+	return filepath.Join(m.baseDir, "telemetry-queue.json")
+}
+
+// GetLogFile returns the file that git transcripts and other diagnostics are logged to
+func (m *Manager) GetLogFile() string {
+	return filepath.Join(m.GetLogsDir(), "ue-git-plugin-manager.log")
+}
+
+// MoveLegacyLog moves the log from the top of the data directory, where older versions kept it,
+// into the logs folder so its history isn't lost
+func (m *Manager) MoveLegacyLog() {
+	legacy := filepath.Join(m.baseDir, "ue-git-plugin-manager.log")
+	if _, err := os.Stat(legacy); err != nil {
+		return
+	}
+	if _, err := os.Stat(m.GetLogFile()); err == nil {
+		return
+	}
+	if err := os.MkdirAll(m.GetLogsDir(), 0755); err != nil {
+		return
+	}
+	os.Rename(legacy, m.GetLogFile())
+}
+
 // GetPossibleBaseDirs returns both the default and fallback base directories
 // This is used for detection code to check both locations
 func GetPossibleBaseDirs() []string {
@@ -139,7 +396,21 @@ func (m *Manager) Load() (*Config, error) {
 
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, err
+		restored, restoreErr := m.restoreBackup()
+		if restoreErr != nil {
+			return nil, fmt.Errorf("%v (no usable backup: %v)", err, restoreErr)
+		}
+		events.Warn("%s was corrupted (%v); restored the last good backup.", m.configPath, err)
+		config = *restored
+	}
+
+	for _, field := range sensitiveFields(&config) {
+		plain, err := secret.Unprotect(*field)
+		if err != nil {
+			events.Warn("Could not decrypt a config value, it will be ignored: %v", err)
+			plain = ""
+		}
+		*field = plain
 	}
 
 	if strings.TrimSpace(config.DefaultRemoteBranch) == "" {
@@ -149,6 +420,12 @@ func (m *Manager) Load() (*Config, error) {
 		config.PinnedCommitSHA = defaultPinnedCommit
 	}
 
+	// Engines recorded on a mapped drive are matched by UNC path, which is what discovery reports
+	for i := range config.Engines {
+		config.Engines[i].EnginePath = utils.ResolveNetworkPath(config.Engines[i].EnginePath)
+		config.Engines[i].PluginLinkPath = utils.ResolveNetworkPath(config.Engines[i].PluginLinkPath)
+	}
+
 	// Resolve relative paths
 	config.BaseDir = m.resolvePath(config.BaseDir)
 	config.OriginDir = m.resolvePath(config.OriginDir)
@@ -170,12 +447,97 @@ func (m *Manager) Save(config *Config) error {
 	// Update last run time
 	saveConfig.LastRunUTC = time.Now().UTC().Format(time.RFC3339)
 
+	// Encrypt credentials-bearing fields at rest
+	for _, field := range sensitiveFields(&saveConfig) {
+		protected, err := secret.Protect(*field)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt config value: %v", err)
+		}
+		*field = protected
+	}
+
 	data, err := json.MarshalIndent(saveConfig, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(m.configPath, data, 0644)
+	unlock, err := acquireLock(m.configPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Keep the current file as the last good backup before replacing it
+	if current, err := os.ReadFile(m.configPath); err == nil && json.Valid(current) {
+		if err := writeFileAtomic(m.backupPath(), current); err != nil {
+			return fmt.Errorf("failed to back up config: %v", err)
+		}
+	}
+
+	return writeFileAtomic(m.configPath, data)
+}
+
+// restoreBackup replaces a corrupted config file with the last good backup
+func (m *Manager) restoreBackup() (*Config, error) {
+	data, err := os.ReadFile(m.backupPath())
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	unlock, err := acquireLock(m.configPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Keep the corrupted file for inspection
+	os.Rename(m.configPath, m.configPath+".corrupt")
+	if err := writeFileAtomic(m.configPath, data); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// sensitiveFields returns the fields that can carry credentials (URLs with embedded tokens,
+// webhooks) and are therefore encrypted in config.json
+func sensitiveFields(config *Config) []*string {
+	return []*string{
+		&config.MirrorURL,
+		&config.MetricsPushgatewayURL,
+		&config.TelemetryURL,
+	}
+}
+
+// Snapshot returns config.json as it is on disk with the fields that can carry credentials
+// masked, for crash reports. It doesn't decrypt anything or restore the backup.
+func (m *Manager) Snapshot() ([]byte, error) {
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("config.json is not valid: %v", err)
+	}
+	for _, field := range sensitiveFields(&config) {
+		if *field != "" {
+			*field = "***"
+		}
+	}
+	data, err = json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(logging.Redact(string(data))), nil
+}
+
+// backupPath returns the location of the last good configuration
+func (m *Manager) backupPath() string {
+	return m.configPath + ".bak"
 }
 
 // CreateDefault creates a default configuration
@@ -188,7 +550,7 @@ func (m *Manager) CreateDefault() *Config {
 		DefaultRemoteBranch: defaultRemoteBranch,
 		PinnedCommitSHA:     defaultPinnedCommit,
 		Engines:             []Engine{},
-		CustomEngineRoots:   []string{},
+		CustomEngineRoots:   []ScanRoot{},
 		LastRunUTC:          time.Now().UTC().Format(time.RFC3339),
 	}
 }
@@ -201,17 +563,267 @@ func (m *Manager) AddEngine(config *Config, eng Engine) {
 // RemoveEngine removes an engine from the configuration
 func (m *Manager) RemoveEngine(config *Config, enginePath string) {
 	for i, eng := range config.Engines {
-		if eng.EnginePath == enginePath {
+		if utils.SamePath(eng.EnginePath, enginePath) {
 			config.Engines = append(config.Engines[:i], config.Engines[i+1:]...)
 			break
 		}
 	}
 }
 
+// UpsertEngine adds an engine to the configuration or replaces the entry with the same path
+func (m *Manager) UpsertEngine(config *Config, eng Engine) {
+	for i, existing := range config.Engines {
+		if utils.SamePath(existing.EnginePath, eng.EnginePath) {
+			config.Engines[i] = eng
+			return
+		}
+	}
+	config.Engines = append(config.Engines, eng)
+}
+
+// AddProject registers a project, replacing an existing entry with the same path
+func (m *Manager) AddProject(config *Config, project Project) {
+	for i, existing := range config.Projects {
+		if utils.SamePath(existing.Path, project.Path) {
+			config.Projects[i] = project
+			return
+		}
+	}
+	config.Projects = append(config.Projects, project)
+}
+
+// RemoveProject removes a registered project by path
+func (m *Manager) RemoveProject(config *Config, projectPath string) {
+	for i, existing := range config.Projects {
+		if utils.SamePath(existing.Path, projectPath) {
+			config.Projects = append(config.Projects[:i], config.Projects[i+1:]...)
+			break
+		}
+	}
+}
+
+// WorktreeSubdirFor returns the worktree directory name used for an engine.
+// A managed engine keeps the subdir recorded in config. Otherwise the first
+// install of a version uses UE_<version>, and further installs of the same
+// version get a suffix derived from their path so they don't share a worktree.
+func WorktreeSubdirFor(config *Config, enginePath, engineVersion string) string {
+	for _, eng := range config.Engines {
+		if utils.SamePath(eng.EnginePath, enginePath) && eng.WorktreeSubdir != "" {
+			return eng.WorktreeSubdir
+		}
+	}
+
+	subdir := DefaultWorktreeSubdir(engineVersion)
+	for _, eng := range config.Engines {
+		if !utils.SamePath(eng.EnginePath, enginePath) && (eng.WorktreeSubdir == subdir || eng.AlternateWorktreeSubdir == subdir) {
+			return fmt.Sprintf("%s_%s", subdir, pathHash(enginePath))
+		}
+	}
+	return subdir
+}
+
+// DefaultWorktreeSubdir returns the worktree directory name for the first install of a version
+func DefaultWorktreeSubdir(engineVersion string) string {
+	return fmt.Sprintf("UE_%s", engineVersion)
+}
+
+// pathHash returns a short, stable identifier for an engine path
+func pathHash(enginePath string) string {
+	sum := sha1.Sum([]byte(utils.PathKey(enginePath)))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// engineBySubdir returns the managed engine using a worktree subdir, or nil
+func (c *Config) engineBySubdir(subdir string) *Engine {
+	for i := range c.Engines {
+		if strings.EqualFold(c.Engines[i].WorktreeSubdir, subdir) {
+			return &c.Engines[i]
+		}
+	}
+	return nil
+}
+
+// PluginApproved reports whether an engine plugin is on the approved list, or true when there is no list
+func (c *Config) PluginApproved(name string) bool {
+	if len(c.ApprovedEnginePlugins) == 0 {
+		return true
+	}
+	for _, approved := range c.ApprovedEnginePlugins {
+		if strings.EqualFold(approved, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPluginOverrideKept reports whether a project's copy of the plugin was chosen to be kept
+func (c *Config) IsPluginOverrideKept(dir string) bool {
+	for _, kept := range c.KeptPluginOverrides {
+		if utils.SamePath(kept, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// OtherUserSetupFor returns the choice made about an engine linked to owner's setup, or "" when
+// none was made or it was made about another owner's link
+func (c *Config) OtherUserSetupFor(enginePath, owner string) string {
+	for _, setup := range c.OtherUserSetups {
+		if utils.SamePath(setup.EnginePath, enginePath) && strings.EqualFold(setup.Owner, owner) {
+			return setup.Choice
+		}
+	}
+	return ""
+}
+
+// SetOtherUserSetup records the choice made about an engine linked to owner's setup; an empty
+// choice forgets it
+func (c *Config) SetOtherUserSetup(enginePath, owner, choice string) {
+	var kept []OtherUserSetup
+	for _, setup := range c.OtherUserSetups {
+		if !utils.SamePath(setup.EnginePath, enginePath) {
+			kept = append(kept, setup)
+		}
+	}
+	if choice != "" {
+		kept = append(kept, OtherUserSetup{EnginePath: enginePath, Owner: owner, Choice: choice})
+	}
+	c.OtherUserSetups = kept
+}
+
+// alternateBySubdir returns the engine whose alternate worktree is in subdir
+func (c *Config) alternateBySubdir(subdir string) *Engine {
+	for i := range c.Engines {
+		if c.Engines[i].AlternateWorktreeSubdir != "" && strings.EqualFold(c.Engines[i].AlternateWorktreeSubdir, subdir) {
+			return &c.Engines[i]
+		}
+	}
+	return nil
+}
+
+// TrackedBranch returns the upstream branch a worktree follows, honouring a per-engine override
+func (c *Config) TrackedBranch(subdir string) string {
+	if eng := c.engineBySubdir(subdir); eng != nil && eng.UpstreamBranch != "" {
+		return eng.UpstreamBranch
+	}
+	if eng := c.alternateBySubdir(subdir); eng != nil && eng.AlternateUpstreamBranch != "" {
+		return eng.AlternateUpstreamBranch
+	}
+	return c.DefaultRemoteBranch
+}
+
+// BuildWorkers returns how many plugin builds may run at the same time
+func (c *Config) BuildWorkers() int {
+	switch {
+	case c.ParallelBuilds <= 0:
+		return DefaultParallelBuilds
+	case c.ParallelBuilds > MaxParallelBuilds:
+		return MaxParallelBuilds
+	}
+	return c.ParallelBuilds
+}
+
+// GitTimeout returns how long a git command may run, or zero for no limit
+func (c *Config) GitTimeout() time.Duration {
+	return minutesOrDefault(c.GitTimeoutMinutes, DefaultGitTimeoutMinutes)
+}
+
+// BuildTimeout returns how long a plugin build may run, or zero for no limit
+func (c *Config) BuildTimeout() time.Duration {
+	return minutesOrDefault(c.BuildTimeoutMinutes, DefaultBuildTimeoutMinutes)
+}
+
+// minutesOrDefault turns a configured number of minutes into a duration: zero uses the default
+// and negative means none
+func minutesOrDefault(minutes, defaultMinutes int) time.Duration {
+	switch {
+	case minutes < 0:
+		return 0
+	case minutes == 0:
+		minutes = defaultMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// RepoMaintenanceInterval returns how many days pass between compactions of the plugin
+// repository, or zero when they are turned off
+func (c *Config) RepoMaintenanceInterval() int {
+	switch {
+	case c.RepoMaintenanceDays < 0:
+		return 0
+	case c.RepoMaintenanceDays == 0:
+		return DefaultRepoMaintenanceDays
+	}
+	return c.RepoMaintenanceDays
+}
+
+// RepoMaintenanceDue reports whether the plugin repository should be compacted now
+func (c *Config) RepoMaintenanceDue(now time.Time) bool {
+	days := c.RepoMaintenanceInterval()
+	if days == 0 {
+		return false
+	}
+	last, err := time.Parse(time.RFC3339, c.LastRepoMaintenanceUTC)
+	return err != nil || now.Sub(last) >= time.Duration(days)*24*time.Hour
+}
+
+// TrackedPin returns the commit a worktree is pinned to, honouring a per-engine override
+func (c *Config) TrackedPin(subdir string) string {
+	if eng := c.engineBySubdir(subdir); eng != nil && eng.PinnedCommitSHA != "" {
+		return eng.PinnedCommitSHA
+	}
+	if eng := c.alternateBySubdir(subdir); eng != nil && eng.AlternatePinnedCommitSHA != "" {
+		return eng.AlternatePinnedCommitSHA
+	}
+	return c.PinnedCommitSHA
+}
+
+// CandidateWorktreeSubdir returns the subdirectory of the second worktree of an engine whose
+// worktree is in subdir; for the candidate's own subdirectory it returns the stable one
+func CandidateWorktreeSubdir(subdir string) string {
+	if stable, ok := strings.CutSuffix(subdir, candidateSuffix); ok {
+		return stable
+	}
+	return subdir + candidateSuffix
+}
+
+// IsCandidateWorktree reports whether subdir holds an engine's candidate worktree
+func IsCandidateWorktree(subdir string) bool {
+	return strings.HasSuffix(subdir, candidateSuffix)
+}
+
+// SwapAlternate makes the alternate worktree the engine's worktree and the current one the alternate
+func (e *Engine) SwapAlternate() {
+	e.WorktreeSubdir, e.AlternateWorktreeSubdir = e.AlternateWorktreeSubdir, e.WorktreeSubdir
+	e.UpstreamBranch, e.AlternateUpstreamBranch = e.AlternateUpstreamBranch, e.UpstreamBranch
+	e.PinnedCommitSHA, e.AlternatePinnedCommitSHA = e.AlternatePinnedCommitSHA, e.PinnedCommitSHA
+}
+
+// UpdatesSnoozedUntil returns when a worktree's update reminder is due again, if it is snoozed
+func (c *Config) UpdatesSnoozedUntil(subdir string) (time.Time, bool) {
+	eng := c.engineBySubdir(subdir)
+	if eng == nil || eng.UpdatesSnoozedUntilUTC == "" {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, eng.UpdatesSnoozedUntilUTC)
+	if err != nil || !time.Now().Before(until) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// SnoozeUpdates hides a worktree's available updates until the given time
+func (c *Config) SnoozeUpdates(subdir string, until time.Time) {
+	if eng := c.engineBySubdir(subdir); eng != nil {
+		eng.UpdatesSnoozedUntilUTC = until.UTC().Format(time.RFC3339)
+	}
+}
+
 // GetEngineByPath gets an engine by its path
 func (m *Manager) GetEngineByPath(config *Config, enginePath string) *Engine {
 	for i, eng := range config.Engines {
-		if eng.EnginePath == enginePath {
+		if utils.SamePath(eng.EnginePath, enginePath) {
 			return &config.Engines[i]
 		}
 	}
@@ -234,3 +846,23 @@ func (m *Manager) makeRelative(path string) string {
 	}
 	return rel
 }
+
+// LinkNames returns the plugin link names chosen per engine, keyed by engine path
+func (c *Config) LinkNames() map[string]string {
+	names := make(map[string]string)
+	for _, eng := range c.Engines {
+		if eng.PluginLinkName != "" {
+			names[eng.EnginePath] = eng.PluginLinkName
+		}
+	}
+	return names
+}
+
+// RemoteURLs returns the additional remotes as a name to URL map
+func (c *Config) RemoteURLs() map[string]string {
+	urls := make(map[string]string, len(c.Remotes))
+	for _, remote := range c.Remotes {
+		urls[remote.Name] = remote.URL
+	}
+	return urls
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// Defaults for custom scan roots that don't set their own options
+const (
+	DefaultScanDepth      = 2
+	DefaultNetworkTimeout = 5 * time.Second
+)
+
+// ScanRoot is a custom folder searched for engine installations, with options for deep or noisy
+// trees such as build shares. Roots without options are stored as a plain path string.
+type ScanRoot struct {
+	Path string `json:"path"`
+	// MaxDepth is how many folder levels below the root are searched; 0 uses DefaultScanDepth
+	MaxDepth int `json:"max_depth,omitempty"`
+	// Include lists glob patterns for engine folder names or root-relative paths, e.g. "5.*-CL*".
+	// When set, matching folders are checked for an engine even if they aren't named UE_x.y.
+	Include []string `json:"include,omitempty"`
+	// Exclude lists glob patterns for folders that are skipped together with everything below them
+	Exclude []string `json:"exclude,omitempty"`
+	// NetworkTimeoutSeconds bounds the scan of a network path so an offline share doesn't stall
+	// discovery; 0 uses DefaultNetworkTimeout and -1 skips network scanning of this root
+	NetworkTimeoutSeconds int `json:"network_timeout_seconds,omitempty"`
+}
+
+// HasOptions reports whether the root sets anything besides its path
+func (r ScanRoot) HasOptions() bool {
+	return r.MaxDepth != 0 || len(r.Include) > 0 || len(r.Exclude) > 0 || r.NetworkTimeoutSeconds != 0
+}
+
+// Depth returns the effective scan depth
+func (r ScanRoot) Depth() int {
+	if r.MaxDepth > 0 {
+		return r.MaxDepth
+	}
+	return DefaultScanDepth
+}
+
+// NetworkTimeout returns how long a network root may be scanned, or 0 when it is never scanned
+func (r ScanRoot) NetworkTimeout() time.Duration {
+	switch {
+	case r.NetworkTimeoutSeconds < 0:
+		return 0
+	case r.NetworkTimeoutSeconds > 0:
+		return time.Duration(r.NetworkTimeoutSeconds) * time.Second
+	}
+	return DefaultNetworkTimeout
+}
+
+// Describe returns a one-line summary of the root's options
+func (r ScanRoot) Describe() string {
+	parts := []string{fmt.Sprintf("depth %d", r.Depth())}
+	if len(r.Include) > 0 {
+		parts = append(parts, "include "+strings.Join(r.Include, ", "))
+	}
+	if len(r.Exclude) > 0 {
+		parts = append(parts, "exclude "+strings.Join(r.Exclude, ", "))
+	}
+	if utils.IsNetworkPath(r.Path) {
+		if timeout := r.NetworkTimeout(); timeout > 0 {
+			parts = append(parts, fmt.Sprintf("network, %s timeout", timeout))
+		} else {
+			parts = append(parts, "network, not scanned")
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// MarshalJSON writes roots without options as a plain path so older versions can read them
+func (r ScanRoot) MarshalJSON() ([]byte, error) {
+	if !r.HasOptions() {
+		return json.Marshal(r.Path)
+	}
+	type plain ScanRoot
+	return json.Marshal(plain(r))
+}
+
+// UnmarshalJSON accepts either a plain path string or an object with options
+func (r *ScanRoot) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		*r = ScanRoot{Path: path}
+		return nil
+	}
+	type plain ScanRoot
+	var root plain
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	*r = ScanRoot(root)
+	return nil
+}
+
+// HasScanRoot reports whether a list contains a root for path
+func HasScanRoot(roots []ScanRoot, path string) bool {
+	for _, root := range roots {
+		if utils.SamePath(root.Path, path) {
+			return true
+		}
+	}
+	return false
+}
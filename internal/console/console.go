@@ -0,0 +1,147 @@
+package console
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// utf8Output records whether the console accepted UTF-8 output; Init updates it on Windows
+var utf8Output = true
+
+// UTF8 reports whether emoji and other non-ASCII text can be written to the console
+func UTF8() bool {
+	return utf8Output
+}
+
+// RuneWidth returns the number of terminal columns a rune occupies
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0 || r == 0x200d || (r >= 0xfe00 && r <= 0xfe0f):
+		// NUL, zero width joiner and variation selectors
+		return 0
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide reports East Asian wide and fullwidth runes as well as emoji presentation symbols
+func isWide(r rune) bool {
+	return (r >= 0x1100 && r <= 0x115f) ||
+		(r >= 0x231a && r <= 0x231b) ||
+		(r >= 0x23e9 && r <= 0x23ec) || r == 0x23f0 || r == 0x23f3 ||
+		(r >= 0x25fd && r <= 0x25fe) ||
+		(r >= 0x2614 && r <= 0x2615) ||
+		(r >= 0x2648 && r <= 0x2653) ||
+		r == 0x267f || r == 0x2693 || r == 0x26a1 ||
+		(r >= 0x26aa && r <= 0x26ab) ||
+		(r >= 0x26bd && r <= 0x26be) ||
+		(r >= 0x26c4 && r <= 0x26c5) ||
+		r == 0x26ce || r == 0x26d4 || r == 0x26ea ||
+		(r >= 0x26f2 && r <= 0x26f3) || r == 0x26f5 || r == 0x26fa || r == 0x26fd ||
+		r == 0x2705 || (r >= 0x270a && r <= 0x270b) || r == 0x2728 || r == 0x274c || r == 0x274e ||
+		(r >= 0x2753 && r <= 0x2755) || r == 0x2757 ||
+		(r >= 0x2795 && r <= 0x2797) || r == 0x27b0 || r == 0x27bf ||
+		(r >= 0x2b1b && r <= 0x2b1c) || r == 0x2b50 || r == 0x2b55 ||
+		(r >= 0x2e80 && r <= 0x303e) ||
+		(r >= 0x3041 && r <= 0x33ff) ||
+		(r >= 0x3400 && r <= 0x4dbf) ||
+		(r >= 0x4e00 && r <= 0x9fff) ||
+		(r >= 0xa000 && r <= 0xa4cf) ||
+		(r >= 0xac00 && r <= 0xd7a3) ||
+		(r >= 0xf900 && r <= 0xfaff) ||
+		(r >= 0xfe30 && r <= 0xfe4f) ||
+		(r >= 0xff00 && r <= 0xff60) ||
+		(r >= 0xffe0 && r <= 0xffe6) ||
+		(r >= 0x1f300 && r <= 0x1f64f) ||
+		(r >= 0x1f680 && r <= 0x1f6ff) ||
+		(r >= 0x1f900 && r <= 0x1f9ff) ||
+		(r >= 0x1fa70 && r <= 0x1faff) ||
+		(r >= 0x20000 && r <= 0x3fffd)
+}
+
+// Width returns the number of terminal columns s occupies
+func Width(s string) int {
+	width := 0
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+// Pad appends spaces to s until it fills width columns
+func Pad(s string, width int) string {
+	w := Width(s)
+	if w >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-w)
+}
+
+// Truncate shortens s to at most width columns, ending with "..." when anything was cut
+func Truncate(s string, width int) string {
+	if Width(s) <= width {
+		return s
+	}
+	if width <= 3 {
+		return strings.Repeat(".", max(width, 0))
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := RuneWidth(r)
+		if used+w > width-3 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "..."
+}
+
+// asciiFallbacks replaces the emoji this tool prints when the console cannot show them
+var asciiFallbacks = map[rune]string{
+	'✅': "[OK]", '❌': "[XX]", '⚠': "[!!]", '❓': "[??]", 'ℹ': "[--]",
+	'🔍': ">", '🔧': ">", '📦': ">", '📁': ">", '📋': ">", '💡': "Tip:", '🚀': ">",
+	'🔄': ">", '🧹': ">", '🎉': "", '⏳': "...", '✓': "+", '✗': "x", '→': "->", '•': "*", '—': "-",
+}
+
+// sanitize returns s unchanged on UTF-8 consoles and an ASCII approximation otherwise
+func sanitize(s string) string {
+	if utf8Output || isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case RuneWidth(r) == 0:
+			// Variation selectors and joiners have nothing to fall back to
+		default:
+			if text, ok := asciiFallbacks[r]; ok {
+				b.WriteString(text)
+			} else if r <= 0xff {
+				// Latin-1 survives most Western code pages
+				b.WriteRune(r)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package console
+
+// Init is a no-op outside Windows, where terminals already use UTF-8
+func Init() bool {
+	utf8Output = true
+	return true
+}
+
+// OwnWindow is always false outside Windows, where programs run in a terminal that stays open
+func OwnWindow() bool {
+	return false
+}
@@ -0,0 +1,61 @@
+package console
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// utf8CodePage is the Windows code page identifier for UTF-8
+const utf8CodePage = 65001
+
+var (
+	kernel32               = windows.NewLazySystemDLL("kernel32.dll")
+	procSetConsoleOutputCP = kernel32.NewProc("SetConsoleOutputCP")
+	procSetConsoleCP       = kernel32.NewProc("SetConsoleCP")
+	procGetConsoleOutputCP = kernel32.NewProc("GetConsoleOutputCP")
+	procGetConsoleCP       = kernel32.NewProc("GetConsoleCP")
+	procGetConsoleProcList = kernel32.NewProc("GetConsoleProcessList")
+)
+
+// Init switches the console to UTF-8 and enables ANSI escape sequences; it returns false when
+// the console keeps a legacy code page, in which case standard output falls back to ASCII. The
+// console is shared with the shell that started the tool, so Reset puts the code pages and mode
+// back on exit.
+func Init() bool {
+	handle := windows.Handle(os.Stdout.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		// Redirected to a file or pipe: write UTF-8 and let the reader decode it
+		utf8Output = true
+		return true
+	}
+	outputCP, _, _ := procGetConsoleOutputCP.Call()
+	inputCP, _, _ := procGetConsoleCP.Call()
+	restoreConsole = func() {
+		procSetConsoleOutputCP.Call(outputCP)
+		procSetConsoleCP.Call(inputCP)
+		windows.SetConsoleMode(handle, mode)
+	}
+	windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+
+	procSetConsoleOutputCP.Call(utf8CodePage)
+	procSetConsoleCP.Call(utf8CodePage)
+	cp, _, _ := procGetConsoleOutputCP.Call()
+	utf8Output = cp == utf8CodePage
+	if !utf8Output {
+		sanitizeStdout()
+	}
+	return utf8Output
+}
+
+// OwnWindow reports whether the console window was opened for this process alone, as it is when
+// the exe is started from Explorer. That window closes as soon as the process exits, so errors
+// have to wait for Enter to be read.
+func OwnWindow() bool {
+	// A console shared with cmd or PowerShell lists them as well; only the list size matters
+	var processes [2]uint32
+	count, _, _ := procGetConsoleProcList.Call(uintptr(unsafe.Pointer(&processes[0])), uintptr(len(processes)))
+	return count == 1
+}
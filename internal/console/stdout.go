@@ -0,0 +1,75 @@
+package console
+
+import (
+	"os"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+var (
+	// restoreConsole puts back the console settings Init changed; nil when it changed none
+	restoreConsole func()
+	// closeStdout flushes and removes the ASCII filter in front of standard output, if any
+	closeStdout func()
+	resetOnce   sync.Once
+)
+
+// sanitizeStdout puts a filter in front of standard output that passes everything through
+// sanitize, so fmt, color and prompt output all fall back to ASCII the same way
+func sanitizeStdout() {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return
+	}
+	stdout, colorOutput := os.Stdout, color.Output
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 4096)
+		pending := 0
+		for {
+			n, err := r.Read(buf[pending:])
+			n += pending
+			// A rune split between two writes is kept until the rest of it arrives
+			end := n
+			for i := n - 1; i >= 0 && i >= n-utf8.UTFMax; i-- {
+				if utf8.RuneStart(buf[i]) {
+					if !utf8.FullRune(buf[i:n]) {
+						end = i
+					}
+					break
+				}
+			}
+			stdout.WriteString(sanitize(string(buf[:end])))
+			pending = copy(buf, buf[end:n])
+			if err != nil {
+				stdout.WriteString(sanitize(string(buf[:pending])))
+				return
+			}
+		}
+	}()
+
+	os.Stdout, color.Output = w, w
+	closeStdout = func() {
+		os.Stdout, color.Output = stdout, colorOutput
+		w.Close()
+		<-done
+		r.Close()
+	}
+}
+
+// Reset flushes output and puts back the console's code pages and mode as they were before
+// Init, for the shell the tool was started from. It is deferred in main and also called before
+// the tool exits through os.Exit, which skips deferred calls.
+func Reset() {
+	resetOnce.Do(func() {
+		if closeStdout != nil {
+			closeStdout()
+		}
+		if restoreConsole != nil {
+			restoreConsole()
+		}
+	})
+}
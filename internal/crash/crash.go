@@ -0,0 +1,198 @@
+package crash
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/console"
+	"ue-git-plugin-manager/internal/logging"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// IssuesURL is where crash reports are filed
+const IssuesURL = "https://github.com/benjavides/ue-git-plugin-manager/issues/new"
+
+const (
+	// logTailLines is how much of the log goes into a report
+	logTailLines = 100
+	// logTailSize bounds how much of the log file is read to find those lines
+	logTailSize = 64 * 1024
+	// maxIssueStack keeps the pre-filled issue URL within what browsers accept; the full stack
+	// is in the report file
+	maxIssueStack = 3000
+	// exitCode is what a crashed run exits with, the same as a failed command line run
+	exitCode = 3
+)
+
+var (
+	reportsDir  string
+	logFile     string
+	snapshot    func() ([]byte, error)
+	interactive = true
+)
+
+// Configure sets where reports are written, the log whose tail they include and how the
+// configuration is read. Until then reports go to the temp folder without log or config.
+func Configure(dir, log string, configSnapshot func() ([]byte, error)) {
+	reportsDir, logFile, snapshot = dir, log, configSnapshot
+}
+
+// SetInteractive turns the prompts after a crash off for command line runs, which may have no
+// one at the console
+func SetInteractive(enabled bool) {
+	interactive = enabled
+}
+
+// Recover turns a panic on the calling goroutine into a crash report. It has to be deferred
+// directly in main; panics on other goroutines still end the program without a report.
+func Recover() {
+	value := recover()
+	if value == nil {
+		return
+	}
+	stack := debug.Stack()
+
+	path, err := writeReport(value, stack)
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintf(os.Stderr, "💥 UE Git Plugin Manager crashed: %v\n", value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "   The crash report could not be written: %v\n", err)
+		fmt.Fprintf(os.Stderr, "%s\n", stack)
+	} else {
+		fmt.Fprintf(os.Stderr, "   Crash report: %s\n", path)
+	}
+
+	if interactive {
+		fmt.Println()
+		fmt.Println("The report has the error, the end of the log and your settings with credentials")
+		fmt.Println("masked. It still contains engine and project paths; look it over before sharing it.")
+		if utils.Confirm("Open a pre-filled issue on GitHub?") {
+			if err := utils.OpenURL(issueURL(value, stack, path)); err != nil {
+				fmt.Printf("❌ Failed to open the browser: %v\n", err)
+				fmt.Printf("   Report it at %s\n", IssuesURL)
+			} else if path != "" {
+				fmt.Println("Attach the crash report to the issue.")
+			}
+		}
+		utils.Pause()
+	}
+	console.Reset()
+	os.Exit(exitCode)
+}
+
+// writeReport writes the panic, its stack, the tail of the log and the masked configuration to
+// a new file and returns its path
+func writeReport(value interface{}, stack []byte) (string, error) {
+	dir := reportsDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %v", dir, err)
+	}
+
+	var report bytes.Buffer
+	now := time.Now()
+	fmt.Fprintf(&report, "UE Git Plugin Manager crash report\n")
+	fmt.Fprintf(&report, "Time:    %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&report, "OS:      %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&report, "Go:      %s\n", runtime.Version())
+	if exe, err := os.Executable(); err == nil {
+		fmt.Fprintf(&report, "Exe:     %s\n", exe)
+	}
+	fmt.Fprintf(&report, "Args:    %s\n", logging.Redact(strings.Join(os.Args[1:], " ")))
+	fmt.Fprintf(&report, "\n== Panic ==\n%v\n\n%s\n", value, stack)
+
+	fmt.Fprintf(&report, "\n== Log (last %d lines) ==\n", logTailLines)
+	if tail, err := readLogTail(); err != nil {
+		fmt.Fprintf(&report, "(not available: %v)\n", err)
+	} else {
+		report.WriteString(logging.Redact(tail))
+	}
+
+	fmt.Fprintf(&report, "\n== Configuration (credentials masked) ==\n")
+	report.WriteString(configSection())
+	report.WriteString("\n")
+
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s.txt", now.Format("20060102-150405")))
+	if err := os.WriteFile(path, report.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return path, nil
+}
+
+// readLogTail returns the last lines of the log
+func readLogTail() (string, error) {
+	if logFile == "" {
+		return "", fmt.Errorf("no log file")
+	}
+	file, err := os.Open(logFile)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if info, err := file.Stat(); err == nil && info.Size() > logTailSize {
+		file.Seek(info.Size()-logTailSize, io.SeekStart)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > logTailLines {
+		lines = lines[len(lines)-logTailLines:]
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// configSection returns the masked configuration, or why it is missing. A broken config must
+// not stop the report from being written, so a panic while reading it is caught as well.
+func configSection() (section string) {
+	if snapshot == nil {
+		return "(not available)\n"
+	}
+	defer func() {
+		if value := recover(); value != nil {
+			section = fmt.Sprintf("(not available: %v)\n", value)
+		}
+	}()
+	data, err := snapshot()
+	if err != nil {
+		return fmt.Sprintf("(not available: %v)\n", err)
+	}
+	return string(data)
+}
+
+// issueURL returns a new issue pre-filled with the panic and the start of its stack
+func issueURL(value interface{}, stack []byte, reportPath string) string {
+	trace := string(stack)
+	if len(trace) > maxIssueStack {
+		trace = trace[:maxIssueStack] + "\n..."
+	}
+	title := fmt.Sprintf("Crash: %v", value)
+	if len(title) > 120 {
+		title = title[:120]
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "**What I was doing:**\n\n\n")
+	fmt.Fprintf(&body, "**Error:** `%v`\n\n", value)
+	fmt.Fprintf(&body, "**OS:** %s/%s\n\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&body, "```\n%s\n```\n", logging.Redact(trace))
+	if reportPath != "" {
+		fmt.Fprintf(&body, "\nThe full report is in `%s` (attach it after checking it for anything private).\n", filepath.Base(reportPath))
+	}
+
+	query := url.Values{}
+	query.Set("title", title)
+	query.Set("body", body.String())
+	return IssuesURL + "?" + query.Encode()
+}
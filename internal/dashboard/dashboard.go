@@ -0,0 +1,233 @@
+package dashboard
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/report"
+)
+
+// logTailSize is how much of the end of the log is searched for recent operations
+const logTailSize = 512 * 1024
+
+// stepLinePattern matches the step_finished entries the events log handler writes, with or
+// without the level column older versions didn't write
+var stepLinePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})\.\d{3} (?:[A-Z]+ +)?\[` + events.KindStepFinished + `\] (\S+) (?:took (\S+)|failed after (\S+): (.*))$`)
+
+// Operation is a finished step of an earlier setup, update or build, read back from the log
+type Operation struct {
+	Time     string `json:"time"`
+	Step     string `json:"step"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Data is everything the dashboard shows
+type Data struct {
+	Machine    report.MachineStatus `json:"machine"`
+	Operations []Operation          `json:"operations"`
+}
+
+// Collector gathers the dashboard data; it is called at most once per refresh interval
+type Collector func() (Data, error)
+
+// ReadOperations returns up to limit of the most recent finished steps in the log, newest first
+func ReadOperations(logPath string, limit int) []Operation {
+	file, err := os.Open(logPath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	if info, err := file.Stat(); err == nil && info.Size() > logTailSize {
+		file.Seek(info.Size()-logTailSize, io.SeekStart)
+	}
+
+	var operations []Operation
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		match := stepLinePattern.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		op := Operation{Time: match[1], Step: match[2], Duration: match[3]}
+		if op.Duration == "" {
+			op.Duration, op.Error = match[4], match[5]
+		}
+		operations = append(operations, op)
+	}
+
+	// Newest first
+	for i, j := 0, len(operations)-1; i < j; i, j = i+1, j-1 {
+		operations[i], operations[j] = operations[j], operations[i]
+	}
+	if len(operations) > limit {
+		operations = operations[:limit]
+	}
+	return operations
+}
+
+// Handler serves the dashboard page at "/" and the same data as JSON at "/status.json". It only
+// answers GET requests; nothing on the machine can be changed through it. Data is collected
+// again once it is older than refresh.
+func Handler(collect Collector, refresh time.Duration) http.Handler {
+	var (
+		mu        sync.Mutex
+		cached    Data
+		cachedErr error
+		collected time.Time
+	)
+	current := func() (Data, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if collected.IsZero() || time.Since(collected) >= refresh {
+			cached, cachedErr = collect()
+			collected = time.Now()
+		}
+		return cached, cachedErr
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if !allowRequest(w, r) {
+			return
+		}
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := current()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		page, err := Render(data, refresh)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+	mux.HandleFunc("/status.json", func(w http.ResponseWriter, r *http.Request) {
+		if !allowRequest(w, r) {
+			return
+		}
+		data, err := current()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(data)
+	})
+	return mux
+}
+
+// allowRequest rejects everything but GET and HEAD
+func allowRequest(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "the dashboard is read-only", http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+// Render renders the dashboard page, which reloads itself every refresh interval
+func Render(data Data, refresh time.Duration) ([]byte, error) {
+	funcs := template.FuncMap{
+		"timestamp": formatTimestamp,
+		"state":     describeState,
+		"short":     git.ShortSHA,
+		"join":      strings.Join,
+	}
+	tmpl, err := template.New("dashboard").Funcs(funcs).Parse(pageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	page := struct {
+		Data
+		RefreshSeconds int
+	}{data, int(refresh.Seconds())}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func describeState(eng report.EngineStatus) string {
+	switch eng.State {
+	case report.StateBroken:
+		return "Broken"
+	case report.StateNotSetUp:
+		return "Not set up"
+	}
+	if eng.CommitsBehind > 0 {
+		return "Update available"
+	}
+	return "OK"
+}
+
+func formatTimestamp(value string) string {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return parsed.Local().Format("2006-01-02 15:04")
+}
+
+const pageTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+{{if .RefreshSeconds}}<meta http-equiv="refresh" content="{{.RefreshSeconds}}">{{end}}
+<title>{{.Machine.Machine}} - UE Git Plugin Manager</title>
+<style>
+body { font-family: Segoe UI, Arial, sans-serif; font-size: 14px; margin: 24px; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+.ok { color: #1a7f37; } .warn { color: #9a6700; } .bad { color: #cf222e; } .dim { color: #777; }
+</style>
+</head>
+<body>
+<h1>{{.Machine.Machine}}</h1>
+<p class="dim">Collected {{timestamp .Machine.CollectedUTC}} &mdash; read-only view{{if .RefreshSeconds}}, refreshes every {{.RefreshSeconds}}s{{end}} &mdash; <a href="status.json">JSON</a></p>
+
+<h2>Engines</h2>
+{{if .Machine.Engines}}<table>
+<tr><th>Engine</th><th>Path</th><th>State</th><th>Commits behind</th><th>Commit</th><th>Last updated</th><th>Issues</th></tr>
+{{range .Machine.Engines}}{{$state := state .}}<tr>
+<td>UE {{.Version}}</td><td><code>{{.Path}}</code></td>
+<td class="{{if eq $state "OK"}}ok{{else if eq $state "Broken"}}bad{{else if eq $state "Not set up"}}dim{{else}}warn{{end}}">{{$state}}</td>
+<td>{{if eq .State "complete"}}{{.CommitsBehind}}{{end}}</td>
+<td><code>{{short .LocalSHA}}</code></td>
+<td>{{timestamp .LastUpdatedUTC}}</td>
+<td>{{join .Issues "; "}}</td>
+</tr>
+{{end}}</table>{{else}}<p>No engines detected.</p>{{end}}
+
+<h2>Recent operations</h2>
+{{if .Operations}}<table>
+<tr><th>Time</th><th>Step</th><th>Duration</th><th>Result</th></tr>
+{{range .Operations}}<tr><td>{{.Time}}</td><td>{{.Step}}</td><td>{{.Duration}}</td><td>{{if .Error}}<span class="bad">{{.Error}}</span>{{else}}<span class="ok">OK</span>{{end}}</td></tr>
+{{end}}</table>{{else}}<p class="dim">No operations in the log yet.</p>{{end}}
+</body>
+</html>
+`
@@ -5,27 +5,65 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
+	"ue-git-plugin-manager/internal/config"
 	"ue-git-plugin-manager/internal/engine"
 	"ue-git-plugin-manager/internal/git"
 	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/projects"
+	"ue-git-plugin-manager/internal/theme"
+	"ue-git-plugin-manager/internal/utils"
 )
 
 // SetupStatus represents the current state of the setup for a specific engine
 type SetupStatus struct {
-	EngineVersion     string   `json:"engine_version"`
-	EnginePath        string   `json:"engine_path"`
-	IsSetupComplete   bool     `json:"is_setup_complete"`
-	JunctionExists    bool     `json:"junction_exists"`
-	JunctionValid     bool     `json:"junction_valid"`
-	BinariesExist     bool     `json:"binaries_exist"`
-	WorktreeExists    bool     `json:"worktree_exists"`
-	StockPluginStatus string   `json:"stock_plugin_status"` // "enabled", "disabled", "not_found"
-	Issues            []string `json:"issues"`
-	IsNeverSetUp      bool     `json:"is_never_set_up"` // True if this engine was never set up
-	IsBroken          bool     `json:"is_broken"`       // True if it was set up but is now broken
+	EngineVersion     string            `json:"engine_version"`
+	EnginePath        string            `json:"engine_path"`
+	WorktreeSubdir    string            `json:"worktree_subdir"`
+	IsSetupComplete   bool              `json:"is_setup_complete"`
+	JunctionExists    bool              `json:"junction_exists"`
+	JunctionValid     bool              `json:"junction_valid"`
+	JunctionTarget    string            `json:"junction_target,omitempty"` // Where the link points, or the worktree a copy was made from
+	PluginCopied      bool              `json:"plugin_copied,omitempty"`   // The engine is on a network share and holds a copy instead of a junction
+	BinariesExist     bool              `json:"binaries_exist"`
+	BinariesStale     bool              `json:"binaries_stale"`           // Binaries were built from a different commit than the worktree's
+	BinariesCorrupted bool              `json:"binaries_corrupted"`       // Binaries are missing or changed since they were built
+	EngineUpdated     string            `json:"engine_updated,omitempty"` // Engine patch the binaries were built for and the one installed now, e.g. "5.4.3 → 5.4.4"
+	PluginVersionName string            `json:"plugin_version_name"`      // VersionName from the worktree's .uplugin, including the build stamp
+	EditorLoadIssues  []string          `json:"editor_load_issues"`       // Reasons the editor would not load our binaries
+	WorktreeExists    bool              `json:"worktree_exists"`
+	StockPluginStatus string            `json:"stock_plugin_status"` // "enabled", "disabled", "not_found"
+	Issues            []string          `json:"issues"`
+	Projects          []string          `json:"projects"`                    // Registered projects that use this engine
+	ProjectChecks     []ProjectCheck    `json:"project_checks,omitempty"`    // Last source control smoke test of those projects
+	ProjectOverrides  []ProjectOverride `json:"project_overrides,omitempty"` // Copies of the plugin in those projects that hide this engine's
+	LinkOwner         string            `json:"link_owner,omitempty"`        // Other Windows user whose setup the plugin link points into
+	LinkOwnerChoice   string            `json:"link_owner_choice,omitempty"` // config.OtherUserAdopt or OtherUserCoexist, once chosen
+	IsNeverSetUp      bool              `json:"is_never_set_up"`             // True if this engine was never set up
+	IsBroken          bool              `json:"is_broken"`                   // True if it was set up but is now broken
 }
 
+// ProjectCheck is the last source control smoke test of a registered project
+type ProjectCheck struct {
+	Project string                    `json:"project"`
+	Check   config.SourceControlCheck `json:"check"`
+}
+
+// ProjectOverride is a copy of GitSourceControl in a registered project's Plugins folder. The
+// editor loads it instead of the engine's plugin, so engine-level updates never reach that project.
+type ProjectOverride struct {
+	Project     string `json:"project"`
+	ProjectRoot string `json:"project_root"`
+	Dir         string `json:"dir"`
+	Submodule   bool   `json:"submodule,omitempty"` // The plugin submodule added from Project Tools
+	Kept        bool   `json:"kept,omitempty"`      // The user chose to keep it, so it isn't reported as an issue
+}
+
+// stockPluginIssue is reported while the engine's own Git plugin would load next to ours
+const stockPluginIssue = "Stock Git plugin is still enabled (may cause conflicts)"
+
 // Detector handles detection of current setup state
 type Detector struct {
 	exeDir  string
@@ -33,6 +71,16 @@ type Detector struct {
 	engine  *engine.Manager
 	git     *git.Manager
 	plugin  *plugin.Manager
+
+	// records serializes writes to the status cache and history, since the main menu detects in
+	// the background while an action may detect in the foreground
+	records sync.Mutex
+
+	// discovery guards the engines found by the last discovery, see discoverEngines
+	discovery       sync.Mutex
+	discovered      []engine.EngineInfo
+	discoveredRoots string
+	discoveredAt    time.Time
 }
 
 // New creates a new detector
@@ -57,33 +105,175 @@ func NewWithBaseDir(exeDir, baseDir string) *Detector {
 	}
 }
 
-// DetectSetupStatus detects the current setup status for all discovered engines
-func (d *Detector) DetectSetupStatus(customEngineRoots []string) ([]SetupStatus, error) {
+// DetectSetupStatus detects the current setup status for all discovered engines and records it
+// in the status cache and history
+func (d *Detector) DetectSetupStatus(cfg *config.Config) ([]SetupStatus, error) {
+	statuses, err := d.ReadSetupStatus(cfg)
+	if err != nil {
+		return nil, err
+	}
+	d.records.Lock()
+	defer d.records.Unlock()
+	d.cacheStatuses(statuses)
+	d.recordHistory(statuses)
+	return statuses, nil
+}
+
+// ReadSetupStatus detects the current setup status for all discovered engines without writing
+// anything, for viewers such as the dashboard that only report it
+func (d *Detector) ReadSetupStatus(cfg *config.Config) ([]SetupStatus, error) {
 	// Discover all engines
-	engines, err := d.engine.DiscoverEngines(customEngineRoots)
+	engines, err := d.discoverEngines(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover engines: %w", err)
 	}
 
+	// Read registered projects once so each engine can list the projects that use it
+	var projectInfos []projects.Info
+	var projectChecks []*config.SourceControlCheck
+	var projectOverrides [][]ProjectOverride
+	for _, project := range cfg.Projects {
+		info, err := projects.Read(project.Path)
+		if err != nil {
+			continue
+		}
+		if project.Name != "" {
+			info.Name = project.Name
+		}
+		projectInfos = append(projectInfos, info)
+		projectChecks = append(projectChecks, project.SourceControlCheck)
+		projectOverrides = append(projectOverrides, d.findProjectOverrides(cfg, info))
+	}
+
 	var statuses []SetupStatus
 	for _, eng := range engines {
-		status := d.detectEngineSetupStatus(eng.Path, eng.Version)
+		subdir := config.WorktreeSubdirFor(cfg, eng.Path, eng.Version)
+		status := d.detectEngineSetupStatus(eng.Path, eng.Version, subdir)
+		d.checkEngineHotfix(cfg, &status)
+		d.applyOtherUserChoice(cfg, &status)
+		for i, info := range projectInfos {
+			if info.UsesEngine(eng.Path, eng.Version) {
+				status.Projects = append(status.Projects, info.Name)
+				if projectChecks[i] != nil {
+					status.ProjectChecks = append(status.ProjectChecks, ProjectCheck{Project: info.Name, Check: *projectChecks[i]})
+				}
+				// Without the engine's plugin there is nothing for the project's copy to hide
+				if status.JunctionExists || status.PluginCopied {
+					for _, override := range projectOverrides[i] {
+						status.ProjectOverrides = append(status.ProjectOverrides, override)
+						if !override.Kept {
+							status.Issues = append(status.Issues, fmt.Sprintf("Project %s overrides the engine plugin with its own copy in %s", info.Name, override.Dir))
+						}
+					}
+				}
+			}
+		}
 		statuses = append(statuses, status)
 	}
-
 	return statuses, nil
 }
 
+// FindProjectOverrides returns the copies of the plugin in every registered project
+func (d *Detector) FindProjectOverrides(cfg *config.Config) []ProjectOverride {
+	var overrides []ProjectOverride
+	for _, project := range cfg.Projects {
+		info, err := projects.Read(project.Path)
+		if err != nil {
+			continue
+		}
+		if project.Name != "" {
+			info.Name = project.Name
+		}
+		overrides = append(overrides, d.findProjectOverrides(cfg, info)...)
+	}
+	return overrides
+}
+
+// findProjectOverrides returns the copies of the plugin in one project
+func (d *Detector) findProjectOverrides(cfg *config.Config, info projects.Info) []ProjectOverride {
+	submoduleDir := filepath.Join(info.Root, filepath.FromSlash(git.ProjectPluginDir))
+	var overrides []ProjectOverride
+	for _, dir := range d.plugin.FindProjectPluginCopies(info.Root) {
+		overrides = append(overrides, ProjectOverride{
+			Project:     info.Name,
+			ProjectRoot: info.Root,
+			Dir:         dir,
+			Submodule:   utils.SamePath(dir, submoduleDir),
+			Kept:        cfg.IsPluginOverrideKept(dir),
+		})
+	}
+	return overrides
+}
+
+// MovedEngine is a managed engine whose folder no longer exists, with installs that may be it
+type MovedEngine struct {
+	Engine      config.Engine
+	Candidates  []engine.EngineInfo
+	SameInstall bool // The first candidate carries the engine's install ID, so it is certainly the same install
+}
+
+// FindMovedEngines returns managed engines whose path is gone, each with discovered unmanaged
+// installs of the same version (and same build, when the fingerprint was recorded). An install
+// with the engine's install ID is listed first, and installs with another ID are left out.
+func (d *Detector) FindMovedEngines(cfg *config.Config) ([]MovedEngine, error) {
+	var missing []config.Engine
+	for _, eng := range cfg.Engines {
+		if _, err := os.Stat(eng.EnginePath); os.IsNotExist(err) {
+			missing = append(missing, eng)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	engines, err := d.discoverEngines(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover engines: %w", err)
+	}
+
+	managed := make(map[string]bool)
+	for _, eng := range cfg.Engines {
+		managed[utils.PathKey(eng.EnginePath)] = true
+	}
+
+	var moved []MovedEngine
+	for _, eng := range missing {
+		entry := MovedEngine{Engine: eng}
+		for _, candidate := range engines {
+			if managed[utils.PathKey(candidate.Path)] {
+				continue
+			}
+			if id := d.engine.InstallID(candidate.Path); eng.InstallID != "" && id != "" {
+				if id == eng.InstallID {
+					entry.Candidates = append([]engine.EngineInfo{candidate}, entry.Candidates...)
+					entry.SameInstall = true
+				}
+				continue
+			}
+			if candidate.Version != eng.EngineVersion {
+				continue
+			}
+			if eng.BuildFingerprint != "" && d.engine.BuildFingerprint(candidate.Path) != eng.BuildFingerprint {
+				continue
+			}
+			entry.Candidates = append(entry.Candidates, candidate)
+		}
+		moved = append(moved, entry)
+	}
+	return moved, nil
+}
+
 // DetectEngineSetupStatus detects the setup status for a specific engine
-func (d *Detector) DetectEngineSetupStatus(enginePath, engineVersion string) SetupStatus {
-	return d.detectEngineSetupStatus(enginePath, engineVersion)
+func (d *Detector) DetectEngineSetupStatus(enginePath, engineVersion, worktreeSubdir string) SetupStatus {
+	return d.detectEngineSetupStatus(enginePath, engineVersion, worktreeSubdir)
 }
 
 // detectEngineSetupStatus performs the actual detection for a single engine
-func (d *Detector) detectEngineSetupStatus(enginePath, engineVersion string) SetupStatus {
+func (d *Detector) detectEngineSetupStatus(enginePath, engineVersion, worktreeSubdir string) SetupStatus {
 	status := SetupStatus{
 		EngineVersion:   engineVersion,
 		EnginePath:      enginePath,
+		WorktreeSubdir:  worktreeSubdir,
 		IsSetupComplete: false,
 		Issues:          []string{},
 		IsNeverSetUp:    false,
@@ -91,22 +281,30 @@ func (d *Detector) detectEngineSetupStatus(enginePath, engineVersion string) Set
 	}
 
 	// Check if worktree exists
-	worktreePath := d.git.GetWorktreePath(engineVersion)
-	status.WorktreeExists = d.git.WorktreeExists(engineVersion)
+	worktreePath := d.git.GetWorktreePath(worktreeSubdir)
+	status.WorktreeExists = d.git.WorktreeExists(worktreeSubdir)
 	if !status.WorktreeExists {
 		status.Issues = append(status.Issues, "Worktree does not exist")
 	}
 
-	// Check if junction exists
-	pluginLinkPath := d.plugin.GetPluginLinkPath(enginePath)
-	status.JunctionExists = d.plugin.JunctionExists(pluginLinkPath)
+	// Check if junction exists; one query gives its type and target
+	link := d.plugin.InspectLink(d.plugin.GetPluginLinkPath(enginePath))
+	status.JunctionExists = link.IsLink || link.IsCopy
+	status.PluginCopied = link.IsCopy
+	status.JunctionTarget = link.Target
 	if !status.JunctionExists {
 		status.Issues = append(status.Issues, "Plugin junction does not exist")
 	} else {
 		// Check if junction is valid (points to correct worktree)
-		status.JunctionValid = d.plugin.VerifyJunction(enginePath, worktreePath)
+		status.JunctionValid = utils.SamePath(link.Target, worktreePath)
 		if !status.JunctionValid {
-			status.Issues = append(status.Issues, "Plugin junction points to incorrect location")
+			// Another Windows user's setup is not broken, only not this user's
+			if owner := otherUserOf(link.Target); owner != "" {
+				status.LinkOwner = owner
+				status.Issues = append(status.Issues, fmt.Sprintf(linkOwnerIssue, owner, link.Target))
+			} else {
+				status.Issues = append(status.Issues, "Plugin junction points to incorrect location")
+			}
 		}
 	}
 
@@ -114,15 +312,48 @@ func (d *Detector) detectEngineSetupStatus(enginePath, engineVersion string) Set
 	if status.WorktreeExists {
 		binariesPath := filepath.Join(worktreePath, "Binaries", "Win64")
 		status.BinariesExist = d.checkBinariesExist(binariesPath)
-		if !status.BinariesExist {
+		// Detection only reads; the next build puts binaries an interrupted install moved aside back
+		if d.plugin.InterruptedInstall(worktreePath) {
+			status.Issues = append(status.Issues, "An install of new plugin binaries was interrupted; repair restores the previous ones")
+		}
+
+		// Files quarantined by an antivirus or damaged by a partial copy no longer match the build's hashes
+		if problems := d.plugin.VerifyBinaries(worktreePath); len(problems) > 0 {
+			status.BinariesCorrupted = true
+			for _, problem := range problems {
+				status.Issues = append(status.Issues, "Plugin binaries corrupted: "+problem)
+			}
+		} else if !status.BinariesExist {
 			status.Issues = append(status.Issues, "Plugin binaries not found in worktree")
 		}
+
+		// Compare the commit stamped at build time with the worktree's current commit
+		if stamp, err := d.plugin.ReadVersionStamp(worktreePath); err == nil {
+			status.PluginVersionName = stamp.VersionName
+			if status.BinariesExist && stamp.CommitSHA != "" {
+				if head, err := d.git.GetHeadSHA(worktreeSubdir); err == nil && !strings.HasPrefix(head, stamp.CommitSHA) {
+					status.BinariesStale = true
+					status.Issues = append(status.Issues, fmt.Sprintf("Plugin binaries were built from commit %s but the worktree is at %s", stamp.CommitSHA, git.ShortSHA(head)))
+				}
+			}
+		}
+	}
+
+	// Confirm the editor will actually load our binaries rather than refuse them or use another copy
+	if status.BinariesExist && status.JunctionValid {
+		loadCheck := d.plugin.CheckEditorLoad(enginePath, worktreePath)
+		if !loadCheck.BuildIDMatches {
+			// A rebuild against this engine produces a matching BuildId
+			status.BinariesStale = true
+		}
+		status.EditorLoadIssues = loadCheck.Issues()
+		status.Issues = append(status.Issues, status.EditorLoadIssues...)
 	}
 
 	// Check stock plugin status
 	status.StockPluginStatus = d.engine.GetStockPluginStatus(enginePath)
 	if status.StockPluginStatus == "enabled" {
-		status.Issues = append(status.Issues, "Stock Git plugin is still enabled (may cause conflicts)")
+		status.Issues = append(status.Issues, stockPluginIssue)
 	}
 
 	// Determine if setup is complete
@@ -130,8 +361,16 @@ func (d *Detector) detectEngineSetupStatus(enginePath, engineVersion string) Set
 		status.JunctionExists &&
 		status.JunctionValid &&
 		status.BinariesExist &&
+		!status.BinariesStale &&
+		!status.BinariesCorrupted &&
+		len(status.EditorLoadIssues) == 0 &&
 		status.StockPluginStatus != "enabled"
 
+	// Setups built before hashes were recorded get them the first time they are found working
+	if status.IsSetupComplete && !d.plugin.HasBinaryHashes(worktreePath) {
+		d.plugin.RecordBinaryHashes(worktreePath)
+	}
+
 	// Determine if this engine was never set up vs. is broken
 	// If nothing exists (no worktree, no junction), it was never set up
 	if !status.WorktreeExists && !status.JunctionExists {
@@ -144,6 +383,43 @@ func (d *Detector) detectEngineSetupStatus(enginePath, engineVersion string) Set
 	return status
 }
 
+// EngineHotfix reports the engine build a managed engine's plugin was last built against and the
+// build installed now, as versions such as "5.4.3" and "5.4.4", when they differ
+func (d *Detector) EngineHotfix(cfg *config.Config, enginePath string) (built, current string, changed bool) {
+	var stored string
+	for _, eng := range cfg.Engines {
+		if utils.SamePath(eng.EnginePath, enginePath) {
+			stored = eng.BuildFingerprint
+		}
+	}
+	installed := d.engine.BuildFingerprint(enginePath)
+	if stored == "" || installed == "" || stored == installed {
+		return "", "", false
+	}
+	built, current = engine.FingerprintVersion(stored), engine.FingerprintVersion(installed)
+	if built == current {
+		// A hotfix can keep the version and only change the changelist
+		built, current = stored, installed
+	}
+	return built, current, true
+}
+
+// checkEngineHotfix marks the binaries stale when the engine was patched since they were built,
+// since binaries built against the old patch sometimes fail to load
+func (d *Detector) checkEngineHotfix(cfg *config.Config, status *SetupStatus) {
+	built, current, changed := d.EngineHotfix(cfg, status.EnginePath)
+	if !changed || !status.BinariesExist {
+		return
+	}
+	status.EngineUpdated = fmt.Sprintf("%s → %s", built, current)
+	status.BinariesStale = true
+	status.Issues = append(status.Issues, fmt.Sprintf("Engine was updated from %s to %s since the plugin was built", built, current))
+	if status.IsSetupComplete {
+		status.IsSetupComplete = false
+		status.IsBroken = true
+	}
+}
+
 // checkBinariesExist checks if the required plugin binaries exist
 func (d *Detector) checkBinariesExist(binariesPath string) bool {
 	// Check if the directory exists
@@ -175,8 +451,8 @@ func (d *Detector) checkBinariesExist(binariesPath string) bool {
 }
 
 // GetSetupSummary returns a summary of the current setup state
-func (d *Detector) GetSetupSummary(customEngineRoots []string) (string, error) {
-	statuses, err := d.DetectSetupStatus(customEngineRoots)
+func (d *Detector) GetSetupSummary(cfg *config.Config) (string, error) {
+	statuses, err := d.DetectSetupStatus(cfg)
 	if err != nil {
 		return "", err
 	}
@@ -193,24 +469,52 @@ func (d *Detector) GetSetupSummary(customEngineRoots []string) (string, error) {
 		summary.WriteString(fmt.Sprintf("Engine %s (%s):\n", status.EngineVersion, status.EnginePath))
 
 		if status.IsSetupComplete {
-			summary.WriteString("  ✅ Setup Complete\n")
+			summary.WriteString("  " + theme.Label(theme.OK, "Setup Complete") + "\n")
 		} else if status.IsNeverSetUp {
-			summary.WriteString("  ℹ️  Not Set Up\n")
+			summary.WriteString("  " + theme.Label(theme.Info, "Not Set Up") + "\n")
 		} else if status.IsBroken {
-			summary.WriteString("  ⚠️  Setup Broken\n")
+			summary.WriteString("  " + theme.Label(theme.Warning, "Setup Broken") + "\n")
 		} else {
-			summary.WriteString("  ❌ Setup Incomplete\n")
+			summary.WriteString("  " + theme.Label(theme.Failure, "Setup Incomplete") + "\n")
 		}
 
 		// Show individual status
 		summary.WriteString(fmt.Sprintf("  - Worktree: %s\n", d.boolToStatus(status.WorktreeExists)))
 		summary.WriteString(fmt.Sprintf("  - Junction: %s\n", d.boolToStatus(status.JunctionExists)))
+		if status.PluginCopied {
+			summary.WriteString("    (network engine: the plugin is copied into the engine instead of linked)\n")
+		}
 		if status.JunctionExists {
 			summary.WriteString(fmt.Sprintf("  - Junction Valid: %s\n", d.boolToStatus(status.JunctionValid)))
 		}
-		summary.WriteString(fmt.Sprintf("  - Binaries: %s\n", d.boolToStatus(status.BinariesExist)))
+		if status.BinariesCorrupted {
+			summary.WriteString("  - Binaries: " + theme.Label(theme.Failure, "Corrupted") + "\n")
+		} else {
+			summary.WriteString(fmt.Sprintf("  - Binaries: %s\n", d.boolToStatus(status.BinariesExist)))
+		}
+		if status.PluginVersionName != "" {
+			summary.WriteString(fmt.Sprintf("  - Plugin Version: %s\n", status.PluginVersionName))
+		}
+		if status.BinariesExist && status.JunctionValid {
+			summary.WriteString(fmt.Sprintf("  - Editor Will Load Plugin: %s\n", d.boolToStatus(len(status.EditorLoadIssues) == 0)))
+		}
 		summary.WriteString(fmt.Sprintf("  - Stock Plugin: %s\n", strings.Title(status.StockPluginStatus)))
 
+		if len(status.Projects) > 0 {
+			summary.WriteString(fmt.Sprintf("  - Projects: %s\n", strings.Join(status.Projects, ", ")))
+		}
+		for _, check := range status.ProjectChecks {
+			result := theme.Label(theme.OK, "Connected")
+			if !check.Check.Passed() {
+				result = theme.Label(theme.Failure, "Failed") + " — " + check.Check.Detail
+			}
+			checked := check.Check.CheckedUTC
+			if t, err := time.Parse(time.RFC3339, checked); err == nil {
+				checked = t.Local().Format("2006-01-02 15:04")
+			}
+			summary.WriteString(fmt.Sprintf("  - Source Control (%s, tested %s): %s\n", check.Project, checked, result))
+		}
+
 		// Only show issues for broken setups, not for engines that were never set up
 		if status.IsBroken && len(status.Issues) > 0 {
 			summary.WriteString("  Issues:\n")
@@ -224,57 +528,148 @@ func (d *Detector) GetSetupSummary(customEngineRoots []string) (string, error) {
 	return summary.String(), nil
 }
 
-// GetSimpleSetupSummary returns a simplified summary for the main menu
-func (d *Detector) GetSimpleSetupSummary(customEngineRoots []string, defaultBranch, pinnedCommit string) (string, error) {
-	statuses, err := d.DetectSetupStatus(customEngineRoots)
+// GetSimpleSetupSummary returns a simplified summary for the main menu and records it, with the
+// update check, in the status cache
+func (d *Detector) GetSimpleSetupSummary(cfg *config.Config) (string, error) {
+	statuses, err := d.ReadSetupStatus(cfg)
 	if err != nil {
 		return "", err
 	}
 
+	cache := StatusCache{CheckedUTC: time.Now().UTC()}
+	for _, status := range statuses {
+		entry := CachedStatus{SetupStatus: status}
+		if status.IsSetupComplete {
+			// Check for updates
+			updateInfo, err := d.git.GetUpdateInfo(status.WorktreeSubdir, cfg.TrackedBranch(status.WorktreeSubdir), cfg.TrackedPin(status.WorktreeSubdir))
+			if err == nil {
+				entry.LocalSHA, entry.RemoteSHA, entry.CommitsAhead = updateInfo.LocalSHA, updateInfo.RemoteSHA, updateInfo.CommitsAhead
+			}
+		}
+		cache.Engines = append(cache.Engines, entry)
+	}
+
+	// Managed engines whose folder is gone don't show up in discovery; their likely new location does
+	if len(statuses) > 0 {
+		if moved, err := d.FindMovedEngines(cfg); err == nil {
+			cache.Moved = moved
+		}
+	}
+
+	d.records.Lock()
+	d.recordHistory(statuses)
+	d.writeStatusCache(cache)
+	d.records.Unlock()
+	return renderSimpleSummary(cfg, &cache), nil
+}
+
+// GetCachedSimpleSetupSummary renders the main menu summary from the last full detection without
+// checking anything, and returns when that detection ran
+func (d *Detector) GetCachedSimpleSetupSummary(cfg *config.Config) (string, time.Time, error) {
+	cache, err := ReadStatusCache(d.baseDir)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return renderSimpleSummary(cfg, cache), cache.CheckedUTC, nil
+}
+
+// renderSimpleSummary formats a detection for the main menu
+func renderSimpleSummary(cfg *config.Config, cache *StatusCache) string {
 	var summary strings.Builder
 	summary.WriteString("🔍 Detected Engines:\n\n")
 
-	if len(statuses) == 0 {
+	if len(cache.Engines) == 0 {
 		summary.WriteString("No Unreal Engine installations found.\n")
-		return summary.String(), nil
+		return summary.String()
 	}
 
-	for _, status := range statuses {
-		statusIcon := "❌"
+	movedFrom := make(map[string]string)
+	for _, entry := range cache.Moved {
+		if len(entry.Candidates) > 0 {
+			movedFrom[utils.PathKey(entry.Candidates[0].Path)] = entry.Engine.EnginePath
+		}
+	}
+
+	for _, status := range cache.Engines {
+		statusIcon := theme.Symbol(theme.Failure)
 		statusText := "Not Set Up"
+		snoozeNote := ""
+
+		if from, ok := movedFrom[utils.PathKey(status.EnginePath)]; ok && !status.IsSetupComplete {
+			statusIcon = theme.Symbol(theme.Warning)
+			statusText = fmt.Sprintf("Moved from %s (re-link available)", from)
+		}
 
 		if status.IsSetupComplete {
-			statusIcon = "✅"
+			statusIcon = theme.Symbol(theme.OK)
 			statusText = "Setup Complete"
 
-			// Check for updates
-			updateInfo, err := d.git.GetUpdateInfo(status.EngineVersion, defaultBranch, pinnedCommit)
-			if err == nil && updateInfo.CommitsAhead > 0 {
-				statusText = fmt.Sprintf("Setup Complete (%d updates available)", updateInfo.CommitsAhead)
+			if status.CommitsAhead > 0 {
+				if until, snoozed := cfg.UpdatesSnoozedUntil(status.WorktreeSubdir); snoozed {
+					snoozeNote = fmt.Sprintf("   Updates snoozed until %s\n", until.Local().Format("Mon Jan 2 15:04"))
+				} else {
+					statusText = fmt.Sprintf("Setup Complete (%d updates available)", status.CommitsAhead)
+				}
 			}
+		} else if status.BinariesCorrupted {
+			statusIcon = theme.Symbol(theme.Warning)
+			statusText = "Binaries Corrupted (repair to rebuild)"
 		} else if status.IsBroken {
-			statusIcon = "⚠️"
+			statusIcon = theme.Symbol(theme.Warning)
 			statusText = "Setup Broken"
 		}
 
+		if status.LinkOwner != "" {
+			switch {
+			case status.LinkOwnerChoice == "":
+				statusIcon = theme.Symbol(theme.Warning)
+				statusText = fmt.Sprintf("Linked to %s's setup (adopt, replace or coexist in Edit Setup)", status.LinkOwner)
+			case status.IsSetupComplete && status.LinkOwnerChoice == config.OtherUserAdopt:
+				statusText = fmt.Sprintf("Using %s's setup", status.LinkOwner)
+			case status.IsSetupComplete:
+				statusText = fmt.Sprintf("Setup Complete (link left on %s's setup)", status.LinkOwner)
+			}
+		}
+
 		summary.WriteString(fmt.Sprintf("%s UE %s - %s\n", statusIcon, status.EngineVersion, statusText))
-		summary.WriteString(fmt.Sprintf("   %s\n\n", status.EnginePath))
+		summary.WriteString(fmt.Sprintf("   %s\n", status.EnginePath))
+		if len(status.Projects) > 0 {
+			summary.WriteString(fmt.Sprintf("   Projects: %s\n", strings.Join(status.Projects, ", ")))
+		}
+		for _, override := range status.ProjectOverrides {
+			if !override.Kept {
+				summary.WriteString(fmt.Sprintf("   %s Project %s uses its own plugin copy, not this engine's\n", theme.Symbol(theme.Warning), override.Project))
+			}
+		}
+		if snoozeNote != "" {
+			summary.WriteString(theme.Subdued(snoozeNote))
+		}
+		summary.WriteString("\n")
 	}
 
-	return summary.String(), nil
+	// List the missing engines too so they aren't silently lost
+	for _, entry := range cache.Moved {
+		summary.WriteString(fmt.Sprintf("%s UE %s - Engine folder not found\n", theme.Symbol(theme.Warning), entry.Engine.EngineVersion))
+		summary.WriteString(fmt.Sprintf("   %s\n", entry.Engine.EnginePath))
+		if entry.SameInstall {
+			summary.WriteString(fmt.Sprintf("   Moved to %s\n", entry.Candidates[0].Path))
+		} else if len(entry.Candidates) > 0 {
+			summary.WriteString(fmt.Sprintf("   Possibly moved to %s\n", entry.Candidates[0].Path))
+		}
+		summary.WriteString("\n")
+	}
+
+	return summary.String()
 }
 
 // boolToStatus converts a boolean to a status string
 func (d *Detector) boolToStatus(b bool) string {
-	if b {
-		return "✅ Yes"
-	}
-	return "❌ No"
+	return theme.YesNo(b)
 }
 
 // FindEnginesNeedingSetup returns engines that need setup or repair
-func (d *Detector) FindEnginesNeedingSetup(customEngineRoots []string) ([]SetupStatus, error) {
-	statuses, err := d.DetectSetupStatus(customEngineRoots)
+func (d *Detector) FindEnginesNeedingSetup(cfg *config.Config) ([]SetupStatus, error) {
+	statuses, err := d.DetectSetupStatus(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -290,8 +685,8 @@ func (d *Detector) FindEnginesNeedingSetup(customEngineRoots []string) ([]SetupS
 }
 
 // FindEnginesWithIssues returns engines that have specific issues
-func (d *Detector) FindEnginesWithIssues(customEngineRoots []string) ([]SetupStatus, error) {
-	statuses, err := d.DetectSetupStatus(customEngineRoots)
+func (d *Detector) FindEnginesWithIssues(cfg *config.Config) ([]SetupStatus, error) {
+	statuses, err := d.DetectSetupStatus(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -307,8 +702,8 @@ func (d *Detector) FindEnginesWithIssues(customEngineRoots []string) ([]SetupSta
 }
 
 // ValidateExistingSetup validates that an existing setup is still working
-func (d *Detector) ValidateExistingSetup(enginePath, engineVersion string) error {
-	status := d.DetectEngineSetupStatus(enginePath, engineVersion)
+func (d *Detector) ValidateExistingSetup(enginePath, engineVersion, worktreeSubdir string) error {
+	status := d.DetectEngineSetupStatus(enginePath, engineVersion, worktreeSubdir)
 
 	if !status.IsSetupComplete {
 		return fmt.Errorf("setup validation failed: %s", strings.Join(status.Issues, "; "))
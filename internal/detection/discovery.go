@@ -0,0 +1,41 @@
+package detection
+
+import (
+	"encoding/json"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/engine"
+)
+
+// discoveryTTL is how long discovered engines are reused. The main menu detects every time it is
+// drawn, and walking the scan roots, network shares included, is the slowest part of that.
+const discoveryTTL = time.Minute
+
+// discoverEngines returns the installed engines, reusing the last discovery while it is recent
+// and the scan roots haven't changed
+func (d *Detector) discoverEngines(cfg *config.Config) ([]engine.EngineInfo, error) {
+	roots, _ := json.Marshal(cfg.CustomEngineRoots)
+
+	d.discovery.Lock()
+	defer d.discovery.Unlock()
+	if d.discovered != nil && d.discoveredRoots == string(roots) && time.Since(d.discoveredAt) < discoveryTTL {
+		return d.discovered, nil
+	}
+	engines, err := d.engine.DiscoverEngines(cfg.CustomEngineRoots)
+	if err != nil {
+		return nil, err
+	}
+	if engines == nil {
+		engines = []engine.EngineInfo{}
+	}
+	d.discovered, d.discoveredRoots, d.discoveredAt = engines, string(roots), time.Now()
+	return engines, nil
+}
+
+// ForgetDiscoveredEngines makes the next detection scan for engines again, e.g. after a rescan
+func (d *Detector) ForgetDiscoveredEngines() {
+	d.discovery.Lock()
+	d.discovered = nil
+	d.discovery.Unlock()
+}
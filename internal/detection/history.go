@@ -0,0 +1,287 @@
+package detection
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// statusHistoryFile has a line for every change in an engine's detected state, so a breakage can
+// be dated and matched with what happened on the machine at that time, such as a Launcher update
+const statusHistoryFile = "status-history.jsonl"
+
+// maxHistoryEntries is how many changes are kept; older ones are dropped
+const maxHistoryEntries = 1000
+
+// History states of an engine
+const (
+	HistoryComplete = "complete"
+	HistoryBroken   = "broken"
+	HistoryNotSetUp = "not_set_up"
+)
+
+// HistoryEntry is an engine's detected state at the time it last changed
+type HistoryEntry struct {
+	TimeUTC           time.Time `json:"time_utc"`
+	EnginePath        string    `json:"engine_path"`
+	EngineVersion     string    `json:"engine_version"`
+	EngineBuild       string    `json:"engine_build,omitempty"` // Build fingerprint, see engine.BuildFingerprint
+	State             string    `json:"state"`
+	JunctionExists    bool      `json:"junction_exists"`
+	JunctionValid     bool      `json:"junction_valid"`
+	WorktreeExists    bool      `json:"worktree_exists"`
+	BinariesExist     bool      `json:"binaries_exist"`
+	BinariesCorrupted bool      `json:"binaries_corrupted,omitempty"`
+	StockPluginStatus string    `json:"stock_plugin_status"`
+	Issues            []string  `json:"issues,omitempty"`
+	Changes           []string  `json:"changes,omitempty"` // What changed since the previous entry for the engine
+}
+
+// StatusHistoryPath returns where the status history is kept in a data directory
+func StatusHistoryPath(baseDir string) string {
+	return filepath.Join(baseDir, statusHistoryFile)
+}
+
+// ReadStatusHistory returns the recorded changes of every engine, oldest first
+func ReadStatusHistory(baseDir string) ([]HistoryEntry, error) {
+	file, err := os.Open(StatusHistoryPath(baseDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		// A line cut short by a crash is skipped rather than failing the whole history
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// StatusHistory returns the recorded changes of every engine on this machine, oldest first
+func (d *Detector) StatusHistory() ([]HistoryEntry, error) {
+	return ReadStatusHistory(d.baseDir)
+}
+
+// EngineHistory returns the recorded changes of one engine, oldest first
+func EngineHistory(entries []HistoryEntry, enginePath string) []HistoryEntry {
+	var history []HistoryEntry
+	for _, entry := range entries {
+		if utils.SamePath(entry.EnginePath, enginePath) {
+			history = append(history, entry)
+		}
+	}
+	return history
+}
+
+// BrokenSince returns the change that broke an engine's setup: the first entry after the engine
+// was last complete. It is false when the engine is not broken or was never recorded as complete.
+func BrokenSince(entries []HistoryEntry, enginePath string) (HistoryEntry, bool) {
+	history := EngineHistory(entries, enginePath)
+	if len(history) == 0 || history[len(history)-1].State != HistoryBroken {
+		return HistoryEntry{}, false
+	}
+	for i := len(history) - 1; i > 0; i-- {
+		if history[i-1].State == HistoryComplete {
+			return history[i], true
+		}
+	}
+	return HistoryEntry{}, false
+}
+
+// Describe summarizes what changed, with the likely cause when the engine itself was changed,
+// e.g. "plugin link disappeared, engine build 5.4.3 → 5.4.4 (likely an engine update)"
+func (e HistoryEntry) Describe() string {
+	if len(e.Changes) == 0 {
+		return "first recorded as " + describeHistoryState(e.State)
+	}
+	description := strings.Join(e.Changes, ", ")
+	if cause := e.likelyCause(); cause != "" {
+		description += " (" + cause + ")"
+	}
+	return description
+}
+
+// likelyCause guesses what outside the tool caused a change. The Launcher replaces an engine's
+// Build.version when it installs an update, and its "Verify" restores the stock plugin and
+// removes folders it doesn't know, such as the plugin link.
+func (e HistoryEntry) likelyCause() string {
+	for _, change := range e.Changes {
+		if strings.HasPrefix(change, "engine build ") {
+			return "likely an engine update"
+		}
+	}
+	for _, change := range e.Changes {
+		if change == changeLinkRemoved || change == changeStockEnabled {
+			return "likely an engine update or verify in the Launcher"
+		}
+	}
+	return ""
+}
+
+// Changes a history entry can record
+const (
+	changeLinkRemoved     = "plugin link disappeared"
+	changeLinkRetargeted  = "plugin link points elsewhere"
+	changeWorktreeRemoved = "plugin worktree disappeared"
+	changeBinariesRemoved = "plugin binaries disappeared"
+	changeBinariesChanged = "plugin binaries changed or were quarantined"
+	changeStockEnabled    = "stock Git plugin was enabled again"
+)
+
+// historyChanges lists what differs between an engine's previous entry and its current one
+func historyChanges(previous, current HistoryEntry) []string {
+	var changes []string
+	if previous.EngineBuild != "" && current.EngineBuild != "" && previous.EngineBuild != current.EngineBuild {
+		from, to := engine.FingerprintVersion(previous.EngineBuild), engine.FingerprintVersion(current.EngineBuild)
+		if from == to {
+			from, to = previous.EngineBuild, current.EngineBuild
+		}
+		changes = append(changes, fmt.Sprintf("engine build %s → %s", from, to))
+	}
+	switch {
+	case previous.JunctionExists && !current.JunctionExists:
+		changes = append(changes, changeLinkRemoved)
+	case previous.JunctionValid && current.JunctionExists && !current.JunctionValid:
+		changes = append(changes, changeLinkRetargeted)
+	case !previous.JunctionValid && current.JunctionValid:
+		changes = append(changes, "plugin link restored")
+	}
+	if previous.WorktreeExists != current.WorktreeExists {
+		changes = append(changes, pick(current.WorktreeExists, "plugin worktree restored", changeWorktreeRemoved))
+	}
+	if previous.BinariesExist != current.BinariesExist {
+		changes = append(changes, pick(current.BinariesExist, "plugin binaries rebuilt", changeBinariesRemoved))
+	}
+	if previous.BinariesCorrupted != current.BinariesCorrupted {
+		changes = append(changes, pick(current.BinariesCorrupted, changeBinariesChanged, "plugin binaries verified again"))
+	}
+	if previous.StockPluginStatus == "disabled" && current.StockPluginStatus == "enabled" {
+		changes = append(changes, changeStockEnabled)
+	} else if previous.StockPluginStatus == "enabled" && current.StockPluginStatus == "disabled" {
+		changes = append(changes, "stock Git plugin disabled")
+	}
+	if previous.State != current.State {
+		changes = append(changes, "setup "+describeHistoryState(current.State))
+	}
+	return changes
+}
+
+// pick returns ifTrue or ifFalse
+func pick(condition bool, ifTrue, ifFalse string) string {
+	if condition {
+		return ifTrue
+	}
+	return ifFalse
+}
+
+// describeHistoryState names a state for display
+func describeHistoryState(state string) string {
+	switch state {
+	case HistoryComplete:
+		return "complete"
+	case HistoryBroken:
+		return "broken"
+	}
+	return "not set up"
+}
+
+// historyEntry takes the parts of a detection that the history tracks
+func (d *Detector) historyEntry(status SetupStatus, now time.Time) HistoryEntry {
+	state := HistoryNotSetUp
+	if status.IsSetupComplete {
+		state = HistoryComplete
+	} else if status.IsBroken {
+		state = HistoryBroken
+	}
+	return HistoryEntry{
+		TimeUTC:           now,
+		EnginePath:        status.EnginePath,
+		EngineVersion:     status.EngineVersion,
+		EngineBuild:       d.engine.BuildFingerprint(status.EnginePath),
+		State:             state,
+		JunctionExists:    status.JunctionExists || status.PluginCopied,
+		JunctionValid:     status.JunctionValid || status.PluginCopied,
+		WorktreeExists:    status.WorktreeExists,
+		BinariesExist:     status.BinariesExist,
+		BinariesCorrupted: status.BinariesCorrupted,
+		StockPluginStatus: status.StockPluginStatus,
+		Issues:            status.Issues,
+	}
+}
+
+// recordHistory appends an entry for every engine whose state changed since its last entry.
+// Engines that were never set up are left out until they are. Failures only leave a gap.
+func (d *Detector) recordHistory(statuses []SetupStatus) {
+	entries, err := ReadStatusHistory(d.baseDir)
+	if err != nil {
+		return
+	}
+	last := make(map[string]HistoryEntry)
+	for _, entry := range entries {
+		last[utils.PathKey(entry.EnginePath)] = entry
+	}
+
+	now := time.Now().UTC()
+	var added []HistoryEntry
+	for _, status := range statuses {
+		current := d.historyEntry(status, now)
+		previous, known := last[utils.PathKey(status.EnginePath)]
+		if !known {
+			if current.State != HistoryNotSetUp {
+				added = append(added, current)
+			}
+			continue
+		}
+		if current.Changes = historyChanges(previous, current); len(current.Changes) > 0 {
+			added = append(added, current)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	path := StatusHistoryPath(d.baseDir)
+	if entries = append(entries, added...); len(entries) > maxHistoryEntries {
+		// Write the newest entries next to the file and rename, so a concurrent reader never
+		// sees half of it
+		if err := os.WriteFile(path+".tmp", encodeHistory(entries[len(entries)-maxHistoryEntries:]), 0644); err == nil {
+			os.Rename(path+".tmp", path)
+		}
+		return
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.Write(encodeHistory(added))
+}
+
+// encodeHistory formats entries as history lines
+func encodeHistory(entries []HistoryEntry) []byte {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		if line, err := json.Marshal(entry); err == nil {
+			buf.Write(line)
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,86 @@
+package detection
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+)
+
+// linkOwnerIssue describes a plugin link into another user's data directory
+const linkOwnerIssue = "Plugin link points to %s's setup (%s)"
+
+// LinkOwner returns the Windows user whose data directory an engine's plugin link points into, or
+// "" when it points anywhere else, such as into this user's own
+func (d *Detector) LinkOwner(enginePath string) string {
+	link := d.plugin.InspectLink(d.plugin.GetPluginLinkPath(enginePath))
+	if !link.IsLink && !link.IsCopy {
+		return ""
+	}
+	return otherUserOf(link.Target)
+}
+
+// UsesOtherUserSetup reports whether this user adopted the other user's setup the engine is
+// linked to, so it has no setup of its own to update or repair
+func (s SetupStatus) UsesOtherUserSetup() bool {
+	return s.LinkOwner != "" && s.LinkOwnerChoice == config.OtherUserAdopt
+}
+
+// otherUserOf returns the account whose default data directory (%APPDATA%\ue-git-plugin-manager
+// under another profile in the Users folder) holds path, or "" for anything else. The ProgramData
+// fallback is shared by every user, so a link into it can't be told apart from this user's own.
+func otherUserOf(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || path == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(filepath.Dir(home), path)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	if len(parts) < 4 || parts[0] == ".." || strings.EqualFold(parts[0], filepath.Base(home)) {
+		return ""
+	}
+	if !strings.EqualFold(parts[1], "AppData") || !strings.EqualFold(parts[2], "Roaming") || !strings.EqualFold(parts[3], "ue-git-plugin-manager") {
+		return ""
+	}
+	return parts[0]
+}
+
+// applyOtherUserChoice stops reporting an engine linked to another user's setup as broken once
+// this user chose to adopt that setup or to coexist with it. An adopted setup is judged by the
+// other user's binaries; a coexisting one by this user's own worktree, without the link.
+func (d *Detector) applyOtherUserChoice(cfg *config.Config, status *SetupStatus) {
+	if status.LinkOwner == "" {
+		return
+	}
+	status.LinkOwnerChoice = cfg.OtherUserSetupFor(status.EnginePath, status.LinkOwner)
+
+	issues := []string{}
+	switch status.LinkOwnerChoice {
+	case config.OtherUserAdopt:
+		if !d.checkBinariesExist(filepath.Join(status.JunctionTarget, "Binaries", "Win64")) {
+			issues = append(issues, fmt.Sprintf("Plugin binaries not found in %s's setup", status.LinkOwner))
+		}
+		if status.StockPluginStatus == "enabled" {
+			issues = append(issues, stockPluginIssue)
+		}
+	case config.OtherUserCoexist:
+		linkIssue := fmt.Sprintf(linkOwnerIssue, status.LinkOwner, status.JunctionTarget)
+		for _, issue := range status.Issues {
+			if issue != linkIssue {
+				issues = append(issues, issue)
+			}
+		}
+	default:
+		return
+	}
+
+	status.Issues = issues
+	status.IsSetupComplete = len(issues) == 0
+	status.IsNeverSetUp = false
+	status.IsBroken = !status.IsSetupComplete
+}
@@ -0,0 +1,82 @@
+package detection
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// statusCacheFile holds the result of the last full detection, so the main menu can be shown
+// right away and quick checks such as ping don't have to run git
+const statusCacheFile = "status-cache.json"
+
+// CachedStatus is one engine's result from the last full detection, with its update check
+type CachedStatus struct {
+	SetupStatus
+	LocalSHA     string `json:"local_sha,omitempty"`  // Commit checked out in the worktree
+	RemoteSHA    string `json:"remote_sha,omitempty"` // Commit an update would move to
+	CommitsAhead int    `json:"commits_ahead"`
+}
+
+// StatusCache is the last full detection of every engine on the machine
+type StatusCache struct {
+	CheckedUTC time.Time      `json:"checked_utc"`
+	Engines    []CachedStatus `json:"engines"`
+	Moved      []MovedEngine  `json:"moved,omitempty"`
+}
+
+// StatusCachePath returns where the status cache is kept in a data directory
+func StatusCachePath(baseDir string) string {
+	return filepath.Join(baseDir, statusCacheFile)
+}
+
+// ReadStatusCache reads the status cache of a data directory
+func ReadStatusCache(baseDir string) (*StatusCache, error) {
+	data, err := os.ReadFile(StatusCachePath(baseDir))
+	if err != nil {
+		return nil, err
+	}
+	var cache StatusCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", statusCacheFile, err)
+	}
+	return &cache, nil
+}
+
+// cacheStatuses records a detection that didn't check for updates. The previous update check and
+// moved engines are kept for engines that are still set up, so the cached summary stays complete.
+func (d *Detector) cacheStatuses(statuses []SetupStatus) {
+	previous, err := ReadStatusCache(d.baseDir)
+	if err != nil {
+		previous = &StatusCache{}
+	}
+	cache := StatusCache{CheckedUTC: time.Now().UTC(), Moved: previous.Moved}
+	for _, status := range statuses {
+		entry := CachedStatus{SetupStatus: status}
+		for _, old := range previous.Engines {
+			if status.IsSetupComplete && utils.SamePath(old.EnginePath, status.EnginePath) {
+				entry.LocalSHA, entry.RemoteSHA, entry.CommitsAhead = old.LocalSHA, old.RemoteSHA, old.CommitsAhead
+			}
+		}
+		cache.Engines = append(cache.Engines, entry)
+	}
+	d.writeStatusCache(cache)
+}
+
+// writeStatusCache saves the cache; failures only make the next quick render less current
+func (d *Detector) writeStatusCache(cache StatusCache) {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+	// Write next to the file and rename, so a concurrent ping never reads half of it
+	path := StatusCachePath(d.baseDir)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return
+	}
+	os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,165 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// Outcomes of a check
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn" // Works, but something is likely to go wrong later
+	StatusFail = "fail" // Setting up or building will fail until it is fixed
+)
+
+// longPathsKey is the registry key holding the machine's long path setting
+const longPathsKey = `HKLM\SYSTEM\CurrentControlSet\Control\FileSystem`
+
+// Check is the result of one prerequisite check, with how to fix it when it didn't pass
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// Section groups the checks of the machine or of one engine
+type Section struct {
+	Title  string  `json:"title"`
+	Checks []Check `json:"checks"`
+}
+
+// Failed reports whether any check failed
+func Failed(sections []Section) bool {
+	for _, section := range sections {
+		for _, check := range section.Checks {
+			if check.Status == StatusFail {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Machine checks what every setup needs regardless of the engine: git, Git LFS for projects and
+// long path support for the deep paths UnrealBuildTool writes
+func Machine(g *git.Manager) Section {
+	return Section{
+		Title:  "Machine",
+		Checks: []Check{checkGit(g), checkGitLFS(g), checkLongPaths(g)},
+	}
+}
+
+// Engine checks what building the plugin against an engine needs: RunUAT, the .NET SDK, the
+// Visual C++ toolchain and write access to the engine's Plugins folder
+func Engine(e *engine.Manager, p *plugin.Manager, eng engine.EngineInfo) Section {
+	section := Section{Title: fmt.Sprintf("UE %s (%s)", eng.Version, eng.Path)}
+	for _, prereq := range e.CheckBuildPrerequisites(eng.Path) {
+		check := Check{Name: prereq.Name, Status: StatusOK, Detail: prereq.Detail}
+		switch {
+		case prereq.Unsure:
+			check.Status = StatusWarn
+		case !prereq.Found:
+			check.Status = StatusFail
+			check.Fix = prereq.DownloadURL
+		}
+		section.Checks = append(section.Checks, check)
+	}
+	section.Checks = append(section.Checks, checkWriteAccess(p, eng.Path))
+	return section
+}
+
+func checkGit(g *git.Manager) Check {
+	check := Check{Name: "Git"}
+	if !g.IsGitAvailable() {
+		check.Status = StatusFail
+		check.Detail = "git was not found on PATH"
+		check.Fix = "Install Git for Windows (https://git-scm.com/download/win), or start the tool without arguments to install a portable MinGit"
+		return check
+	}
+	version, _ := g.GetGitVersion()
+	if g.IsUsingMinGit() {
+		version += " (portable MinGit)"
+	}
+	check.Status = StatusOK
+	check.Detail = version
+	return check
+}
+
+func checkGitLFS(g *git.Manager) Check {
+	check := Check{Name: "Git LFS"}
+	if !g.IsGitLFSAvailable() {
+		check.Status = StatusWarn
+		check.Detail = "git-lfs was not found; only projects need it, engine setups don't"
+		check.Fix = "\"Configure project\" offers to install it, or install it from https://git-lfs.com and run git lfs install"
+		return check
+	}
+	check.Status = StatusOK
+	check.Detail = "installed"
+	return check
+}
+
+// checkLongPaths checks that Windows and git accept paths over 260 characters; builds of plugins
+// under a long user profile path fail without them. Git's setting is only checked when git was
+// found, and with the git the tool runs, which may be its portable MinGit.
+func checkLongPaths(g *git.Manager) Check {
+	check := Check{Name: "Long paths", Status: StatusOK}
+	var missing, fixes []string
+	switch enabled, err := longPathsEnabled(); {
+	case err != nil:
+		missing = append(missing, "the Windows setting could not be read")
+	case !enabled:
+		missing = append(missing, "Windows long paths are off")
+		fixes = append(fixes, "turn on \"Enable Win32 long paths\" in Group Policy, or run as administrator: reg add "+longPathsKey+" /v LongPathsEnabled /t REG_DWORD /d 1 /f")
+	}
+	gitAvailable := g.IsGitAvailable()
+	if gitAvailable && g.ConfigValue("core.longpaths") != "true" {
+		missing = append(missing, "git core.longpaths is not set")
+		fixes = append(fixes, "git config --global core.longpaths true")
+	}
+	if len(missing) == 0 {
+		check.Detail = "enabled in Windows and git"
+		if !gitAvailable {
+			check.Detail = "enabled in Windows; git's setting is checked once git is installed"
+		}
+		return check
+	}
+	check.Status = StatusWarn
+	check.Detail = strings.Join(missing, "; ")
+	check.Fix = strings.Join(fixes, "; then ")
+	return check
+}
+
+// longPathsEnabled reads the LongPathsEnabled DWORD of the machine's file system settings
+func longPathsEnabled() (bool, error) {
+	output, err := runner.Output("reg", "query", longPathsKey, "/v", "LongPathsEnabled")
+	if err != nil {
+		return false, err
+	}
+	// Output lines look like: "    LongPathsEnabled    REG_DWORD    0x1"
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "LongPathsEnabled" && fields[1] == "REG_DWORD" {
+			return fields[2] != "0x0", nil
+		}
+	}
+	return false, nil
+}
+
+// checkWriteAccess checks that the plugin link can be created in the engine's Plugins folder
+func checkWriteAccess(p *plugin.Manager, enginePath string) Check {
+	pluginsDir := filepath.Join(enginePath, "Engine", "Plugins")
+	check := Check{Name: "Write access", Status: StatusOK, Detail: pluginsDir}
+	if !p.CheckWriteAccess(pluginsDir) {
+		check.Status = StatusFail
+		check.Detail = pluginsDir + " can't be written"
+		check.Fix = "Run the tool as administrator (engines under Program Files need it), or ask IT for write access to the folder"
+	}
+	return check
+}
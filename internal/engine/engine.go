@@ -9,6 +9,10 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
 )
 
 // EngineInfo represents information about a discovered Unreal Engine installation
@@ -27,40 +31,36 @@ func New() *Manager {
 }
 
 // DiscoverEngines discovers all Unreal Engine installations
-func (m *Manager) DiscoverEngines(customRoots []string) ([]EngineInfo, error) {
+func (m *Manager) DiscoverEngines(customRoots []config.ScanRoot) ([]EngineInfo, error) {
 	var engines []EngineInfo
 
-	// Default Epic Games installation path
-	defaultPath := `C:\Program Files\Epic Games`
-	if _, err := os.Stat(defaultPath); err == nil {
-		engines = append(engines, m.scanDirectory(defaultPath)...)
+	// Default Epic Games installation folders, and engines the launcher installed elsewhere
+	for _, root := range DefaultInstallRoots() {
+		if _, err := os.Stat(root); err == nil {
+			engines = append(engines, m.scanDirectory(config.ScanRoot{Path: root}, nil)...)
+		}
+	}
+	for _, location := range LauncherInstallLocations() {
+		if !utils.IsNetworkPath(location) {
+			engines = append(engines, m.scanRoot(config.ScanRoot{Path: location}, nil)...)
+		}
 	}
 
-	// Custom engine roots
+	// Custom engine roots; network shares are scanned with a time limit so an offline share doesn't stall discovery
 	for _, root := range customRoots {
-		if _, err := os.Stat(root); err == nil {
-			// Check if the root path is itself a valid engine directory
-			// A valid engine has Engine\Binaries\Win64\UnrealEditor.exe
-			if m.validateEngine(root) {
-				// This is a specific engine path, add it directly
-				version := m.extractVersion(root)
-				engines = append(engines, EngineInfo{
-					Path:    root,
-					Version: version,
-					Valid:   true,
-				})
-			} else {
-				// This is a parent directory, scan it recursively for engines
-				engines = append(engines, m.scanDirectory(root)...)
-			}
+		if !utils.IsNetworkPath(root.Path) {
+			engines = append(engines, m.scanRoot(root, nil)...)
+		} else if timeout := root.NetworkTimeout(); timeout > 0 {
+			engines = append(engines, m.scanRootWithTimeout(root, timeout)...)
 		}
 	}
 
-	// Remove duplicates and validate
+	// Remove duplicates and validate; engines on mapped drives are keyed by their UNC path
 	uniqueEngines := make(map[string]EngineInfo)
 	for _, eng := range engines {
 		if eng.Valid {
-			uniqueEngines[eng.Path] = eng
+			eng.Path = utils.ResolveNetworkPath(eng.Path)
+			uniqueEngines[utils.PathKey(eng.Path)] = eng
 		}
 	}
 
@@ -77,18 +77,30 @@ func (m *Manager) DiscoverEngines(customRoots []string) ([]EngineInfo, error) {
 	return result, nil
 }
 
-// scanDirectory recursively scans a directory for Unreal Engine installations
-func (m *Manager) scanDirectory(root string) []EngineInfo {
-	var engines []EngineInfo
+// scanRoot returns the engine a custom root points at, or the engines found below it
+func (m *Manager) scanRoot(root config.ScanRoot, stop <-chan struct{}) []EngineInfo {
+	if _, err := os.Stat(root.Path); err != nil {
+		return nil
+	}
+	// Check if the root path is itself a valid engine directory
+	// A valid engine has Engine\Binaries\Win64\UnrealEditor.exe
+	if m.validateEngine(root.Path) {
+		return []EngineInfo{{Path: root.Path, Version: m.extractVersion(root.Path), Valid: true}}
+	}
+	// This is a parent directory, scan it recursively for engines
+	return m.scanDirectory(root, stop)
+}
 
-	// Limit recursion depth to 2 as per spec
-	m.scanDirectoryRecursive(root, 0, 2, &engines)
+// scanDirectory recursively scans a directory for Unreal Engine installations until stop is closed
+func (m *Manager) scanDirectory(root config.ScanRoot, stop <-chan struct{}) []EngineInfo {
+	var engines []EngineInfo
+	m.scanDirectoryRecursive(root, root.Path, 0, stop, &engines)
 	return engines
 }
 
-// scanDirectoryRecursive recursively scans directories with depth limit
-func (m *Manager) scanDirectoryRecursive(dir string, currentDepth, maxDepth int, engines *[]EngineInfo) {
-	if currentDepth > maxDepth {
+// scanDirectoryRecursive recursively scans directories up to the root's depth limit
+func (m *Manager) scanDirectoryRecursive(root config.ScanRoot, dir string, currentDepth int, stop <-chan struct{}, engines *[]EngineInfo) {
+	if currentDepth > root.Depth() || stopped(stop) {
 		return
 	}
 
@@ -103,9 +115,16 @@ func (m *Manager) scanDirectoryRecursive(dir string, currentDepth, maxDepth int,
 		}
 
 		entryPath := filepath.Join(dir, entry.Name())
+		if excluded(root, entryPath) {
+			continue
+		}
 
-		// Check if this looks like an Unreal Engine directory
-		if m.isUnrealEngineDirectory(entryPath) {
+		// Check if this looks like an Unreal Engine directory; include patterns replace the UE_x.y naming rule
+		candidate := m.isUnrealEngineDirectory(entryPath)
+		if len(root.Include) > 0 {
+			candidate = included(root, entryPath)
+		}
+		if candidate {
 			version := m.extractVersion(entryPath)
 			valid := m.validateEngine(entryPath)
 
@@ -114,10 +133,14 @@ func (m *Manager) scanDirectoryRecursive(dir string, currentDepth, maxDepth int,
 				Version: version,
 				Valid:   valid,
 			})
+			if valid {
+				// Engines don't contain other engines; skip their large folder trees
+				continue
+			}
 		}
 
 		// Continue scanning subdirectories
-		m.scanDirectoryRecursive(entryPath, currentDepth+1, maxDepth, engines)
+		m.scanDirectoryRecursive(root, entryPath, currentDepth+1, stop, engines)
 	}
 }
 
@@ -154,14 +177,71 @@ func (m *Manager) extractVersion(path string) string {
 	return "unknown"
 }
 
+// InspectEngine returns engine information for a folder given by the user, accepting the
+// engine root, its Engine subfolder or the UnrealEditor.exe inside it
+func (m *Manager) InspectEngine(path string) (EngineInfo, bool) {
+	root := filepath.Clean(path)
+	if strings.EqualFold(filepath.Base(root), "UnrealEditor.exe") {
+		// <root>\Engine\Binaries\Win64\UnrealEditor.exe
+		root = filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(root))))
+	} else if strings.EqualFold(filepath.Base(root), "Engine") {
+		root = filepath.Dir(root)
+	}
+
+	if !m.validateEngine(root) {
+		return EngineInfo{}, false
+	}
+	root = utils.ResolveNetworkPath(root)
+	return EngineInfo{Path: root, Version: m.extractVersion(root), Valid: true}, true
+}
+
+// BuildFingerprint identifies an exact engine build from its Build.version file, so an
+// install can be recognised after its folder was moved or renamed
+func (m *Manager) BuildFingerprint(enginePath string) string {
+	data, err := os.ReadFile(filepath.Join(enginePath, "Engine", "Build", "Build.version"))
+	if err != nil {
+		return ""
+	}
+	var buildInfo struct {
+		MajorVersion int    `json:"MajorVersion"`
+		MinorVersion int    `json:"MinorVersion"`
+		PatchVersion int    `json:"PatchVersion"`
+		Changelist   int    `json:"Changelist"`
+		BranchName   string `json:"BranchName"`
+	}
+	if json.Unmarshal(data, &buildInfo) != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d.%d-%d+%s", buildInfo.MajorVersion, buildInfo.MinorVersion, buildInfo.PatchVersion, buildInfo.Changelist, buildInfo.BranchName)
+}
+
+// FingerprintVersion returns the engine version in a build fingerprint, e.g. "5.4.3"
+func FingerprintVersion(fingerprint string) string {
+	version, _, _ := strings.Cut(fingerprint, "-")
+	return version
+}
+
 // validateEngine validates that a directory is a proper Unreal Engine installation
 func (m *Manager) validateEngine(path string) bool {
 	// Check for the required UnrealEditor.exe
-	editorPath := filepath.Join(path, "Engine", "Binaries", "Win64", "UnrealEditor.exe")
-	_, err := os.Stat(editorPath)
+	_, err := os.Stat(m.GetEditorPath(path))
 	return err == nil
 }
 
+// GetEditorPath returns the path to an engine's UnrealEditor.exe
+func (m *Manager) GetEditorPath(enginePath string) string {
+	return filepath.Join(enginePath, "Engine", "Binaries", "Win64", "UnrealEditor.exe")
+}
+
+// LaunchEditor starts the engine's editor with a project and returns without waiting for it to exit
+func (m *Manager) LaunchEditor(enginePath, uprojectPath string) error {
+	// The editor outlives this tool, so it is started without waiting for it
+	if err := runner.Start(filepath.Dir(uprojectPath), m.GetEditorPath(enginePath), uprojectPath); err != nil {
+		return fmt.Errorf("failed to start the editor: %v", err)
+	}
+	return nil
+}
+
 // GetPluginPath returns the plugins directory path for an engine
 func (m *Manager) GetPluginPath(enginePath string) string {
 	return filepath.Join(enginePath, "Engine", "Plugins")
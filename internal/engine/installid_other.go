@@ -0,0 +1,8 @@
+//go:build !windows
+
+package engine
+
+// InstallID returns ""; engine installs are only recognised by volume and file index on Windows
+func (m *Manager) InstallID(enginePath string) string {
+	return ""
+}
@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// InstallID returns an ID for this particular engine install, or "" when the folder can't be
+// opened. It is read from the file system rather than written into the engine: the engine
+// folder's volume serial number and file index stay the same when a drive letter changes or the
+// folder is moved on its volume, so the configuration can recognise the install at its new path.
+func (m *Manager) InstallID(enginePath string) string {
+	path, err := windows.UTF16PtrFromString(enginePath)
+	if err != nil {
+		return ""
+	}
+	// Backup semantics are needed to open a folder rather than a file
+	handle, err := windows.CreateFile(path, 0, windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return ""
+	}
+	defer windows.CloseHandle(handle)
+
+	var info windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(handle, &info); err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%08X-%08X%08X", info.VolumeSerialNumber, info.FileIndexHigh, info.FileIndexLow)
+}
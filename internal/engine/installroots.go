@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// launcherInstalledFile, under %ProgramData%, lists what the Epic Games Launcher installed,
+// wherever the user chose to put it
+const launcherInstalledFile = `Epic\UnrealEngineLauncher\LauncherInstalled.dat`
+
+// DefaultInstallRoots returns the folders searched for launcher-installed engines before any
+// custom engine path: the Epic Games folder in every Program Files location the environment
+// names, which differ on Windows installed to another drive, and in Program Files or at the root
+// of the machine's other local drives, where the launcher lets engines be installed too
+func DefaultInstallRoots() []string {
+	var roots []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if key := utils.PathKey(path); !seen[key] {
+			seen[key] = true
+			roots = append(roots, path)
+		}
+	}
+
+	for _, dir := range programFilesDirs() {
+		add(filepath.Join(dir, "Epic Games"))
+	}
+	for _, drive := range utils.Drives() {
+		// Mapped shares are only scanned when added as custom paths, with a time limit
+		if utils.IsNetworkPath(drive) {
+			continue
+		}
+		add(filepath.Join(drive, "Program Files", "Epic Games"))
+		add(filepath.Join(drive, "Epic Games"))
+	}
+	return roots
+}
+
+// LauncherInstallLocations returns the folders of the engines the Epic Games Launcher recorded
+// as installed, so engines in folders of any name on any drive are found. Missing or unreadable
+// records yield none.
+func LauncherInstallLocations() []string {
+	programData := os.Getenv("ProgramData")
+	if programData == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(programData, launcherInstalledFile))
+	if err != nil {
+		return nil
+	}
+	var installed struct {
+		InstallationList []struct {
+			InstallLocation string `json:"InstallLocation"`
+			AppName         string `json:"AppName"`
+		} `json:"InstallationList"`
+	}
+	if err := json.Unmarshal(data, &installed); err != nil {
+		return nil
+	}
+
+	var locations []string
+	for _, entry := range installed.InstallationList {
+		// Engines are recorded as UE_5.4 and the like; the list also holds games and plugins
+		if strings.HasPrefix(entry.AppName, "UE_") && entry.InstallLocation != "" {
+			locations = append(locations, filepath.Clean(entry.InstallLocation))
+		}
+	}
+	return locations
+}
+
+// programFilesDirs returns the Program Files folders the environment names, the 64-bit one
+// first. A system on another drive than C: moves them, so C:\Program Files is only the fallback
+// when the environment has none.
+func programFilesDirs() []string {
+	var dirs []string
+	for _, name := range []string{"ProgramW6432", "ProgramFiles", "ProgramFiles(x86)"} {
+		if dir := os.Getenv(name); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		dirs = append(dirs, `C:\Program Files`)
+	}
+	return dirs
+}
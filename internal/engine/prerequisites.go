@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// Prerequisite is something RunUAT needs to build the plugin
+type Prerequisite struct {
+	Name        string
+	Found       bool
+	Unsure      bool   // Not found where expected, but may be there; a warning that never blocks a build
+	Detail      string // What was found, or exactly what is missing
+	DownloadURL string // Where to get it when missing
+}
+
+// PrerequisiteError lists the prerequisites that are missing for a build
+type PrerequisiteError struct {
+	Missing []Prerequisite
+}
+
+func (e *PrerequisiteError) Error() string {
+	var parts []string
+	for _, p := range e.Missing {
+		part := fmt.Sprintf("%s: %s", p.Name, p.Detail)
+		if p.DownloadURL != "" {
+			part += " (" + p.DownloadURL + ")"
+		}
+		parts = append(parts, part)
+	}
+	return "missing build prerequisites — " + strings.Join(parts, "; ")
+}
+
+// visualStudioURL is where the Visual Studio installer and Build Tools are downloaded
+const visualStudioURL = "https://visualstudio.microsoft.com/downloads/"
+
+// CheckBuildPrerequisites probes what RunUAT needs to build against an engine: the .NET SDK
+// AutomationTool runs on and the Visual C++ toolchain UnrealBuildTool compiles with
+func (m *Manager) CheckBuildPrerequisites(enginePath string) []Prerequisite {
+	return []Prerequisite{
+		checkRunUAT(enginePath),
+		m.checkDotNet(enginePath),
+		checkVisualCpp(),
+	}
+}
+
+// passedPrerequisites holds the engines whose prerequisites were all found during this run. Tools
+// aren't uninstalled while the tool runs, so the setup's early check and the build's own don't
+// probe twice; a failed check is repeated, so installing the missing tool is noticed.
+var passedPrerequisites sync.Map
+
+// MissingBuildPrerequisites returns a *PrerequisiteError when a prerequisite is known to be missing
+func (m *Manager) MissingBuildPrerequisites(enginePath string) error {
+	key := strings.ToLower(filepath.Clean(enginePath))
+	if _, passed := passedPrerequisites.Load(key); passed {
+		return nil
+	}
+	var missing []Prerequisite
+	for _, p := range m.CheckBuildPrerequisites(enginePath) {
+		if !p.Found && !p.Unsure {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		return &PrerequisiteError{Missing: missing}
+	}
+	passedPrerequisites.Store(key, true)
+	return nil
+}
+
+func checkRunUAT(enginePath string) Prerequisite {
+	p := Prerequisite{Name: "RunUAT"}
+	uat := filepath.Join(enginePath, "Engine", "Build", "BatchFiles", "RunUAT.bat")
+	if _, err := os.Stat(uat); err != nil {
+		p.Detail = fmt.Sprintf("%s is missing; verify the engine install in the Epic Games Launcher", uat)
+		return p
+	}
+	p.Found = true
+	p.Detail = uat
+	return p
+}
+
+// checkDotNet finds the .NET SDK AutomationTool runs on. UE 5 engines bundle it under
+// Engine\Binaries\ThirdParty\DotNet, either in a <version>\<platform> folder or, as in UE 5.0,
+// directly in a platform folder; UE_USE_SYSTEM_DOTNET=1 makes RunUAT use an installed SDK
+// instead. A layout the check doesn't know is reported as unsure rather than missing.
+func (m *Manager) checkDotNet(enginePath string) Prerequisite {
+	p := Prerequisite{Name: ".NET SDK"}
+
+	dotnetRoot := filepath.Join(enginePath, "Engine", "Binaries", "ThirdParty", "DotNet")
+	entries, err := os.ReadDir(dotnetRoot)
+	if err != nil {
+		// UE 4 engines build AutomationTool with the .NET Framework and have no bundled SDK
+		if strings.HasPrefix(m.extractVersion(enginePath), "4.") {
+			p.Found = true
+			p.Detail = "not needed (UE 4 uses the .NET Framework)"
+			return p
+		}
+		p.Detail = fmt.Sprintf("the engine's bundled SDK (%s) is missing; verify the engine in the Epic Games Launcher or run Setup.bat for a source build", dotnetRoot)
+		p.DownloadURL = "https://dotnet.microsoft.com/download"
+		return p
+	}
+
+	// The newest <version> folder is the one RunUAT uses
+	var required string
+	for _, entry := range entries {
+		if entry.IsDir() && isVersionName(entry.Name()) && (required == "" || compareVersions(entry.Name(), required) > 0) {
+			required = entry.Name()
+		}
+	}
+	sdkDir := dotnetRoot
+	if required != "" {
+		sdkDir = filepath.Join(dotnetRoot, required)
+	}
+	bundled := findDotNetExe(sdkDir)
+
+	channel := ""
+	p.DownloadURL = "https://dotnet.microsoft.com/download"
+	if required != "" {
+		channel = majorMinor(required)
+		p.DownloadURL += "/dotnet/" + channel
+	}
+
+	if os.Getenv("UE_USE_SYSTEM_DOTNET") == "1" {
+		output, err := runner.Output("dotnet", "--list-sdks")
+		if err != nil {
+			p.Detail = "UE_USE_SYSTEM_DOTNET=1 is set but no dotnet is installed; install the .NET SDK the engine needs"
+			return p
+		}
+		for _, line := range strings.Split(string(output), "\n") {
+			if fields := strings.Fields(line); len(fields) > 0 && (channel == "" || majorMinor(fields[0]) == channel) {
+				p.Found = true
+				p.Detail = fmt.Sprintf("system SDK %s (UE_USE_SYSTEM_DOTNET=1)", fields[0])
+				p.DownloadURL = ""
+				return p
+			}
+		}
+		p.Detail = fmt.Sprintf("UE_USE_SYSTEM_DOTNET=1 is set but the .NET %s SDK is not installed (dotnet --list-sdks)", channel)
+		return p
+	}
+
+	switch {
+	case bundled != "":
+		p.Found = true
+		p.Detail = "bundled SDK " + required
+		if required == "" {
+			p.Detail = "bundled SDK " + bundled
+		}
+		p.DownloadURL = ""
+	case required != "":
+		p.Detail = fmt.Sprintf("dotnet.exe is missing from the engine's bundled SDK %s; verify the engine install", required)
+	default:
+		p.Unsure = true
+		p.Detail = fmt.Sprintf("no dotnet.exe in a layout this check knows under %s; RunUAT reports it if the SDK is really missing", dotnetRoot)
+		p.DownloadURL = ""
+	}
+	return p
+}
+
+// findDotNetExe returns dotnet.exe in dir or in one of its platform folders, or ""
+func findDotNetExe(dir string) string {
+	for _, pattern := range []string{filepath.Join(dir, "dotnet.exe"), filepath.Join(dir, "*", "dotnet.exe")} {
+		if matches, _ := filepath.Glob(pattern); len(matches) > 0 {
+			return matches[0]
+		}
+	}
+	return ""
+}
+
+// isVersionName reports whether a folder name is a version such as "8.0.300"
+func isVersionName(name string) bool {
+	for _, part := range strings.Split(name, ".") {
+		if _, err := strconv.Atoi(part); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// checkVisualCpp looks for a Visual Studio or Build Tools install with the MSVC x64 toolchain
+func checkVisualCpp() Prerequisite {
+	p := Prerequisite{Name: "Visual C++ toolchain", DownloadURL: visualStudioURL}
+
+	vswhere := filepath.Join(os.Getenv("ProgramFiles(x86)"), "Microsoft Visual Studio", "Installer", "vswhere.exe")
+	if _, err := os.Stat(vswhere); err != nil {
+		p.Detail = "Visual Studio is not installed; install Visual Studio or the Build Tools with the \"Desktop development with C++\" and \"Game development with C++\" workloads"
+		return p
+	}
+	output, err := runner.Output(vswhere, "-latest", "-products", "*",
+		"-requires", "Microsoft.VisualStudio.Component.VC.Tools.x86.x64",
+		"-property", "displayName")
+	name := strings.TrimSpace(string(output))
+	if err != nil || name == "" {
+		p.Detail = "Visual Studio is installed without the MSVC x64 build tools; add the \"Desktop development with C++\" workload in the Visual Studio Installer"
+		return p
+	}
+	p.Found = true
+	p.Detail = name
+	p.DownloadURL = ""
+	return p
+}
+
+// majorMinor returns the "8.0" part of a version such as "8.0.300"
+func majorMinor(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
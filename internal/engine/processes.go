@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// editorProcesses are the executable names of running Unreal editors, including commandlets
+var editorProcesses = []string{"UnrealEditor.exe", "UnrealEditor-Cmd.exe", "UE4Editor.exe", "UE4Editor-Cmd.exe"}
+
+// RunningEditors returns the names of the Unreal editor processes currently running on this machine
+func (m *Manager) RunningEditors() ([]string, error) {
+	out, err := runner.Output("tasklist", "/FO", "CSV", "/NH")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %v", err)
+	}
+	reader := csv.NewReader(strings.NewReader(string(out)))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read process list: %v", err)
+	}
+
+	var running []string
+	for _, record := range records {
+		if len(record) < 2 {
+			continue
+		}
+		for _, name := range editorProcesses {
+			if strings.EqualFold(record[0], name) {
+				running = append(running, fmt.Sprintf("%s (PID %s)", record[0], record[1]))
+			}
+		}
+	}
+	return running, nil
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/logging"
+)
+
+// excluded reports whether a folder matches one of the root's exclude patterns
+func excluded(root config.ScanRoot, path string) bool {
+	return matchesAny(root.Exclude, root.Path, path)
+}
+
+// included reports whether a folder matches one of the root's include patterns
+func included(root config.ScanRoot, path string) bool {
+	return matchesAny(root.Include, root.Path, path)
+}
+
+// matchesAny matches patterns against a folder's name and its path relative to the root
+func matchesAny(patterns []string, root, path string) bool {
+	name := strings.ToLower(filepath.Base(path))
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = path
+	}
+	rel = strings.ToLower(filepath.ToSlash(rel))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(filepath.ToSlash(strings.TrimSpace(pattern)))
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scanRootWithTimeout scans a network root in the background, giving up when it takes too long.
+// The abandoned scan is told to stop so it doesn't keep walking the share.
+func (m *Manager) scanRootWithTimeout(root config.ScanRoot, timeout time.Duration) []EngineInfo {
+	done := make(chan []EngineInfo, 1)
+	stop := make(chan struct{})
+	go func() {
+		done <- m.scanRoot(root, stop)
+	}()
+	select {
+	case engines := <-done:
+		return engines
+	case <-time.After(timeout):
+		close(stop)
+		logging.Printf("engine scan of network path %s took longer than %s; skipped", root.Path, timeout)
+		return nil
+	}
+}
+
+// stopped reports whether a scan was told to stop; a nil channel never stops
+func stopped(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
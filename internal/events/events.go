@@ -0,0 +1,166 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kinds of events the managers emit
+const (
+	KindStepStarted  = "step_started"
+	KindStepFinished = "step_finished"
+	KindDebug        = "debug"
+	KindInfo         = "info"
+	KindSuccess      = "success"
+	KindWarning      = "warning"
+	KindProgress     = "progress"
+)
+
+// Event is something a manager reports while it works. The menu, the command line and any
+// other front end decide how it is shown.
+type Event struct {
+	Time     time.Time     `json:"time"`
+	Kind     string        `json:"kind"`
+	Step     string        `json:"step,omitempty"` // Step of the operation, e.g. timing.StepBuild
+	Message  string        `json:"message,omitempty"`
+	Error    string        `json:"error,omitempty"`    // Why a step failed
+	Current  int           `json:"current,omitempty"`  // Progress so far
+	Total    int           `json:"total,omitempty"`    // Progress when done; zero when unknown
+	Duration time.Duration `json:"duration,omitempty"` // How long a finished step took
+	Scope    string        `json:"scope,omitempty"`    // What the event is about while several run at once, e.g. "UE 5.4"
+}
+
+// Handler receives every emitted event
+type Handler func(Event)
+
+var (
+	mu       sync.Mutex
+	handlers = map[int]Handler{}
+	nextID   int
+	// The flags are read by handlers while the settings menu may change them
+	verbose atomic.Bool
+	quiet   atomic.Bool
+)
+
+// SetVerbose turns printing of debug events on the console on or off. They always reach the log.
+func SetVerbose(on bool) {
+	verbose.Store(on)
+}
+
+// Verbose reports whether debug events are printed on the console
+func Verbose() bool {
+	return verbose.Load()
+}
+
+// SetQuiet limits the console to warnings, for scripts and scheduled runs; it wins over verbose
+// output. Everything still reaches the log.
+func SetQuiet(on bool) {
+	quiet.Store(on)
+}
+
+// Subscribe adds a handler and returns a function that removes it again
+func Subscribe(handler Handler) func() {
+	mu.Lock()
+	defer mu.Unlock()
+	id := nextID
+	nextID++
+	handlers[id] = handler
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(handlers, id)
+	}
+}
+
+// Emit sends an event to every handler, in the order they subscribed. Handlers are called one
+// event at a time, so output from background work never interleaves within a line.
+func Emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for id := 0; id < nextID; id++ {
+		if handler, ok := handlers[id]; ok {
+			handler(event)
+		}
+	}
+}
+
+// Debug reports a diagnostic detail that is only printed at verbose output
+func Debug(format string, args ...interface{}) {
+	Reporter{}.Debug(format, args...)
+}
+
+// Info reports a detail of the work in progress
+func Info(format string, args ...interface{}) {
+	Reporter{}.Info(format, args...)
+}
+
+// Success reports that part of the work completed
+func Success(format string, args ...interface{}) {
+	Reporter{}.Success(format, args...)
+}
+
+// Warn reports a problem the work recovered from or the user should know about
+func Warn(format string, args ...interface{}) {
+	Reporter{}.Warn(format, args...)
+}
+
+// StepStarted reports that a step of an operation began
+func StepStarted(step, message string) {
+	Reporter{}.StepStarted(step, message)
+}
+
+// StepFinished reports that a step ended, with the error it failed with, if any
+func StepFinished(step string, duration time.Duration, err error) {
+	Reporter{}.StepFinished(step, duration, err)
+}
+
+// Progress reports how far a step has come; total is zero when it isn't known
+func Progress(step, message string, current, total int) {
+	Emit(Event{Kind: KindProgress, Step: step, Message: message, Current: current, Total: total})
+}
+
+// Reporter emits events about one of several operations running at the same time, such as the
+// build of one engine, with its scope set so they can be told apart. The zero Reporter emits
+// them without a scope.
+type Reporter struct {
+	Scope string
+}
+
+// Debug reports a diagnostic detail that is only printed at verbose output
+func (r Reporter) Debug(format string, args ...interface{}) {
+	Emit(Event{Kind: KindDebug, Message: fmt.Sprintf(format, args...), Scope: r.Scope})
+}
+
+// Info reports a detail of the work in progress
+func (r Reporter) Info(format string, args ...interface{}) {
+	Emit(Event{Kind: KindInfo, Message: fmt.Sprintf(format, args...), Scope: r.Scope})
+}
+
+// Success reports that part of the work completed
+func (r Reporter) Success(format string, args ...interface{}) {
+	Emit(Event{Kind: KindSuccess, Message: fmt.Sprintf(format, args...), Scope: r.Scope})
+}
+
+// Warn reports a problem the work recovered from or the user should know about
+func (r Reporter) Warn(format string, args ...interface{}) {
+	Emit(Event{Kind: KindWarning, Message: fmt.Sprintf(format, args...), Scope: r.Scope})
+}
+
+// StepStarted reports that a step of an operation began
+func (r Reporter) StepStarted(step, message string) {
+	Emit(Event{Kind: KindStepStarted, Step: step, Message: message, Scope: r.Scope})
+}
+
+// StepFinished reports that a step ended, with the error it failed with, if any
+func (r Reporter) StepFinished(step string, duration time.Duration, err error) {
+	event := Event{Kind: KindStepFinished, Step: step, Duration: duration, Scope: r.Scope}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	Emit(event)
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ue-git-plugin-manager/internal/logging"
+)
+
+// Quiet reports whether the console is limited to warnings and errors
+func Quiet() bool {
+	return quiet.Load()
+}
+
+// Printf prints progress a menu or command reports itself, as fmt.Printf does. Under --quiet it
+// goes to the log instead; errors and warnings are printed with fmt so they always show.
+func Printf(format string, args ...interface{}) {
+	text := fmt.Sprintf(format, args...)
+	if !Quiet() {
+		fmt.Print(text)
+		return
+	}
+	if line := strings.TrimSpace(text); line != "" {
+		logging.Printf("%s", line)
+	}
+}
+
+// Println is Printf for a line made of its operands, as fmt.Println does
+func Println(args ...interface{}) {
+	Printf("%s", fmt.Sprintln(args...))
+}
+
+// Output returns where long command output such as a build's is streamed: the console, or the
+// log under --quiet
+func Output() io.Writer {
+	if Quiet() {
+		return logging.Writer()
+	}
+	return os.Stdout
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"ue-git-plugin-manager/internal/logging"
+	"ue-git-plugin-manager/internal/theme"
+)
+
+// Console returns a handler that prints events for a person reading the terminal. Details are
+// indented under the heading the menu printed for the operation. Finished steps are left to the
+// step timings summary and the error the operation returns.
+func Console(w io.Writer) Handler {
+	return func(event Event) {
+		if quiet.Load() && event.Kind != KindWarning {
+			return
+		}
+		// Events of one of several operations running at once start with what they are about,
+		// as the lines of their output do
+		scope := ""
+		if event.Scope != "" {
+			scope = "[" + event.Scope + "] "
+		}
+		switch event.Kind {
+		case KindDebug:
+			if verbose.Load() {
+				fmt.Fprintf(w, "%s  %s\n", scope, theme.Subdued(event.Message))
+			}
+		case KindInfo:
+			fmt.Fprintf(w, "%s  %s\n", scope, event.Message)
+		case KindSuccess:
+			fmt.Fprintf(w, "%s  %s\n", scope, theme.Status(theme.OK, event.Message))
+		case KindWarning:
+			fmt.Fprintf(w, "%s  %s\n", scope, theme.Status(theme.Warning, event.Message))
+		case KindStepStarted:
+			if event.Message != "" {
+				fmt.Fprintf(w, "%s%s\n", scope, event.Message)
+			}
+		case KindProgress:
+			if event.Total > 0 {
+				fmt.Fprintf(w, "%s  %s (%d/%d)\n", scope, event.Message, event.Current, event.Total)
+			} else {
+				fmt.Fprintf(w, "%s  %s\n", scope, event.Message)
+			}
+		}
+	}
+}
+
+// Log returns a handler that writes every event, debug ones included, to the log file at the
+// level matching its kind
+func Log() Handler {
+	return func(event Event) {
+		kind := event.Kind
+		if event.Scope != "" {
+			kind += "] [" + event.Scope
+		}
+		switch {
+		case event.Kind == KindStepFinished && event.Error != "":
+			logging.Errorf("[%s] %s failed after %s: %s", kind, event.Step, event.Duration.Round(time.Millisecond), event.Error)
+		case event.Kind == KindStepFinished:
+			logging.Printf("[%s] %s took %s", kind, event.Step, event.Duration.Round(time.Millisecond))
+		case event.Kind == KindStepStarted:
+			logging.Printf("[%s] %s %s", kind, event.Step, event.Message)
+		case event.Kind == KindDebug:
+			logging.Debugf("[%s] %s", kind, event.Message)
+		case event.Kind == KindWarning:
+			logging.Warnf("[%s] %s", kind, event.Message)
+		default:
+			logging.Printf("[%s] %s", kind, event.Message)
+		}
+	}
+}
+
+// JSONLines returns a handler that writes each event as one line of JSON, for scripts and
+// front ends that run the tool as a child process
+func JSONLines(w io.Writer) Handler {
+	encoder := json.NewEncoder(w)
+	return func(event Event) {
+		encoder.Encode(event)
+	}
+}
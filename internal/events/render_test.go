@@ -0,0 +1,71 @@
+package events
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConsoleFollowsVerboseAndQuiet(t *testing.T) {
+	defer SetVerbose(false)
+	defer SetQuiet(false)
+	tests := []struct {
+		name           string
+		verbose, quiet bool
+		want, notWant  []string
+	}{
+		{name: "normal", want: []string{"info", "warning"}, notWant: []string{"debug"}},
+		{name: "verbose", verbose: true, want: []string{"debug", "info", "warning"}},
+		{name: "quiet", quiet: true, want: []string{"warning"}, notWant: []string{"debug", "info"}},
+		{name: "quiet wins over verbose", verbose: true, quiet: true, want: []string{"warning"}, notWant: []string{"debug", "info"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetVerbose(tt.verbose)
+			SetQuiet(tt.quiet)
+			var out bytes.Buffer
+			unsubscribe := Subscribe(Console(&out))
+			Debug("debug")
+			Info("info")
+			Warn("warning")
+			unsubscribe()
+			for _, message := range tt.want {
+				if !strings.Contains(out.String(), message) {
+					t.Errorf("console output %q lacks %q", out.String(), message)
+				}
+			}
+			for _, message := range tt.notWant {
+				if strings.Contains(out.String(), message) {
+					t.Errorf("console output %q has %q", out.String(), message)
+				}
+			}
+		})
+	}
+}
+
+// TestConsoleWhileSettingsChange changes the flags while events are emitted, as the settings
+// menu does while background work reports; run with -race
+func TestConsoleWhileSettingsChange(t *testing.T) {
+	defer SetVerbose(false)
+	defer SetQuiet(false)
+	var out bytes.Buffer
+	unsubscribe := Subscribe(Console(&out))
+	defer unsubscribe()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			Debug("debug %d", i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			SetVerbose(i%2 == 0)
+			SetQuiet(i%3 == 0)
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,76 @@
+package explorer
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// MenuLabel is the text shown in the folder right-click menu
+const MenuLabel = "Configure Unreal project for Git"
+
+// Per-user registrations, so installing doesn't need administrator rights.
+// Directory\shell is the menu on a folder; Directory\Background\shell is the menu inside one.
+var menuKeys = []string{
+	`HKCU\Software\Classes\Directory\shell\UEGitPluginManager`,
+	`HKCU\Software\Classes\Directory\Background\shell\UEGitPluginManager`,
+}
+
+// Install registers the folder context menu entry to launch exePath with the folder as argument
+func Install(exePath string) error {
+	command := fmt.Sprintf(`"%s" "%%V"`, exePath)
+	for _, key := range menuKeys {
+		if err := regAdd(key, "", MenuLabel); err != nil {
+			return err
+		}
+		if err := regAdd(key, "Icon", exePath); err != nil {
+			return err
+		}
+		if err := regAdd(key+`\command`, "", command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Uninstall removes the folder context menu entry
+func Uninstall() error {
+	for _, key := range menuKeys {
+		if !keyExists(key) {
+			continue
+		}
+		output, err := runner.CombinedOutput("reg", "delete", key, "/f")
+		if err != nil {
+			return fmt.Errorf("failed to delete %s: %v\nOutput: %s", key, err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// IsInstalled reports whether the context menu entry is registered
+func IsInstalled() bool {
+	return keyExists(menuKeys[0] + `\command`)
+}
+
+// regAdd sets a registry string value; an empty name sets the key's default value
+func regAdd(key, name, value string) error {
+	args := []string{"add", key}
+	if name == "" {
+		args = append(args, "/ve")
+	} else {
+		args = append(args, "/v", name)
+	}
+	args = append(args, "/t", "REG_SZ", "/d", value, "/f")
+
+	output, err := runner.CombinedOutput("reg", args...)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v\nOutput: %s", key, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func keyExists(key string) bool {
+	_, err := runner.Output("reg", "query", key)
+	return err == nil
+}
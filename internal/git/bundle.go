@@ -0,0 +1,89 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CreateBundle writes every branch, remote branch and tag of the origin repository, including the
+// engine branches with their local commits, to a single bundle file. It returns the branch the
+// origin repository has checked out, or its commit when the checkout is detached.
+func (m *Manager) CreateBundle(bundlePath string) (string, error) {
+	if !m.IsOriginCloned() {
+		return "", fmt.Errorf("plugin repository has not been cloned: %w", ErrNotARepo)
+	}
+	originDir := m.getActualOriginDir()
+
+	output, err := m.run("-C", originDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to read the checked out branch: %w", err)
+	}
+	if strings.TrimSpace(output) == "HEAD" {
+		if output, err = m.run("-C", originDir, "rev-parse", "HEAD"); err != nil {
+			return "", fmt.Errorf("failed to read the checked out commit: %w", err)
+		}
+	}
+	if _, err := m.run("-C", originDir, "bundle", "create", bundlePath, "--branches", "--remotes", "--tags"); err != nil {
+		return "", fmt.Errorf("failed to bundle the plugin repository: %w", err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// RestoreOriginFromBundle recreates the origin repository from a bundle written by CreateBundle,
+// with branch (or a commit) checked out and the origin remote pointing at GitHub again. The
+// repository is built in a folder next to the origin directory and renamed into place when
+// complete, so a failed restore never removes anything that was already there.
+func (m *Manager) RestoreOriginFromBundle(bundlePath, branch string) error {
+	if m.IsOriginCloned() {
+		return fmt.Errorf("the plugin repository already exists at %s", m.getActualOriginDir())
+	}
+	if err := os.MkdirAll(filepath.Dir(m.originDir), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %v", err)
+	}
+
+	staging := m.originDir + ".restoring"
+	os.RemoveAll(staging)
+	steps := [][]string{
+		{"init", staging},
+		// Refs are copied as they are, so remote branches stay origin/* and engine branches stay local
+		{"-C", staging, "fetch", "--update-head-ok", bundlePath,
+			"+refs/heads/*:refs/heads/*", "+refs/remotes/*:refs/remotes/*", "+refs/tags/*:refs/tags/*"},
+		{"-C", staging, "remote", "add", "origin", UpstreamURL},
+		{"-C", staging, "checkout", "-f", branch},
+	}
+	for _, args := range steps {
+		if _, err := m.run(args...); err != nil {
+			os.RemoveAll(staging)
+			return fmt.Errorf("failed to restore the plugin repository: %w", err)
+		}
+	}
+
+	// An empty leftover origin folder is replaced; anything else there is kept and reported
+	os.Remove(m.originDir)
+	if err := os.Rename(staging, m.originDir); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to move the restored plugin repository into place: %v", err)
+	}
+	return nil
+}
+
+// RestoreWorktree checks out an engine's existing branch into its worktree folder, keeping the
+// branch's commits as they are
+func (m *Manager) RestoreWorktree(subdir string) error {
+	branch := EngineBranch(subdir)
+	if !m.branchExists(branch) {
+		return fmt.Errorf("engine branch %s not found in the plugin repository", branch)
+	}
+	if err := os.MkdirAll(m.worktreesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create worktrees directory: %v", err)
+	}
+
+	originDir := m.getActualOriginDir()
+	m.run("-C", originDir, "worktree", "prune")
+	if _, err := m.run("-C", originDir, "worktree", "add", filepath.Join(m.worktreesDir, subdir), branch); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+	return nil
+}
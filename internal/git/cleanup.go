@@ -0,0 +1,74 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WorktreeSubdirs returns the subdirectories of the worktrees directory, one per worktree
+func (m *Manager) WorktreeSubdirs() ([]string, error) {
+	entries, err := os.ReadDir(m.worktreesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var subdirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			subdirs = append(subdirs, entry.Name())
+		}
+	}
+	return subdirs, nil
+}
+
+// EngineBranches returns the local engine-* branches of the origin repository, sorted
+func (m *Manager) EngineBranches() ([]string, error) {
+	output, err := m.run("-C", m.getActualOriginDir(), "for-each-ref", "--format=%(refname:short)", "refs/heads/engine-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list engine branches: %w", err)
+	}
+	branches := splitLines(output)
+	sort.Strings(branches)
+	return branches, nil
+}
+
+// UnpushedCommits counts the commits on a local branch that no remote branch contains, such as
+// fixes committed for one engine version
+func (m *Manager) UnpushedCommits(branch string) (int, error) {
+	output, err := m.run("-C", m.getActualOriginDir(), "rev-list", "--count", "refs/heads/"+branch, "--not", "--remotes")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count the commits of %s: %w", branch, err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count the commits of %s: %v", branch, err)
+	}
+	return count, nil
+}
+
+// DeleteBranch deletes a local branch of the origin repository, merged or not
+func (m *Manager) DeleteBranch(branch string) error {
+	if _, err := m.run("-C", m.getActualOriginDir(), "branch", "-D", branch); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// GarbageCollect forgets worktrees whose folder is gone and compacts the origin repository.
+// Objects nothing refers to are dropped after git's default expiry, so a worktree another git
+// command is still writing to keeps its new objects.
+func (m *Manager) GarbageCollect() error {
+	originDir := m.getActualOriginDir()
+	if _, err := m.run("-C", originDir, "worktree", "prune"); err != nil {
+		return fmt.Errorf("git worktree prune failed: %w", err)
+	}
+	if _, err := m.run("-C", originDir, "gc", "--quiet"); err != nil {
+		return fmt.Errorf("git gc failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EngineBranch returns the local branch checked out in a worktree, e.g. engine-5.4 for UE_5.4.
+// Commits made on it (cherry-picked fixes for one engine version) are kept across updates.
+func EngineBranch(subdir string) string {
+	return "engine-" + strings.TrimPrefix(subdir, "UE_")
+}
+
+// branchExists reports whether a local branch exists in the origin repository
+func (m *Manager) branchExists(branch string) bool {
+	_, err := m.run("-C", m.getActualOriginDir(), "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// localCommit is a commit on a worktree's HEAD that no remote branch contains
+type localCommit struct {
+	SHA     string
+	Subject string
+	Patch   string // PatchTrailer value when the commit was created from a local patch
+}
+
+func (c localCommit) String() string {
+	return ShortSHA(c.SHA) + " " + c.Subject
+}
+
+// localCommits returns the commits on HEAD that no remote branch contains, newest first
+func (m *Manager) localCommits(worktreePath string) ([]localCommit, error) {
+	format := "--format=%H%x00%s%x00%(trailers:key=" + PatchTrailer + ",valueonly,separator=%x20)%x1e"
+	output, err := m.run("-C", worktreePath, "log", format, "HEAD", "--not", "--remotes")
+	if err != nil {
+		return nil, err
+	}
+	var commits []localCommit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x00")
+		if len(fields) != 3 || fields[0] == "" {
+			continue
+		}
+		commits = append(commits, localCommit{SHA: fields[0], Subject: fields[1], Patch: strings.TrimSpace(fields[2])})
+	}
+	return commits, nil
+}
+
+// ownCommits drops the commits created from local patches, which are re-created on every update
+func ownCommits(commits []localCommit) []localCommit {
+	var own []localCommit
+	for _, commit := range commits {
+		if commit.Patch == "" {
+			own = append(own, commit)
+		}
+	}
+	return own
+}
+
+// upstreamBase returns the upstream commit the worktree's local commits sit on (HEAD when
+// there are none) together with those local commits
+func (m *Manager) upstreamBase(worktreePath string) (string, []localCommit, error) {
+	commits, err := m.localCommits(worktreePath)
+	if err != nil {
+		return "", nil, err
+	}
+	rev := "HEAD"
+	if len(commits) > 0 {
+		rev = commits[len(commits)-1].SHA + "^"
+	}
+	output, err := m.run("-C", worktreePath, "rev-parse", rev)
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.TrimSpace(output), commits, nil
+}
+
+// ConflictError reports the patch or local commit that stopped an update. The worktree has
+// already been restored to where it was before the update.
+type ConflictError struct {
+	Step    string   // The patch or commit that failed, e.g. "local patch file fix.patch"
+	SkipID  string   // Add to LocalPatches.Skip to leave the step out of the next attempt
+	IsPatch bool     // Whether the step is a local patch rather than one of the branch's own commits
+	Target  string   // Commit the branch was being moved onto
+	Files   []string // Conflicting files, or the files the step touches when git reports none
+	Err     error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: %s doesn't apply cleanly onto %s: %v", ErrLocalCommitsConflict, e.Step, ShortSHA(e.Target), e.Err)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrLocalCommitsConflict) match
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrLocalCommitsConflict
+}
+
+// moveToTarget rebuilds the engine branch checked out in a clean worktree on the target commit:
+// local patches first, then the branch's own commits, leaving out those in patches.Skip. If
+// anything fails to apply, the branch is restored to where it was and a *ConflictError is returned.
+func (m *Manager) moveToTarget(worktreePath, target, upstreamRef string, patches LocalPatches) error {
+	base, commits, err := m.upstreamBase(worktreePath)
+	if err != nil {
+		return err
+	}
+	allSteps, err := m.patchSteps(patches, target, upstreamRef)
+	if err != nil {
+		return err
+	}
+	var steps []patchStep
+	for _, step := range allSteps {
+		if !patches.skips(step.ID) {
+			steps = append(steps, step)
+		}
+	}
+	if base == target && patchesMatch(commits, steps) {
+		return nil
+	}
+
+	head, err := m.run("-C", worktreePath, "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	restore := func() {
+		m.run("-C", worktreePath, "reset", "--hard", strings.TrimSpace(head))
+	}
+
+	if _, err := m.run("-C", worktreePath, "reset", "--hard", target); err != nil {
+		return err
+	}
+	for _, step := range steps {
+		if err := m.applyPatch(worktreePath, step); err != nil {
+			conflict := &ConflictError{Step: "local patch " + step.ID, SkipID: step.ID, IsPatch: true, Target: target, Err: err}
+			conflict.Files = m.conflictFiles(worktreePath, step)
+			m.abortPatch(worktreePath, step)
+			restore()
+			return conflict
+		}
+	}
+
+	own := ownCommits(commits)
+	for i := len(own) - 1; i >= 0; i-- {
+		if patches.skips(own[i].SHA) {
+			continue
+		}
+		if _, err := m.run("-C", worktreePath, "cherry-pick", own[i].SHA); err != nil {
+			conflict := &ConflictError{Step: "local commit " + own[i].String(), SkipID: own[i].SHA, Target: target, Err: err}
+			conflict.Files = m.conflictFiles(worktreePath, patchStep{Commit: own[i].SHA})
+			m.run("-C", worktreePath, "cherry-pick", "--abort")
+			restore()
+			return conflict
+		}
+	}
+	return nil
+}
+
+// conflictFiles lists the files left unmerged by a failed step, or the files the step touches
+// when it failed without starting a merge
+func (m *Manager) conflictFiles(worktreePath string, step patchStep) []string {
+	output, _ := m.run("-C", worktreePath, "diff", "--name-only", "--diff-filter=U")
+	files := splitLines(output)
+	if len(files) > 0 {
+		return files
+	}
+
+	if step.Commit != "" {
+		output, _ = m.run("-C", worktreePath, "diff-tree", "--no-commit-id", "--name-only", "-r", step.Commit)
+		return splitLines(output)
+	}
+	// --numstat lines are "added<TAB>deleted<TAB>path"
+	output, _ = m.run("-C", worktreePath, "apply", "--numstat", step.File)
+	for _, line := range splitLines(output) {
+		if fields := strings.SplitN(line, "\t", 3); len(fields) == 3 {
+			files = append(files, fields[2])
+		}
+	}
+	return files
+}
+
+// splitLines returns the non-empty lines of git's output
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// WorktreeState describes how a worktree's checkout relates to the commit it should track
+type WorktreeState struct {
+	HeadSHA      string
+	TargetSHA    string
+	EngineBranch string   // Branch the worktree is expected to have checked out
+	Branch       string   // Branch actually checked out; empty when HEAD is detached
+	LocalCommits []string // The branch's own commits that no remote contains, as "sha subject" lines
+}
+
+// GetWorktreeState inspects which branch a worktree has checked out and the commits it carries
+func (m *Manager) GetWorktreeState(subdir, defaultBranch, pinnedCommit string) (*WorktreeState, error) {
+	worktreePath := m.GetWorktreePath(subdir)
+	if !m.WorktreeExists(subdir) {
+		return nil, fmt.Errorf("worktree %s does not exist: %w", subdir, ErrNotARepo)
+	}
+
+	head, err := m.run("-C", worktreePath, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	targetSHA, err := m.resolveTargetSHA(defaultBranch, pinnedCommit)
+	if err != nil {
+		return nil, err
+	}
+	state := &WorktreeState{HeadSHA: strings.TrimSpace(head), TargetSHA: targetSHA, EngineBranch: EngineBranch(subdir)}
+
+	// symbolic-ref fails on a detached HEAD
+	if branch, err := m.run("-C", worktreePath, "symbolic-ref", "--quiet", "--short", "HEAD"); err == nil {
+		state.Branch = strings.TrimSpace(branch)
+	}
+
+	commits, err := m.localCommits(worktreePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, commit := range ownCommits(commits) {
+		state.LocalCommits = append(state.LocalCommits, commit.String())
+	}
+	return state, nil
+}
+
+// ResetWorktree checks out the engine branch at the tracked commit with the local patches applied,
+// discarding the branch's own commits and any local changes
+func (m *Manager) ResetWorktree(subdir, defaultBranch, pinnedCommit string, patches LocalPatches) error {
+	targetSHA, err := m.resolveTargetSHA(defaultBranch, pinnedCommit)
+	if err != nil {
+		return err
+	}
+	worktreePath := m.GetWorktreePath(subdir)
+	if _, err := m.run("-C", worktreePath, "checkout", "--force", "-B", EngineBranch(subdir), targetSHA); err != nil {
+		return err
+	}
+	return m.moveToTarget(worktreePath, targetSHA, m.TrackingRef(defaultBranch), patches)
+}
+
+// ShortSHA abbreviates a commit SHA for messages to its first 8 characters; shorter SHAs are
+// shown whole and a missing one as "none"
+func ShortSHA(sha string) string {
+	if sha == "" {
+		return "none"
+	}
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
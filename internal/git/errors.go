@@ -0,0 +1,55 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors a git operation can be classified as, for use with errors.Is
+var (
+	ErrNotARepo             = errors.New("not a git repository")
+	ErrDirtyWorktree        = errors.New("worktree has local changes")
+	ErrNetworkUnavailable   = errors.New("repository could not be reached")
+	ErrAuthRequired         = errors.New("repository requires authentication")
+	ErrDiverged             = errors.New("worktree is not on its engine branch")
+	ErrLocalCommitsConflict = errors.New("local commits conflict with the update")
+)
+
+// errorPatterns map git's messages to the error kinds, checked in order. Authentication is
+// checked before network errors because git reports both as "unable to access".
+var errorPatterns = []struct {
+	kind    error
+	needles []string
+}{
+	{ErrAuthRequired, []string{
+		"authentication failed", "could not read username", "could not read password",
+		"terminal prompts disabled", "access denied", "permission denied (publickey",
+		"the requested url returned error: 401", "the requested url returned error: 403",
+	}},
+	{ErrNetworkUnavailable, []string{
+		"could not resolve host", "failed to connect", "couldn't connect", "connection timed out",
+		"connection refused", "connection was reset", "operation too slow", "timed out after",
+		"could not resolve proxy", "unable to access", "ssl_connect", "schannel",
+		"the remote end hung up unexpectedly", "early eof",
+	}},
+	{ErrDirtyWorktree, []string{
+		"your local changes to the following files would be overwritten",
+		"please commit your changes or stash them", "untracked working tree files would be overwritten",
+	}},
+	{ErrNotARepo, []string{
+		"not a git repository", "does not appear to be a git repository", "cannot change to",
+	}},
+}
+
+// classify returns the error kind matching git's output, or nil if none matches
+func classify(output string) error {
+	lower := strings.ToLower(output)
+	for _, pattern := range errorPatterns {
+		for _, needle := range pattern.needles {
+			if strings.Contains(lower, needle) {
+				return pattern.kind
+			}
+		}
+	}
+	return nil
+}
@@ -5,16 +5,20 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/events"
 )
 
 // UpdateInfo represents information about available updates
 type UpdateInfo struct {
-	EngineVersion   string `json:"engine_version"`
+	WorktreeSubdir  string `json:"worktree_subdir"`
 	CommitsAhead    int    `json:"commits_ahead"`
 	LocalSHA        string `json:"local_sha"`
+	BaseSHA         string `json:"base_sha"`      // Upstream commit under the engine branch's local commits
+	LocalCommits    int    `json:"local_commits"` // Engine branch commits that aren't on the remote, excluding local patches
 	RemoteSHA       string `json:"remote_sha"`
 	LatestCommitURL string `json:"latest_commit_url"`
 	CompareURL      string `json:"compare_url"`
@@ -58,23 +62,53 @@ func (m *Manager) IsGitAvailable() bool {
 
 // GetGitVersion returns the Git version
 func (m *Manager) GetGitVersion() (string, error) {
-	cmd := exec.Command("git", "--version")
-	output, err := cmd.Output()
+	output, err := m.run("--version")
 	if err != nil {
 		return "", err
 	}
-	return strings.TrimSpace(string(output)), nil
+	return strings.TrimSpace(output), nil
 }
 
-// CloneOrigin clones the UEGitPlugin repository
-func (m *Manager) CloneOrigin() error {
+// ConfigValue returns a git setting as it applies outside any repository, or "" when it isn't set
+func (m *Manager) ConfigValue(key string) string {
+	output, err := m.run("config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// UpstreamURL is the public UEGitPlugin repository
+const UpstreamURL = "https://github.com/ProjectBorealis/UEGitPlugin"
+
+// mirrorTimeoutArgs make git give up on a stalled mirror instead of hanging, so the
+// fallback to GitHub kicks in
+var mirrorTimeoutArgs = []string{"-c", "http.lowSpeedLimit=1000", "-c", "http.lowSpeedTime=15"}
+
+// CloneOrigin clones the UEGitPlugin repository, from the studio mirror when one is configured.
+// The origin remote always points at GitHub so later fetches can fall back to it.
+func (m *Manager) CloneOrigin(mirrorURL string) error {
 	if m.IsOriginCloned() {
 		return nil
 	}
 
-	cmd := exec.Command("git", "clone", "https://github.com/ProjectBorealis/UEGitPlugin", m.originDir)
-	cmd.Dir = m.exeDir
-	return cmd.Run()
+	if mirror := strings.TrimSpace(mirrorURL); mirror != "" {
+		args := append(append([]string{}, mirrorTimeoutArgs...), "clone", mirror, m.originDir)
+		if _, err := m.run(args...); err == nil {
+			if _, err = m.run("-C", m.originDir, "remote", "set-url", "origin", UpstreamURL); err != nil {
+				return err
+			}
+			return m.ensureFetchPrune(m.originDir)
+		} else {
+			events.Warn("Clone from mirror failed, falling back to GitHub: %v", err)
+			os.RemoveAll(m.originDir)
+		}
+	}
+
+	if _, err := m.run("clone", UpstreamURL, m.originDir); err != nil {
+		return err
+	}
+	return m.ensureFetchPrune(m.originDir)
 }
 
 // IsOriginCloned checks if the origin repository is cloned
@@ -103,14 +137,13 @@ func (m *Manager) IsOriginCloned() bool {
 // Checks both the default and fallback base directories
 func (m *Manager) GetDefaultBranch() (string, error) {
 	originDir := m.getActualOriginDir()
-	cmd := exec.Command("git", "-C", originDir, "remote", "show", "origin")
-	output, err := cmd.Output()
+	output, err := m.run("-C", originDir, "remote", "show", "origin")
 	if err != nil {
 		return "dev", err // Fallback to dev
 	}
 
 	// Parse the output to find the HEAD branch
-	lines := strings.Split(string(output), "\n")
+	lines := strings.Split(output, "\n")
 	for _, line := range lines {
 		if strings.Contains(line, "HEAD branch:") {
 			parts := strings.Fields(line)
@@ -145,11 +178,134 @@ func (m *Manager) getActualOriginDir() string {
 	return m.originDir
 }
 
-// FetchAll fetches all remote changes
-func (m *Manager) FetchAll() error {
+// CheckRemote verifies that a repository URL can be reached
+func (m *Manager) CheckRemote(url string) error {
+	args := append(append([]string{}, mirrorTimeoutArgs...), "ls-remote", "--heads", url)
+	_, err := m.run(args...)
+	return err
+}
+
+// RemoteRefs are the branches and tags that can be tracked
+type RemoteRefs struct {
+	Branches []string
+	Tags     []string
+}
+
+// add sorts a full ref name into branches or tags
+func (r *RemoteRefs) add(ref, branchPrefix string) {
+	switch {
+	case strings.HasSuffix(ref, "^{}") || strings.HasSuffix(ref, "/HEAD"):
+	case strings.HasPrefix(ref, branchPrefix):
+		r.Branches = append(r.Branches, strings.TrimPrefix(ref, branchPrefix))
+	case strings.HasPrefix(ref, "refs/tags/"):
+		r.Tags = append(r.Tags, strings.TrimPrefix(ref, "refs/tags/"))
+	}
+}
+
+// CachedRemoteRefs returns the branches and tags as of the last fetch, without network access
+func (m *Manager) CachedRemoteRefs() (*RemoteRefs, error) {
+	if !m.IsOriginCloned() {
+		return nil, fmt.Errorf("plugin repository has not been cloned: %w", ErrNotARepo)
+	}
+	output, err := m.run("-C", m.getActualOriginDir(), "for-each-ref", "--format=%(refname)", "refs/remotes/origin", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	refs := &RemoteRefs{}
+	for _, line := range strings.Split(output, "\n") {
+		refs.add(strings.TrimSpace(line), "refs/remotes/origin/")
+	}
+	sort.Strings(refs.Branches)
+	sort.Strings(refs.Tags)
+	return refs, nil
+}
+
+// ListRemoteRefs queries the branches on the studio mirror when one is configured, or on
+// GitHub if there is none or it can't be reached. Tags are only listed from a fetched clone,
+// where they can be resolved to a commit.
+func (m *Manager) ListRemoteRefs(mirrorURL string) (*RemoteRefs, error) {
+	url := UpstreamURL
+	if mirror := strings.TrimSpace(mirrorURL); mirror != "" {
+		url = mirror
+	}
+
+	args := append(append([]string{}, mirrorTimeoutArgs...), "ls-remote", "--heads", url)
+	output, err := m.run(args...)
+	if err != nil && url != UpstreamURL {
+		events.Warn("Listing branches on the mirror failed, falling back to GitHub: %v", err)
+		args = append(append([]string{}, mirrorTimeoutArgs...), "ls-remote", "--heads", UpstreamURL)
+		output, err = m.run(args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	refs := &RemoteRefs{}
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			refs.add(fields[1], "refs/heads/")
+		}
+	}
+	sort.Strings(refs.Branches)
+	sort.Strings(refs.Tags)
+	return refs, nil
+}
+
+// ResolveTag returns the commit a tag points to in the origin repository
+func (m *Manager) ResolveTag(tag string) (string, error) {
+	return m.ResolveCommit("refs/tags/" + tag)
+}
+
+// ResolveCommit returns the full SHA of a commit, tag or branch name in the origin repository
+func (m *Manager) ResolveCommit(rev string) (string, error) {
+	output, err := m.run("-C", m.getActualOriginDir(), "rev-parse", "--verify", rev+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// ReadFileAt returns a file's content at a commit, tag or branch of the origin repository,
+// without checking it out
+func (m *Manager) ReadFileAt(rev, path string) ([]byte, error) {
+	output, err := m.run("-C", m.getActualOriginDir(), "show", rev+":"+path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(output), nil
+}
+
+// FetchAll fetches all remote changes, from the studio mirror when one is configured
+// and from GitHub if the mirror can't be reached
+func (m *Manager) FetchAll(mirrorURL string) error {
+	if !m.IsOriginCloned() {
+		return fmt.Errorf("plugin repository has not been cloned: %w", ErrNotARepo)
+	}
 	originDir := m.getActualOriginDir()
-	cmd := exec.Command("git", "-C", originDir, "fetch", "--all", "--prune")
-	return cmd.Run()
+	// Repositories cloned before the setting was introduced get it on their next fetch
+	if err := m.ensureFetchPrune(originDir); err != nil {
+		return err
+	}
+
+	if mirror := strings.TrimSpace(mirrorURL); mirror != "" {
+		// Fetch the mirror's branches into origin/* so the rest of the tool is unaware of it
+		args := append(append([]string{}, mirrorTimeoutArgs...), "-C", originDir, "fetch", "--prune", mirror,
+			"+refs/heads/*:refs/remotes/origin/*", "+refs/tags/*:refs/tags/*")
+		_, err := m.run(args...)
+		if err == nil {
+			m.fetchExtraRemotes()
+			return nil
+		}
+		events.Warn("Fetch from mirror failed, falling back to GitHub: %v", err)
+	}
+
+	// Only origin decides whether the update can go ahead; an unreachable fork is a warning
+	if _, err := m.run("-C", originDir, "fetch", "--prune", OriginRemote); err != nil {
+		return err
+	}
+	m.fetchExtraRemotes()
+	return nil
 }
 
 func (m *Manager) normalizeBranch(defaultBranch string) string {
@@ -164,39 +320,25 @@ func (m *Manager) resolveTargetSHA(defaultBranch, pinnedCommit string) (string,
 	originDir := m.getActualOriginDir()
 	pin := strings.TrimSpace(pinnedCommit)
 	if pin != "" {
-		cmd := exec.Command("git", "-C", originDir, "rev-parse", "--verify", fmt.Sprintf("%s^{commit}", pin))
-		output, err := cmd.Output()
+		output, err := m.run("-C", originDir, "rev-parse", "--verify", fmt.Sprintf("%s^{commit}", pin))
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve pinned commit %q: %w", pin, err)
 		}
-		return strings.TrimSpace(string(output)), nil
+		return strings.TrimSpace(output), nil
 	}
 
-	branch := m.normalizeBranch(defaultBranch)
-	cmd := exec.Command("git", "-C", originDir, "rev-parse", fmt.Sprintf("origin/%s", branch))
-	output, err := cmd.Output()
+	ref := m.TrackingRef(defaultBranch)
+	output, err := m.run("-C", originDir, "rev-parse", ref)
 	if err != nil {
-		return "", fmt.Errorf("failed to resolve origin/%s: %w", branch, err)
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	return strings.TrimSpace(output), nil
 }
 
-// CreateEngineBranch creates a branch for a specific engine version
-func (m *Manager) CreateEngineBranch(version, defaultBranch string) error {
+// CreateWorktree creates a worktree in the given subdirectory of the worktrees directory
+func (m *Manager) CreateWorktree(subdir, defaultBranch, pinnedCommit string, patches LocalPatches) error {
 	originDir := m.getActualOriginDir()
-	branchName := fmt.Sprintf("engine-%s", version)
-	cmd := exec.Command("git", "-C", originDir, "branch", "--force", branchName, fmt.Sprintf("origin/%s", defaultBranch))
-	output, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("failed to create engine branch: %v, output: %s", err, string(output))
-	}
-	return nil
-}
-
-// CreateWorktree creates a worktree for an engine version
-func (m *Manager) CreateWorktree(version, defaultBranch, pinnedCommit string) error {
-	originDir := m.getActualOriginDir()
-	worktreePath := filepath.Join(m.worktreesDir, fmt.Sprintf("UE_%s", version))
+	worktreePath := filepath.Join(m.worktreesDir, subdir)
 
 	// Create the worktrees directory if it doesn't exist
 	if err := os.MkdirAll(m.worktreesDir, 0755); err != nil {
@@ -208,14 +350,9 @@ func (m *Manager) CreateWorktree(version, defaultBranch, pinnedCommit string) er
 		return fmt.Errorf("origin directory does not exist: %s", originDir)
 	}
 
-	branch := m.normalizeBranch(defaultBranch)
-	targetRef := fmt.Sprintf("origin/%s", branch)
-	if strings.TrimSpace(pinnedCommit) != "" {
-		targetSHA, err := m.resolveTargetSHA(branch, pinnedCommit)
-		if err != nil {
-			return err
-		}
-		targetRef = targetSHA
+	targetRef, err := m.resolveTargetSHA(m.normalizeBranch(defaultBranch), pinnedCommit)
+	if err != nil {
+		return err
 	}
 
 	// Check if worktree already exists and remove it
@@ -225,16 +362,20 @@ func (m *Manager) CreateWorktree(version, defaultBranch, pinnedCommit string) er
 		}
 	}
 
-	// Create the worktree from the default branch
-	// Use --detach to avoid conflicts with the main repository
-	cmd := exec.Command("git", "-C", originDir, "worktree", "add", "--detach", worktreePath, targetRef)
-	output, err := cmd.Output()
-	if err != nil {
-		// Try to get stderr as well
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return fmt.Errorf("failed to create worktree: %v, stderr: %s", err, string(exitError.Stderr))
-		}
-		return fmt.Errorf("failed to create worktree: %v, output: %s", err, string(output))
+	// Forget worktrees whose folders are gone so their engine branch can be checked out again
+	m.run("-C", originDir, "worktree", "prune")
+
+	// Check out the engine's own branch, created at the target or kept with its local commits
+	engineBranch := EngineBranch(subdir)
+	addArgs := []string{"-C", originDir, "worktree", "add", "-b", engineBranch, worktreePath, targetRef}
+	if m.branchExists(engineBranch) {
+		addArgs = []string{"-C", originDir, "worktree", "add", worktreePath, engineBranch}
+	}
+	if _, err := m.run(addArgs...); err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+	if err := m.moveToTarget(worktreePath, targetRef, m.TrackingRef(defaultBranch), patches); err != nil {
+		return err
 	}
 
 	// Verify the worktree was created
@@ -242,24 +383,24 @@ func (m *Manager) CreateWorktree(version, defaultBranch, pinnedCommit string) er
 		return fmt.Errorf("worktree directory was not created: %s", worktreePath)
 	}
 
+	m.guardWorktree(worktreePath)
 	return nil
 }
 
-// WorktreeExists checks if a worktree exists for the given version
+// WorktreeExists checks if a worktree exists in the given subdirectory
 // Checks both the default and fallback base directories
-func (m *Manager) WorktreeExists(version string) bool {
+func (m *Manager) WorktreeExists(subdir string) bool {
 	// First check the configured baseDir
-	worktreePath := filepath.Join(m.worktreesDir, fmt.Sprintf("UE_%s", version))
+	worktreePath := filepath.Join(m.worktreesDir, subdir)
 	if _, err := os.Stat(worktreePath); err == nil {
 		return true
 	}
 
 	// Check both possible base directories
 	possibleBaseDirs := config.GetPossibleBaseDirs()
-	worktreeSubdir := fmt.Sprintf("UE_%s", version)
 	for _, baseDir := range possibleBaseDirs {
 		worktreesDir := filepath.Join(baseDir, "worktrees")
-		worktreePath := filepath.Join(worktreesDir, worktreeSubdir)
+		worktreePath := filepath.Join(worktreesDir, subdir)
 		if _, err := os.Stat(worktreePath); err == nil {
 			return true
 		}
@@ -268,132 +409,198 @@ func (m *Manager) WorktreeExists(version string) bool {
 	return false
 }
 
-// GetWorktreePath returns the path to a worktree
+// GetWorktreePath returns the path to the worktree in the given subdirectory
 // Checks both the default and fallback base directories to find the actual location
-func (m *Manager) GetWorktreePath(version string) string {
+func (m *Manager) GetWorktreePath(subdir string) string {
 	// First check the configured baseDir
-	worktreePath := filepath.Join(m.worktreesDir, fmt.Sprintf("UE_%s", version))
+	worktreePath := filepath.Join(m.worktreesDir, subdir)
 	if _, err := os.Stat(worktreePath); err == nil {
 		return worktreePath
 	}
 
 	// Check both possible base directories
 	possibleBaseDirs := config.GetPossibleBaseDirs()
-	worktreeSubdir := fmt.Sprintf("UE_%s", version)
 	for _, baseDir := range possibleBaseDirs {
 		worktreesDir := filepath.Join(baseDir, "worktrees")
-		worktreePath := filepath.Join(worktreesDir, worktreeSubdir)
+		worktreePath := filepath.Join(worktreesDir, subdir)
 		if _, err := os.Stat(worktreePath); err == nil {
 			return worktreePath
 		}
 	}
 
 	// If not found, return the path based on configured baseDir (for creation)
-	return filepath.Join(m.worktreesDir, fmt.Sprintf("UE_%s", version))
+	return filepath.Join(m.worktreesDir, subdir)
 }
 
 // GetUpdateInfo gets update information for a worktree
-func (m *Manager) GetUpdateInfo(version, defaultBranch, pinnedCommit string) (*UpdateInfo, error) {
-	worktreePath := m.GetWorktreePath(version)
-	if !m.WorktreeExists(version) {
-		return nil, fmt.Errorf("worktree does not exist for version %s", version)
+func (m *Manager) GetUpdateInfo(subdir, defaultBranch, pinnedCommit string) (*UpdateInfo, error) {
+	worktreePath := m.GetWorktreePath(subdir)
+	if !m.WorktreeExists(subdir) {
+		return nil, fmt.Errorf("worktree %s does not exist: %w", subdir, ErrNotARepo)
 	}
 	branch := m.normalizeBranch(defaultBranch)
 
 	// Get local HEAD
-	localCmd := exec.Command("git", "-C", worktreePath, "rev-parse", "HEAD")
-	localOutput, err := localCmd.Output()
+	localOutput, err := m.run("-C", worktreePath, "rev-parse", "HEAD")
 	if err != nil {
 		return nil, err
 	}
-	localSHA := strings.TrimSpace(string(localOutput))
+	localSHA := strings.TrimSpace(localOutput)
 
 	targetSHA, err := m.resolveTargetSHA(branch, pinnedCommit)
 	if err != nil {
 		return nil, err
 	}
 
+	// Local commits on the engine branch are carried over by updates, so compare the upstream commit they sit on
+	baseSHA, localCommits, err := m.upstreamBase(worktreePath)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get commits ahead
 	commitsAhead := 0
 	if strings.TrimSpace(pinnedCommit) != "" {
-		if localSHA != targetSHA {
+		if baseSHA != targetSHA {
 			commitsAhead = 1
 		}
 	} else {
 		originDir := m.getActualOriginDir()
-		aheadCmd := exec.Command("git", "-C", originDir, "rev-list", "--count", fmt.Sprintf("%s..origin/%s", localSHA, branch))
-		aheadOutput, err := aheadCmd.Output()
+		aheadOutput, err := m.run("-C", originDir, "rev-list", "--count", baseSHA+".."+m.TrackingRef(branch))
 		if err != nil {
 			return nil, err
 		}
-		fmt.Sscanf(strings.TrimSpace(string(aheadOutput)), "%d", &commitsAhead)
+		fmt.Sscanf(strings.TrimSpace(aheadOutput), "%d", &commitsAhead)
 	}
 
 	// Generate URLs
-	latestCommitURL := fmt.Sprintf("https://github.com/ProjectBorealis/UEGitPlugin/commit/%s", targetSHA)
-	compareURL := fmt.Sprintf("https://github.com/ProjectBorealis/UEGitPlugin/compare/%s...%s", localSHA, targetSHA)
+	webURL := m.webURL(branch)
+	latestCommitURL := fmt.Sprintf("%s/commit/%s", webURL, targetSHA)
+	compareURL := fmt.Sprintf("%s/compare/%s...%s", webURL, baseSHA, targetSHA)
 
 	return &UpdateInfo{
-		EngineVersion:   version,
+		WorktreeSubdir:  subdir,
 		CommitsAhead:    commitsAhead,
 		LocalSHA:        localSHA,
+		BaseSHA:         baseSHA,
+		LocalCommits:    len(ownCommits(localCommits)),
 		RemoteSHA:       targetSHA,
 		LatestCommitURL: latestCommitURL,
 		CompareURL:      compareURL,
 	}, nil
 }
 
+// GetHeadSHA returns the commit currently checked out in a worktree
+func (m *Manager) GetHeadSHA(subdir string) (string, error) {
+	output, err := m.run("-C", m.GetWorktreePath(subdir), "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
 // UpdateWorktree updates a worktree to the latest version
-func (m *Manager) UpdateWorktree(version, defaultBranch, pinnedCommit string) error {
-	worktreePath := m.GetWorktreePath(version)
-	if !m.WorktreeExists(version) {
-		return fmt.Errorf("worktree does not exist for version %s", version)
+func (m *Manager) UpdateWorktree(subdir, defaultBranch, pinnedCommit string, patches LocalPatches) error {
+	worktreePath := m.GetWorktreePath(subdir)
+	if !m.WorktreeExists(subdir) {
+		return fmt.Errorf("worktree %s does not exist: %w", subdir, ErrNotARepo)
 	}
 	targetSHA, err := m.resolveTargetSHA(defaultBranch, pinnedCommit)
 	if err != nil {
 		return err
 	}
+	// Worktrees set up by older versions get the guard on their next update
+	m.guardWorktree(worktreePath)
 
-	if strings.TrimSpace(pinnedCommit) != "" {
-		cmd := exec.Command("git", "-C", worktreePath, "checkout", "--detach", targetSHA)
-		return cmd.Run()
+	// The build stamps the commit into the plugin descriptor; restore it so the
+	// local change never blocks the checkout or fast-forward
+	m.run("-C", worktreePath, "checkout", "--", "GitSourceControl.uplugin")
+
+	changes, err := m.LocalChanges(subdir)
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		return fmt.Errorf("%w in %s: %s", ErrDirtyWorktree, subdir, strings.Join(changes, ", "))
 	}
 
-	// Fast-forward merge
-	cmd := exec.Command("git", "-C", worktreePath, "merge", "--ff-only", targetSHA)
-	return cmd.Run()
+	state, err := m.GetWorktreeState(subdir, defaultBranch, pinnedCommit)
+	if err != nil {
+		return err
+	}
+	engineBranch := EngineBranch(subdir)
+	switch {
+	case state.Branch != "" && state.Branch != engineBranch:
+		return fmt.Errorf("%w: %s has branch %s checked out instead of %s", ErrDiverged, subdir, state.Branch, engineBranch)
+	case state.Branch == "" && len(state.LocalCommits) > 0:
+		return fmt.Errorf("%w: %s has a detached checkout with %d commit(s) that are not on the remote", ErrDiverged, subdir, len(state.LocalCommits))
+	case state.Branch == "":
+		// Worktrees created before engine branches were detached; move them onto their branch
+		if _, err := m.run("-C", worktreePath, "checkout", "-B", engineBranch, targetSHA); err != nil {
+			return err
+		}
+	}
+	return m.moveToTarget(worktreePath, targetSHA, m.TrackingRef(defaultBranch), patches)
+}
+
+// LocalChanges returns the modified tracked files in a worktree, one "XY path" entry each
+func (m *Manager) LocalChanges(subdir string) ([]string, error) {
+	output, err := m.run("-C", m.GetWorktreePath(subdir), "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			changes = append(changes, line)
+		}
+	}
+	return changes, nil
+}
+
+// DiscardLocalChanges resets modified tracked files in a worktree to the checked out commit
+func (m *Manager) DiscardLocalChanges(subdir string) error {
+	_, err := m.run("-C", m.GetWorktreePath(subdir), "reset", "--hard", "HEAD")
+	return err
+}
+
+// RepairWorktree fixes the links between a worktree and the origin repository after either was
+// moved or restored from a backup to another folder
+func (m *Manager) RepairWorktree(subdir string) error {
+	if _, err := m.run("-C", m.getActualOriginDir(), "worktree", "repair", m.GetWorktreePath(subdir)); err != nil {
+		return fmt.Errorf("failed to repair worktree: %w", err)
+	}
+	return nil
 }
 
 // RemoveWorktree removes a worktree
-func (m *Manager) RemoveWorktree(version string) error {
+func (m *Manager) RemoveWorktree(subdir string) error {
 	originDir := m.getActualOriginDir()
-	worktreePath := m.GetWorktreePath(version)
-	if !m.WorktreeExists(version) {
+	worktreePath := m.GetWorktreePath(subdir)
+	if !m.WorktreeExists(subdir) {
 		return nil // Already removed
 	}
 
 	// First, try to remove the worktree normally
-	cmd := exec.Command("git", "-C", originDir, "worktree", "remove", worktreePath)
-	if err := cmd.Run(); err != nil {
+	if _, err := m.run("-C", originDir, "worktree", "remove", worktreePath); err != nil {
 		// If normal removal fails, try force removal
-		fmt.Printf("  Normal worktree removal failed, trying force removal...\n")
-		cmd = exec.Command("git", "-C", originDir, "worktree", "remove", "--force", worktreePath)
-		if err := cmd.Run(); err != nil {
+		events.Info("Normal worktree removal failed, trying force removal...")
+		if _, err := m.run("-C", originDir, "worktree", "remove", "--force", worktreePath); err != nil {
 			// If Git worktree remove still fails, manually remove the directory
-			fmt.Printf("  Git worktree remove failed, manually removing directory...\n")
+			events.Info("Git worktree remove failed, manually removing directory...")
 			if err := os.RemoveAll(worktreePath); err != nil {
 				return fmt.Errorf("failed to remove worktree directory: %v", err)
 			}
-			fmt.Printf("  ✅ Manually removed worktree directory\n")
+			events.Success("Manually removed worktree directory")
 		} else {
-			fmt.Printf("  ✅ Force removed worktree\n")
+			events.Success("Force removed worktree")
 		}
 	} else {
-		fmt.Printf("  ✅ Removed worktree\n")
+		events.Success("Removed worktree")
 	}
 
-	// Note: We don't remove branches because all engines use the same default branch
-	// Each worktree is created with --detach from the default branch
+	// The engine branch is kept so local commits on it survive an uninstall and are
+	// picked up again if the engine is set up later
 
 	return nil
 }
@@ -0,0 +1,122 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/events"
+)
+
+// WorktreeMarkerFile is written into every managed worktree, so someone who opens it in an IDE
+// sees that the tool owns it
+const WorktreeMarkerFile = "MANAGED_BY_UE_GIT_PLUGIN_MANAGER.txt"
+
+// guardHookMarker identifies the pre-commit hook written by this tool, so it can be replaced safely
+const guardHookMarker = "Worktree guard installed by UE Git Plugin Manager"
+
+const worktreeMarkerText = `This folder is managed by UE Git Plugin Manager. It is the Git plugin checkout an
+Unreal Engine install links to, and the tool moves it to new commits when it updates.
+
+Don't edit or commit here: commits on the engine-* branch are blocked by a pre-commit hook.
+Every commit on it is replayed on top of each update, so an accidental one ends up in the
+plugin the editor loads and can stop updates with a conflict. To carry a fix for this engine
+on purpose, add it under Settings -> "Local Patches", or commit with "git commit --no-verify".
+`
+
+// guardHook blocks commits on the engine branches. Hooks live in the origin repository and are
+// shared by all of its worktrees, so other branches are let through.
+const guardHook = `#!/bin/sh
+# ` + guardHookMarker + `
+branch=$(git symbolic-ref --quiet --short HEAD 2>/dev/null)
+case "$branch" in
+engine-*) ;;
+*) exit 0 ;;
+esac
+echo "This worktree is managed by UE Git Plugin Manager and the commit was blocked." >&2
+echo "Commits on the engine-* branches are replayed on top of every plugin update." >&2
+echo "To keep a fix for this engine, add it under Settings -> \"Local Patches\", or" >&2
+echo "commit again with --no-verify to keep it on purpose." >&2
+exit 1
+`
+
+// guardWorktree protects a managed worktree against accidental commits: it writes the marker
+// file, installs the pre-commit hook and refuses pushes into the origin repository's checked out
+// branches. It is best effort; a failure is only a warning.
+func (m *Manager) guardWorktree(worktreePath string) {
+	if err := m.installGuard(worktreePath); err != nil {
+		events.Warn("Could not guard %s against accidental commits: %v", filepath.Base(worktreePath), err)
+	}
+}
+
+func (m *Manager) installGuard(worktreePath string) error {
+	originDir := m.getActualOriginDir()
+	if err := os.WriteFile(filepath.Join(worktreePath, WorktreeMarkerFile), []byte(worktreeMarkerText), 0644); err != nil {
+		return fmt.Errorf("failed to write the marker file: %v", err)
+	}
+	if err := m.excludeMarker(originDir); err != nil {
+		return err
+	}
+	if _, err := m.run("-C", originDir, "config", "receive.denyCurrentBranch", "refuse"); err != nil {
+		return err
+	}
+
+	hooksDir, err := m.gitPath(originDir, "hooks")
+	if err != nil {
+		return err
+	}
+	hookPath := filepath.Join(hooksDir, "pre-commit")
+	if existing, err := os.ReadFile(hookPath); err == nil {
+		if !bytes.Contains(existing, []byte(guardHookMarker)) {
+			return fmt.Errorf("%s already exists and was not written by this tool", hookPath)
+		}
+		if string(existing) == guardHook {
+			return nil
+		}
+	}
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(hookPath, []byte(guardHook), 0755); err != nil {
+		return fmt.Errorf("failed to write %s: %v", hookPath, err)
+	}
+	return nil
+}
+
+// excludeMarker keeps the marker file out of git status in every worktree
+func (m *Manager) excludeMarker(originDir string) error {
+	excludePath, err := m.gitPath(originDir, "info/exclude")
+	if err != nil {
+		return err
+	}
+	entry := "/" + WorktreeMarkerFile
+	existing, _ := os.ReadFile(excludePath)
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == entry {
+			return nil
+		}
+	}
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		existing = append(existing, '\n')
+	}
+	existing = append(existing, entry+"\n"...)
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(excludePath, existing, 0644)
+}
+
+// gitPath resolves a path inside the origin repository's git directory, e.g. "hooks"
+func (m *Manager) gitPath(originDir, name string) (string, error) {
+	output, err := m.run("-C", originDir, "rev-parse", "--git-path", name)
+	if err != nil {
+		return "", err
+	}
+	path := strings.TrimSpace(output)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(originDir, path)
+	}
+	return filepath.Clean(path), nil
+}
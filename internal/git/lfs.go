@@ -0,0 +1,199 @@
+package git
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// gitLFSReleaseAPI describes the latest official git-lfs release
+const gitLFSReleaseAPI = "https://api.github.com/repos/git-lfs/git-lfs/releases/latest"
+
+// userEnvironmentKey holds the per-user PATH, which can be changed without administrator rights
+const userEnvironmentKey = `HKCU\Environment`
+
+// GitLFSDir returns where the downloaded git-lfs is installed
+func (m *Manager) GitLFSDir() string {
+	return filepath.Join(m.baseDir, "git-lfs")
+}
+
+// IsGitLFSAvailable reports whether git can run git-lfs
+func (m *Manager) IsGitLFSAvailable() bool {
+	_, err := runner.Output("git", "lfs", "version")
+	return err == nil
+}
+
+// UseBundledGitLFS puts the downloaded git-lfs on PATH for this run when git can't find another one
+func (m *Manager) UseBundledGitLFS() {
+	if _, err := os.Stat(filepath.Join(m.GitLFSDir(), "git-lfs.exe")); err != nil {
+		return
+	}
+	if _, err := exec.LookPath("git-lfs"); err == nil {
+		return
+	}
+	os.Setenv("PATH", m.GitLFSDir()+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// InstallGitLFS downloads the latest official git-lfs release, verifies it against the release's
+// published SHA-256 checksums, installs it into the base dir, adds that folder to the user's PATH
+// so other Git clients find it, and runs "git lfs install"
+func (m *Manager) InstallGitLFS() error {
+	zipPath, err := downloadGitLFS(m.baseDir)
+	if err != nil {
+		return fmt.Errorf("failed to download git-lfs: %v", err)
+	}
+	defer os.Remove(zipPath)
+
+	staging := m.GitLFSDir() + ".partial"
+	os.RemoveAll(staging)
+	defer os.RemoveAll(staging)
+	if err := extractZip(zipPath, staging); err != nil {
+		return fmt.Errorf("failed to extract %s: %v", filepath.Base(zipPath), err)
+	}
+
+	// Newer archives keep the binary in a git-lfs-<version> folder
+	var exePath string
+	filepath.WalkDir(staging, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() && strings.EqualFold(entry.Name(), "git-lfs.exe") {
+			exePath = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if exePath == "" {
+		return fmt.Errorf("%s does not contain git-lfs.exe", filepath.Base(zipPath))
+	}
+
+	if err := os.MkdirAll(m.GitLFSDir(), 0755); err != nil {
+		return err
+	}
+	target := filepath.Join(m.GitLFSDir(), "git-lfs.exe")
+	os.Remove(target)
+	if err := os.Rename(exePath, target); err != nil {
+		return fmt.Errorf("failed to install git-lfs: %v", err)
+	}
+
+	m.UseBundledGitLFS()
+	if err := addToUserPath(m.GitLFSDir()); err != nil {
+		return err
+	}
+	if _, err := m.run("lfs", "install"); err != nil {
+		return fmt.Errorf("git-lfs was installed but \"git lfs install\" failed: %w", err)
+	}
+	return nil
+}
+
+// downloadGitLFS downloads the 64-bit Windows zip of the latest git-lfs release into dir and
+// returns its path once its checksum matches the release's sha256sums file
+func downloadGitLFS(dir string) (string, error) {
+	client := &http.Client{Timeout: 10 * time.Minute}
+
+	resp, err := client.Get(gitLFSReleaseAPI)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub returned %s for the git-lfs release", resp.Status)
+	}
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to read the git-lfs release: %v", err)
+	}
+
+	var zipName, zipURL, sumsURL string
+	for _, asset := range release.Assets {
+		switch {
+		case strings.HasPrefix(asset.Name, "git-lfs-windows-amd64-") && strings.HasSuffix(asset.Name, ".zip"):
+			zipName, zipURL = asset.Name, asset.DownloadURL
+		case asset.Name == "sha256sums.asc":
+			sumsURL = asset.DownloadURL
+		}
+	}
+	if zipURL == "" {
+		return "", fmt.Errorf("release %s has no 64-bit Windows download", release.TagName)
+	}
+	if sumsURL == "" {
+		return "", fmt.Errorf("release %s has no checksums to verify the download against", release.TagName)
+	}
+
+	want, err := releaseChecksum(client, sumsURL, zipName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, zipName)
+	if err := downloadFile(client, zipURL, path); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	if got, err := fileSHA256(path); err != nil || !strings.EqualFold(got, want) {
+		os.Remove(path)
+		return "", fmt.Errorf("checksum of %s does not match the release", zipName)
+	}
+	return path, nil
+}
+
+// releaseChecksum finds a file's SHA-256 in a release's sha256sums file, whose lines read "<hash>  <name>"
+func releaseChecksum(client *http.Client, sumsURL, name string) (string, error) {
+	resp, err := client.Get(sumsURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checksum download returned %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name && len(fields[0]) == 64 {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("no checksum for %s in the release", name)
+}
+
+// addToUserPath appends a folder to the per-user PATH unless it is already listed
+func addToUserPath(dir string) error {
+	current := ""
+	output, err := runner.Output("reg", "query", userEnvironmentKey, "/v", "Path")
+	if err == nil {
+		// Output lines look like: "    Path    REG_EXPAND_SZ    C:\...;C:\..."
+		for _, line := range strings.Split(string(output), "\n") {
+			fields := strings.SplitN(strings.TrimSpace(line), "    ", 3)
+			if len(fields) == 3 && strings.EqualFold(fields[0], "Path") {
+				current = strings.TrimSpace(fields[2])
+			}
+		}
+	}
+	for _, entry := range strings.Split(current, ";") {
+		if utils.SamePath(entry, dir) {
+			return nil
+		}
+	}
+
+	updated := dir
+	if current != "" {
+		updated = strings.TrimRight(current, ";") + ";" + dir
+	}
+	output, err = runner.CombinedOutput("reg", "add", userEnvironmentKey, "/v", "Path", "/t", "REG_EXPAND_SZ", "/d", updated, "/f")
+	if err != nil {
+		return fmt.Errorf("failed to add %s to the user PATH: %v\nOutput: %s", dir, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// maintenanceSettings bound how long the origin repository keeps history nothing refers to. Every
+// fetch of a force-pushed or deleted branch leaves such objects behind, and without limits they
+// pile up over years of updates.
+var maintenanceSettings = [][2]string{
+	{"gc.pruneExpire", "2.weeks.ago"},
+	{"gc.reflogExpire", "90.days"},
+	{"gc.reflogExpireUnreachable", "30.days"},
+	{"gc.worktreePruneExpire", "3.months.ago"},
+}
+
+// Maintain compacts the origin repository: it applies the prune settings, forgets worktrees whose
+// folder is gone, expires old reflog entries and repacks, dropping unreachable objects older than
+// the settings allow. It uses git maintenance where git has it, and git gc otherwise.
+func (m *Manager) Maintain() error {
+	originDir := m.getActualOriginDir()
+	for _, setting := range maintenanceSettings {
+		if _, err := m.run("-C", originDir, "config", setting[0], setting[1]); err != nil {
+			return fmt.Errorf("failed to set %s: %w", setting[0], err)
+		}
+	}
+	if _, err := m.run("-C", originDir, "worktree", "prune"); err != nil {
+		return fmt.Errorf("failed to prune worktrees: %w", err)
+	}
+
+	_, err := m.run("-C", originDir, "maintenance", "run", "--task=gc", "--task=commit-graph", "--quiet")
+	if err != nil && strings.Contains(err.Error(), "is not a git command") {
+		// Git before 2.29
+		_, err = m.run("-C", originDir, "gc", "--quiet")
+	}
+	if err != nil {
+		return fmt.Errorf("repository maintenance failed: %w", err)
+	}
+	return nil
+}
+
+// ensureFetchPrune makes every fetch in the origin repository drop the remote branches deleted
+// upstream, including fetches run by hand in a worktree. Otherwise a deleted branch lingers as
+// origin/<branch>, is offered in branch lists and keeps its history from being compacted.
+func (m *Manager) ensureFetchPrune(originDir string) error {
+	if value, err := m.run("-C", originDir, "config", "--get", "fetch.prune"); err == nil && strings.TrimSpace(value) == "true" {
+		return nil
+	}
+	if _, err := m.run("-C", originDir, "config", "fetch.prune", "true"); err != nil {
+		return fmt.Errorf("failed to set fetch.prune: %w", err)
+	}
+	return nil
+}
+
+// OriginSize returns the size of the origin repository on disk, without the worktrees
+func (m *Manager) OriginSize() int64 {
+	return utils.DirSize(m.getActualOriginDir())
+}
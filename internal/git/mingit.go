@@ -0,0 +1,281 @@
+package git
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// minGitReleaseAPI describes the latest Git for Windows release, whose assets include MinGit
+const minGitReleaseAPI = "https://api.github.com/repos/git-for-windows/git/releases/latest"
+
+// MinGitDir returns where the portable MinGit is installed
+func (m *Manager) MinGitDir() string {
+	return filepath.Join(m.baseDir, "mingit")
+}
+
+// minGitCmdDir is the folder holding MinGit's git.exe
+func (m *Manager) minGitCmdDir() string {
+	return filepath.Join(m.MinGitDir(), "cmd")
+}
+
+// IsMinGitInstalled reports whether a portable MinGit has been installed in the base dir
+func (m *Manager) IsMinGitInstalled() bool {
+	_, err := os.Stat(filepath.Join(m.minGitCmdDir(), "git.exe"))
+	return err == nil
+}
+
+// UseBundledGit puts the portable MinGit on PATH when no system Git is found, so every git
+// invocation (ours and the project tools') picks it up. It reports whether MinGit is in use.
+func (m *Manager) UseBundledGit() bool {
+	if m.IsUsingMinGit() {
+		return true
+	}
+	if m.IsGitAvailable() || !m.IsMinGitInstalled() {
+		return false
+	}
+	os.Setenv("PATH", m.minGitCmdDir()+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return m.IsGitAvailable()
+}
+
+// IsUsingMinGit reports whether the git found on PATH is the portable MinGit
+func (m *Manager) IsUsingMinGit() bool {
+	path, err := exec.LookPath("git")
+	if err != nil {
+		return false
+	}
+	return utils.SamePath(filepath.Dir(path), m.minGitCmdDir())
+}
+
+// FindBundledMinGit returns the newest MinGit zip shipped next to the executable, or "" when
+// there is none
+func FindBundledMinGit(exeDir string) string {
+	matches, _ := filepath.Glob(filepath.Join(exeDir, "MinGit-*.zip"))
+	newest := ""
+	for _, match := range matches {
+		if newest == "" || compareMinGitVersions(minGitVersion(match), minGitVersion(newest)) > 0 {
+			newest = match
+		}
+	}
+	return newest
+}
+
+// minGitVersion returns the version in a MinGit zip's name, e.g. 2.45.1 in MinGit-2.45.1-64-bit.zip
+func minGitVersion(path string) string {
+	name := strings.TrimPrefix(filepath.Base(path), "MinGit-")
+	version, _, _ := strings.Cut(name, "-")
+	return version
+}
+
+// compareMinGitVersions compares dotted versions such as 2.10.0 and 2.9.5 number by number
+func compareMinGitVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x > y {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// InstallMinGit installs the portable MinGit into the base dir from a bundled zip, or from the
+// latest Git for Windows release when zipPath is empty, and puts it on PATH
+func (m *Manager) InstallMinGit(zipPath string) error {
+	if zipPath == "" {
+		downloaded, err := downloadMinGit(m.baseDir)
+		if err != nil {
+			return fmt.Errorf("failed to download MinGit: %v", err)
+		}
+		defer os.Remove(downloaded)
+		zipPath = downloaded
+	}
+
+	// Extract next to the final folder and swap it in, so a failed extraction leaves no half-installed copy
+	staging := m.MinGitDir() + ".partial"
+	os.RemoveAll(staging)
+	if err := extractZip(zipPath, staging); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to extract %s: %v", filepath.Base(zipPath), err)
+	}
+	if _, err := os.Stat(filepath.Join(staging, "cmd", "git.exe")); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("%s is not a MinGit archive (cmd\\git.exe is missing)", filepath.Base(zipPath))
+	}
+	if err := os.RemoveAll(m.MinGitDir()); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to remove the previous MinGit: %v", err)
+	}
+	if err := os.Rename(staging, m.MinGitDir()); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to install MinGit: %v", err)
+	}
+
+	if !m.UseBundledGit() {
+		return fmt.Errorf("MinGit was installed to %s but git still can't be run", m.MinGitDir())
+	}
+	return nil
+}
+
+// githubRelease holds the GitHub release fields needed to pick a download
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name        string `json:"name"`
+		DownloadURL string `json:"browser_download_url"`
+		Digest      string `json:"digest"` // "sha256:<hex>" on current GitHub releases
+	} `json:"assets"`
+}
+
+// downloadMinGit downloads the 64-bit MinGit zip of the latest Git for Windows release into dir,
+// verifying its checksum, and returns the file path. A download the release publishes no checksum
+// for is refused, since git would run whatever it contains.
+func downloadMinGit(dir string) (string, error) {
+	client := &http.Client{Timeout: 10 * time.Minute}
+
+	resp, err := client.Get(minGitReleaseAPI)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub returned %s for the Git for Windows release", resp.Status)
+	}
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to read the Git for Windows release: %v", err)
+	}
+
+	for _, asset := range release.Assets {
+		// The busybox variant lacks the tools git's scripts rely on
+		if !strings.HasPrefix(asset.Name, "MinGit-") || !strings.HasSuffix(asset.Name, "-64-bit.zip") || strings.Contains(asset.Name, "busybox") {
+			continue
+		}
+
+		want, ok := strings.CutPrefix(asset.Digest, "sha256:")
+		if !ok {
+			return "", fmt.Errorf("release %s publishes no checksum for %s; download it from %s yourself and put it next to the tool", release.TagName, asset.Name, asset.DownloadURL)
+		}
+
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", err
+		}
+		path := filepath.Join(dir, asset.Name)
+		if err := downloadFile(client, asset.DownloadURL, path); err != nil {
+			os.Remove(path)
+			return "", err
+		}
+		if got, err := fileSHA256(path); err != nil || !strings.EqualFold(got, want) {
+			os.Remove(path)
+			return "", fmt.Errorf("checksum of %s does not match the release", asset.Name)
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("release %s has no 64-bit MinGit download", release.TagName)
+}
+
+// downloadFile saves the body of a GET request to path
+func downloadFile(client *http.Client, url, path string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download returned %s", resp.Status)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// fileSHA256 returns the hex SHA-256 of a file
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// extractZip extracts an archive into dest, refusing entries that would land outside it
+func extractZip(zipPath, dest string) error {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	root := filepath.Clean(dest) + string(filepath.Separator)
+	for _, entry := range reader.File {
+		target := filepath.Join(dest, entry.Name)
+		if !strings.HasPrefix(target, root) {
+			return fmt.Errorf("archive entry %s is outside the destination", entry.Name)
+		}
+		if entry.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractZipEntry(entry, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractZipEntry writes one archive file to target
+func extractZipEntry(entry *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	src, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, entry.Mode()|0200)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
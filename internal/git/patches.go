@@ -0,0 +1,167 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PatchTrailer marks commits created from local patches so updates can tell them apart from
+// an engine branch's own commits and re-create them on the new upstream commit
+const PatchTrailer = "Local-Patch"
+
+// LocalPatches are studio fixes applied on top of upstream in every worktree during each update
+type LocalPatches struct {
+	Files  []string // Patch files (git format-patch output or plain diffs), applied in order
+	Branch string   // Branch whose commits that upstream lacks are cherry-picked after the files
+	Skip   []string // Patch IDs and own commit SHAs to leave out, from ConflictError.SkipID
+}
+
+// skips reports whether a patch ID or commit SHA should be left out
+func (p LocalPatches) skips(id string) bool {
+	for _, skip := range p.Skip {
+		if skip == id {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty reports whether no patches are configured
+func (p LocalPatches) IsEmpty() bool {
+	return len(p.Files) == 0 && strings.TrimSpace(p.Branch) == ""
+}
+
+// patchStep is one patch to apply; ID is recorded in the commit's PatchTrailer
+type patchStep struct {
+	ID     string
+	File   string
+	Commit string
+}
+
+// patchSteps lists the patches to apply on top of target, in order
+func (m *Manager) patchSteps(patches LocalPatches, target, upstreamRef string) ([]patchStep, error) {
+	var steps []patchStep
+	for _, file := range patches.Files {
+		if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("patch file %s not found", file)
+		}
+		steps = append(steps, patchStep{ID: "file " + filepath.Base(file), File: file})
+	}
+
+	if branch := strings.TrimSpace(patches.Branch); branch != "" {
+		ref, err := m.resolvePatchesBranch(branch)
+		if err != nil {
+			return nil, err
+		}
+		// Only the branch's own commits, not the upstream history it was started from
+		args := []string{"-C", m.getActualOriginDir(), "rev-list", "--reverse", "--no-merges", ref, "--not", target}
+		if _, err := m.run("-C", m.getActualOriginDir(), "rev-parse", "--verify", "--quiet", upstreamRef); err == nil {
+			args = append(args, upstreamRef)
+		}
+		output, err := m.run(args...)
+		if err != nil {
+			return nil, err
+		}
+		for _, sha := range strings.Fields(output) {
+			steps = append(steps, patchStep{ID: "commit " + sha, Commit: sha})
+		}
+	}
+	return steps, nil
+}
+
+// resolvePatchesBranch finds the patches branch locally in the origin repository or among the fetched remote branches
+func (m *Manager) resolvePatchesBranch(branch string) (string, error) {
+	for _, ref := range []string{"refs/heads/" + branch, "refs/remotes/origin/" + branch, branch} {
+		if _, err := m.run("-C", m.getActualOriginDir(), "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err == nil {
+			return ref, nil
+		}
+	}
+	return "", fmt.Errorf("patches branch %s not found in the plugin repository", branch)
+}
+
+// applyPatch applies one patch as a commit in the worktree and tags it with the PatchTrailer.
+// On failure the worktree is left mid-apply so the conflicting files can be listed; see abortPatch.
+func (m *Manager) applyPatch(worktreePath string, step patchStep) error {
+	switch {
+	case step.Commit != "":
+		if _, err := m.run("-C", worktreePath, "cherry-pick", step.Commit); err != nil {
+			return err
+		}
+	case isMailboxPatch(step.File):
+		if _, err := m.run("-C", worktreePath, "am", "--3way", "--keep-cr", step.File); err != nil {
+			return err
+		}
+	default:
+		if _, err := m.run("-C", worktreePath, "apply", "--3way", "--index", step.File); err != nil {
+			return err
+		}
+		if _, err := m.run("-C", worktreePath, "commit", "--no-verify", "-m", "Apply local patch "+filepath.Base(step.File)); err != nil {
+			return err
+		}
+	}
+
+	message, err := m.run("-C", worktreePath, "log", "-1", "--format=%B")
+	if err != nil {
+		return err
+	}
+	message = strings.TrimRight(message, "\r\n") + "\n\n" + PatchTrailer + ": " + step.ID + "\n"
+	_, err = m.run("-C", worktreePath, "commit", "--no-verify", "--amend", "--allow-empty", "-m", message)
+	return err
+}
+
+// abortPatch ends the cherry-pick or am left in progress by a failed applyPatch
+func (m *Manager) abortPatch(worktreePath string, step patchStep) {
+	switch {
+	case step.Commit != "":
+		m.run("-C", worktreePath, "cherry-pick", "--abort")
+	case isMailboxPatch(step.File):
+		m.run("-C", worktreePath, "am", "--abort")
+	}
+}
+
+// isMailboxPatch reports whether a patch file was written by git format-patch
+func isMailboxPatch(file string) bool {
+	data, err := os.ReadFile(file)
+	return err == nil && bytes.HasPrefix(data, []byte("From "))
+}
+
+// PatchesOutdated reports whether a worktree's applied local patches differ from the configured ones,
+// so an update is needed even when upstream hasn't moved
+func (m *Manager) PatchesOutdated(subdir, defaultBranch, pinnedCommit string, patches LocalPatches) (bool, error) {
+	worktreePath := m.GetWorktreePath(subdir)
+	targetSHA, err := m.resolveTargetSHA(defaultBranch, pinnedCommit)
+	if err != nil {
+		return false, err
+	}
+	steps, err := m.patchSteps(patches, targetSHA, m.TrackingRef(defaultBranch))
+	if err != nil {
+		return false, err
+	}
+	commits, err := m.localCommits(worktreePath)
+	if err != nil {
+		return false, err
+	}
+	return !patchesMatch(commits, steps), nil
+}
+
+// patchesMatch reports whether the patch commits on a branch are exactly the given steps, in order
+func patchesMatch(commits []localCommit, steps []patchStep) bool {
+	var applied []string
+	for i := len(commits) - 1; i >= 0; i-- {
+		if commits[i].Patch != "" {
+			applied = append(applied, commits[i].Patch)
+		}
+	}
+	if len(applied) != len(steps) {
+		return false
+	}
+	for i, step := range steps {
+		if applied[i] != step.ID {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,213 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ue-git-plugin-manager/internal/events"
+)
+
+// OriginRemote is the remote every engine tracks unless it is switched to another one
+const OriginRemote = "origin"
+
+// remoteNamePattern limits remote names to what is safe in a ref and on the command line
+var remoteNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidRemoteName reports why a name can't be used for an additional remote, or nil
+func ValidRemoteName(name string) error {
+	if !remoteNamePattern.MatchString(name) {
+		return fmt.Errorf("remote names may only contain letters, digits, '.', '_' and '-'")
+	}
+	if strings.EqualFold(name, OriginRemote) {
+		return fmt.Errorf("%s is the plugin repository's own remote", OriginRemote)
+	}
+	return nil
+}
+
+// QualifiedBranch names a branch on an additional remote as "<remote>/<branch>", the form
+// every branch argument of the Manager accepts. Branches of origin stay unqualified.
+func QualifiedBranch(remote, branch string) string {
+	if remote == "" || remote == OriginRemote {
+		return branch
+	}
+	return remote + "/" + branch
+}
+
+// SplitBranch separates a branch argument into its remote and the branch name on that remote.
+// A prefix only counts as a remote when the plugin repository has a remote of that name, so
+// origin branches with a slash in their name, such as "release/5.4", are left alone.
+func (m *Manager) SplitBranch(branch string) (remote, name string) {
+	branch = m.normalizeBranch(branch)
+	if prefix, rest, ok := strings.Cut(branch, "/"); ok && rest != "" {
+		for _, known := range m.Remotes() {
+			if known == prefix {
+				return prefix, rest
+			}
+		}
+	}
+	return OriginRemote, branch
+}
+
+// TrackingRef returns the remote-tracking ref of a branch argument, e.g. "origin/dev" or "fork/dev"
+func (m *Manager) TrackingRef(branch string) string {
+	remote, name := m.SplitBranch(branch)
+	return remote + "/" + name
+}
+
+// Remotes returns the additional remotes of the plugin repository, without origin
+func (m *Manager) Remotes() []string {
+	if !m.IsOriginCloned() {
+		return nil
+	}
+	output, err := m.run("-C", m.getActualOriginDir(), "remote")
+	if err != nil {
+		return nil
+	}
+	var remotes []string
+	for _, name := range strings.Fields(output) {
+		if name != OriginRemote {
+			remotes = append(remotes, name)
+		}
+	}
+	sort.Strings(remotes)
+	return remotes
+}
+
+// SyncRemotes adds the configured additional remotes to the plugin repository, or updates their
+// URL. Remotes added by hand are left alone.
+func (m *Manager) SyncRemotes(urls map[string]string) error {
+	if !m.IsOriginCloned() {
+		return nil
+	}
+	existing := m.Remotes()
+	for name, url := range urls {
+		if err := m.setRemote(name, url, existing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddRemote adds a remote to the plugin repository, or changes its URL when it already exists
+func (m *Manager) AddRemote(name, url string) error {
+	if !m.IsOriginCloned() {
+		return fmt.Errorf("plugin repository has not been cloned: %w", ErrNotARepo)
+	}
+	return m.setRemote(name, url, m.Remotes())
+}
+
+// setRemote adds or updates one remote, given the remotes the repository already has
+func (m *Manager) setRemote(name, url string, existing []string) error {
+	if err := ValidRemoteName(name); err != nil {
+		return fmt.Errorf("remote %q: %v", name, err)
+	}
+	action := "add"
+	for _, known := range existing {
+		if known == name {
+			action = "set-url"
+		}
+	}
+	if _, err := m.run("-C", m.getActualOriginDir(), "remote", action, name, url); err != nil {
+		return fmt.Errorf("failed to configure remote %s: %w", name, err)
+	}
+	return nil
+}
+
+// RemoveRemote removes an additional remote and its remote-tracking branches
+func (m *Manager) RemoveRemote(name string) error {
+	if name == OriginRemote {
+		return fmt.Errorf("%s can't be removed", OriginRemote)
+	}
+	if _, err := m.run("-C", m.getActualOriginDir(), "remote", "remove", name); err != nil {
+		return fmt.Errorf("failed to remove remote %s: %w", name, err)
+	}
+	return nil
+}
+
+// FetchRemote fetches one additional remote
+func (m *Manager) FetchRemote(name string) error {
+	args := append(append([]string{}, mirrorTimeoutArgs...), "-C", m.getActualOriginDir(), "fetch", "--prune", name)
+	_, err := m.run(args...)
+	return err
+}
+
+// CachedRemoteBranches returns the branches of a remote as of the last fetch
+func (m *Manager) CachedRemoteBranches(remote string) ([]string, error) {
+	if !m.IsOriginCloned() {
+		return nil, fmt.Errorf("plugin repository has not been cloned: %w", ErrNotARepo)
+	}
+	output, err := m.run("-C", m.getActualOriginDir(), "for-each-ref", "--format=%(refname)", "refs/remotes/"+remote)
+	if err != nil {
+		return nil, err
+	}
+	refs := &RemoteRefs{}
+	for _, line := range strings.Split(output, "\n") {
+		refs.add(strings.TrimSpace(line), "refs/remotes/"+remote+"/")
+	}
+	sort.Strings(refs.Branches)
+	return refs.Branches, nil
+}
+
+// RemoteUpdate is how far one remote's branch is ahead of the upstream commit a worktree is on
+type RemoteUpdate struct {
+	Remote       string
+	Branch       string // Qualified branch argument, see QualifiedBranch
+	CommitsAhead int
+	Tracked      bool // The engine follows this remote
+}
+
+// GetRemoteUpdates compares a worktree with the branch of the same name on origin and on every
+// additional remote that has it, so a fork can be compared with upstream before merging
+func (m *Manager) GetRemoteUpdates(subdir, trackedBranch string) ([]RemoteUpdate, error) {
+	if !m.WorktreeExists(subdir) {
+		return nil, fmt.Errorf("worktree %s does not exist: %w", subdir, ErrNotARepo)
+	}
+	baseSHA, _, err := m.upstreamBase(m.GetWorktreePath(subdir))
+	if err != nil {
+		return nil, err
+	}
+	trackedRemote, name := m.SplitBranch(trackedBranch)
+
+	var updates []RemoteUpdate
+	for _, remote := range append([]string{OriginRemote}, m.Remotes()...) {
+		ref := remote + "/" + name
+		output, err := m.run("-C", m.getActualOriginDir(), "rev-list", "--count", baseSHA+".."+ref)
+		if err != nil {
+			continue // The remote has no branch of this name
+		}
+		update := RemoteUpdate{Remote: remote, Branch: QualifiedBranch(remote, name), Tracked: remote == trackedRemote}
+		fmt.Sscanf(strings.TrimSpace(output), "%d", &update.CommitsAhead)
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// webURL returns the browsable URL of the remote a branch argument is on, for commit links
+func (m *Manager) webURL(branch string) string {
+	remote, _ := m.SplitBranch(branch)
+	if remote == OriginRemote {
+		return UpstreamURL
+	}
+	output, err := m.run("-C", m.getActualOriginDir(), "remote", "get-url", remote)
+	if err != nil {
+		return UpstreamURL
+	}
+	url := strings.TrimSuffix(strings.TrimSpace(output), ".git")
+	// SSH remotes such as git@github.com:studio/UEGitPlugin browse at https://github.com/studio/UEGitPlugin
+	if rest, ok := strings.CutPrefix(url, "git@"); ok {
+		url = "https://" + strings.Replace(rest, ":", "/", 1)
+	}
+	return url
+}
+
+// fetchExtraRemotes fetches the additional remotes after origin came from the mirror. A remote
+// that can't be reached only costs its own updates, so it is a warning.
+func (m *Manager) fetchExtraRemotes() {
+	for _, remote := range m.Remotes() {
+		if err := m.FetchRemote(remote); err != nil {
+			events.Warn("Fetch from remote %s failed: %v", remote, err)
+		}
+	}
+}
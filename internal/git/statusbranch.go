@@ -0,0 +1,44 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProjectRemote returns the URL of a project's origin remote, or an error when it has none
+func (m *Manager) ProjectRemote(projectRoot string) (string, error) {
+	output, err := m.run("-C", projectRoot, "remote", "get-url", "origin")
+	if err != nil {
+		return "", fmt.Errorf("project %s has no origin remote: %w", projectRoot, err)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// FetchProject fetches a project's origin remote so remote branches can be compared and created
+func (m *Manager) FetchProject(projectRoot string) error {
+	if _, err := m.run("-C", projectRoot, "fetch", "--prune", "origin"); err != nil {
+		return fmt.Errorf("failed to fetch the project: %w", err)
+	}
+	return nil
+}
+
+// ProjectRemoteBranchExists reports whether branch exists on the project's origin remote, as of the last fetch
+func (m *Manager) ProjectRemoteBranchExists(projectRoot, branch string) bool {
+	_, err := m.run("-C", projectRoot, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch)
+	return err == nil
+}
+
+// CreateProjectRemoteBranch creates branch on the project's origin remote at the remote tip of
+// base, so commits that were never pushed don't end up on it
+func (m *Manager) CreateProjectRemoteBranch(projectRoot, branch, base string) error {
+	if !m.ProjectRemoteBranchExists(projectRoot, base) {
+		return fmt.Errorf("origin/%s doesn't exist; push %s first", base, base)
+	}
+	refspec := "refs/remotes/origin/" + base + ":refs/heads/" + branch
+	if _, err := m.run("-C", projectRoot, "push", "origin", refspec); err != nil {
+		return fmt.Errorf("failed to create origin/%s: %w", branch, err)
+	}
+	// Record the new branch locally too, so the editor sees it without another fetch
+	_, err := m.run("-C", projectRoot, "fetch", "origin", branch)
+	return err
+}
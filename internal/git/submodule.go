@@ -0,0 +1,179 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ProjectPluginDir is the plugin submodule's folder inside a game project, named like the engine-level junction
+const ProjectPluginDir = "Plugins/UEGitPlugin_PB"
+
+// ProjectSubmodule describes the plugin submodule of a game project
+type ProjectSubmodule struct {
+	RepoRoot      string // Top level of the project's git repository (the project may live in a subfolder)
+	Path          string // Submodule path relative to RepoRoot, with forward slashes
+	Name          string // Submodule name in .gitmodules
+	URL           string
+	Branch        string // Branch recorded in .gitmodules, followed by updates
+	PinnedSHA     string // Commit recorded in the project's index; this is what the project commits
+	CheckedOutSHA string // Commit checked out on disk; empty until the submodule is initialized
+	CommitsBehind int    // Commits on origin/<Branch> since PinnedSHA, as of the last fetch
+}
+
+// IsInitialized reports whether the submodule's files are checked out on disk
+func (s *ProjectSubmodule) IsInitialized() bool {
+	return s.CheckedOutSHA != ""
+}
+
+// Dir returns the submodule folder on disk
+func (s *ProjectSubmodule) Dir() string {
+	return filepath.Join(s.RepoRoot, filepath.FromSlash(s.Path))
+}
+
+// projectSubmodulePath returns the top level of the project's repository and the plugin
+// submodule path relative to it
+func (m *Manager) projectSubmodulePath(projectRoot string) (string, string, error) {
+	output, err := m.run("-C", projectRoot, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", fmt.Errorf("project %s is not in a git repository: %w", projectRoot, err)
+	}
+	repoRoot := filepath.Clean(strings.TrimSpace(output))
+	rel, err := filepath.Rel(repoRoot, filepath.Join(projectRoot, filepath.FromSlash(ProjectPluginDir)))
+	if err != nil {
+		return "", "", err
+	}
+	return repoRoot, filepath.ToSlash(rel), nil
+}
+
+// GetProjectSubmodule returns the project's plugin submodule, or nil if it has none
+func (m *Manager) GetProjectSubmodule(projectRoot string) (*ProjectSubmodule, error) {
+	repoRoot, path, err := m.projectSubmodulePath(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, ".gitmodules")); err != nil {
+		return nil, nil
+	}
+
+	// Lines look like "submodule.<name>.path <path>"; git exits with 1 when nothing matches
+	output, _ := m.run("-C", repoRoot, "config", "-f", ".gitmodules", "--get-regexp", `^submodule\..*\.path$`)
+	sub := &ProjectSubmodule{RepoRoot: repoRoot, Path: path}
+	for _, line := range splitLines(output) {
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) == 2 && strings.EqualFold(fields[1], path) {
+			sub.Name = strings.TrimSuffix(strings.TrimPrefix(fields[0], "submodule."), ".path")
+		}
+	}
+	if sub.Name == "" {
+		return nil, nil
+	}
+
+	if url, err := m.run("-C", repoRoot, "config", "-f", ".gitmodules", "submodule."+sub.Name+".url"); err == nil {
+		sub.URL = strings.TrimSpace(url)
+	}
+	sub.Branch = "dev"
+	if branch, err := m.run("-C", repoRoot, "config", "-f", ".gitmodules", "submodule."+sub.Name+".branch"); err == nil {
+		sub.Branch = strings.TrimSpace(branch)
+	}
+
+	// ls-files --stage prints "160000 <sha> 0<TAB><path>" for a submodule
+	if stage, err := m.run("-C", repoRoot, "ls-files", "--stage", "--", path); err == nil {
+		if fields := strings.Fields(stage); len(fields) >= 2 && fields[0] == "160000" {
+			sub.PinnedSHA = fields[1]
+		}
+	}
+	if _, err := os.Stat(filepath.Join(sub.Dir(), ".git")); err == nil {
+		if head, err := m.run("-C", sub.Dir(), "rev-parse", "HEAD"); err == nil {
+			sub.CheckedOutSHA = strings.TrimSpace(head)
+		}
+		if sub.PinnedSHA != "" {
+			if count, err := m.run("-C", sub.Dir(), "rev-list", "--count", sub.PinnedSHA+"..origin/"+sub.Branch); err == nil {
+				sub.CommitsBehind, _ = strconv.Atoi(strings.TrimSpace(count))
+			}
+		}
+	}
+	return sub, nil
+}
+
+// AddProjectSubmodule adds the plugin to a project as a submodule following branch, checked out
+// at pin (or the branch tip when pin is empty). The change is staged for the user to commit.
+func (m *Manager) AddProjectSubmodule(projectRoot, url, branch, pin string) (*ProjectSubmodule, error) {
+	repoRoot, path, err := m.projectSubmodulePath(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, filepath.FromSlash(path))); err == nil {
+		return nil, fmt.Errorf("%s already exists in the project; remove it before adding the submodule", path)
+	}
+	if _, err := m.run("-C", repoRoot, "submodule", "add", "-b", branch, url, path); err != nil {
+		return nil, fmt.Errorf("failed to add submodule: %w", err)
+	}
+	sub, err := m.GetProjectSubmodule(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, fmt.Errorf("submodule %s was not recorded in .gitmodules", path)
+	}
+	if pin != "" {
+		if err := m.checkoutProjectSubmodule(sub, pin); err != nil {
+			return nil, err
+		}
+	}
+	return m.GetProjectSubmodule(projectRoot)
+}
+
+// InitProjectSubmodule checks out the pinned commit of the plugin submodule, e.g. after cloning the project
+func (m *Manager) InitProjectSubmodule(sub *ProjectSubmodule) error {
+	_, err := m.run("-C", sub.RepoRoot, "submodule", "update", "--init", "--", sub.Path)
+	return err
+}
+
+// UpdateProjectSubmodule fetches the plugin submodule and pins it to rev, or to the tip of its
+// branch when rev is empty. The new pin is staged for the user to commit.
+func (m *Manager) UpdateProjectSubmodule(sub *ProjectSubmodule, rev string) error {
+	if !sub.IsInitialized() {
+		if err := m.InitProjectSubmodule(sub); err != nil {
+			return err
+		}
+	}
+	if _, err := m.run("-C", sub.Dir(), "fetch", "--tags", "--prune", "origin"); err != nil {
+		return fmt.Errorf("failed to fetch submodule: %w", err)
+	}
+	if rev == "" {
+		rev = "origin/" + sub.Branch
+	}
+	return m.checkoutProjectSubmodule(sub, rev)
+}
+
+// checkoutProjectSubmodule detaches the submodule at rev and stages the new pin in the project
+func (m *Manager) checkoutProjectSubmodule(sub *ProjectSubmodule, rev string) error {
+	if _, err := m.run("-C", sub.Dir(), "rev-parse", "--verify", "--quiet", rev+"^{commit}"); err != nil {
+		return fmt.Errorf("%s is not a commit in the plugin submodule", rev)
+	}
+	if _, err := m.run("-C", sub.Dir(), "checkout", "--quiet", "--detach", rev); err != nil {
+		return fmt.Errorf("failed to check out %s in the submodule: %w", rev, err)
+	}
+	if _, err := m.run("-C", sub.RepoRoot, "add", "--", sub.Path); err != nil {
+		return fmt.Errorf("failed to stage the submodule: %w", err)
+	}
+	return nil
+}
+
+// RemoveProjectSubmodule removes the plugin submodule from the project and stages the removal
+func (m *Manager) RemoveProjectSubmodule(sub *ProjectSubmodule) error {
+	if _, err := m.run("-C", sub.RepoRoot, "submodule", "deinit", "--force", "--", sub.Path); err != nil {
+		return fmt.Errorf("failed to deinitialize submodule: %w", err)
+	}
+	if _, err := m.run("-C", sub.RepoRoot, "rm", "--force", "--", sub.Path); err != nil {
+		return fmt.Errorf("failed to remove submodule: %w", err)
+	}
+	// git keeps the submodule's repository under .git/modules; drop it so the path can be reused
+	if gitDir, err := m.run("-C", sub.RepoRoot, "rev-parse", "--absolute-git-dir"); err == nil {
+		os.RemoveAll(filepath.Join(strings.TrimSpace(gitDir), "modules", filepath.FromSlash(sub.Name)))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/logging"
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// maxErrorLines caps how much git output is repeated in an error message; the log keeps all of it
+const maxErrorLines = 20
+
+// CommandError is returned when a git command fails and carries its full transcript.
+// errors.Is reports whether it matches one of the Err* kinds.
+type CommandError struct {
+	Args   []string
+	Err    error
+	Kind   error // One of the Err* kinds, or nil when the cause wasn't recognised
+	Stdout string
+	Stderr string
+}
+
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("%s failed: %v", commandLine(e.Args), e.Err)
+	output := strings.TrimSpace(e.Stderr)
+	if output == "" {
+		output = strings.TrimSpace(e.Stdout)
+	}
+	if output == "" {
+		return msg
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) > maxErrorLines {
+		lines = append([]string{"..."}, lines[len(lines)-maxErrorLines:]...)
+	}
+	return msg + "\n" + logging.Redact(strings.Join(lines, "\n"))
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+func (e *CommandError) Is(target error) bool {
+	return e.Kind != nil && target == e.Kind
+}
+
+// run executes git with the given arguments, logs the command line, stdout and stderr,
+// and returns stdout. A failure is returned as a *CommandError holding the transcript.
+func (m *Manager) run(args ...string) (string, error) {
+	result, err := runner.Run(context.Background(), runner.Command{
+		Name: "git",
+		Args: args,
+		Dir:  m.exeDir,
+		// Output is captured, so a credential prompt would never be seen; fail with ErrAuthRequired instead
+		Env: append(os.Environ(), "GIT_TERMINAL_PROMPT=0"),
+	})
+	logTranscript(args, err, result.Duration, result.Stdout, result.Stderr)
+
+	if err != nil {
+		return result.Stdout, &CommandError{
+			Args:   args,
+			Err:    err,
+			Kind:   classify(result.Stderr),
+			Stdout: result.Stdout,
+			Stderr: result.Stderr,
+		}
+	}
+	return result.Stdout, nil
+}
+
+// logTranscript writes one git invocation and its output to the log
+func logTranscript(args []string, err error, elapsed time.Duration, stdout, stderr string) {
+	result := "ok"
+	if err != nil {
+		result = err.Error()
+	}
+
+	var entry strings.Builder
+	entry.WriteString(fmt.Sprintf("$ %s (%s, %s)", commandLine(args), result, elapsed.Round(time.Millisecond)))
+	for _, stream := range []struct{ name, text string }{{"stdout", stdout}, {"stderr", stderr}} {
+		text := strings.TrimRight(stream.text, "\r\n")
+		if text == "" {
+			continue
+		}
+		entry.WriteString(fmt.Sprintf("\n  [%s]", stream.name))
+		for _, line := range strings.Split(text, "\n") {
+			entry.WriteString("\n  " + strings.TrimRight(line, "\r"))
+		}
+	}
+	logging.Printf("%s", logging.Redact(entry.String()))
+}
+
+// commandLine renders a git invocation for logs and errors with credentials removed
+func commandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t") {
+			arg = `"` + arg + `"`
+		}
+		quoted[i] = arg
+	}
+	return logging.Redact("git " + strings.Join(quoted, " "))
+}
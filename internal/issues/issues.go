@@ -0,0 +1,141 @@
+package issues
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/policy"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/timing"
+)
+
+// DefaultDocsURL is where error codes link to unless support_docs_url points at a studio's own
+// page; {code} is replaced by the code
+const DefaultDocsURL = "https://github.com/benjavides/ue-git-plugin-manager/blob/main/docs/errors.md#{code}"
+
+// stepPrepare names failures before an operation's first step, such as a policy refusal
+const stepPrepare = "prepare"
+
+// Error attaches a stable code to the error an operation failed with. Its message is the
+// error's own, so it can be wrapped without changing what is printed.
+type Error struct {
+	Code string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Category names a failure by the step it happened in and the kind of error, e.g.
+// "worktree/network". The step's own error is used when it failed, since the error an operation
+// returns has often lost its type on the way up.
+func Category(steps []timing.StepDuration, err error) string {
+	step := stepPrepare
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].Failed {
+			step = steps[i].Name
+			if steps[i].Err != nil {
+				err = steps[i].Err
+			}
+			break
+		}
+	}
+	return step + "/" + kind(err)
+}
+
+// Code turns a category into its error code, e.g. "build/timeout" into UEGPM-BUILD-TIMEOUT
+func Code(category string) string {
+	return "UEGPM-" + strings.ToUpper(strings.ReplaceAll(category, "/", "-"))
+}
+
+// Wrap gives the error an operation failed with the code of its category. It returns nil for nil
+// and leaves an error that already has a code alone.
+func Wrap(steps []timing.StepDuration, err error) error {
+	if err == nil {
+		return nil
+	}
+	var coded *Error
+	if errors.As(err, &coded) {
+		return err
+	}
+	return &Error{Code: Code(Category(steps, err)), Err: err}
+}
+
+// CodeOf returns the code of an error: the one attached by Wrap or, for an error of a recognised
+// kind, the code of that kind before any step. Other errors have none.
+func CodeOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	if k := kind(err); k != "other" {
+		return Code(stepPrepare + "/" + k)
+	}
+	return ""
+}
+
+// Link returns the documentation page for a code. docsURL is a template where {code} stands for
+// the code; without it the code is appended as a fragment. Empty uses DefaultDocsURL.
+func Link(docsURL, code string) string {
+	if docsURL == "" {
+		docsURL = DefaultDocsURL
+	}
+	if strings.Contains(docsURL, "{code}") {
+		return strings.ReplaceAll(docsURL, "{code}", code)
+	}
+	return strings.TrimRight(docsURL, "#") + "#" + code
+}
+
+// Describe returns the line printed under a failure to quote in support tickets, with the code
+// and its documentation link, or "" when the error has no code
+func Describe(docsURL string, err error) string {
+	code := CodeOf(err)
+	if code == "" {
+		return ""
+	}
+	return "   Error code " + code + ": " + Link(docsURL, code)
+}
+
+// kind classifies an error by the typed errors in its chain
+func kind(err error) string {
+	var prerequisites *engine.PrerequisiteError
+	switch {
+	case errors.Is(err, git.ErrNetworkUnavailable):
+		return "network"
+	case errors.Is(err, git.ErrAuthRequired):
+		return "auth"
+	case errors.Is(err, git.ErrLocalCommitsConflict):
+		return "conflict"
+	case errors.Is(err, git.ErrDirtyWorktree):
+		return "local-changes"
+	case errors.Is(err, git.ErrDiverged):
+		return "diverged"
+	case errors.Is(err, git.ErrNotARepo):
+		return "not-a-repo"
+	case errors.Is(err, plugin.ErrBinariesLocked):
+		return "binaries-locked"
+	case errors.Is(err, runner.ErrTimeout):
+		return "timeout"
+	case errors.Is(err, runner.ErrCancelled):
+		return "cancelled"
+	case errors.Is(err, policy.ErrEngineChangesForbidden):
+		return "policy"
+	case errors.As(err, &prerequisites):
+		return "prerequisites"
+	case errors.Is(err, os.ErrPermission):
+		return "permission"
+	}
+	return "other"
+}
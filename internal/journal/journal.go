@@ -0,0 +1,214 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// Steps of an engine setup, in the order they run
+const (
+	StepClone       = "clone"
+	StepWorktree    = "worktree"
+	StepJunction    = "junction"
+	StepStockPlugin = "stock_plugin"
+	StepBuild       = "build"
+)
+
+// steps lists every step with how it is shown
+var steps = []struct{ name, label string }{
+	{StepClone, "clone the plugin repository"},
+	{StepWorktree, "create the worktree"},
+	{StepJunction, "link the plugin into the engine"},
+	{StepStockPlugin, "disable the stock Git plugin"},
+	{StepBuild, "build the plugin"},
+}
+
+// Label describes a step for the user, e.g. "create the worktree"
+func Label(step string) string {
+	for _, s := range steps {
+		if s.name == step {
+			return s.label
+		}
+	}
+	return step
+}
+
+// Setup is an engine setup that started and hasn't finished
+type Setup struct {
+	EnginePath     string   `json:"engine_path"`
+	EngineVersion  string   `json:"engine_version"`
+	WorktreeSubdir string   `json:"worktree_subdir"`
+	Completed      []string `json:"completed,omitempty"`       // Steps that succeeded, in the order they ran
+	StockDisabled  bool     `json:"stock_disabled,omitempty"`  // This setup disabled the stock plugin
+	UpstreamBranch string   `json:"upstream_branch,omitempty"` // Plugin branch chosen for the engine, recorded once the setup succeeds
+	StartedUTC     string   `json:"started_utc"`
+	UpdatedUTC     string   `json:"updated_utc"`
+	LastError      string   `json:"last_error,omitempty"` // Why the last attempt stopped
+}
+
+// Done reports whether a step of the setup succeeded
+func (s *Setup) Done(step string) bool {
+	for _, completed := range s.Completed {
+		if completed == step {
+			return true
+		}
+	}
+	return false
+}
+
+// Resumable reports whether the setup got far enough for resuming to save work: its worktree
+// was created. Before that, resuming would be the same as starting over.
+func (s *Setup) Resumable() bool {
+	return s.Done(StepWorktree)
+}
+
+// NextStep returns the first step that hasn't succeeded yet, or "" when all have
+func (s *Setup) NextStep() string {
+	for _, step := range steps {
+		if !s.Done(step.name) {
+			return step.name
+		}
+	}
+	return ""
+}
+
+// Journal records the progress of engine setups in a file as each step completes, so a setup
+// interrupted by a failed build, a crash or a closed window can resume where it stopped
+type Journal struct {
+	path   string
+	Setups []Setup `json:"setups"`
+}
+
+// Load reads the journal file, returning an empty journal if it doesn't exist yet
+func Load(path string) (*Journal, error) {
+	j := &Journal{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return j, err
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return &Journal{path: path}, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return j, nil
+}
+
+// Get returns the unfinished setup of an engine, or nil
+func (j *Journal) Get(enginePath string) *Setup {
+	for i := range j.Setups {
+		if utils.SamePath(j.Setups[i].EnginePath, enginePath) {
+			return &j.Setups[i]
+		}
+	}
+	return nil
+}
+
+// Begin records the start of an engine setup, replacing an earlier unfinished one
+func (j *Journal) Begin(enginePath, engineVersion, worktreeSubdir string) error {
+	j.remove(enginePath)
+	now := time.Now().UTC().Format(time.RFC3339)
+	j.Setups = append(j.Setups, Setup{
+		EnginePath:     enginePath,
+		EngineVersion:  engineVersion,
+		WorktreeSubdir: worktreeSubdir,
+		StartedUTC:     now,
+		UpdatedUTC:     now,
+	})
+	return j.save()
+}
+
+// Complete records that a step of an engine's setup succeeded
+func (j *Journal) Complete(enginePath, step string) error {
+	setup := j.Get(enginePath)
+	if setup == nil {
+		return nil
+	}
+	if !setup.Done(step) {
+		setup.Completed = append(setup.Completed, step)
+	}
+	setup.LastError = ""
+	setup.UpdatedUTC = time.Now().UTC().Format(time.RFC3339)
+	return j.save()
+}
+
+// StockDisabled records that an engine's setup disabled the stock plugin, so a resumed setup
+// still knows to re-enable it on uninstall
+func (j *Journal) StockDisabled(enginePath string) error {
+	setup := j.Get(enginePath)
+	if setup == nil {
+		return nil
+	}
+	setup.StockDisabled = true
+	return j.save()
+}
+
+// UpstreamBranch records the plugin branch chosen for an engine's worktree, so a resumed setup
+// still records the engine as following it
+func (j *Journal) UpstreamBranch(enginePath, branch string) error {
+	setup := j.Get(enginePath)
+	if setup == nil {
+		return nil
+	}
+	setup.UpstreamBranch = branch
+	return j.save()
+}
+
+// Fail records why an engine's setup stopped
+func (j *Journal) Fail(enginePath string, err error) error {
+	setup := j.Get(enginePath)
+	if setup == nil || err == nil {
+		return nil
+	}
+	setup.LastError = err.Error()
+	setup.UpdatedUTC = time.Now().UTC().Format(time.RFC3339)
+	return j.save()
+}
+
+// Finish forgets an engine's setup once it completed, or was repaired or uninstalled instead
+func (j *Journal) Finish(enginePath string) error {
+	if !j.remove(enginePath) {
+		return nil
+	}
+	return j.save()
+}
+
+// remove drops an engine's setup and reports whether there was one
+func (j *Journal) remove(enginePath string) bool {
+	for i := range j.Setups {
+		if utils.SamePath(j.Setups[i].EnginePath, enginePath) {
+			j.Setups = append(j.Setups[:i], j.Setups[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// save writes the journal next to its file and renames it into place, so a crash mid-write
+// never leaves a journal that can't be read
+func (j *Journal) save() error {
+	if len(j.Setups) == 0 {
+		if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
@@ -0,0 +1,82 @@
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrDeferred is returned when an unattended update is put off until a better time
+var ErrDeferred = errors.New("update deferred")
+
+// Window is a daily local time range, such as 02:00-05:00, in which unattended rebuilds may run.
+// A window whose end is before its start wraps past midnight.
+type Window struct {
+	Start time.Duration // Offset from local midnight
+	End   time.Duration
+}
+
+// Parse reads a window in the "HH:MM-HH:MM" form
+func Parse(s string) (Window, error) {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(s), "–", "-"), "-")
+	if len(parts) != 2 {
+		return Window{}, fmt.Errorf("invalid maintenance window %q: expected HH:MM-HH:MM", s)
+	}
+	start, err := parseClock(parts[0])
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid maintenance window %q: %v", s, err)
+	}
+	end, err := parseClock(parts[1])
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid maintenance window %q: %v", s, err)
+	}
+	if start == end {
+		return Window{}, fmt.Errorf("invalid maintenance window %q: start and end are the same", s)
+	}
+	return Window{Start: start, End: end}, nil
+}
+
+// parseClock converts "HH:MM" into an offset from midnight
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day", strings.TrimSpace(s))
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t falls inside the window
+func (w Window) Contains(t time.Time) bool {
+	offset := sinceMidnight(t)
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// Next returns the next time the window opens at or after t
+func (w Window) Next(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	midnight := t.Add(-sinceMidnight(t))
+	next := midnight.Add(w.Start)
+	if next.Before(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// String formats the window as "HH:MM-HH:MM"
+func (w Window) String() string {
+	return fmt.Sprintf("%s-%s", formatClock(w.Start), formatClock(w.End))
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
@@ -0,0 +1,285 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/projectconfig"
+	"ue-git-plugin-manager/internal/secret"
+)
+
+// CurrentVersion is the manifest format version this tool writes
+const CurrentVersion = 1
+
+// DefaultFileName is the name a manifest is exported under unless another is chosen
+const DefaultFileName = "uegpm-manifest.json"
+
+// Engine is one engine setup to reproduce
+type Engine struct {
+	Version        string `json:"version"`
+	Path           string `json:"path"`                      // Where the engine was installed; another machine matches by version when it differs
+	UpstreamBranch string `json:"upstream_branch,omitempty"` // Per-engine branch override
+	CommitSHA      string `json:"commit_sha"`                // Upstream plugin commit the engine was built from
+	LocalCommits   int    `json:"local_commits,omitempty"`   // Commits made only on this machine; not reproduced
+}
+
+// Settings are the configuration values copied to the other machine
+type Settings struct {
+	DefaultRemoteBranch    string            `json:"default_remote_branch"`
+	PinnedCommitSHA        string            `json:"pinned_commit_sha,omitempty"`
+	MirrorURL              string            `json:"mirror_url,omitempty"`
+	PatchFiles             []string          `json:"patch_files,omitempty"`
+	PatchesBranch          string            `json:"patches_branch,omitempty"`
+	CustomEngineRoots      []config.ScanRoot `json:"custom_engine_roots,omitempty"`
+	SkipPluginCacheCleanup bool              `json:"skip_plugin_cache_cleanup,omitempty"`
+	ColorTheme             string            `json:"color_theme,omitempty"`
+	TextStatusSymbols      bool              `json:"text_status_symbols,omitempty"`
+	// Remotes are tracked remotes such as a studio fork; BranchCompatibility and PluginIniOptions
+	// are the defaults used when configuring projects
+	Remotes             []config.Remote           `json:"remotes,omitempty"`
+	BranchCompatibility map[string]string         `json:"branch_compatibility,omitempty"`
+	PluginIniOptions    []projectconfig.IniOption `json:"plugin_ini_options,omitempty"`
+}
+
+// Manifest describes a machine's setup so it can be reproduced elsewhere
+type Manifest struct {
+	Version    int      `json:"version"`
+	Machine    string   `json:"machine"`
+	CreatedUTC string   `json:"created_utc"`
+	Settings   Settings `json:"settings"`
+	Engines    []Engine `json:"engines"`
+	// URLsDropped records that a mirror or remote URL was left out because it held a password or
+	// was encrypted for the exporting machine
+	URLsDropped bool `json:"urls_dropped,omitempty"`
+}
+
+// SettingsFrom copies the reproducible settings from a configuration. A mirror or remote URL
+// with a password is left out because the manifest is stored in plain text; the second result
+// reports whether that happened.
+func SettingsFrom(cfg *config.Config) (Settings, bool) {
+	settings := Settings{
+		DefaultRemoteBranch:    cfg.DefaultRemoteBranch,
+		PinnedCommitSHA:        cfg.PinnedCommitSHA,
+		MirrorURL:              cfg.MirrorURL,
+		PatchFiles:             cfg.PatchFiles,
+		PatchesBranch:          cfg.PatchesBranch,
+		CustomEngineRoots:      cfg.CustomEngineRoots,
+		SkipPluginCacheCleanup: cfg.SkipPluginCacheCleanup,
+		ColorTheme:             cfg.ColorTheme,
+		TextStatusSymbols:      cfg.TextStatusSymbols,
+		BranchCompatibility:    cfg.BranchCompatibility,
+		PluginIniOptions:       cfg.PluginIniOptions,
+	}
+	dropped := false
+	if hasPassword(settings.MirrorURL) {
+		settings.MirrorURL = ""
+		dropped = true
+	}
+	for _, remote := range cfg.Remotes {
+		if hasPassword(remote.URL) {
+			dropped = true
+			continue
+		}
+		settings.Remotes = append(settings.Remotes, remote)
+	}
+	return settings, dropped
+}
+
+// hasPassword reports whether a URL carries a password
+func hasPassword(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User == nil {
+		return false
+	}
+	_, ok := parsed.User.Password()
+	return ok
+}
+
+// ApplyTo copies the settings into a configuration. Engines are handled separately because
+// they have to be set up. Patch files are resolved against baseDir, the data directory, when
+// relative; those that don't exist on this machine are left out and returned.
+func (s Settings) ApplyTo(cfg *config.Config, baseDir string) []string {
+	if s.DefaultRemoteBranch != "" {
+		cfg.DefaultRemoteBranch = s.DefaultRemoteBranch
+	}
+	cfg.PinnedCommitSHA = s.PinnedCommitSHA
+	if s.MirrorURL != "" {
+		cfg.MirrorURL = s.MirrorURL
+	}
+	var missingPatches []string
+	cfg.PatchFiles = nil
+	for _, file := range s.PatchFiles {
+		resolved := file
+		if !filepath.IsAbs(resolved) {
+			resolved = filepath.Join(baseDir, file)
+		}
+		if !fileExists(resolved) {
+			missingPatches = append(missingPatches, file)
+			continue
+		}
+		cfg.PatchFiles = append(cfg.PatchFiles, file)
+	}
+	cfg.PatchesBranch = s.PatchesBranch
+	for _, root := range s.CustomEngineRoots {
+		if !config.HasScanRoot(cfg.CustomEngineRoots, root.Path) {
+			cfg.CustomEngineRoots = append(cfg.CustomEngineRoots, root)
+		}
+	}
+	cfg.SkipPluginCacheCleanup = s.SkipPluginCacheCleanup
+	cfg.ColorTheme = s.ColorTheme
+	cfg.TextStatusSymbols = s.TextStatusSymbols
+
+	// Remotes, branch suggestions and ini options this machine added itself are kept
+	for _, remote := range s.Remotes {
+		if i := remoteIndex(cfg.Remotes, remote.Name); i >= 0 {
+			cfg.Remotes[i].URL = remote.URL
+		} else {
+			cfg.Remotes = append(cfg.Remotes, remote)
+		}
+	}
+	for version, branch := range s.BranchCompatibility {
+		if cfg.BranchCompatibility == nil {
+			cfg.BranchCompatibility = make(map[string]string)
+		}
+		cfg.BranchCompatibility[version] = branch
+	}
+	for _, option := range s.PluginIniOptions {
+		if !HasIniOption(cfg.PluginIniOptions, option) {
+			cfg.PluginIniOptions = append(cfg.PluginIniOptions, option)
+		}
+	}
+	return missingPatches
+}
+
+// remoteIndex returns the position of the remote with a name, or -1
+func remoteIndex(remotes []config.Remote, name string) int {
+	for i, remote := range remotes {
+		if strings.EqualFold(remote.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// HasIniOption reports whether an option for the same ini key is in the list
+func HasIniOption(options []projectconfig.IniOption, option projectconfig.IniOption) bool {
+	for _, existing := range options {
+		if strings.EqualFold(existing.File, option.File) && strings.EqualFold(existing.Section, option.Section) &&
+			strings.EqualFold(existing.Key, option.Key) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteFile writes a manifest as JSON
+func WriteFile(path string, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// commitSHA matches a full or abbreviated commit SHA, as git accepts them
+var commitSHA = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// Load reads a manifest file
+func Load(path string) (Manifest, error) {
+	var m Manifest
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if m.Version > CurrentVersion {
+		return m, fmt.Errorf("%s was written by a newer version of this tool (manifest version %d)", path, m.Version)
+	}
+	for _, eng := range m.Engines {
+		if eng.Version == "" || eng.CommitSHA == "" {
+			return m, fmt.Errorf("%s has an engine entry without a version or commit", path)
+		}
+		if !commitSHA.MatchString(eng.CommitSHA) {
+			return m, fmt.Errorf("%s has an invalid commit %q for UE %s; use a full or abbreviated SHA of at least 7 characters", path, eng.CommitSHA, eng.Version)
+		}
+	}
+	return m, nil
+}
+
+// LoadPeer reads the settings to import from a teammate: an exported manifest, or a shared folder
+// holding one or their config.json (their data directory, or a portable install's folder). Values
+// encrypted for the teammate's Windows account can't be read here and are left out.
+func LoadPeer(path string) (Manifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if !info.IsDir() {
+		if strings.EqualFold(filepath.Base(path), "config.json") {
+			return fromConfigFile(path)
+		}
+		return Load(path)
+	}
+	if candidate := filepath.Join(path, DefaultFileName); fileExists(candidate) {
+		return Load(candidate)
+	}
+	if candidate := filepath.Join(path, "config.json"); fileExists(candidate) {
+		return fromConfigFile(candidate)
+	}
+	return Manifest{}, fmt.Errorf("%s holds neither %s nor config.json", path, DefaultFileName)
+}
+
+// fromConfigFile turns another machine's config.json into a manifest of its settings; its engines
+// are left out, since they are set up rather than copied
+func fromConfigFile(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+	var cfg config.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	// An encrypted mirror URL can only be read on the machine that saved it
+	protectedDropped := secret.IsProtected(cfg.MirrorURL)
+	if protectedDropped {
+		cfg.MirrorURL = ""
+	}
+	// Relative patch files are kept in the data directory the config belongs to
+	for i, file := range cfg.PatchFiles {
+		if !filepath.IsAbs(file) {
+			cfg.PatchFiles[i] = filepath.Join(filepath.Dir(path), file)
+		}
+	}
+	settings, dropped := SettingsFrom(&cfg)
+
+	m := Manifest{
+		Version:     CurrentVersion,
+		Machine:     filepath.Dir(path),
+		Settings:    settings,
+		Engines:     []Engine{},
+		URLsDropped: dropped || protectedDropped,
+	}
+	if info, err := os.Stat(path); err == nil {
+		m.CreatedUTC = info.ModTime().UTC().Format(time.RFC3339)
+	}
+	return m, nil
+}
+
+// fileExists reports whether a regular file exists at path
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,82 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadValidatesEngines(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string // Empty when the manifest is valid
+	}{
+		{
+			name: "full SHA",
+			json: `{"version": 1, "engines": [{"version": "5.4", "commit_sha": "0123456789abcdef0123456789abcdef01234567"}]}`,
+		},
+		{
+			name: "abbreviated SHA",
+			json: `{"version": 1, "engines": [{"version": "5.4", "commit_sha": "0123abc"}]}`,
+		},
+		{
+			name: "no engines",
+			json: `{"version": 1}`,
+		},
+		{
+			name:    "SHA too short",
+			json:    `{"version": 1, "engines": [{"version": "5.4", "commit_sha": "abc"}]}`,
+			wantErr: `invalid commit "abc" for UE 5.4`,
+		},
+		{
+			name:    "SHA too long",
+			json:    `{"version": 1, "engines": [{"version": "5.4", "commit_sha": "0123456789abcdef0123456789abcdef012345678"}]}`,
+			wantErr: "invalid commit",
+		},
+		{
+			name:    "not a SHA",
+			json:    `{"version": 1, "engines": [{"version": "5.4", "commit_sha": "main-branch"}]}`,
+			wantErr: "invalid commit",
+		},
+		{
+			name:    "missing commit",
+			json:    `{"version": 1, "engines": [{"version": "5.4"}]}`,
+			wantErr: "without a version or commit",
+		},
+		{
+			name:    "missing version",
+			json:    `{"version": 1, "engines": [{"commit_sha": "0123abc"}]}`,
+			wantErr: "without a version or commit",
+		},
+		{
+			name:    "newer format",
+			json:    `{"version": 99}`,
+			wantErr: "newer version of this tool",
+		},
+		{
+			name:    "not JSON",
+			json:    `{"version":`,
+			wantErr: "failed to parse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), DefaultFileName)
+			if err := os.WriteFile(path, []byte(tt.json), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Load: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package menu
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/backup"
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/policy"
+)
+
+// CreateBackup writes the configuration, the plugin repository with every engine branch, and each
+// set-up engine's built plugin files to a single archive, so a reimaged machine can be restored
+// with RestoreBackup without cloning or building again
+func CreateBackup(app Application, archivePath string) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+	if !app.GetGit().IsOriginCloned() {
+		return fmt.Errorf("nothing to back up: the plugin repository has not been cloned")
+	}
+
+	tempDir, err := os.MkdirTemp("", "uegpm-backup-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary folder: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	events.Println("📦 Bundling the plugin repository...")
+	bundlePath := filepath.Join(tempDir, backup.BundleEntry)
+	branch, err := app.GetGit().CreateBundle(bundlePath)
+	if err != nil {
+		return err
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	meta := backup.Metadata{
+		Version:      backup.CurrentVersion,
+		Machine:      host,
+		CreatedUTC:   time.Now().UTC().Format(time.RFC3339),
+		OriginBranch: branch,
+		Engines:      []config.Engine{},
+	}
+
+	w, err := backup.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", archivePath, err)
+	}
+	if err := writeBackup(app, cfg, w, &meta, bundlePath); err != nil {
+		w.Close()
+		os.Remove(archivePath)
+		return err
+	}
+	if err := w.Close(); err != nil {
+		os.Remove(archivePath)
+		return fmt.Errorf("failed to write %s: %v", archivePath, err)
+	}
+
+	events.Printf("✅ Backed up %d engine(s) to %s\n", len(meta.Engines), archivePath)
+	return nil
+}
+
+// writeBackup adds everything except the metadata's own entry, then the metadata
+func writeBackup(app Application, cfg *config.Config, w *backup.Writer, meta *backup.Metadata, bundlePath string) error {
+	if err := w.AddFile(backup.BundleEntry, bundlePath); err != nil {
+		return err
+	}
+
+	saved, dropped := withoutPasswords(cfg)
+	if dropped {
+		fmt.Println("⚠️  URLs containing a password are left out; set them again after restoring")
+	}
+	if err := w.AddJSON(backup.ConfigEntry, saved); err != nil {
+		return err
+	}
+
+	// Patch files kept in the data directory aren't anywhere else
+	for _, file := range cfg.PatchFiles {
+		if filepath.IsAbs(file) {
+			continue
+		}
+		name := path.Join(backup.DataDir, filepath.ToSlash(file))
+		if err := w.AddFile(name, filepath.Join(app.GetConfig().GetBaseDir(), file)); err != nil {
+			fmt.Printf("⚠️  Patch file %s is left out: %v\n", file, err)
+		}
+	}
+
+	for _, eng := range cfg.Engines {
+		subdir := engineSubdir(eng)
+		if !app.GetGit().WorktreeExists(subdir) {
+			fmt.Printf("⚠️  UE %s is not set up and is left out\n", eng.EngineVersion)
+			continue
+		}
+		worktreePath := app.GetGit().GetWorktreePath(subdir)
+		if changes, err := app.GetGit().LocalChanges(subdir); err == nil {
+			for _, change := range changes {
+				// The stamped .uplugin is saved with the binaries below
+				if !strings.HasSuffix(change, plugin.UPluginFileName) {
+					fmt.Printf("⚠️  UE %s: uncommitted change is not backed up: %s\n", eng.EngineVersion, change)
+				}
+			}
+		}
+
+		name := path.Join(backup.EnginesDir, subdir)
+		if err := w.AddFile(path.Join(name, plugin.UPluginFileName), filepath.Join(worktreePath, plugin.UPluginFileName)); err != nil {
+			return err
+		}
+		if binaries := filepath.Join(worktreePath, "Binaries"); dirExists(binaries) {
+			if err := w.AddDir(path.Join(name, "Binaries"), binaries); err != nil {
+				return fmt.Errorf("failed to back up UE %s binaries: %v", eng.EngineVersion, err)
+			}
+		}
+		meta.Engines = append(meta.Engines, eng)
+		fmt.Printf("✅ UE %s\n", eng.EngineVersion)
+	}
+
+	return w.AddJSON(backup.MetadataEntry, meta)
+}
+
+// withoutPasswords returns a copy of the configuration without URLs that contain a password, and
+// whether any was removed. The archive is not encrypted like config.json is.
+func withoutPasswords(cfg *config.Config) (config.Config, bool) {
+	saved := *cfg
+	dropped := false
+	for _, field := range []*string{&saved.MirrorURL, &saved.MetricsPushgatewayURL, &saved.TelemetryURL} {
+		if parsed, err := url.Parse(*field); err == nil && parsed.User != nil {
+			if _, hasPassword := parsed.User.Password(); hasPassword {
+				*field = ""
+				dropped = true
+			}
+		}
+	}
+	return saved, dropped
+}
+
+// RestoreBackup rebuilds the plugin repository, worktrees and plugin links from an archive
+// written by CreateBackup. The data directory must not have a plugin repository yet. Engines
+// are found at their old path or, after a drive letter change, by install ID.
+func RestoreBackup(app Application, archivePath string) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+	if err := policy.CheckEngineChanges(cfg); err != nil {
+		return err
+	}
+	if app.GetGit().IsOriginCloned() {
+		return fmt.Errorf("this machine already has a plugin repository; restore only into an empty data directory (%s)", app.GetConfig().GetBaseDir())
+	}
+
+	tempDir, err := os.MkdirTemp("", "uegpm-restore-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary folder: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	meta, err := backup.Extract(archivePath, tempDir)
+	if err != nil {
+		return err
+	}
+	events.Printf("📦 Restoring backup of %s (%s)\n", meta.Machine, formatLockTimestamp(meta.CreatedUTC))
+
+	restored, err := readBackupConfig(tempDir, cfg)
+	if err != nil {
+		return err
+	}
+	if err := backup.CopyEntry(tempDir, backup.DataDir, app.GetConfig().GetBaseDir()); err != nil {
+		return fmt.Errorf("failed to restore data files: %v", err)
+	}
+
+	events.Println("Restoring the plugin repository...")
+	if err := app.GetGit().RestoreOriginFromBundle(filepath.Join(tempDir, backup.BundleEntry), meta.OriginBranch); err != nil {
+		return err
+	}
+
+	var installed []engine.EngineInfo
+	failed := 0
+	for _, eng := range meta.Engines {
+		events.Println()
+		enginePath := eng.EnginePath
+		if !dirExists(enginePath) {
+			if installed == nil {
+				installed, _ = app.GetEngine().DiscoverEngines(restored.CustomEngineRoots)
+			}
+			enginePath = findRestoredEngine(app, installed, eng)
+		}
+		if enginePath == "" {
+			fmt.Printf("⚠️  UE %s is not installed on this machine (it was at %s). Set it up once it is installed; its engine branch is kept.\n", eng.EngineVersion, eng.EnginePath)
+			continue
+		}
+		if err := restoreEngine(app, restored, tempDir, eng, enginePath); err != nil {
+			fmt.Printf("❌ UE %s: %v\n", eng.EngineVersion, err)
+			failed++
+		}
+	}
+
+	if err := app.GetConfig().Save(restored); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	events.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d engine(s) could not be restored", failed, len(meta.Engines))
+	}
+	events.Println("✅ Backup restored")
+	return nil
+}
+
+// readBackupConfig loads the backed up settings, keeping this machine's data directory paths and
+// any password-bearing URL that was left out of the backup. Engines are added as they are restored.
+func readBackupConfig(dir string, current *config.Config) (*config.Config, error) {
+	data, err := os.ReadFile(filepath.Join(dir, backup.ConfigEntry))
+	if err != nil {
+		return nil, fmt.Errorf("the backup has no configuration: %v", err)
+	}
+	var restored config.Config
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return nil, fmt.Errorf("failed to parse the backed up configuration: %v", err)
+	}
+
+	restored.BaseDir = current.BaseDir
+	restored.OriginDir = current.OriginDir
+	restored.WorktreesDir = current.WorktreesDir
+	if restored.MirrorURL == "" {
+		restored.MirrorURL = current.MirrorURL
+	}
+	if restored.MetricsPushgatewayURL == "" {
+		restored.MetricsPushgatewayURL = current.MetricsPushgatewayURL
+	}
+	if restored.TelemetryURL == "" {
+		restored.TelemetryURL = current.TelemetryURL
+	}
+	restored.Engines = nil
+	return &restored, nil
+}
+
+// findRestoredEngine finds a backed up engine that is no longer at its old path, by install ID or
+// else by version and build fingerprint
+func findRestoredEngine(app Application, installed []engine.EngineInfo, eng config.Engine) string {
+	for _, candidate := range installed {
+		if eng.InstallID != "" && app.GetEngine().InstallID(candidate.Path) == eng.InstallID {
+			return candidate.Path
+		}
+	}
+	for _, candidate := range installed {
+		if candidate.Version == eng.EngineVersion && eng.BuildFingerprint != "" &&
+			app.GetEngine().BuildFingerprint(candidate.Path) == eng.BuildFingerprint {
+			return candidate.Path
+		}
+	}
+	return ""
+}
+
+// restoreEngine recreates one engine's worktree with its built files and links it into the engine
+func restoreEngine(app Application, cfg *config.Config, dir string, eng config.Engine, enginePath string) error {
+	subdir := engineSubdir(eng)
+	fmt.Printf("Restoring UE %s at %s...\n", eng.EngineVersion, enginePath)
+
+	if err := app.GetGit().RestoreWorktree(subdir); err != nil {
+		return err
+	}
+	worktreePath := app.GetGit().GetWorktreePath(subdir)
+	if err := backup.CopyEntry(dir, path.Join(backup.EnginesDir, subdir), worktreePath); err != nil {
+		return fmt.Errorf("failed to restore built plugin files: %v", err)
+	}
+	if err := app.GetPlugin().CreateJunction(enginePath, worktreePath); err != nil {
+		return fmt.Errorf("failed to create junction: %v", err)
+	}
+
+	stockDisabled := eng.StockPluginDisabledByTool
+	if app.GetEngine().CheckPluginCollision(enginePath) {
+		if err := app.GetEngine().DisableStockPlugin(enginePath); err != nil {
+			return fmt.Errorf("failed to disable stock plugin: %v", err)
+		}
+		stockDisabled = true
+	}
+
+	restored := eng
+	restored.EnginePath = enginePath
+	restored.PluginLinkPath = app.GetPlugin().GetPluginLinkPath(enginePath)
+	restored.StockPluginDisabledByTool = stockDisabled
+	restored.InstallID = app.GetEngine().InstallID(enginePath)
+	app.GetConfig().UpsertEngine(cfg, restored)
+
+	// The backed up binaries only load in the same engine build
+	status := app.GetDetection().DetectEngineSetupStatus(enginePath, eng.EngineVersion, subdir)
+	if status.IsSetupComplete {
+		fmt.Printf("✅ UE %s restored\n", eng.EngineVersion)
+		return nil
+	}
+	fmt.Printf("⚠️  UE %s restored, but still needs attention (use \"Repair Setup\"):\n", eng.EngineVersion)
+	for _, issue := range status.Issues {
+		fmt.Printf("   - %s\n", issue)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// confirmBuildPaths warns before a setup when a folder the build uses contains non-ASCII characters,
+// which UBT and MSVC can't handle, explains how to move it, and asks whether to continue anyway
+func confirmBuildPaths(app Application, enginePath, worktreeSubdir string) bool {
+	worktreePath := app.GetGit().GetWorktreePath(worktreeSubdir)
+	paths := app.GetPlugin().NonASCIIBuildPaths(enginePath, worktreePath)
+	if len(paths) == 0 {
+		return true
+	}
+
+	fmt.Println("⚠️  These folders used by the build contain non-ASCII characters, which UBT and MSVC can't handle:")
+	for _, path := range paths {
+		fmt.Printf("   - %s: %s\n", path.Role, path.Path)
+	}
+	fmt.Println()
+	for _, path := range paths {
+		switch path.Role {
+		case "Engine", "Plugin link":
+			fmt.Println("   Move the engine to a folder with only ASCII characters (the Epic Games Launcher can move")
+			fmt.Println("   installs), then use \"Re-link Moved Engines\" from the main menu.")
+		case "Plugin worktree", "Build output":
+			// The data directory only ends up here when the user profile can't be read and the exe folder is used
+			fmt.Printf("   The data directory %s is next to the exe; move the exe to a folder with only\n", app.GetConfig().GetBaseDir())
+			fmt.Println("   ASCII characters and run it from there.")
+		default:
+			// TEMP and TMP are moved automatically for the build
+			if path.Path == os.Getenv("TEMP") || path.Path == os.Getenv("TMP") {
+				fmt.Println("   Temporary files are redirected to an ASCII-only folder during the build automatically.")
+			}
+		}
+	}
+	fmt.Println()
+
+	// Only the temporary folder can be handled automatically; anything else needs the user to move it
+	for _, path := range paths {
+		if path.Path != os.Getenv("TEMP") && path.Path != os.Getenv("TMP") {
+			return utils.ConfirmStep("Continue the setup anyway?")
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/projectconfig"
+	"ue-git-plugin-manager/internal/projects"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// offerCIWorkflow optionally adds a GitHub Actions or Azure Pipelines file to the project that
+// checks out with LFS and validates the editor build against the engine this tool manages for it
+func offerCIWorkflow(app Application, cfg *config.Config, root string) {
+	info, err := projects.Read(root)
+	if err != nil {
+		// The workflow builds the .uproject, so there is nothing to generate before it exists
+		return
+	}
+
+	fmt.Println()
+	prompt := promptui.Select{
+		Label:    "Add a CI workflow that validates the editor build?",
+		Items:    []string{"No", projectconfig.CIGitHubActions, projectconfig.CIAzurePipelines},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	_, system, err := utils.RunSelect(&prompt)
+	if err != nil || system == "No" {
+		return
+	}
+
+	params := projectconfig.CIParams{
+		ProjectName:   info.Name,
+		UProjectFile:  filepath.Base(info.UProjectPath),
+		EngineVersion: info.EngineAssociation,
+		Branch:        projectconfig.CurrentBranch(root),
+	}
+	if _, err := os.Stat(filepath.Join(root, "Source")); err == nil {
+		params.HasCode = true
+	}
+	for _, eng := range cfg.Engines {
+		if info.UsesEngine(eng.EnginePath, eng.EngineVersion) {
+			params.EngineVersion = eng.EngineVersion
+			params.EnginePath = eng.EnginePath
+			break
+		}
+	}
+	if params.EnginePath == "" {
+		// The build machine usually has a launcher install; adjust UE_ROOT in the file otherwise
+		params.EnginePath = filepath.Join(`C:\Program Files\Epic Games`, "UE_"+params.EngineVersion)
+		fmt.Printf("⚠️  The project's engine (%s) isn't set up with this tool; using %s\n", info.EngineAssociation, params.EnginePath)
+	}
+
+	path, err := projectconfig.WriteCIWorkflow(root, system, params)
+	if err != nil {
+		fmt.Printf("❌ Failed to create the %s workflow: %v\n", system, err)
+		return
+	}
+	fmt.Printf("✅ Created %s for UE %s (builds on %s)\n", path, params.EngineVersion, params.Branch)
+	fmt.Println("   It needs a self-hosted Windows runner or agent with the engine installed at UE_ROOT.")
+}
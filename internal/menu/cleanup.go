@@ -0,0 +1,253 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/journal"
+	"ue-git-plugin-manager/internal/policy"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// cleanupPlan is what cleaning up the data directory would remove
+type cleanupPlan struct {
+	goneEngines []config.Engine // Managed engines whose folder was deleted
+	worktrees   []string        // Worktree subdirs no managed engine uses
+	branches    []string        // engine-* branches of no remaining worktree
+	linked      []string        // Unused worktrees kept because an engine's plugin link still points there
+}
+
+// isEmpty reports whether the plan removes nothing
+func (p cleanupPlan) isEmpty() bool {
+	return len(p.goneEngines) == 0 && len(p.worktrees) == 0 && len(p.branches) == 0
+}
+
+// planCleanup finds the worktrees and engine branches that no managed engine uses anymore, and
+// managed engines whose folder was deleted. An engine on a drive that isn't connected, or that
+// may have moved to another folder, is left to Re-link Moved Engines. Worktrees of interrupted
+// setups are kept for them to resume.
+func planCleanup(app Application, cfg *config.Config) (cleanupPlan, error) {
+	var plan cleanupPlan
+	moved, err := app.GetDetection().FindMovedEngines(cfg)
+	if err != nil {
+		return plan, err
+	}
+	gone := make(map[string]bool)
+	for _, entry := range moved {
+		if len(entry.Candidates) == 0 && volumeAvailable(entry.Engine.EnginePath) {
+			plan.goneEngines = append(plan.goneEngines, entry.Engine)
+			gone[utils.PathKey(entry.Engine.EnginePath)] = true
+		}
+	}
+
+	inUse := make(map[string]bool)
+	for _, eng := range cfg.Engines {
+		if gone[utils.PathKey(eng.EnginePath)] {
+			continue
+		}
+		inUse[strings.ToLower(engineSubdir(eng))] = true
+		if eng.AlternateWorktreeSubdir != "" {
+			inUse[strings.ToLower(eng.AlternateWorktreeSubdir)] = true
+		}
+	}
+	// An interrupted setup resumes in its worktree, though its engine isn't managed yet
+	if jr, err := journal.Load(app.GetConfig().GetSetupJournalFile()); err == nil {
+		for _, setup := range jr.Setups {
+			inUse[strings.ToLower(setup.WorktreeSubdir)] = true
+		}
+	}
+
+	// A link left behind by a lost configuration, or made by another Windows user's setup, still loads the worktree
+	var linkTargets []string
+	if installed, err := app.GetEngine().DiscoverEngines(cfg.CustomEngineRoots); err == nil {
+		for _, eng := range installed {
+			if link := app.GetPlugin().InspectLink(app.GetPlugin().GetPluginLinkPath(eng.Path)); link.Target != "" {
+				linkTargets = append(linkTargets, link.Target)
+			}
+		}
+	}
+
+	subdirs, err := app.GetGit().WorktreeSubdirs()
+	if err != nil {
+		return plan, fmt.Errorf("failed to list worktrees: %v", err)
+	}
+	for _, subdir := range subdirs {
+		if inUse[strings.ToLower(subdir)] {
+			continue
+		}
+		if isLinkTarget(linkTargets, app.GetGit().GetWorktreePath(subdir)) {
+			plan.linked = append(plan.linked, subdir)
+			inUse[strings.ToLower(subdir)] = true
+			continue
+		}
+		plan.worktrees = append(plan.worktrees, subdir)
+	}
+
+	branches, err := app.GetGit().EngineBranches()
+	if err != nil {
+		return plan, err
+	}
+	keptBranches := make(map[string]bool)
+	for subdir := range inUse {
+		keptBranches[strings.ToLower(git.EngineBranch(subdir))] = true
+	}
+	for _, branch := range branches {
+		if !keptBranches[strings.ToLower(branch)] {
+			plan.branches = append(plan.branches, branch)
+		}
+	}
+	return plan, nil
+}
+
+// isLinkTarget reports whether any of the link targets is the worktree or inside it
+func isLinkTarget(targets []string, worktreePath string) bool {
+	for _, target := range targets {
+		rel, err := filepath.Rel(utils.NormalizePath(worktreePath), utils.NormalizePath(target))
+		if err == nil && !strings.HasPrefix(strings.ToLower(rel), "..") {
+			return true
+		}
+	}
+	return false
+}
+
+// volumeAvailable reports whether the drive or share a path is on can be reached, so a missing
+// folder on it was really deleted
+func volumeAvailable(path string) bool {
+	volume := filepath.VolumeName(path)
+	if volume == "" {
+		return false
+	}
+	_, err := os.Stat(volume + string(filepath.Separator))
+	return err == nil
+}
+
+// runCleanup shows the worktrees and branches nothing uses anymore, removes them once confirmed
+// and compacts the plugin repository
+func runCleanup(app Application, cfg *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🧹 Clean Up Unused Worktrees"))
+	fmt.Println()
+
+	if !app.GetGit().IsOriginCloned() {
+		fmt.Println("The plugin repository has not been cloned; there is nothing to clean up.")
+		utils.Pause()
+		return
+	}
+	plan, err := planCleanup(app, cfg)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		utils.Pause()
+		return
+	}
+	// Forgetting engines is an engine change a studio policy may forbid; their worktrees then stay too
+	if len(plan.goneEngines) > 0 {
+		if err := policy.CheckEngineChanges(cfg); err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+			utils.Pause()
+			return
+		}
+	}
+
+	for _, subdir := range plan.linked {
+		fmt.Printf("ℹ️  %s is not managed, but an engine's plugin link still points to it; it is kept.\n", subdir)
+	}
+	if plan.isEmpty() {
+		fmt.Println("✅ Every worktree and engine branch is in use.")
+		fmt.Println()
+		if utils.ConfirmStep("Compact the plugin repository with git gc anyway?") {
+			compactRepository(app)
+		}
+		utils.Pause()
+		return
+	}
+
+	if len(plan.goneEngines) > 0 {
+		fmt.Println("Engines whose folder was deleted (removed from the configuration):")
+		for _, eng := range plan.goneEngines {
+			fmt.Printf("  - UE %s at %s\n", eng.EngineVersion, eng.EnginePath)
+		}
+		fmt.Println()
+	}
+	if len(plan.worktrees) > 0 {
+		fmt.Println("Worktrees no managed engine uses:")
+		for _, subdir := range plan.worktrees {
+			size := utils.DirSize(app.GetGit().GetWorktreePath(subdir))
+			fmt.Printf("  - %s (%s)\n", subdir, utils.FormatSize(size))
+			if changes, _ := app.GetGit().LocalChanges(subdir); len(changes) > 0 {
+				fmt.Printf("    ⚠️  %d uncommitted change(s) will be lost\n", len(changes))
+			}
+		}
+		fmt.Println()
+	}
+	if len(plan.branches) > 0 {
+		fmt.Println("Engine branches of no remaining worktree:")
+		for _, branch := range plan.branches {
+			// A branch that can't be checked is treated as holding unpushed commits
+			count, err := app.GetGit().UnpushedCommits(branch)
+			switch {
+			case err != nil:
+				fmt.Printf("  - %s ⚠️  commits not on any remote can't be ruled out and would be lost (%v)\n", branch, err)
+			case count > 0:
+				fmt.Printf("  - %s ⚠️  %d commit(s) not on any remote will be lost\n", branch, count)
+			default:
+				fmt.Printf("  - %s\n", branch)
+			}
+		}
+		fmt.Println()
+	}
+
+	if !utils.ConfirmDestructive("Remove these and compact the plugin repository?") {
+		return
+	}
+
+	dataDir := app.GetConfig().GetBaseDir()
+	before := utils.DirSize(dataDir)
+	for _, eng := range plan.goneEngines {
+		app.GetConfig().RemoveEngine(cfg, eng.EnginePath)
+	}
+	if len(plan.goneEngines) > 0 {
+		if err := app.GetConfig().Save(cfg); err != nil {
+			fmt.Printf("❌ Failed to save configuration: %v\n", err)
+			utils.Pause()
+			return
+		}
+	}
+	failed := 0
+	for _, subdir := range plan.worktrees {
+		if err := app.GetGit().RemoveWorktree(subdir); err != nil {
+			fmt.Printf("❌ %s: %v\n", subdir, err)
+			failed++
+		}
+	}
+	// Branches go after the worktrees, which have them checked out
+	for _, branch := range plan.branches {
+		if err := app.GetGit().DeleteBranch(branch); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			failed++
+		}
+	}
+	compactRepository(app)
+
+	after := utils.DirSize(dataDir)
+	fmt.Println()
+	fmt.Printf("Data directory: %s → %s\n", utils.FormatSize(before), utils.FormatSize(after))
+	if failed > 0 {
+		fmt.Printf("⚠️  %d item(s) could not be removed; see above.\n", failed)
+	} else {
+		fmt.Println("✅ Clean-up complete.")
+	}
+	utils.Pause()
+}
+
+// compactRepository runs git gc on the plugin repository
+func compactRepository(app Application) {
+	fmt.Println("Compacting the plugin repository (git gc)...")
+	if err := app.GetGit().GarbageCollect(); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+}
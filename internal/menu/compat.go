@@ -0,0 +1,129 @@
+package menu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// confirmBranchCompatibility reads the plugin descriptor on the branch or pin an engine would be
+// set up from and, when it is made for an incompatible UE version, warns and offers a branch that
+// supports the engine. It returns the branch the user chose instead, or "" to keep the tracked
+// one, and false when the user cancels the setup.
+func confirmBranchCompatibility(app Application, cfg *config.Config, engineVersion, worktreeSubdir string) (string, bool) {
+	branch := cfg.TrackedBranch(worktreeSubdir)
+	rev := app.GetGit().TrackingRef(branch)
+	pin := cfg.TrackedPin(worktreeSubdir)
+	if pin != "" {
+		rev = pin
+	}
+	descriptor, err := app.GetGit().ReadFileAt(rev, plugin.UPluginFileName)
+	if err != nil {
+		return "", true // Nothing to compare against; the build reports real problems
+	}
+	made := plugin.DescriptorEngineVersion(descriptor)
+	if plugin.SupportsEngine(made, engineVersion) {
+		return "", true
+	}
+
+	source := "branch " + branch
+	if pin != "" {
+		source = "pinned commit " + git.ShortSHA(pin)
+	}
+	fmt.Printf("⚠️  The plugin on %s is made for UE %s and may not build for UE %s.\n", source, made, engineVersion)
+
+	const (
+		continueItem = "Continue anyway"
+		cancelItem   = "Cancel setup"
+	)
+	suggested := suggestCompatibleBranch(app, cfg, engineVersion)
+	useItem := fmt.Sprintf("Use branch %s for this engine", suggested)
+	var items []string
+	switch {
+	case suggested == "":
+		fmt.Printf("   No plugin branch made for UE %s was found; map one in branch_compatibility in config.json.\n", engineVersion)
+	case pin != "":
+		fmt.Printf("   Branch %s supports UE %s; remove the pin to use it.\n", suggested, engineVersion)
+	default:
+		fmt.Printf("   Branch %s supports UE %s.\n", suggested, engineVersion)
+		items = append(items, useItem)
+	}
+	items = append(items, continueItem, cancelItem)
+
+	prompt := promptui.Select{
+		Label:    "How do you want to continue?",
+		Items:    items,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	_, choice, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return "", false
+	}
+	switch choice {
+	case useItem:
+		return suggested, true
+	case continueItem:
+		return "", true
+	}
+	return "", false
+}
+
+// suggestCompatibleBranch returns the plugin branch mapped to an engine version in the
+// configuration or, failing that, a fetched branch whose descriptor supports it, preferring one
+// made for exactly that version
+func suggestCompatibleBranch(app Application, cfg *config.Config, engineVersion string) string {
+	// Sorted, so the same branch is suggested every time
+	versions := make([]string, 0, len(cfg.BranchCompatibility))
+	for version := range cfg.BranchCompatibility {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	for _, version := range versions {
+		if majorMinorOf(version) == majorMinorOf(engineVersion) {
+			return cfg.BranchCompatibility[version]
+		}
+	}
+
+	refs, err := app.GetGit().CachedRemoteRefs()
+	if err != nil {
+		return ""
+	}
+	branches := append([]string(nil), refs.Branches...)
+	sort.Strings(branches)
+	fallback := ""
+	for _, branch := range branches {
+		descriptor, err := app.GetGit().ReadFileAt("origin/"+branch, plugin.UPluginFileName)
+		if err != nil {
+			continue
+		}
+		made := plugin.DescriptorEngineVersion(descriptor)
+		if made == "" || !plugin.SupportsEngine(made, engineVersion) {
+			continue
+		}
+		if majorMinorOf(made) == majorMinorOf(engineVersion) {
+			return branch
+		}
+		if fallback == "" {
+			fallback = branch
+		}
+	}
+	return fallback
+}
+
+// majorMinorOf returns the "5.4" part of a version such as "5.4.0"
+func majorMinorOf(version string) string {
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
@@ -0,0 +1,37 @@
+package menu
+
+import (
+	"fmt"
+
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// offerGitLFS warns that git-lfs is missing, which Unreal projects need for their binary assets,
+// and offers to download and install the official release
+func offerGitLFS(app Application) {
+	if !app.GetGit().IsGitAvailable() || app.GetGit().IsGitLFSAvailable() {
+		return
+	}
+	fmt.Println(color.YellowString("⚠️  Git LFS was not found on this machine."))
+	fmt.Println("The project's .gitattributes stores assets with Git LFS; without it, commits contain")
+	fmt.Println("the full binary files and checkouts show pointer files instead of assets.")
+	fmt.Println()
+	if !utils.ConfirmStep("Download and install the official git-lfs from its GitHub releases?") {
+		fmt.Println("Install it later from https://git-lfs.com and run \"git lfs install\".")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("📥 Downloading git-lfs...")
+	if err := app.GetGit().InstallGitLFS(); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		fmt.Println("Install it manually from https://git-lfs.com and run \"git lfs install\".")
+		fmt.Println()
+		return
+	}
+	fmt.Printf("✅ git-lfs installed to %s and added to your PATH\n", app.GetGit().GitLFSDir())
+	fmt.Println("   Restart other Git clients so they pick up the new PATH.")
+	fmt.Println()
+}
@@ -0,0 +1,263 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// withGitRecovery runs a git operation and, when it fails for a recognised reason, explains
+// the cause and offers a matching fix before retrying. worktreeSubdir is the worktree the
+// operation touches, or empty for operations on the origin repository.
+func withGitRecovery(app Application, cfg *config.Config, worktreeSubdir string, op func() error) error {
+	// Skipped patches only apply to this operation; the next update tries them again
+	defer delete(skippedUpdateSteps, worktreeSubdir)
+
+	for {
+		err := op()
+		if err == nil {
+			return nil
+		}
+
+		var retry bool
+		var conflict *git.ConflictError
+		switch {
+		case errors.Is(err, git.ErrAuthRequired):
+			retry = recoverAuthRequired(app, cfg)
+		case errors.Is(err, git.ErrNetworkUnavailable):
+			retry = recoverNetworkUnavailable(app, cfg)
+		case errors.Is(err, git.ErrDirtyWorktree) && worktreeSubdir != "":
+			retry = recoverDirtyWorktree(app, worktreeSubdir)
+		case errors.Is(err, git.ErrDiverged) && worktreeSubdir != "":
+			retry = recoverDivergedWorktree(app, cfg, worktreeSubdir, "is no longer on the branch this tool manages")
+		case errors.As(err, &conflict) && worktreeSubdir != "":
+			retry = recoverUpdateConflict(app, cfg, worktreeSubdir, conflict)
+		case errors.Is(err, git.ErrNotARepo):
+			retry = recoverNotARepo(app, cfg)
+		}
+		if !retry {
+			return err
+		}
+		fmt.Println("🔄 Retrying...")
+	}
+}
+
+// recoverNetworkUnavailable explains an unreachable repository and offers to retry or change the mirror
+func recoverNetworkUnavailable(app Application, cfg *config.Config) bool {
+	fmt.Println()
+	fmt.Println("🌐 The plugin repository could not be reached.")
+	if cfg.MirrorURL != "" {
+		fmt.Println("   Both the studio mirror and GitHub failed.")
+	}
+	fmt.Println("   Check your network connection, VPN and proxy settings.")
+	fmt.Println()
+
+	switch selectRecovery("How would you like to continue?", "Retry", "Set Repository Mirror", "Cancel") {
+	case "Retry":
+		return true
+	case "Set Repository Mirror":
+		changeMirror(app, cfg)
+		return true
+	}
+	return false
+}
+
+// recoverAuthRequired explains a credentials failure and offers to change the mirror URL
+func recoverAuthRequired(app Application, cfg *config.Config) bool {
+	fmt.Println()
+	fmt.Println("🔒 The plugin repository requires credentials that Git doesn't have.")
+	if cfg.MirrorURL != "" {
+		fmt.Println("   Include them in the mirror URL (it is stored encrypted), or sign in once by running")
+		fmt.Println("   `git ls-remote <mirror URL>` in a terminal so Git Credential Manager remembers them.")
+	} else {
+		fmt.Println("   Sign in once by running `git ls-remote " + git.UpstreamURL + "` in a terminal.")
+	}
+	fmt.Println()
+
+	items := []string{"Retry", "Set Repository Mirror"}
+	if cfg.MirrorURL != "" {
+		items = append(items, "Use GitHub Only")
+	}
+	items = append(items, "Cancel")
+
+	switch selectRecovery("How would you like to continue?", items...) {
+	case "Retry":
+		return true
+	case "Set Repository Mirror":
+		changeMirror(app, cfg)
+		return true
+	case "Use GitHub Only":
+		cfg.MirrorURL = ""
+		if err := app.GetConfig().Save(cfg); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+		return true
+	}
+	return false
+}
+
+// recoverDirtyWorktree lists local edits that block an update and offers to discard them
+func recoverDirtyWorktree(app Application, worktreeSubdir string) bool {
+	fmt.Println()
+	fmt.Printf("✏️  The worktree %s has local changes that block the update:\n", worktreeSubdir)
+	if changes, err := app.GetGit().LocalChanges(worktreeSubdir); err == nil {
+		for _, change := range changes {
+			fmt.Printf("   %s\n", change)
+		}
+	}
+	fmt.Println()
+
+	switch selectRecovery("How would you like to continue?", "Discard Local Changes", "Open Worktree Folder", "Cancel") {
+	case "Discard Local Changes":
+		if !utils.ConfirmDestructive("These edits will be lost. Discard them?") {
+			return false
+		}
+		if err := app.GetGit().DiscardLocalChanges(worktreeSubdir); err != nil {
+			fmt.Printf("❌ Failed to discard local changes: %v\n", err)
+			return false
+		}
+		return true
+	case "Open Worktree Folder":
+		utils.OpenURL("file:///" + strings.ReplaceAll(app.GetGit().GetWorktreePath(worktreeSubdir), "\\", "/"))
+	}
+	return false
+}
+
+// recoverDivergedWorktree shows how a worktree differs from the tracked commit and offers to
+// reset its engine branch to it after confirming what would be lost
+func recoverDivergedWorktree(app Application, cfg *config.Config, worktreeSubdir, problem string) bool {
+	state, err := app.GetGit().GetWorktreeState(worktreeSubdir, cfg.TrackedBranch(worktreeSubdir), cfg.TrackedPin(worktreeSubdir))
+	if err != nil {
+		fmt.Printf("❌ Failed to inspect worktree %s: %v\n", worktreeSubdir, err)
+		return false
+	}
+
+	fmt.Println()
+	fmt.Printf("🔀 The worktree %s %s.\n", worktreeSubdir, problem)
+	if state.Branch != "" && state.Branch != state.EngineBranch {
+		fmt.Printf("   Branch %s was checked out in it instead of %s.\n", state.Branch, state.EngineBranch)
+	}
+	fmt.Printf("   Current commit: %s\n", git.ShortSHA(state.HeadSHA))
+	fmt.Printf("   Tracked commit: %s\n", git.ShortSHA(state.TargetSHA))
+	fmt.Println()
+
+	// Commits on another checked out branch stay on it; on the engine branch or a detached HEAD they are dropped
+	var lostCommits []string
+	if state.Branch == "" || state.Branch == state.EngineBranch {
+		lostCommits = state.LocalCommits
+	} else if len(state.LocalCommits) > 0 {
+		fmt.Printf("%d local commit(s) stay on branch %s after the reset.\n", len(state.LocalCommits), state.Branch)
+	}
+	changes, _ := app.GetGit().LocalChanges(worktreeSubdir)
+	if len(lostCommits) == 0 && len(changes) == 0 {
+		fmt.Println("Nothing would be lost by resetting.")
+	} else {
+		fmt.Println("Resetting would lose:")
+		for _, commit := range lostCommits {
+			fmt.Printf("   commit %s\n", commit)
+		}
+		for _, change := range changes {
+			fmt.Printf("   edit   %s\n", change)
+		}
+	}
+	fmt.Println()
+
+	switch selectRecovery("How would you like to continue?", "Reset to Tracked Commit", "Open Worktree Folder", "Cancel") {
+	case "Reset to Tracked Commit":
+		if !utils.ConfirmDestructive(fmt.Sprintf("Reset %s to %s?", worktreeSubdir, git.ShortSHA(state.TargetSHA))) {
+			return false
+		}
+		if err := app.GetGit().ResetWorktree(worktreeSubdir, cfg.TrackedBranch(worktreeSubdir), cfg.TrackedPin(worktreeSubdir), localPatches(app, cfg, worktreeSubdir)); err != nil {
+			fmt.Printf("❌ Failed to reset worktree: %v\n", err)
+			return false
+		}
+		fmt.Printf("✅ Worktree %s reset to %s\n", worktreeSubdir, git.ShortSHA(state.TargetSHA))
+		return true
+	case "Open Worktree Folder":
+		utils.OpenURL("file:///" + strings.ReplaceAll(app.GetGit().GetWorktreePath(worktreeSubdir), "\\", "/"))
+	}
+	return false
+}
+
+// recoverUpdateConflict shows the patch or commit that stopped an update and the files it
+// conflicts in, and offers to skip it, inspect the worktree, reset, or abort
+func recoverUpdateConflict(app Application, cfg *config.Config, worktreeSubdir string, conflict *git.ConflictError) bool {
+	fmt.Println()
+	fmt.Printf("⚔️  The update of %s stopped: %s doesn't apply cleanly onto %s.\n", worktreeSubdir, conflict.Step, git.ShortSHA(conflict.Target))
+	fmt.Println("   The worktree was left as it was before the update.")
+	if len(conflict.Files) > 0 {
+		fmt.Println("   Conflicting files:")
+		for _, file := range conflict.Files {
+			fmt.Printf("     %s\n", file)
+		}
+	}
+	fmt.Println()
+
+	skipItem := "Skip This Commit"
+	if conflict.IsPatch {
+		skipItem = "Skip This Patch"
+	}
+	for {
+		switch selectRecovery("How would you like to continue?", skipItem, "Open Worktree Folder", "Reset to Tracked Commit", "Abort Update") {
+		case skipItem:
+			if conflict.IsPatch {
+				fmt.Println("The patch is left out of this update and tried again on the next one.")
+				fmt.Println("Fix or remove it under Settings → \"Local Patches\".")
+			} else if !utils.ConfirmDestructive(fmt.Sprintf("The commit will be dropped from %s (git's reflog still has it). Skip it?", git.EngineBranch(worktreeSubdir))) {
+				continue
+			}
+			skippedUpdateSteps[worktreeSubdir] = append(skippedUpdateSteps[worktreeSubdir], conflict.SkipID)
+			return true
+		case "Open Worktree Folder":
+			utils.OpenURL("file:///" + strings.ReplaceAll(app.GetGit().GetWorktreePath(worktreeSubdir), "\\", "/"))
+		case "Reset to Tracked Commit":
+			return recoverDivergedWorktree(app, cfg, worktreeSubdir, "has local commits that conflict with the update")
+		default:
+			return false
+		}
+	}
+}
+
+// recoverNotARepo explains a missing or damaged repository and re-clones it when it is missing
+func recoverNotARepo(app Application, cfg *config.Config) bool {
+	fmt.Println()
+	if app.GetGit().IsOriginCloned() {
+		fmt.Println("🧩 A plugin worktree is missing or damaged.")
+		fmt.Println("   Use \"Repair Setup\" for the engine under \"Edit Setup\" to recreate it.")
+		fmt.Println()
+		return false
+	}
+
+	fmt.Println("🧩 The plugin repository has not been cloned on this machine.")
+	fmt.Println()
+	if selectRecovery("How would you like to continue?", "Clone Repository", "Cancel") != "Clone Repository" {
+		return false
+	}
+	if err := app.GetGit().CloneOrigin(cfg.MirrorURL); err != nil {
+		fmt.Printf("❌ Failed to clone plugin repository: %v\n", err)
+		return false
+	}
+	return true
+}
+
+// selectRecovery shows a recovery choice and returns the selected item, or "" if cancelled
+func selectRecovery(label string, items ...string) string {
+	prompt := promptui.Select{
+		Label:    label,
+		Items:    items,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	_, choice, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return ""
+	}
+	return choice
+}
@@ -0,0 +1,397 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// helpItem is the entry added to every menu with a help page; typing ? picks it
+const helpItem = "[?] Help"
+
+// Help topics, one per menu screen
+const (
+	helpMain             = "main"
+	helpViewer           = "viewer"
+	helpEditSetup        = "edit-setup"
+	helpEngine           = "engine"
+	helpEngineTracking   = "engine-tracking"
+	helpPluginVersions   = "plugin-versions"
+	helpOtherUser        = "other-user"
+	helpSettings         = "settings"
+	helpAdvanced         = "advanced"
+	helpEnginePaths      = "engine-paths"
+	helpRemotes          = "remotes"
+	helpPatches          = "patches"
+	helpProjectTools     = "project-tools"
+	helpProjects         = "projects"
+	helpPluginSettings   = "plugin-settings"
+	helpProjectSubmodule = "project-submodule"
+)
+
+// helpSection is a heading and the lines under it
+type helpSection struct {
+	heading string
+	lines   []string
+}
+
+// helpPage is the help shown for one menu screen
+type helpPage struct {
+	title    string
+	sections []helpSection
+}
+
+var helpPages = map[string]helpPage{
+	helpMain: {
+		title: "UE Git Plugin Manager",
+		sections: []helpSection{
+			{"", []string{
+				"This tool sets up Project Borealis' Git plugin (UEGitPlugin) for every Unreal Engine",
+				"installed on this machine, and keeps it updated and built for each engine version.",
+				"Press ? on any menu for help on that screen.",
+			}},
+			{"What the setup does", []string{
+				"1. Clones the plugin repository once into the data directory.",
+				"2. Creates a worktree per engine version: a separate folder with its own branch that",
+				"   shares the repository's history, so each engine can be on a different plugin version.",
+				"3. Builds the plugin for that engine.",
+				"4. Links Engine\\Plugins\\UEGitPlugin_PB to the worktree with a junction, a folder that",
+				"   points at another folder; the editor loads the plugin through it as if it were there.",
+				"5. Disables the stock Git plugin, which would conflict with it.",
+			}},
+			{"Menu", []string{
+				"Setup Status        what is set up, broken or out of date on each engine",
+				"Update All Engines  fetch the latest plugin and rebuild every managed engine",
+				"Edit Setup          install, update, repair or remove the setup of one engine",
+				"Configure project   prepare a game project for Git and manage its locks and settings",
+				"Settings            branch, mirror, patches, appearance and maintenance options",
+			}},
+			{"When setup fails", []string{
+				"The tool expects git on the PATH, write access to the engine folders and",
+				"%APPDATA%\\ue-git-plugin-manager, and the plugin to build with the engine's build",
+				"tools into Binaries\\Win64\\UnrealEditor-GitSourceControl.dll. Antivirus software that",
+				"blocks junctions also stops the setup. Settings → Open Logs Folder has every step.",
+			}},
+		},
+	},
+	helpViewer: {
+		title: "Viewer Mode",
+		sections: []helpSection{
+			{"", []string{
+				"Viewer mode only looks: nothing on this machine is changed, and every action that",
+				"would change it is hidden. It is on because of --viewer or \"viewer_mode\" in config.json.",
+			}},
+			{"Menu", []string{
+				"Detailed Setup Status  each engine's worktree, plugin link, binaries and issues",
+				"Diagnostics            git, engines, the data directory and anything that looks wrong",
+				"Engine Plugin Audit    plugins added to the engines' Plugins folders",
+				"Show Step Timings      how long setup, update and build steps took on this machine",
+				"Status History         engine status over time",
+			}},
+			{"", []string{
+				"Run the tool without --viewer, on an account that may change the engines, to fix",
+				"what these screens report.",
+			}},
+		},
+	},
+	helpEditSetup: {
+		title: "Edit Setup",
+		sections: []helpSection{
+			{"", []string{
+				"Every detected engine is listed with its state. Pick one to see what can be done with it.",
+			}},
+			{"States", []string{
+				"Setup Complete  worktree, plugin link and built plugin are all in place",
+				"Not Set Up      the tool has never set up this engine",
+				"Setup Broken    it was set up, but a part is missing or wrong, e.g. the link was",
+				"                deleted, the binaries were built from another commit, or the",
+				"                engine was patched since the plugin was built",
+			}},
+			{"", []string{
+				"The lines under each engine show which part failed; Repair Setup fixes just those.",
+			}},
+		},
+	},
+	helpEngine: {
+		title: "Engine Options",
+		sections: []helpSection{
+			{"Install Setup", []string{
+				"Creates this engine's worktree, builds the plugin, links it into the engine and",
+				"disables the stock Git plugin.",
+			}},
+			{"Resume Interrupted Setup", []string{
+				"Shown when an earlier Install Setup stopped part way, e.g. on a failed build. It",
+				"continues after the last step that completed, checking that the worktree and link",
+				"it made are still there, instead of starting over.",
+			}},
+			{"Update Setup", []string{
+				"Fetches the plugin branch this engine follows, moves its worktree to the new commit,",
+				"replaying any local commits and patches, and rebuilds the plugin.",
+			}},
+			{"Repair Setup", []string{
+				"Only redoes what is broken, and changes nothing else:",
+				"• recreates the worktree if it is missing",
+				"• replaces a missing or wrong plugin link",
+				"• disables the stock Git plugin again if it was re-enabled",
+				"• rebuilds the plugin if the binaries are missing, damaged, from another commit,",
+				"  or older than the engine's latest patch",
+				"Uncommitted changes in the worktree are kept.",
+			}},
+			{"Other options", []string{
+				"Engine Branch & Pin    follow another branch or stay on one commit for this engine",
+				"Plugin Versions (A/B)  build a candidate plugin next to the one in use and switch",
+				"Plugin Folder Name     link the plugin as GitSourceControl or another folder name",
+				"Uninstall Setup        remove the link and worktrees and re-enable the stock plugin;",
+				"                       the repository goes too when no other engine uses it",
+			}},
+		},
+	},
+	helpEngineTracking: {
+		title: "Engine Branch & Pin",
+		sections: []helpSection{
+			{"", []string{
+				"Each engine's worktree is on its own engine branch. Updates bring it to the upstream",
+				"branch it follows, or to the pinned commit, and replay commits made on the engine branch",
+				"on top, so fixes for one engine version survive updates.",
+			}},
+			{"Options", []string{
+				"Follow a Different Upstream Branch  this engine only; others keep the default",
+				"Switch Remote                       follow a branch of a tracked remote, e.g. a fork",
+				"Pin to Current Commit               stop updating past the commit in use",
+				"Pin to Commit or Tag                move to a given commit and stay there",
+				"Use Default Upstream Branch         drop this engine's own branch setting",
+				"Remove Engine Pin                   follow the upstream branch again",
+			}},
+		},
+	},
+	helpPluginVersions: {
+		title: "Plugin Versions (A/B)",
+		sections: []helpSection{
+			{"", []string{
+				"A candidate version of the plugin is built in a second worktree next to the stable one",
+				"in use. Switching only re-points the engine's plugin link, so you can try a new",
+				"plugin version and go back without rebuilding. Close the editor before switching.",
+				"Update refreshes and rebuilds the inactive version; Remove deletes its worktree.",
+			}},
+		},
+	},
+	helpOtherUser: {
+		title: "Another User's Setup",
+		sections: []helpSection{
+			{"", []string{
+				"Another Windows user of this machine set the engine up with this tool, so its plugin",
+				"link points into their profile. An engine has one link: everyone on the machine loads",
+				"the build it points to. Setup and repair leave the link alone until you choose:",
+			}},
+			{"Adopt", []string{
+				"Use their setup as it is. Your own worktree for the engine is removed and this tool",
+				"stops updating the engine; they update it for everyone.",
+			}},
+			{"Replace", []string{
+				"Point the link at your own setup, creating or repairing it. Their editor will load",
+				"your build, and their tool will show the engine as linked to your setup.",
+			}},
+			{"Coexist", []string{
+				"Keep their link and your own worktree side by side. Updates and repairs build your",
+				"worktree without touching the link; choose Replace later to switch to it.",
+			}},
+		},
+	},
+	helpSettings: {
+		title: "Settings",
+		sections: []helpSection{
+			{"Plugin source", []string{
+				"Change Branch to Track  the upstream branch every engine follows by default",
+				"Set Repository Mirror   fetch from a studio mirror instead of GitHub",
+				"Tracked Remotes         more remotes, such as forks, that engines can follow",
+				"Local Patches           patch files or a branch applied on top of every update",
+			}},
+			{"Behavior", []string{
+				"Plugin Cache Cleanup    delete stale plugin build caches before rebuilding",
+				"Parallel Builds         how many engines compile at the same time (1 to 4)",
+				"Repository Maintenance  how often the plugin repository is compacted; run it now",
+				"Maintenance Window      when scheduled \"update --scheduled\" runs may rebuild",
+				"Confirmations           ask before every step, or only before destructive ones",
+				"Prompts                 arrow-key lists, or numbered lists for screen readers",
+				"Telemetry               opt in to sending anonymous outcomes to your studio",
+				"Explorer Context Menu   \"Configure Unreal project for Git\" on folder right-click",
+			}},
+			{"Appearance", []string{
+				"Color Theme, Status Symbols and Output change how the tool looks; Verbose output",
+				"prints every diagnostic step, which is always in the log file either way.",
+			}},
+			{"Machine", []string{
+				"Export Machine Manifest  save this setup so \"apply\" can reproduce it elsewhere",
+				"Import Settings from a Teammate",
+				"                         copy their branch, mirror, fork, engine paths and project defaults",
+				"Re-point Plugin Links    fix the links after moving or restoring the data directory",
+				"Open ...                 the plugin's GitHub page, the data directory or the logs",
+				"Check for Tool Updates   download this tool's latest release and replace the exe",
+			}},
+		},
+	},
+	helpAdvanced: {
+		title: "Advanced Options",
+		sections: []helpSection{
+			{"", []string{
+				"Show configuration       what config.json holds",
+				"Change scan roots        folders searched for engines outside the default locations",
+				"Rescan engines           look for engines again and pick up new installs",
+				"Fix plugin collision     disable the stock Git plugin where both are enabled",
+				"Re-enable stock Git plugin  undo that for one engine",
+				"Rebuild plugin for engine   build the plugin again without updating it",
+				"Repair broken setup      repair every engine shown as broken",
+				"Diagnostics              checks of git, engines and the data directory",
+				"Clean up unused worktrees  remove worktrees and branches of engines no longer",
+				"                         managed or installed, then compact the repository",
+			}},
+		},
+	},
+	helpEnginePaths: {
+		title: "Custom Engine Paths",
+		sections: []helpSection{
+			{"", []string{
+				"Engines installed by the Epic Games Launcher or registered by a source build are",
+				"found automatically. Add a folder here for engines anywhere else, e.g. a shared",
+				"drive with several builds; it is scanned for engine folders down to the depth set",
+				"in Edit Scan Options.",
+			}},
+		},
+	},
+	helpRemotes: {
+		title: "Tracked Remotes",
+		sections: []helpSection{
+			{"", []string{
+				"Besides origin, the plugin repository can fetch from more remotes, such as your",
+				"studio's fork. Once added, an engine can follow one of its branches through",
+				"Edit Setup → Engine Branch & Pin → Switch Remote. A remote can only be removed once",
+				"no engine, and not the default branch, follows it.",
+			}},
+		},
+	},
+	helpPatches: {
+		title: "Local Patches",
+		sections: []helpSection{
+			{"", []string{
+				"Patch files and a patches branch are applied on top of the upstream plugin on every",
+				"setup and update, for fixes your studio needs before they are merged upstream.",
+				"Each engine picks up a change here on its next Update Setup.",
+			}},
+		},
+	},
+	helpProjectTools: {
+		title: "Configure project",
+		sections: []helpSection{
+			{"", []string{
+				"Tools for a game project rather than an engine. Most ask for the project folder,",
+				"offering the projects registered with Manage Registered Projects.",
+			}},
+			{"Options", []string{
+				"Repair My Locks             release your LFS locks on files you have no changes to",
+				"Show Current Project Locks  who has which files locked",
+				"Run Project Setup Wizard    .gitignore, .gitattributes, LFS and editor settings",
+				"Open Project in Editor      start the editor the project is registered to",
+				"Source Control Smoke Test   check that the plugin connects in this project",
+				"Check Repository Health     case conflicts, LFS files not downloaded, mangled assets",
+				"Plugin as Project Submodule install the plugin in the project instead of the engine",
+				"Plugin Settings             the plugin's options saved in the project's config files",
+				"Status Branches             branches the plugin checks for changes by others",
+				"Project Plugin Overrides    copies of the plugin in projects that hide the engine's",
+			}},
+		},
+	},
+	helpProjects: {
+		title: "Registered Projects",
+		sections: []helpSection{
+			{"", []string{
+				"Registered projects are offered whenever a tool asks for a project, and are checked",
+				"for copies of the plugin that would hide the engine's. Removing a project only",
+				"forgets it; nothing in its folder changes.",
+			}},
+		},
+	},
+	helpPluginSettings: {
+		title: "Plugin Settings",
+		sections: []helpSection{
+			{"", []string{
+				"These are the Git plugin's and the editor's source control options, kept in the",
+				"project's ini files. Provider, LFS locking, LFS user name and git path are stored per",
+				"user in the project's Saved folder; the others are in Config and apply to everyone",
+				"who pulls the project. Reset removes the key so the default applies again.",
+			}},
+		},
+	},
+	helpProjectSubmodule: {
+		title: "Plugin as Project Submodule",
+		sections: []helpSection{
+			{"", []string{
+				"Instead of linking the plugin into the engine, the project can carry it as a git",
+				"submodule in Plugins\\UEGitPlugin_PB, so everyone who clones the project gets the same",
+				"plugin commit. Use this when engine installs must not be modified. Update to Latest",
+				"and Pin move the submodule; commit the change so others get it.",
+			}},
+		},
+	},
+}
+
+// showHelp prints the help page of a menu screen
+func showHelp(topic string) {
+	page, ok := helpPages[topic]
+	if !ok {
+		fmt.Println("There is no help for this screen yet.")
+		return
+	}
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("ℹ️  " + page.title))
+	for _, section := range page.sections {
+		fmt.Println()
+		if section.heading != "" {
+			fmt.Println(color.New(color.FgYellow, color.Bold).Sprint(section.heading))
+		}
+		for _, line := range section.lines {
+			fmt.Println(line)
+		}
+	}
+	fmt.Println()
+}
+
+// selectWithHelp runs a menu with its help page one keystroke away: the menu starts in search
+// mode, so typing ? and Enter shows the page for topic, after which the menu is shown again.
+// Typing other text filters the items.
+func selectWithHelp(prompt *promptui.Select, topic string) (int, string, error) {
+	items, _ := prompt.Items.([]string)
+	withHelp := append(append([]string{}, items...), helpItem)
+
+	menu := *prompt
+	menu.Label = fmt.Sprint(prompt.Label) + " (? for help)"
+	menu.Items = withHelp
+	menu.StartInSearchMode = true
+	if menu.Size >= len(items) {
+		menu.Size = len(withHelp)
+	}
+	searcher := prompt.Searcher
+	menu.Searcher = func(input string, index int) bool {
+		query := strings.ToLower(strings.TrimSpace(input))
+		if query == "?" {
+			return index == len(items)
+		}
+		if searcher != nil && index < len(items) {
+			return searcher(input, index)
+		}
+		return strings.Contains(strings.ToLower(withHelp[index]), query)
+	}
+
+	for {
+		index, choice, err := utils.RunSelect(&menu)
+		if err != nil || index != len(items) {
+			return index, choice, err
+		}
+		fmt.Println()
+		showHelp(topic)
+		utils.Pause()
+		fmt.Println()
+	}
+}
@@ -0,0 +1,166 @@
+package menu
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// legacyEntries are what releases before the per-user data directory kept next to the exe
+var legacyEntries = []string{"repo-origin", "worktrees", "config.json"}
+
+// MigrateLegacyLayout moves the repository, worktrees and configuration an older release kept
+// next to the exe into the data directory, repairs the worktrees' links to the repository and
+// re-points engine plugin links that still lead to the old worktrees. Nothing is moved when the
+// data directory already has a repository of its own; the old folder is then reported and left.
+func MigrateLegacyLayout(app Application) error {
+	legacyDir := app.GetConfig().GetExeDir()
+	baseDir := app.GetConfig().GetBaseDir()
+	if legacyDir == "" || utils.SamePath(legacyDir, baseDir) || !pathExists(filepath.Join(legacyDir, "repo-origin", ".git")) {
+		return nil
+	}
+	if pathExists(filepath.Join(baseDir, "repo-origin", ".git")) {
+		events.Warn("%s has a plugin repository from an older version, but %s has one already; the old one is left as it is", legacyDir, baseDir)
+		return nil
+	}
+
+	events.Info("📦 Moving data from an older version of this tool from %s to %s", legacyDir, baseDir)
+	var moved []string
+	for _, name := range legacyEntries {
+		src, dst := filepath.Join(legacyDir, name), filepath.Join(baseDir, name)
+		if !pathExists(src) || pathExists(dst) {
+			continue
+		}
+		if err := moveLegacyEntry(src, dst); err != nil {
+			// Put back what was moved, so the layout isn't left split between both folders
+			for _, done := range moved {
+				moveLegacyEntry(filepath.Join(baseDir, done), filepath.Join(legacyDir, done))
+			}
+			return fmt.Errorf("failed to move %s (close the Unreal editor and try again): %v", src, err)
+		}
+		moved = append(moved, name)
+	}
+
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+	// An old configuration may record the repository and worktrees at their old absolute paths
+	defaults := app.GetConfig().CreateDefault()
+	cfg.BaseDir, cfg.OriginDir, cfg.WorktreesDir = defaults.BaseDir, defaults.OriginDir, defaults.WorktreesDir
+	if err := app.GetConfig().Save(cfg); err != nil {
+		events.Warn("Failed to save configuration: %v", err)
+	}
+	plugin.SetLinkNames(cfg.LinkNames())
+
+	worktreesDir := filepath.Join(baseDir, "worktrees")
+	subdirs, _ := os.ReadDir(worktreesDir)
+	for _, entry := range subdirs {
+		if !entry.IsDir() {
+			continue
+		}
+		if err := app.GetGit().RepairWorktree(entry.Name()); err != nil {
+			events.Warn("%s: %v", entry.Name(), err)
+		}
+	}
+
+	// Links are found on every engine, not only the configured ones, in case the old
+	// configuration was lost
+	oldWorktrees := filepath.Join(legacyDir, "worktrees")
+	relinked, failed := 0, 0
+	for _, enginePath := range legacyLinkEngines(app, cfg) {
+		link := app.GetPlugin().InspectLink(app.GetPlugin().GetPluginLinkPath(enginePath))
+		rel, err := filepath.Rel(oldWorktrees, link.Target)
+		if !(link.IsLink || link.IsCopy) || err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		worktreePath := filepath.Join(worktreesDir, rel)
+		if err := app.GetPlugin().CreateJunction(enginePath, worktreePath); err != nil {
+			events.Warn("Failed to re-point the plugin link of %s: %v", enginePath, err)
+			failed++
+			continue
+		}
+		events.Info("🔗 %s → %s", enginePath, worktreePath)
+		relinked++
+	}
+
+	events.Info("✅ Moved %s and re-pointed %d plugin link(s)", strings.Join(moved, ", "), relinked)
+	if failed > 0 {
+		return fmt.Errorf("%d plugin link(s) could not be re-pointed; run \"Re-point Plugin Links\" in Settings as administrator", failed)
+	}
+	return nil
+}
+
+// legacyLinkEngines returns the configured engines followed by the discovered ones
+func legacyLinkEngines(app Application, cfg *config.Config) []string {
+	var paths []string
+	for _, eng := range cfg.Engines {
+		paths = append(paths, eng.EnginePath)
+	}
+	engines, _ := app.GetEngine().DiscoverEngines(cfg.CustomEngineRoots)
+	for _, eng := range engines {
+		known := false
+		for _, path := range paths {
+			known = known || utils.SamePath(path, eng.Path)
+		}
+		if !known {
+			paths = append(paths, eng.Path)
+		}
+	}
+	return paths
+}
+
+// moveLegacyEntry renames a file or folder, copying it when the exe is on another drive than
+// the data directory
+func moveLegacyEntry(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || strings.EqualFold(filepath.VolumeName(src), filepath.VolumeName(dst)) {
+		return err
+	}
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+// copyTree copies a file or a folder with everything in it, keeping file modes
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
+
+// pathExists reports whether anything is at path
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,105 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// runPluginLinkName lets an engine's plugin link be named GitSourceControl, or any other folder
+// name, instead of UEGitPlugin_PB, and moves the link to the new name
+func runPluginLinkName(app Application, cfg *config.Config, status detection.SetupStatus) error {
+	eng := app.GetConfig().GetEngineByPath(cfg, status.EnginePath)
+	if eng == nil {
+		return fmt.Errorf("UE %s is not managed by this tool", status.EngineVersion)
+	}
+	current := plugin.LinkName(eng.EnginePath)
+	fmt.Printf("\nThe plugin is linked as %s\n", app.GetPlugin().GetPluginLinkPath(eng.EnginePath))
+	fmt.Println("The folder name doesn't change the plugin the editor loads; pick GitSourceControl")
+	fmt.Println("when your team's docs or tools expect the plugin in a folder of that name.")
+	fmt.Println()
+
+	const (
+		defaultItem = plugin.DefaultLinkName + " (default)"
+		stockItem   = plugin.StockLinkName
+		customItem  = "Other name..."
+	)
+	prompt := promptui.Select{
+		Label:    "Plugin folder name",
+		Items:    []string{defaultItem, stockItem, customItem, "Back"},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	_, choice, err := utils.RunSelect(&prompt)
+	if err != nil {
+		if err == promptui.ErrInterrupt {
+			return nil
+		}
+		return err
+	}
+
+	var name string
+	switch choice {
+	case defaultItem:
+		name = plugin.DefaultLinkName
+	case stockItem:
+		name = plugin.StockLinkName
+	case customItem:
+		fmt.Print("Enter folder name: ")
+		if name = utils.ReadLine(); name == "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if strings.EqualFold(name, current) {
+		fmt.Printf("✅ The plugin is already linked as %s\n", current)
+		return nil
+	}
+	return renamePluginLink(app, cfg, eng, name)
+}
+
+// renamePluginLink moves an engine's plugin link to a new folder name. The old link is only
+// removed once the new one exists, and the old name is kept if anything fails.
+func renamePluginLink(app Application, cfg *config.Config, eng *config.Engine, name string) error {
+	if err := app.GetPlugin().CheckLinkName(eng.EnginePath, name); err != nil {
+		return err
+	}
+	if running, err := app.GetEngine().RunningEditors(); err == nil && len(running) > 0 {
+		return fmt.Errorf("close the Unreal editor first (%s)", strings.Join(running, ", "))
+	}
+
+	oldName := plugin.LinkName(eng.EnginePath)
+	oldPath := app.GetPlugin().GetPluginLinkPath(eng.EnginePath)
+	if !utils.ConfirmStep(fmt.Sprintf("Link the plugin of UE %s as %s instead of %s?", eng.EngineVersion, name, oldName)) {
+		return nil
+	}
+
+	worktreePath := app.GetGit().GetWorktreePath(engineSubdir(*eng))
+	plugin.SetLinkName(eng.EnginePath, name)
+	if err := app.GetPlugin().CreateJunction(eng.EnginePath, worktreePath); err != nil {
+		plugin.SetLinkName(eng.EnginePath, oldName)
+		return fmt.Errorf("failed to link the plugin as %s: %v", name, err)
+	}
+	if err := app.GetPlugin().RemoveJunction(oldPath); err != nil {
+		fmt.Printf("⚠️  Failed to remove the old link %s: %v\n", oldPath, err)
+		fmt.Println("   Remove it by hand; the editor would find the plugin twice.")
+	}
+
+	eng.PluginLinkName = name
+	if name == plugin.DefaultLinkName {
+		eng.PluginLinkName = ""
+	}
+	eng.PluginLinkPath = app.GetPlugin().GetPluginLinkPath(eng.EnginePath)
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+	}
+	fmt.Printf("✅ UE %s now links the plugin as %s\n", eng.EngineVersion, eng.PluginLinkPath)
+	return nil
+}
@@ -0,0 +1,58 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// buildPlugin builds the plugin for an engine. When its binaries are held open by a running
+// process, it names the process and offers to retry once it was closed, instead of failing
+// with a half-copied Binaries folder.
+func buildPlugin(app Application, enginePath, worktreePath string) error {
+	return retryLockedBuild(app, enginePath, worktreePath, app.GetPlugin().BuildForEngine(enginePath, worktreePath))
+}
+
+// retryLockedBuild takes the error of a build and, while it is a *plugin.LockedError, offers to
+// retry once the locking programs were closed. Other errors and nil are returned as they are.
+func retryLockedBuild(app Application, enginePath, worktreePath string, err error) error {
+	for {
+		var locked *plugin.LockedError
+		if !errors.As(err, &locked) {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Println("🔒 The plugin binaries can't be replaced while they are in use:")
+		for _, process := range locked.Processes() {
+			fmt.Printf("   - %s\n", process)
+		}
+		fmt.Println("   Close these programs (save your work in the editor first), then retry.")
+		if !askRetryAfterClose() {
+			return err
+		}
+
+		// A finished build only needs its output copied again
+		if locked.Built {
+			err = app.GetPlugin().InstallBuiltBinaries(enginePath, worktreePath)
+		} else {
+			err = app.GetPlugin().BuildForEngine(enginePath, worktreePath)
+		}
+	}
+}
+
+// askRetryAfterClose asks whether to retry after the locking programs were closed
+func askRetryAfterClose() bool {
+	prompt := promptui.Select{
+		Label:    "Retry",
+		Items:    []string{"Retry (I closed them)", "Cancel"},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	index, _, err := utils.RunSelect(&prompt)
+	return err == nil && index == 0
+}
@@ -0,0 +1,206 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/manifest"
+	"ue-git-plugin-manager/internal/policy"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// exportMachineManifest writes the engines, plugin commits and settings of this machine to a
+// file that the apply command reproduces on another machine
+func exportMachineManifest(app Application, cfg *config.Config) {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	settings, credentialsDropped := manifest.SettingsFrom(cfg)
+	m := manifest.Manifest{
+		Version:     manifest.CurrentVersion,
+		Machine:     host,
+		CreatedUTC:  time.Now().UTC().Format(time.RFC3339),
+		Settings:    settings,
+		Engines:     []manifest.Engine{},
+		URLsDropped: credentialsDropped,
+	}
+
+	for _, eng := range cfg.Engines {
+		if !app.GetGit().WorktreeExists(eng.WorktreeSubdir) {
+			fmt.Printf("⚠️  UE %s is not set up and is left out\n", eng.EngineVersion)
+			continue
+		}
+		updateInfo, err := app.GetGit().GetUpdateInfo(eng.WorktreeSubdir, cfg.TrackedBranch(eng.WorktreeSubdir), cfg.TrackedPin(eng.WorktreeSubdir))
+		if err != nil {
+			fmt.Printf("⚠️  UE %s is left out: %v\n", eng.EngineVersion, err)
+			continue
+		}
+		m.Engines = append(m.Engines, manifest.Engine{
+			Version:        eng.EngineVersion,
+			Path:           eng.EnginePath,
+			UpstreamBranch: eng.UpstreamBranch,
+			CommitSHA:      updateInfo.BaseSHA,
+			LocalCommits:   updateInfo.LocalCommits,
+		})
+		if updateInfo.LocalCommits > 0 {
+			fmt.Printf("⚠️  UE %s has %d local commit(s) on %s; the manifest records the upstream commit only\n", eng.EngineVersion, updateInfo.LocalCommits, eng.Branch)
+		}
+	}
+	if credentialsDropped {
+		fmt.Println("⚠️  URLs containing a password are left out; set the mirror or remote on the other machine")
+	}
+
+	path, err := utils.PathPrompt{
+		Label:       "Save manifest to: ",
+		HistoryFile: app.GetConfig().GetHistoryFile("manifest_paths"),
+		Default:     filepath.Join(app.GetConfig().GetUserFilesDir(), manifest.DefaultFileName),
+	}.Run()
+	if err != nil {
+		return
+	}
+	if err := manifest.WriteFile(path, m); err != nil {
+		fmt.Printf("❌ Failed to write manifest: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Manifest with %d engine(s) written to %s\n", len(m.Engines), path)
+	fmt.Printf("   Reproduce this setup on another machine with: %s apply \"%s\"\n", filepath.Base(os.Args[0]), path)
+}
+
+// ApplyManifest reproduces the setup described by a machine manifest: it copies the settings,
+// then sets up every engine at the plugin commit the manifest records
+func ApplyManifest(app Application, path string) error {
+	m, err := manifest.Load(path)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+
+	events.Printf("📋 Applying manifest from %s (%s)\n", m.Machine, formatLockTimestamp(m.CreatedUTC))
+	for _, file := range m.Settings.ApplyTo(cfg, app.GetConfig().GetBaseDir()) {
+		fmt.Printf("⚠️  Patch file %s doesn't exist on this machine and is left out; copy it over and add it in Settings → \"Local Patches\"\n", file)
+	}
+	// A manifest that can't be applied in full leaves the configuration as it was
+	if len(m.Engines) > 0 {
+		if err := policy.CheckEngineChanges(cfg); err != nil {
+			return err
+		}
+	}
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	events.Println("✅ Settings applied")
+
+	// Commits recorded on the other machine may be newer than this machine's last fetch
+	if app.GetGit().IsOriginCloned() {
+		events.Println("Fetching plugin updates...")
+		if err := withGitRecovery(app, cfg, "", func() error { return app.GetGit().FetchAll(cfg.MirrorURL) }); err != nil {
+			return fmt.Errorf("failed to fetch updates: %w", err)
+		}
+	}
+
+	installed, err := app.GetEngine().DiscoverEngines(cfg.CustomEngineRoots)
+	if err != nil {
+		return fmt.Errorf("failed to discover engines: %v", err)
+	}
+
+	failed := 0
+	for _, want := range m.Engines {
+		events.Println()
+		local, ok := matchManifestEngine(installed, want)
+		if !ok {
+			fmt.Printf("❌ UE %s is not installed on this machine (it was at %s)\n", want.Version, want.Path)
+			failed++
+			continue
+		}
+		if err := applyManifestEngine(app, cfg, m, want, local); err != nil {
+			fmt.Printf("❌ UE %s: %v\n", want.Version, err)
+			failed++
+		}
+	}
+
+	events.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d engine(s) could not be set up", failed, len(m.Engines))
+	}
+	events.Printf("✅ All %d engine(s) match the manifest\n", len(m.Engines))
+	return nil
+}
+
+// applyManifestEngine pins one engine to the manifest's commit and sets it up unless it already matches
+func applyManifestEngine(app Application, cfg *config.Config, m manifest.Manifest, want manifest.Engine, local engine.EngineInfo) error {
+	subdir := config.WorktreeSubdirFor(cfg, local.Path, local.Version)
+
+	// Engines on the shared pin follow it; any other commit becomes a pin of their own
+	pin := want.CommitSHA
+	if want.UpstreamBranch == "" && strings.EqualFold(pin, m.Settings.PinnedCommitSHA) {
+		pin = ""
+	}
+	if eng := app.GetConfig().GetEngineByPath(cfg, local.Path); eng != nil {
+		eng.UpstreamBranch = want.UpstreamBranch
+		eng.PinnedCommitSHA = pin
+	} else {
+		app.GetConfig().UpsertEngine(cfg, config.Engine{
+			EnginePath:      local.Path,
+			EngineVersion:   local.Version,
+			WorktreeSubdir:  subdir,
+			Branch:          git.EngineBranch(subdir),
+			UpstreamBranch:  want.UpstreamBranch,
+			PinnedCommitSHA: pin,
+			PluginLinkPath:  app.GetPlugin().GetPluginLinkPath(local.Path),
+		})
+	}
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+
+	status := app.GetDetection().DetectEngineSetupStatus(local.Path, local.Version, subdir)
+	if status.IsSetupComplete {
+		updateInfo, err := app.GetGit().GetUpdateInfo(subdir, cfg.TrackedBranch(subdir), cfg.TrackedPin(subdir))
+		if err == nil && updateInfo.BaseSHA == want.CommitSHA && !localPatchesOutdated(app, cfg, subdir) {
+			fmt.Printf("✅ UE %s already at %s\n", local.Version, git.ShortSHA(want.CommitSHA))
+			return nil
+		}
+	}
+	if status.WorktreeExists {
+		if changes, _ := app.GetGit().LocalChanges(subdir); len(changes) > 0 {
+			return fmt.Errorf("the worktree %s has local changes; commit or discard them and apply again", subdir)
+		}
+	}
+
+	if err := SetupEngine(app, cfg, local.Path, local.Version, subdir); err != nil {
+		return err
+	}
+	if pin != "" {
+		fmt.Printf("   Pinned to %s; remove the pin under \"Edit Setup\" → \"Engine Branch & Pin\" to follow updates\n", git.ShortSHA(pin))
+	}
+	if want.LocalCommits > 0 {
+		fmt.Printf("   %d local commit(s) from %s are not part of the manifest\n", want.LocalCommits, m.Machine)
+	}
+	return nil
+}
+
+// matchManifestEngine finds the installed engine for a manifest entry, preferring the same path
+func matchManifestEngine(installed []engine.EngineInfo, want manifest.Engine) (engine.EngineInfo, bool) {
+	for _, eng := range installed {
+		if utils.SamePath(eng.Path, want.Path) {
+			return eng, true
+		}
+	}
+	for _, eng := range installed {
+		if eng.Version == want.Version {
+			return eng, true
+		}
+	}
+	return engine.EngineInfo{}, false
+}
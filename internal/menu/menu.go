@@ -1,21 +1,31 @@
 package menu
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/console"
 	"ue-git-plugin-manager/internal/detection"
 	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/explorer"
 	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/journal"
 	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/policy"
 	"ue-git-plugin-manager/internal/projectconfig"
 	"ue-git-plugin-manager/internal/projectlocks"
+	"ue-git-plugin-manager/internal/projects"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/telemetry"
+	"ue-git-plugin-manager/internal/theme"
+	"ue-git-plugin-manager/internal/timing"
 	"ue-git-plugin-manager/internal/utils"
 
 	"github.com/fatih/color"
@@ -32,21 +42,104 @@ type Application interface {
 	GetDetection() *detection.Detector
 }
 
+// readOnly hides every action that changes the machine, leaving status and diagnostics
+var readOnly bool
+
+// SetReadOnly turns the viewer mode on or off
+func SetReadOnly(enabled bool) {
+	readOnly = enabled
+}
+
+// IsReadOnly reports whether the viewer mode is active
+func IsReadOnly() bool {
+	return readOnly
+}
+
 // Run starts the main menu system
 func Run(app Application) error {
+	offeredMinGit := false
+	offeredRelink := false
+	offeredOverrides := false
+	offeredHotfixRebuild := false
 	for {
 		config, err := app.GetConfig().Load()
+		newInstall := false
 		if err != nil {
 			// If no config exists, create a default one
 			if !app.GetConfig().Exists() {
 				config = app.GetConfig().CreateDefault()
-				if err := app.GetConfig().Save(config); err != nil {
-					return fmt.Errorf("failed to create default config: %v", err)
+				newInstall = true
+				if !readOnly {
+					if err := app.GetConfig().Save(config); err != nil {
+						return fmt.Errorf("failed to create default config: %v", err)
+					}
 				}
 			} else {
 				return fmt.Errorf("failed to load config: %v", err)
 			}
 		}
+		if config.ViewerMode {
+			readOnly = true
+		}
+		theme.Apply(config.ColorTheme, config.TextStatusSymbols)
+		if config.VerboseOutput {
+			events.SetVerbose(true)
+		}
+		utils.SetConfirmPolicy(config.Confirmations)
+		utils.SetPromptDefaults(config.PromptDefaults)
+		if config.PlainPrompts {
+			utils.SetPlainPrompts(true)
+		}
+		plugin.SetLinkNames(config.LinkNames())
+		runner.SetTimeout(runner.KindGit, config.GitTimeout())
+		runner.SetTimeout(runner.KindBuild, config.BuildTimeout())
+
+		if readOnly {
+			quit, err := runViewerMenu(app, config)
+			if err != nil || quit {
+				return err
+			}
+			continue
+		}
+
+		// Ask once per session; the setup flow asks again before it needs git
+		if !offeredMinGit && !app.GetGit().IsGitAvailable() {
+			offeredMinGit = true
+			offerMinGit(app)
+			app.GetUtils().ClearScreen()
+		}
+
+		// A new hire can start from a teammate's settings instead of entering them one by one
+		if newInstall && utils.ConfirmStep("Import settings from a teammate's machine?") {
+			importTeammateSettings(app, config)
+			utils.Pause()
+			app.GetUtils().ClearScreen()
+			continue
+		}
+
+		// A drive letter change leaves the engine "not set up"; offer the re-link once per session
+		if !offeredRelink {
+			offeredRelink = true
+			if offerRelinkMovedEngines(app, config) {
+				app.GetUtils().ClearScreen()
+			}
+		}
+
+		// Binaries built against an engine's previous patch may not load; ask once per session
+		if !offeredHotfixRebuild {
+			offeredHotfixRebuild = true
+			if offerHotfixRebuild(app, config) {
+				app.GetUtils().ClearScreen()
+			}
+		}
+
+		// A project's own copy of the plugin hides the engine's from it; ask once per session
+		if !offeredOverrides {
+			offeredOverrides = true
+			if offerResolveProjectOverrides(app, config) {
+				app.GetUtils().ClearScreen()
+			}
+		}
 
 		choice, err := showMainMenu(app, config)
 		if err != nil {
@@ -56,16 +149,53 @@ func Run(app Application) error {
 			return err
 		}
 
+		// A background detection must finish before anything else inspects or changes the setup
+		switch choice {
+		case "Quit":
+		case "Setup Status":
+			settleStatusRefresh(false)
+		default:
+			settleStatusRefresh(true)
+		}
+
 		switch choice {
-		case "What is this?":
+		case "Resume Interrupted Setup":
 			app.GetUtils().ClearScreen()
-			ShowWhatIsThis()
-			utils.Pause()
+			if err := runResumeSetups(app, config); err != nil {
+				fmt.Printf("Error resuming setup: %v\n", err)
+				printIssueCode(config, err)
+				utils.Pause()
+			}
+			app.GetUtils().ClearScreen()
+		case "Re-link Moved Engines":
+			app.GetUtils().ClearScreen()
+			if err := runRelinkMovedEngines(app, config); err != nil {
+				fmt.Printf("Error re-linking engines: %v\n", err)
+				printIssueCode(config, err)
+				utils.Pause()
+			}
+			app.GetUtils().ClearScreen()
+		case "Setup Status":
+			app.GetUtils().ClearScreen()
+			if err := runDetailedSetupStatus(app, config); err != nil {
+				fmt.Printf("Error checking status: %v\n", err)
+				printIssueCode(config, err)
+				utils.Pause()
+			}
+			app.GetUtils().ClearScreen()
+		case "Update All Engines":
+			app.GetUtils().ClearScreen()
+			if err := runUpdate(app, config); err != nil {
+				fmt.Printf("Error updating engines: %v\n", err)
+				printIssueCode(config, err)
+				utils.Pause()
+			}
 			app.GetUtils().ClearScreen()
 		case "Edit Setup":
 			app.GetUtils().ClearScreen()
 			if err := runEditSetup(app, config); err != nil {
 				fmt.Printf("Error in edit setup: %v\n", err)
+				printIssueCode(config, err)
 				utils.Pause()
 			}
 			app.GetUtils().ClearScreen()
@@ -73,6 +203,7 @@ func Run(app Application) error {
 			app.GetUtils().ClearScreen()
 			if err := runProjectToolsMenu(app); err != nil {
 				fmt.Printf("Error configuring project: %v\n", err)
+				printIssueCode(config, err)
 				utils.Pause()
 			}
 			app.GetUtils().ClearScreen()
@@ -80,6 +211,7 @@ func Run(app Application) error {
 			app.GetUtils().ClearScreen()
 			if err := runSettings(app, config); err != nil {
 				fmt.Printf("Error in settings: %v\n", err)
+				printIssueCode(config, err)
 				utils.Pause()
 			}
 			app.GetUtils().ClearScreen()
@@ -89,6 +221,60 @@ func Run(app Application) error {
 	}
 }
 
+// runViewerMenu shows the read-only main menu and reports whether the user chose to quit
+func runViewerMenu(app Application, config *config.Config) (bool, error) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🎮 UE Git Plugin Manager - Viewer Mode"))
+	fmt.Println("Read-only: nothing on this machine will be changed.")
+	fmt.Println()
+
+	summary, err := mainMenuSummary(app, config)
+	if err != nil {
+		fmt.Printf("Warning: Could not detect setup status: %v\n", err)
+		fmt.Println()
+	} else {
+		fmt.Println(summary)
+	}
+
+	prompt := promptui.Select{
+		Label:    "Select an option",
+		Items:    []string{"Detailed Setup Status", "Diagnostics", "Engine Plugin Audit", "Show Step Timings", "Status History", "Quit"},
+		Size:     10,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	_, choice, err := selectWithHelp(&prompt, helpViewer)
+	if err != nil {
+		if err == promptui.ErrInterrupt {
+			return true, nil
+		}
+		return true, err
+	}
+	if choice != "Quit" {
+		settleStatusRefresh(false)
+	}
+
+	app.GetUtils().ClearScreen()
+	switch choice {
+	case "Detailed Setup Status":
+		if err := runDetailedSetupStatus(app, config); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			utils.Pause()
+		}
+	case "Diagnostics":
+		runDiagnostics(app, config)
+	case "Engine Plugin Audit":
+		runEnginePluginAudit(app, config)
+	case "Show Step Timings":
+		showStepTimings(app)
+	case "Status History":
+		runStatusHistory(app)
+	case "Quit":
+		return true, nil
+	}
+	app.GetUtils().ClearScreen()
+	return false, nil
+}
+
 // showMainMenu displays the main menu
 func showMainMenu(app Application, config *config.Config) (string, error) {
 	// Show status of managed engines
@@ -96,7 +282,7 @@ func showMainMenu(app Application, config *config.Config) (string, error) {
 	fmt.Println()
 
 	// Use detection system to show current status
-	summary, err := app.GetDetection().GetSimpleSetupSummary(config.CustomEngineRoots, config.DefaultRemoteBranch, config.PinnedCommitSHA)
+	summary, err := mainMenuSummary(app, config)
 	if err != nil {
 		fmt.Printf("Warning: Could not detect setup status: %v\n", err)
 		fmt.Println()
@@ -104,24 +290,197 @@ func showMainMenu(app Application, config *config.Config) (string, error) {
 		fmt.Println(summary)
 	}
 
-	items := []string{
-		"What is this?",
-		"Edit Setup",
-		"Configure project",
-		"Settings",
-		"Quit",
+	// Engine-level actions are hidden when policy restricts the tool to projects
+	if policy.ProjectLevelOnly(config) {
+		fmt.Println("🔒 Policy: engine installs must not be modified. Install the plugin per project")
+		fmt.Println("   with \"Configure project\" → \"Plugin as Project Submodule\".")
+		fmt.Println()
+		return quickSelect("Select an option", helpMain, []menuAction{
+			{Key: "s", Label: "Setup Status"},
+			{Key: "p", Label: "Configure project"},
+			{Key: "c", Label: "Settings"},
+			{Key: "q", Label: "Quit"},
+		})
 	}
 
-	prompt := promptui.Select{
-		Label:    "Select an option",
-		Items:    items,
-		Size:     10,
-		HideHelp: true,
-		Stdout:   &utils.BellSkipper{},
+	actions := []menuAction{
+		{Key: "s", Label: "Setup Status"},
+		{Key: "u", Label: "Update All Engines"},
+		{Key: "e", Label: "Edit Setup"},
+		{Key: "p", Label: "Configure project"},
+		{Key: "c", Label: "Settings"},
+		{Key: "q", Label: "Quit"},
+	}
+	if moved, err := app.GetDetection().FindMovedEngines(config); err == nil && len(moved) > 0 {
+		actions = append([]menuAction{{Key: "r", Label: "Re-link Moved Engines"}}, actions...)
+	}
+	if len(interruptedSetups(app, config)) > 0 {
+		actions = append([]menuAction{{Key: "i", Label: "Resume Interrupted Setup"}}, actions...)
 	}
 
-	_, result, err := prompt.Run()
-	return result, err
+	return quickSelect("Select an option", helpMain, actions)
+}
+
+// runRelinkMovedEngines finds managed engines whose folder is gone and re-links their existing
+// worktree to the engine's new location
+func runRelinkMovedEngines(app Application, config *config.Config) error {
+	if err := policy.CheckEngineChanges(config); err != nil {
+		return err
+	}
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🔗 Re-link Moved Engines"))
+	fmt.Println()
+
+	moved, err := app.GetDetection().FindMovedEngines(config)
+	if err != nil {
+		return err
+	}
+	if len(moved) == 0 {
+		fmt.Println("✅ All managed engine folders exist.")
+		utils.Pause()
+		return nil
+	}
+
+	for _, entry := range moved {
+		fmt.Printf("UE %s was managed at %s, which no longer exists.\n", entry.Engine.EngineVersion, entry.Engine.EnginePath)
+
+		const (
+			manualItem = "Enter the new location manually"
+			forgetItem = "Forget this engine"
+			skipItem   = "Skip (e.g. the drive is not connected)"
+		)
+		var items []string
+		for _, candidate := range entry.Candidates {
+			items = append(items, candidate.Path)
+		}
+		items = append(items, manualItem, forgetItem, skipItem)
+
+		prompt := promptui.Select{
+			Label:    "Where is this engine now?",
+			Items:    items,
+			Size:     10,
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		index, choice, err := utils.RunSelect(&prompt)
+		if err != nil {
+			if err == promptui.ErrInterrupt {
+				return nil
+			}
+			return err
+		}
+
+		switch {
+		case index < len(entry.Candidates):
+			relinkEngine(app, config, entry.Engine, entry.Candidates[index].Path)
+		case choice == manualItem:
+			pathPrompt := utils.PathPrompt{
+				Label:       "Enter the engine folder: ",
+				HistoryFile: app.GetConfig().GetHistoryFile("engine_paths"),
+				Validate: func(path string) error {
+					if _, ok := app.GetEngine().InspectEngine(path); !ok {
+						return fmt.Errorf("not an Unreal Engine folder (no Engine\\Binaries\\Win64\\UnrealEditor.exe): %s", path)
+					}
+					return nil
+				},
+			}
+			path, err := pathPrompt.Run()
+			if err != nil {
+				continue
+			}
+			eng, _ := app.GetEngine().InspectEngine(path)
+			relinkEngine(app, config, entry.Engine, eng.Path)
+		case choice == forgetItem:
+			app.GetConfig().RemoveEngine(config, entry.Engine.EnginePath)
+			if err := app.GetConfig().Save(config); err != nil {
+				fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+			}
+			fmt.Println("✅ Engine removed from configuration. Its worktree is kept.")
+		}
+		fmt.Println()
+	}
+
+	utils.Pause()
+	return nil
+}
+
+// offerRelinkMovedEngines asks to re-link managed engines found at a new location by their install
+// ID, and reports whether it asked
+func offerRelinkMovedEngines(app Application, cfg *config.Config) bool {
+	if policy.CheckEngineChanges(cfg) != nil {
+		return false
+	}
+	moved, err := app.GetDetection().FindMovedEngines(cfg)
+	if err != nil {
+		return false
+	}
+
+	asked := false
+	for _, entry := range moved {
+		if !entry.SameInstall {
+			continue
+		}
+		asked = true
+		newPath := entry.Candidates[0].Path
+		fmt.Printf("🔗 UE %s moved from %s to %s.\n", entry.Engine.EngineVersion, entry.Engine.EnginePath, newPath)
+		if utils.ConfirmStep("Re-link it now?") {
+			relinkEngine(app, cfg, entry.Engine, newPath)
+		}
+		fmt.Println()
+	}
+	if asked {
+		utils.Pause()
+	}
+	return asked
+}
+
+// relinkEngine points an existing worktree at an engine's new folder and updates the configuration
+func relinkEngine(app Application, cfg *config.Config, old config.Engine, newPath string) {
+	subdir := engineSubdir(old)
+	worktreePath := app.GetGit().GetWorktreePath(subdir)
+	fmt.Printf("Re-linking UE %s to %s...\n", old.EngineVersion, newPath)
+	plugin.SetLinkName(newPath, old.PluginLinkName)
+
+	if err := app.GetPlugin().CreateJunction(newPath, worktreePath); err != nil {
+		fmt.Printf("❌ Failed to create junction: %v\n", err)
+		return
+	}
+
+	stockDisabled := old.StockPluginDisabledByTool
+	if app.GetEngine().CheckPluginCollision(newPath) {
+		if err := app.GetEngine().DisableStockPlugin(newPath); err != nil {
+			fmt.Printf("❌ Failed to disable stock plugin: %v\n", err)
+			return
+		}
+		stockDisabled = true
+	}
+
+	relinked := old
+	relinked.EnginePath = newPath
+	relinked.PluginLinkPath = app.GetPlugin().GetPluginLinkPath(newPath)
+	relinked.StockPluginDisabledByTool = stockDisabled
+	relinked.BuildFingerprint = app.GetEngine().BuildFingerprint(newPath)
+	relinked.InstallID = app.GetEngine().InstallID(newPath)
+	app.GetConfig().RemoveEngine(cfg, old.EnginePath)
+	app.GetConfig().UpsertEngine(cfg, relinked)
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+	}
+
+	// A different build at the new location needs the plugin rebuilt
+	status := app.GetDetection().DetectEngineSetupStatus(newPath, old.EngineVersion, subdir)
+	if status.IsSetupComplete {
+		fmt.Printf("✅ UE %s re-linked to %s\n", old.EngineVersion, newPath)
+		return
+	}
+	fmt.Println("⚠️  The engine at the new location still needs attention:")
+	for _, issue := range status.Issues {
+		fmt.Printf("   - %s\n", issue)
+	}
+	if utils.ConfirmStep("Repair it now?") {
+		if err := runRepairForEngine(app, cfg, newPath, old.EngineVersion, subdir); err != nil {
+			fmt.Printf("❌ Repair failed: %v\n", err)
+		}
+	}
 }
 
 // runCheckSetupStatus shows detailed setup status
@@ -130,7 +489,7 @@ func runCheckSetupStatus(app Application, config *config.Config) error {
 	fmt.Println()
 
 	// Get detailed setup status
-	statuses, err := app.GetDetection().DetectSetupStatus(config.CustomEngineRoots)
+	statuses, err := app.GetDetection().DetectSetupStatus(config)
 	if err != nil {
 		return fmt.Errorf("failed to detect setup status: %v", err)
 	}
@@ -141,18 +500,20 @@ func runCheckSetupStatus(app Application, config *config.Config) error {
 		return nil
 	}
 
+	history, _ := app.GetDetection().StatusHistory()
+
 	// Show detailed status for each engine
 	for _, status := range statuses {
 		fmt.Printf("Engine %s (%s):\n", status.EngineVersion, status.EnginePath)
 
 		if status.IsSetupComplete {
-			fmt.Println(color.New(color.FgGreen).Sprint("  ✅ Setup Complete"))
+			fmt.Println("  " + theme.Status(theme.OK, "Setup Complete"))
 		} else if status.IsNeverSetUp {
-			fmt.Println(color.New(color.FgBlue).Sprint("  ℹ️  Not Set Up"))
+			fmt.Println("  " + theme.Status(theme.Info, "Not Set Up"))
 		} else if status.IsBroken {
-			fmt.Println(color.New(color.FgYellow).Sprint("  ⚠️  Setup Broken"))
+			fmt.Println("  " + theme.Status(theme.Warning, "Setup Broken"))
 		} else {
-			fmt.Println(color.New(color.FgRed).Sprint("  ❌ Setup Incomplete"))
+			fmt.Println("  " + theme.Status(theme.Failure, "Setup Incomplete"))
 		}
 
 		// Show individual status
@@ -171,13 +532,14 @@ func runCheckSetupStatus(app Application, config *config.Config) error {
 				fmt.Printf("    - %s\n", issue)
 			}
 		}
+		printBrokenSince(history, status)
 		fmt.Println()
 	}
 
 	// Show engines that need setup
-	needingSetup, err := app.GetDetection().FindEnginesNeedingSetup(config.CustomEngineRoots)
+	needingSetup, err := app.GetDetection().FindEnginesNeedingSetup(config)
 	if err == nil && len(needingSetup) > 0 {
-		fmt.Println(color.New(color.FgYellow).Sprint("⚠️  Engines needing setup:"))
+		fmt.Println(theme.Status(theme.Warning, "Engines needing setup:"))
 		for _, status := range needingSetup {
 			fmt.Printf("  - UE %s: %s\n", status.EngineVersion, strings.Join(status.Issues, ", "))
 		}
@@ -190,47 +552,162 @@ func runCheckSetupStatus(app Application, config *config.Config) error {
 
 // getStatusIcon returns an icon for a boolean status
 func getStatusIcon(status bool) string {
-	if status {
-		return "✅ Yes"
+	return theme.YesNo(status)
+}
+
+// engineSubdir returns the worktree subdirectory recorded for a managed engine
+func engineSubdir(eng config.Engine) string {
+	if eng.WorktreeSubdir != "" {
+		return eng.WorktreeSubdir
+	}
+	return config.DefaultWorktreeSubdir(eng.EngineVersion)
+}
+
+// recordManagedEngine stores an engine and its worktree subdir in the configuration
+func recordManagedEngine(app Application, cfg *config.Config, enginePath, engineVersion, worktreeSubdir string, stockDisabled bool) error {
+	configMgr := app.GetConfig()
+	eng := config.Engine{
+		EnginePath:                enginePath,
+		EngineVersion:             engineVersion,
+		WorktreeSubdir:            worktreeSubdir,
+		Branch:                    git.EngineBranch(worktreeSubdir),
+		PluginLinkPath:            app.GetPlugin().GetPluginLinkPath(enginePath),
+		StockPluginDisabledByTool: stockDisabled,
+		LastUpdatedUTC:            time.Now().UTC().Format(time.RFC3339),
+		BuildFingerprint:          app.GetEngine().BuildFingerprint(enginePath),
+	}
+	// Without an install ID a moved engine is still found by version and fingerprint
+	eng.InstallID = app.GetEngine().InstallID(enginePath)
+	if existing := configMgr.GetEngineByPath(cfg, enginePath); existing != nil {
+		eng.StockPluginDisabledByTool = eng.StockPluginDisabledByTool || existing.StockPluginDisabledByTool
+		eng.UpstreamBranch = existing.UpstreamBranch
+		eng.PinnedCommitSHA = existing.PinnedCommitSHA
+		eng.AlternateWorktreeSubdir = existing.AlternateWorktreeSubdir
+		eng.AlternateUpstreamBranch = existing.AlternateUpstreamBranch
+		eng.AlternatePinnedCommitSHA = existing.AlternatePinnedCommitSHA
+		eng.PluginLinkName = existing.PluginLinkName
+	}
+	configMgr.UpsertEngine(cfg, eng)
+	return configMgr.Save(cfg)
+}
+
+// recordTimings prints the step timings of an operation and adds them to this machine's aggregates.
+// The recorder is reset, so a deferred call after an explicit one does nothing.
+func recordTimings(app Application, rec *timing.Recorder) {
+	if len(rec.Steps()) == 0 {
+		return
+	}
+	if !events.Quiet() {
+		rec.PrintSummary()
+	}
+
+	path := app.GetConfig().GetTimingsFile()
+	store, err := timing.Load(path)
+	if err != nil {
+		fmt.Printf("Warning: Failed to read step timings: %v\n", err)
+		store = timing.Store{}
+	}
+	store.Add(rec)
+	if err := timing.Save(path, store); err != nil {
+		fmt.Printf("Warning: Failed to save step timings: %v\n", err)
+	}
+	rec.Reset()
+}
+
+// cleanPluginCaches removes stale plugin Intermediate folders for an engine and reports what was deleted
+func cleanPluginCaches(app Application, cfg *config.Config, enginePath, engineVersion string) {
+	dirs := app.GetPlugin().PluginCacheDirs(enginePath, projectRootsForEngine(cfg, enginePath, engineVersion))
+	if len(dirs) == 0 {
+		return
+	}
+	events.Println("Clearing stale plugin caches...")
+	removed, err := app.GetPlugin().CleanPluginCaches(dirs)
+	for _, dir := range removed {
+		events.Printf("  🧹 Removed %s\n", dir)
+	}
+	if err != nil {
+		fmt.Printf("  ⚠️  Could not clear all plugin caches: %v\n", err)
+	}
+}
+
+// offerPluginCacheCleanup asks before clearing plugin caches after the active plugin was swapped
+func offerPluginCacheCleanup(app Application, cfg *config.Config, enginePath, engineVersion string) {
+	dirs := app.GetPlugin().PluginCacheDirs(enginePath, projectRootsForEngine(cfg, enginePath, engineVersion))
+	if len(dirs) == 0 {
+		return
 	}
-	return "❌ No"
+	fmt.Println("These plugin caches may make the editor load stale module manifests:")
+	for _, dir := range dirs {
+		fmt.Printf("  - %s\n", dir)
+	}
+	if utils.ConfirmStep("Clear them now?") {
+		cleanPluginCaches(app, cfg, enginePath, engineVersion)
+	}
+}
+
+// projectRootsForEngine returns the registered project folders associated with an engine
+func projectRootsForEngine(cfg *config.Config, enginePath, engineVersion string) []string {
+	var roots []string
+	for _, project := range cfg.Projects {
+		info, err := projects.Read(project.Path)
+		if err == nil && info.UsesEngine(enginePath, engineVersion) {
+			roots = append(roots, project.Path)
+		}
+	}
+	return roots
 }
 
 // GetStockPluginStatusIcon returns an icon for stock plugin status
 func GetStockPluginStatusIcon(status string) string {
 	switch status {
 	case "enabled":
-		return "❌ Enabled (conflict risk)"
+		return theme.Label(theme.Failure, "Enabled (conflict risk)")
 	case "disabled":
-		return "✅ Disabled (correct)"
+		return theme.Label(theme.OK, "Disabled (correct)")
 	case "not_found":
-		return "❌ Not found"
+		return theme.Label(theme.Failure, "Not found")
 	default:
-		return "❓ Unknown"
+		return theme.Label(theme.Unknown, "Unknown")
 	}
 }
 
 // runUpdate handles the update flow
 func runUpdate(app Application, config *config.Config) error {
+	if err := policy.CheckEngineChanges(config); err != nil {
+		return err
+	}
 	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🔄 Checking for updates..."))
 	fmt.Println()
 
 	// Fetch latest changes
-	if err := app.GetGit().FetchAll(); err != nil {
-		return fmt.Errorf("failed to fetch updates: %v", err)
+	syncTrackedRemotes(app, config, false)
+	err := withGitRecovery(app, config, "", func() error {
+		return app.GetGit().FetchAll(config.MirrorURL)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch updates: %w", err)
 	}
+	maybeMaintainRepository(app, config)
 
 	// Check each managed engine for updates
-	var updatesAvailable []git.UpdateInfo
+	type engineUpdate struct {
+		enginePath      string
+		engineVersion   string
+		info            git.UpdateInfo
+		patchesOutdated bool
+	}
+	var updatesAvailable []engineUpdate
 	for _, eng := range config.Engines {
-		updateInfo, err := app.GetGit().GetUpdateInfo(eng.EngineVersion, config.DefaultRemoteBranch, config.PinnedCommitSHA)
+		subdir := engineSubdir(eng)
+		updateInfo, err := app.GetGit().GetUpdateInfo(subdir, config.TrackedBranch(subdir), config.TrackedPin(subdir))
 		if err != nil {
 			fmt.Printf("❌ Failed to check updates for UE %s: %v\n", eng.EngineVersion, err)
 			continue
 		}
 
-		if updateInfo.CommitsAhead > 0 {
-			updatesAvailable = append(updatesAvailable, *updateInfo)
+		patchesOutdated := localPatchesOutdated(app, config, subdir)
+		if updateInfo.CommitsAhead > 0 || patchesOutdated {
+			updatesAvailable = append(updatesAvailable, engineUpdate{enginePath: eng.EnginePath, engineVersion: eng.EngineVersion, info: *updateInfo, patchesOutdated: patchesOutdated})
 		}
 	}
 
@@ -243,53 +720,79 @@ func runUpdate(app Application, config *config.Config) error {
 	// Show available updates
 	fmt.Printf("📦 %d engine(s) have updates available:\n\n", len(updatesAvailable))
 	for _, update := range updatesAvailable {
-		fmt.Printf("UE %s — %d commits available\n", update.EngineVersion, update.CommitsAhead)
-		fmt.Printf("Latest: %s  [Open in browser]\n", update.RemoteSHA[:8])
-		fmt.Printf("Compare: %s...%s  [Open diff]\n", update.LocalSHA[:8], update.RemoteSHA[:8])
+		fmt.Printf("UE %s — %d commits available\n", update.engineVersion, update.info.CommitsAhead)
+		if until, snoozed := config.UpdatesSnoozedUntil(update.info.WorktreeSubdir); snoozed {
+			fmt.Println(theme.Subdued(fmt.Sprintf("Snoozed until %s", until.Format("Mon Jan 2 15:04"))))
+		}
+		fmt.Printf("Latest: %s  [Open in browser]\n", git.ShortSHA(update.info.RemoteSHA))
+		fmt.Printf("Compare: %s...%s  [Open diff]\n", git.ShortSHA(update.info.BaseSHA), git.ShortSHA(update.info.RemoteSHA))
+		if update.patchesOutdated {
+			fmt.Println("Local patches changed and will be re-applied")
+		}
+		if update.info.LocalCommits > 0 {
+			fmt.Printf("Local commits on %s: %d (replayed on top)\n", git.EngineBranch(update.info.WorktreeSubdir), update.info.LocalCommits)
+		}
 		fmt.Println()
 	}
 
-	if !utils.Confirm("Would you like to update now?") {
-		return nil
+	var targets []snoozeTarget
+	for _, update := range updatesAvailable {
+		targets = append(targets, snoozeTarget{worktreeSubdir: update.info.WorktreeSubdir, engineVersion: update.engineVersion})
+	}
+	proceed, err := confirmUpdateOrSnooze(app, config, targets)
+	if err != nil || !proceed {
+		return err
 	}
 
-	// Perform updates
+	// Perform updates: the worktrees share one repository, so they are updated one at a time;
+	// the builds that follow run side by side
 	fmt.Println("🔄 Updating engines...")
+	rec := timing.NewRecorder()
+	defer recordTimings(app, rec)
+	var builds []*engineBuild
 	for _, update := range updatesAvailable {
-		fmt.Printf("Updating UE %s... ", update.EngineVersion)
-		if err := app.GetGit().UpdateWorktree(update.EngineVersion, config.DefaultRemoteBranch, config.PinnedCommitSHA); err != nil {
+		build := newEngineBuild(update.enginePath, update.engineVersion, update.info.WorktreeSubdir)
+		fmt.Printf("Updating UE %s... ", update.engineVersion)
+		err := withGitRecovery(app, config, update.info.WorktreeSubdir, func() error {
+			return build.rec.Time(timing.StepWorktree, func() error {
+				return app.GetGit().UpdateWorktree(update.info.WorktreeSubdir, config.TrackedBranch(update.info.WorktreeSubdir), config.TrackedPin(update.info.WorktreeSubdir), localPatches(app, config, update.info.WorktreeSubdir))
+			})
+		})
+		if err == nil {
+			fmt.Printf("✅ Done\n")
+			// Ensure stock plugin is disabled before rebuild
+			if app.GetEngine().CheckPluginCollision(update.enginePath) {
+				err = app.GetEngine().DisableStockPlugin(update.enginePath)
+			}
+		}
+		if err != nil {
+			rec.Merge(build.rec)
+			err = reportOutcome(app, config, telemetry.OpUpdate, update.engineVersion, build.rec.All(), err)
 			fmt.Printf("❌ Failed: %v\n", err)
+			printIssueCode(config, err)
 			continue
 		}
-		fmt.Printf("✅ Done\n")
+		builds = append(builds, build)
+	}
 
-		// Ensure stock plugin is disabled before rebuild
-		// Find engine path for this version
-		var enginePath string
-		for _, e := range config.Engines {
-			if e.EngineVersion == update.EngineVersion {
-				enginePath = e.EnginePath
-				break
-			}
-		}
-		if app.GetEngine().CheckPluginCollision(enginePath) {
-			if err := app.GetEngine().DisableStockPlugin(enginePath); err != nil {
-				fmt.Printf("❌ %v\n", err)
-				continue
-			}
+	// Rebuild binaries for the updated engines
+	buildEngines(app, config, builds, true)
+	for _, build := range builds {
+		rec.Merge(build.rec)
+		err := reportOutcome(app, config, telemetry.OpUpdate, build.engineVersion, build.rec.All(), build.err)
+		if err != nil {
+			fmt.Printf("❌ UE %s: %v\n", build.engineVersion, err)
+			printIssueCode(config, err)
+			continue
 		}
-
-		// Rebuild binaries for this engine
-		wt := app.GetGit().GetWorktreePath(update.EngineVersion)
-		fmt.Printf("Compiling plugin for UE %s... ", update.EngineVersion)
-		if err := app.GetPlugin().BuildForEngine(enginePath, wt); err != nil {
-			fmt.Printf("❌ %v\n", err)
-		} else {
-			fmt.Printf("✅\n")
+		fmt.Printf("✅ UE %s updated\n", build.engineVersion)
+		if err := recordManagedEngine(app, config, build.enginePath, build.engineVersion, build.worktreeSubdir, false); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
 		}
 	}
 
 	fmt.Println()
+	recordTimings(app, rec)
 	fmt.Println("🎉 Updates completed!")
 	utils.Pause()
 	return nil
@@ -297,13 +800,16 @@ func runUpdate(app Application, config *config.Config) error {
 
 // runUninstall handles the uninstall flow
 func runUninstall(app Application, config *config.Config) error {
+	if err := policy.CheckEngineChanges(config); err != nil {
+		return err
+	}
 	fmt.Println(color.New(color.FgRed, color.Bold).Sprint("🗑️  Uninstall UE Git Plugin Manager"))
 	fmt.Println()
 	fmt.Println("This will remove all plugin links and worktrees.")
 	fmt.Println("Your Unreal Engine installations will not be affected.")
 	fmt.Println()
 
-	if !utils.Confirm("Are you sure you want to uninstall?") {
+	if !utils.ConfirmDestructive("Are you sure you want to uninstall?") {
 		return nil
 	}
 
@@ -313,30 +819,38 @@ func runUninstall(app Application, config *config.Config) error {
 	for _, eng := range config.Engines {
 		fmt.Printf("Cleaning up UE %s...\n", eng.EngineVersion)
 
-		// Remove junction
-		fmt.Printf("  Removing junction... ")
-		pluginLinkPath := app.GetPlugin().GetPluginLinkPath(eng.EnginePath)
-		if err := app.GetPlugin().RemoveJunction(pluginLinkPath); err != nil {
-			fmt.Printf("❌ Failed: %v\n", err)
+		// A link into another user's setup stays, with the stock plugin setting it relies on,
+		// whether this user coexists with it or never decided
+		owner := app.GetDetection().LinkOwner(eng.EnginePath)
+		if owner != "" {
+			fmt.Printf("  Leaving %s's plugin link and the stock Git plugin as they are\n", owner)
 		} else {
-			fmt.Printf("✅ Done\n")
+			fmt.Printf("  Removing junction... ")
+			pluginLinkPath := app.GetPlugin().GetPluginLinkPath(eng.EnginePath)
+			if err := app.GetPlugin().RemoveJunction(pluginLinkPath); err != nil {
+				fmt.Printf("❌ Failed: %v\n", err)
+			} else {
+				fmt.Printf("✅ Done\n")
+			}
 		}
 
 		// Restore stock plugin if we disabled it
-		if eng.StockPluginDisabledByTool {
+		switch {
+		case owner != "":
+		case eng.StockPluginDisabledByTool:
 			fmt.Printf("  Restoring stock Git plugin... ")
 			if err := app.GetEngine().EnableStockPlugin(eng.EnginePath); err != nil {
 				fmt.Printf("❌ Failed: %v\n", err)
 			} else {
 				fmt.Printf("✅ Done\n")
 			}
-		} else {
+		default:
 			fmt.Printf("  Stock plugin was not disabled by tool, skipping restoration\n")
 		}
 
 		// Remove worktree
 		fmt.Printf("  Removing worktree... ")
-		if err := app.GetGit().RemoveWorktree(eng.EngineVersion); err != nil {
+		if err := app.GetGit().RemoveWorktree(engineSubdir(eng)); err != nil {
 			fmt.Printf("❌ Failed: %v\n", err)
 		} else {
 			fmt.Printf("✅ Done\n")
@@ -352,7 +866,7 @@ func runUninstall(app Application, config *config.Config) error {
 
 	// Remove configuration
 	configMgr := app.GetConfig()
-	configPath := filepath.Join(configMgr.GetExeDir(), "config.json")
+	configPath := filepath.Join(configMgr.GetBaseDir(), "config.json")
 	if err := os.Remove(configPath); err != nil {
 		fmt.Printf("Warning: Failed to remove configuration: %v\n", err)
 	}
@@ -415,6 +929,10 @@ func runAdvancedMenu(app Application, config *config.Config) error {
 			app.GetUtils().ClearScreen()
 			runDiagnostics(app, config)
 			app.GetUtils().ClearScreen()
+		case "Clean up unused worktrees":
+			app.GetUtils().ClearScreen()
+			runCleanup(app, config)
+			app.GetUtils().ClearScreen()
 		case "Open plugin repo in browser":
 			utils.OpenURL("https://github.com/ProjectBorealis/UEGitPlugin")
 		case "Back":
@@ -436,6 +954,7 @@ func showAdvancedMenu(app Application, config *config.Config) (string, error) {
 		"Rebuild plugin for engine",
 		"Repair broken setup",
 		"Diagnostics",
+		"Clean up unused worktrees",
 		"Open plugin repo in browser",
 		"Back",
 	}
@@ -446,7 +965,7 @@ func showAdvancedMenu(app Application, config *config.Config) (string, error) {
 		Size:  10,
 	}
 
-	_, result, err := prompt.Run()
+	_, result, err := selectWithHelp(&prompt, helpAdvanced)
 	return result, err
 }
 
@@ -456,7 +975,7 @@ func runDetailedSetupStatus(app Application, config *config.Config) error {
 	fmt.Println()
 
 	// Get detailed setup status
-	statuses, err := app.GetDetection().DetectSetupStatus(config.CustomEngineRoots)
+	statuses, err := app.GetDetection().DetectSetupStatus(config)
 	if err != nil {
 		return fmt.Errorf("failed to detect setup status: %v", err)
 	}
@@ -467,24 +986,26 @@ func runDetailedSetupStatus(app Application, config *config.Config) error {
 		return nil
 	}
 
+	history, _ := app.GetDetection().StatusHistory()
+
 	// Show detailed status for each engine with debugging info
 	for _, status := range statuses {
 		fmt.Printf("Engine %s (%s):\n", status.EngineVersion, status.EnginePath)
 
 		if status.IsSetupComplete {
-			fmt.Println(color.New(color.FgGreen).Sprint("  ✅ Setup Complete"))
+			fmt.Println("  " + theme.Status(theme.OK, "Setup Complete"))
 		} else if status.IsNeverSetUp {
-			fmt.Println(color.New(color.FgBlue).Sprint("  ℹ️  Not Set Up"))
+			fmt.Println("  " + theme.Status(theme.Info, "Not Set Up"))
 		} else if status.IsBroken {
-			fmt.Println(color.New(color.FgYellow).Sprint("  ⚠️  Setup Broken"))
+			fmt.Println("  " + theme.Status(theme.Warning, "Setup Broken"))
 		} else {
-			fmt.Println(color.New(color.FgRed).Sprint("  ❌ Setup Incomplete"))
+			fmt.Println("  " + theme.Status(theme.Failure, "Setup Incomplete"))
 		}
 
 		// Show individual status with debugging
 		fmt.Printf("  - Worktree: %s", getStatusIcon(status.WorktreeExists))
 		if status.WorktreeExists {
-			worktreePath := app.GetGit().GetWorktreePath(status.EngineVersion)
+			worktreePath := app.GetGit().GetWorktreePath(status.WorktreeSubdir)
 			fmt.Printf(" (%s)", worktreePath)
 		}
 		fmt.Println()
@@ -500,11 +1021,10 @@ func runDetailedSetupStatus(app Application, config *config.Config) error {
 			fmt.Printf("  - Junction Valid: %s", getStatusIcon(status.JunctionValid))
 			if !status.JunctionValid {
 				// Show what the junction actually points to
-				pluginLinkPath := app.GetPlugin().GetPluginLinkPath(status.EnginePath)
-				if target, err := app.GetPlugin().GetJunctionTarget(pluginLinkPath); err == nil {
-					fmt.Printf(" (points to: %s)", target)
+				if status.JunctionTarget != "" {
+					fmt.Printf(" (points to: %s)", status.JunctionTarget)
 				} else {
-					fmt.Printf(" (could not get target: %v)", err)
+					fmt.Print(" (could not get target)")
 				}
 			}
 			fmt.Println()
@@ -512,12 +1032,16 @@ func runDetailedSetupStatus(app Application, config *config.Config) error {
 
 		fmt.Printf("  - Binaries: %s", getStatusIcon(status.BinariesExist))
 		if status.WorktreeExists {
-			worktreePath := app.GetGit().GetWorktreePath(status.EngineVersion)
+			worktreePath := app.GetGit().GetWorktreePath(status.WorktreeSubdir)
 			binariesPath := filepath.Join(worktreePath, "Binaries", "Win64")
 			fmt.Printf(" (%s)", binariesPath)
 		}
 		fmt.Println()
 
+		if status.PluginVersionName != "" {
+			fmt.Printf("  - Plugin Version: %s\n", status.PluginVersionName)
+		}
+
 		fmt.Printf("  - Stock Plugin: %s\n", GetStockPluginStatusIcon(status.StockPluginStatus))
 
 		// Show issues for broken setups
@@ -527,6 +1051,7 @@ func runDetailedSetupStatus(app Application, config *config.Config) error {
 				fmt.Printf("    - %s\n", issue)
 			}
 		}
+		printBrokenSince(history, status)
 		fmt.Println()
 	}
 
@@ -536,11 +1061,14 @@ func runDetailedSetupStatus(app Application, config *config.Config) error {
 
 // runEditSetup shows detailed status and allows editing each engine setup
 func runEditSetup(app Application, config *config.Config) error {
+	if err := policy.CheckEngineChanges(config); err != nil {
+		return err
+	}
 	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🔧 Edit Setup"))
 	fmt.Println()
 
 	// Get detailed setup status
-	statuses, err := app.GetDetection().DetectSetupStatus(config.CustomEngineRoots)
+	statuses, err := app.GetDetection().DetectSetupStatus(config)
 	if err != nil {
 		return fmt.Errorf("failed to detect setup status: %v", err)
 	}
@@ -556,19 +1084,19 @@ func runEditSetup(app Application, config *config.Config) error {
 		fmt.Printf("Engine %s (%s):\n", status.EngineVersion, status.EnginePath)
 
 		if status.IsSetupComplete {
-			fmt.Println(color.New(color.FgGreen).Sprint("  ✅ Setup Complete"))
+			fmt.Println("  " + theme.Status(theme.OK, "Setup Complete"))
 		} else if status.IsNeverSetUp {
-			fmt.Println(color.New(color.FgBlue).Sprint("  ℹ️  Not Set Up"))
+			fmt.Println("  " + theme.Status(theme.Info, "Not Set Up"))
 		} else if status.IsBroken {
-			fmt.Println(color.New(color.FgYellow).Sprint("  ⚠️  Setup Broken"))
+			fmt.Println("  " + theme.Status(theme.Warning, "Setup Broken"))
 		} else {
-			fmt.Println(color.New(color.FgRed).Sprint("  ❌ Setup Incomplete"))
+			fmt.Println("  " + theme.Status(theme.Failure, "Setup Incomplete"))
 		}
 
 		// Show individual status with debugging
 		fmt.Printf("  - Worktree: %s", getStatusIcon(status.WorktreeExists))
 		if status.WorktreeExists {
-			worktreePath := app.GetGit().GetWorktreePath(status.EngineVersion)
+			worktreePath := app.GetGit().GetWorktreePath(status.WorktreeSubdir)
 			fmt.Printf(" (%s)", worktreePath)
 		}
 		fmt.Println()
@@ -584,11 +1112,10 @@ func runEditSetup(app Application, config *config.Config) error {
 			fmt.Printf("  - Junction Valid: %s", getStatusIcon(status.JunctionValid))
 			if !status.JunctionValid {
 				// Show what the junction actually points to
-				pluginLinkPath := app.GetPlugin().GetPluginLinkPath(status.EnginePath)
-				if target, err := app.GetPlugin().GetJunctionTarget(pluginLinkPath); err == nil {
-					fmt.Printf(" (points to: %s)", target)
+				if status.JunctionTarget != "" {
+					fmt.Printf(" (points to: %s)", status.JunctionTarget)
 				} else {
-					fmt.Printf(" (could not get target: %v)", err)
+					fmt.Print(" (could not get target)")
 				}
 			}
 			fmt.Println()
@@ -596,12 +1123,16 @@ func runEditSetup(app Application, config *config.Config) error {
 
 		fmt.Printf("  - Binaries: %s", getStatusIcon(status.BinariesExist))
 		if status.WorktreeExists {
-			worktreePath := app.GetGit().GetWorktreePath(status.EngineVersion)
+			worktreePath := app.GetGit().GetWorktreePath(status.WorktreeSubdir)
 			binariesPath := filepath.Join(worktreePath, "Binaries", "Win64")
 			fmt.Printf(" (%s)", binariesPath)
 		}
 		fmt.Println()
 
+		if status.PluginVersionName != "" {
+			fmt.Printf("  - Plugin Version: %s\n", status.PluginVersionName)
+		}
+
 		fmt.Printf("  - Stock Plugin: %s\n", GetStockPluginStatusIcon(status.StockPluginStatus))
 
 		// Show issues for broken setups
@@ -615,6 +1146,10 @@ func runEditSetup(app Application, config *config.Config) error {
 	}
 
 	// Create menu options for each engine
+	versionCounts := map[string]int{}
+	for _, status := range statuses {
+		versionCounts[status.EngineVersion]++
+	}
 	var engineOptions []string
 	for _, status := range statuses {
 		statusText := "Not Set Up"
@@ -623,7 +1158,12 @@ func runEditSetup(app Application, config *config.Config) error {
 		} else if status.IsBroken {
 			statusText = "Setup Broken"
 		}
-		engineOptions = append(engineOptions, fmt.Sprintf("UE %s - %s", status.EngineVersion, statusText))
+		option := fmt.Sprintf("UE %s - %s", status.EngineVersion, statusText)
+		// Several installs of the same version are told apart by their path
+		if versionCounts[status.EngineVersion] > 1 {
+			option = fmt.Sprintf("%s (%s)", option, status.EnginePath)
+		}
+		engineOptions = append(engineOptions, option)
 	}
 	engineOptions = append(engineOptions, "Back")
 
@@ -636,7 +1176,7 @@ func runEditSetup(app Application, config *config.Config) error {
 		Stdout:   &utils.BellSkipper{},
 	}
 
-	_, selectedEngine, err := prompt.Run()
+	selectedIndex, selectedEngine, err := selectWithHelp(&prompt, helpEditSetup)
 	if err != nil {
 		if err == promptui.ErrInterrupt {
 			return nil
@@ -648,40 +1188,49 @@ func runEditSetup(app Application, config *config.Config) error {
 		return nil
 	}
 
-	// Find the selected engine
-	var selectedStatus *detection.SetupStatus
-	for i, status := range statuses {
-		expectedText := fmt.Sprintf("UE %s -", status.EngineVersion)
-		if strings.Contains(selectedEngine, expectedText) {
-			selectedStatus = &statuses[i]
-			break
-		}
-	}
-
-	if selectedStatus == nil {
+	if selectedIndex < 0 || selectedIndex >= len(statuses) {
 		return fmt.Errorf("selected engine not found")
 	}
 
 	// Show options for the selected engine
-	return runEngineEditOptions(app, config, *selectedStatus)
+	return runEngineEditOptions(app, config, statuses[selectedIndex])
 }
 
 // runEngineEditOptions shows options for editing a specific engine
 func runEngineEditOptions(app Application, config *config.Config, status detection.SetupStatus) error {
+	if err := policy.CheckEngineChanges(config); err != nil {
+		return err
+	}
+	// An engine linked to another user's setup needs a decision before anything else
+	if status.LinkOwner != "" && status.LinkOwnerChoice == "" {
+		return runOtherUserSetup(app, config, status)
+	}
+
 	fmt.Printf("\nEditing UE %s:\n", status.EngineVersion)
 	fmt.Printf("Path: %s\n", status.EnginePath)
 	fmt.Println()
 
+	otherUserItem := fmt.Sprintf("%s's Setup (adopt/replace/coexist)", status.LinkOwner)
 	var options []string
-	if status.IsSetupComplete {
+	if status.UsesOtherUserSetup() {
+		// The setup is the other user's; there is nothing of this user's to update or repair
+		options = []string{
+			otherUserItem,
+			"Back",
+		}
+	} else if status.IsSetupComplete {
 		options = []string{
 			"Update Setup",
+			"Engine Branch & Pin",
+			"Plugin Versions (A/B)",
+			"Plugin Folder Name",
 			"Uninstall Setup",
 			"Back",
 		}
 	} else if status.IsBroken {
 		options = []string{
 			"Repair Setup",
+			"Plugin Versions (A/B)",
 			"Uninstall Setup",
 			"Back",
 		}
@@ -691,6 +1240,24 @@ func runEngineEditOptions(app Application, config *config.Config, status detecti
 			"Back",
 		}
 	}
+	// A setup that stopped part way continues from its last completed step
+	var interrupted *journal.Setup
+	if !status.IsSetupComplete {
+		interrupted = loadJournal(app).Get(status.EnginePath)
+	}
+	if interrupted != nil && interrupted.Resumable() {
+		options = append([]string{"Resume Interrupted Setup"}, options...)
+	}
+	if status.LinkOwner != "" && !status.UsesOtherUserSetup() {
+		// Both move the link, which is the other user's while coexisting
+		kept := []string{otherUserItem}
+		for _, option := range options {
+			if option != "Plugin Versions (A/B)" && option != "Plugin Folder Name" {
+				kept = append(kept, option)
+			}
+		}
+		options = kept
+	}
 
 	prompt := promptui.Select{
 		Label:    "What would you like to do?",
@@ -700,7 +1267,7 @@ func runEngineEditOptions(app Application, config *config.Config, status detecti
 		Stdout:   &utils.BellSkipper{},
 	}
 
-	_, choice, err := prompt.Run()
+	_, choice, err := selectWithHelp(&prompt, helpEngine)
 	if err != nil {
 		if err == promptui.ErrInterrupt {
 			return nil
@@ -709,14 +1276,26 @@ func runEngineEditOptions(app Application, config *config.Config, status detecti
 	}
 
 	switch choice {
+	case "Resume Interrupted Setup":
+		describeInterruptedSetup(*interrupted)
+		fmt.Println()
+		return resumeSetupForEngine(app, config, *interrupted)
 	case "Install Setup":
-		return runSetupForEngine(app, config, status.EnginePath, status.EngineVersion)
+		return runSetupForEngine(app, config, status.EnginePath, status.EngineVersion, status.WorktreeSubdir)
 	case "Update Setup":
-		return runUpdateForEngine(app, config, status.EnginePath, status.EngineVersion)
+		return runUpdateForEngine(app, config, status.EnginePath, status.EngineVersion, status.WorktreeSubdir)
+	case "Engine Branch & Pin":
+		return runEngineTracking(app, config, status)
+	case "Plugin Versions (A/B)":
+		return runPluginVersions(app, config, status)
+	case "Plugin Folder Name":
+		return runPluginLinkName(app, config, status)
 	case "Repair Setup":
-		return runRepairForEngine(app, config, status.EnginePath, status.EngineVersion)
+		return runRepairForEngine(app, config, status.EnginePath, status.EngineVersion, status.WorktreeSubdir)
 	case "Uninstall Setup":
-		return runUninstallForEngine(app, config, status.EnginePath, status.EngineVersion)
+		return runUninstallForEngine(app, config, status.EnginePath, status.EngineVersion, status.WorktreeSubdir)
+	case otherUserItem:
+		return runOtherUserSetup(app, config, status)
 	case "Back":
 		return nil
 	}
@@ -724,25 +1303,205 @@ func runEngineEditOptions(app Application, config *config.Config, status detecti
 	return nil
 }
 
+// runEngineTracking shows an engine's branch and lets it follow its own upstream branch or pin
+func runEngineTracking(app Application, config *config.Config, status detection.SetupStatus) error {
+	eng := app.GetConfig().GetEngineByPath(config, status.EnginePath)
+	if eng == nil {
+		return fmt.Errorf("UE %s is not managed by this tool", status.EngineVersion)
+	}
+	subdir := status.WorktreeSubdir
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprintf("🌿 UE %s Branch & Pin", status.EngineVersion))
+	fmt.Println()
+	fmt.Printf("Engine branch:   %s\n", git.EngineBranch(subdir))
+	if eng.UpstreamBranch != "" {
+		fmt.Printf("Upstream branch: %s (this engine only)\n", eng.UpstreamBranch)
+	} else {
+		fmt.Printf("Upstream branch: %s (default)\n", config.DefaultRemoteBranch)
+	}
+	switch {
+	case eng.PinnedCommitSHA != "":
+		fmt.Printf("Pinned commit:   %s (this engine only)\n", eng.PinnedCommitSHA)
+	case config.PinnedCommitSHA != "":
+		fmt.Printf("Pinned commit:   %s (default)\n", config.PinnedCommitSHA)
+	default:
+		fmt.Println("Pinned commit:   none, follows the upstream branch")
+	}
+
+	if state, err := app.GetGit().GetWorktreeState(subdir, config.TrackedBranch(subdir), config.TrackedPin(subdir)); err == nil && len(state.LocalCommits) > 0 {
+		fmt.Println()
+		fmt.Println("Local commits, replayed on top of every update:")
+		for _, commit := range state.LocalCommits {
+			fmt.Printf("  %s\n", commit)
+		}
+	}
+	printRemoteUpdates(app, config, subdir)
+	fmt.Println()
+	fmt.Printf("Commit fixes for this engine version only on %s in:\n  %s\n", git.EngineBranch(subdir), app.GetGit().GetWorktreePath(subdir))
+	fmt.Println()
+
+	options := []string{"Follow a Different Upstream Branch"}
+	if len(app.GetGit().Remotes()) > 0 {
+		options = append(options, "Switch Remote")
+	}
+	options = append(options, "Pin to Current Commit", "Pin to Commit or Tag")
+	if eng.UpstreamBranch != "" {
+		options = append(options, "Use Default Upstream Branch")
+	}
+	if eng.PinnedCommitSHA != "" {
+		options = append(options, "Remove Engine Pin")
+	}
+	options = append(options, "Back")
+
+	prompt := promptui.Select{
+		Label:    "What would you like to do?",
+		Items:    options,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	_, choice, err := selectWithHelp(&prompt, helpEngineTracking)
+	if err != nil {
+		if err == promptui.ErrInterrupt {
+			return nil
+		}
+		return err
+	}
+
+	switch choice {
+	case "Follow a Different Upstream Branch":
+		branch, err := selectUpstreamBranch(app, "Select upstream branch for this engine (type to filter)")
+		if err != nil || branch == "" {
+			return err
+		}
+		eng.UpstreamBranch = branch
+	case "Switch Remote":
+		remote, ok := selectTrackedRemote(app)
+		if !ok {
+			return nil
+		}
+		_, branch := app.GetGit().SplitBranch(config.TrackedBranch(subdir))
+		if _, err := app.GetGit().ResolveCommit(remote + "/" + branch); err != nil {
+			return fmt.Errorf("%s has no branch %s (fetch first if it is new)", remote, branch)
+		}
+		eng.UpstreamBranch = git.QualifiedBranch(remote, branch)
+	case "Use Default Upstream Branch":
+		eng.UpstreamBranch = ""
+	case "Pin to Current Commit":
+		info, err := app.GetGit().GetUpdateInfo(subdir, config.TrackedBranch(subdir), config.TrackedPin(subdir))
+		if err != nil {
+			return fmt.Errorf("failed to read current commit: %v", err)
+		}
+		eng.PinnedCommitSHA = info.BaseSHA
+	case "Pin to Commit or Tag":
+		fmt.Print("Enter commit SHA or tag: ")
+		rev := utils.ReadLine()
+		if rev == "" {
+			return nil
+		}
+		sha, err := app.GetGit().ResolveCommit(rev)
+		if err != nil {
+			return fmt.Errorf("%s is not a known commit or tag (fetch first if it is new): %v", rev, err)
+		}
+		eng.PinnedCommitSHA = sha
+	case "Remove Engine Pin":
+		eng.PinnedCommitSHA = ""
+	default:
+		return nil
+	}
+
+	if err := app.GetConfig().Save(config); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	fmt.Println("✅ Saved. Run \"Update Setup\" to move the engine to its new target and rebuild.")
+	utils.Pause()
+	return nil
+}
+
 // runSettings shows the settings menu
 func runSettings(app Application, config *config.Config) error {
+	cacheCleanupItem := "Plugin Cache Cleanup: On"
+	if config.SkipPluginCacheCleanup {
+		cacheCleanupItem = "Plugin Cache Cleanup: Off"
+	}
+
+	themeItem := "Color Theme: " + theme.Describe(config.ColorTheme)
+	symbolsItem := "Status Symbols: Emoji"
+	if config.TextStatusSymbols {
+		symbolsItem = "Status Symbols: Text"
+	}
+
+	outputItem := "Output: Normal"
+	if config.VerboseOutput {
+		outputItem = "Output: Verbose"
+	}
+
+	confirmItem := "Confirmations: Every step"
+	if config.Confirmations == utils.ConfirmDestructiveOnly {
+		confirmItem = "Confirmations: Destructive only"
+	}
+
+	promptsItem := "Prompts: Arrow-key lists"
+	if utils.PlainPrompts() {
+		promptsItem = "Prompts: Numbered (screen reader)"
+	}
+
+	buildsItem := fmt.Sprintf("Parallel Builds: %d", config.BuildWorkers())
+	repoMaintenanceItem := "Repository Maintenance: " + describeRepoMaintenance(config)
+
+	windowItem := "Maintenance Window: Any time"
+	if config.MaintenanceWindow != "" {
+		windowItem = "Maintenance Window: " + config.MaintenanceWindow
+	}
+
+	telemetryItem := "Telemetry: Off"
+	if config.TelemetryEnabled {
+		telemetryItem = "Telemetry: On"
+	}
+
+	contextMenuItem := "Explorer Context Menu: Not installed"
+	if explorer.IsInstalled() {
+		contextMenuItem = "Explorer Context Menu: Installed"
+	}
+
 	items := []string{
 		"Manage Custom Engine Paths",
 		"Change Branch to Track",
+		"Set Repository Mirror",
+		"Tracked Remotes",
+		"Local Patches",
+		cacheCleanupItem,
+		buildsItem,
+		repoMaintenanceItem,
+		windowItem,
+		themeItem,
+		symbolsItem,
+		outputItem,
+		confirmItem,
+		promptsItem,
+		telemetryItem,
+		contextMenuItem,
+		"Show Step Timings",
+		"Status History",
+		"Engine Plugin Audit",
+		"Export Machine Manifest",
+		"Import Settings from a Teammate",
+		"Re-point Plugin Links",
 		"Open Plugin Repository",
 		"Open Data Directory",
+		"Open Logs Folder",
+		"Check for Tool Updates",
 		"Back",
 	}
 
 	prompt := promptui.Select{
 		Label:    "Settings",
 		Items:    items,
-		Size:     10,
+		Size:     28,
 		HideHelp: true,
 		Stdout:   &utils.BellSkipper{},
 	}
 
-	_, choice, err := prompt.Run()
+	_, choice, err := selectWithHelp(&prompt, helpSettings)
 	if err != nil {
 		if err == promptui.ErrInterrupt {
 			return nil
@@ -757,6 +1516,93 @@ func runSettings(app Application, config *config.Config) error {
 	case "Change Branch to Track":
 		changeBranch(app, config)
 		return nil
+	case "Local Patches":
+		runLocalPatches(app, config)
+		return nil
+	case "Tracked Remotes":
+		runTrackedRemotes(app, config)
+		return nil
+	case "Set Repository Mirror":
+		changeMirror(app, config)
+		return nil
+	case buildsItem:
+		config.ParallelBuilds = nextBuildWorkers(config)
+		if err := app.GetConfig().Save(config); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+		return nil
+	case repoMaintenanceItem:
+		runRepositoryMaintenance(app, config)
+		return nil
+	case windowItem:
+		changeMaintenanceWindow(app, config)
+		return nil
+	case themeItem:
+		changeColorTheme(app, config)
+		return nil
+	case symbolsItem:
+		config.TextStatusSymbols = !config.TextStatusSymbols
+		if err := app.GetConfig().Save(config); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+		return nil
+	case outputItem:
+		config.VerboseOutput = !config.VerboseOutput
+		events.SetVerbose(config.VerboseOutput)
+		if err := app.GetConfig().Save(config); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+		return nil
+	case confirmItem:
+		if config.Confirmations == utils.ConfirmDestructiveOnly {
+			config.Confirmations = ""
+		} else {
+			config.Confirmations = utils.ConfirmDestructiveOnly
+		}
+		utils.SetConfirmPolicy(config.Confirmations)
+		if err := app.GetConfig().Save(config); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+		return nil
+	case promptsItem:
+		config.PlainPrompts = !utils.PlainPrompts()
+		utils.SetPlainPrompts(config.PlainPrompts)
+		if err := app.GetConfig().Save(config); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+		return nil
+	case telemetryItem:
+		toggleTelemetry(app, config)
+		return nil
+	case contextMenuItem:
+		toggleContextMenu()
+		return nil
+	case "Show Step Timings":
+		showStepTimings(app)
+		return nil
+	case "Status History":
+		runStatusHistory(app)
+		return nil
+	case "Engine Plugin Audit":
+		runEnginePluginAudit(app, config)
+		return nil
+	case "Export Machine Manifest":
+		exportMachineManifest(app, config)
+		utils.Pause()
+		return nil
+	case "Import Settings from a Teammate":
+		importTeammateSettings(app, config)
+		utils.Pause()
+		return nil
+	case cacheCleanupItem:
+		config.SkipPluginCacheCleanup = !config.SkipPluginCacheCleanup
+		if err := app.GetConfig().Save(config); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+		return nil
+	case "Re-point Plugin Links":
+		runRepointJunctions(app)
+		return nil
 	case "Open Plugin Repository":
 		utils.OpenURL("https://github.com/ProjectBorealis/UEGitPlugin")
 		return nil
@@ -764,6 +1610,14 @@ func runSettings(app Application, config *config.Config) error {
 		baseDir := app.GetConfig().GetBaseDir()
 		utils.OpenURL("file:///" + strings.ReplaceAll(baseDir, "\\", "/"))
 		return nil
+	case "Check for Tool Updates":
+		runCheckToolUpdates()
+		return nil
+	case "Open Logs Folder":
+		logsDir := app.GetConfig().GetLogsDir()
+		os.MkdirAll(logsDir, 0755)
+		utils.OpenURL("file:///" + strings.ReplaceAll(logsDir, "\\", "/"))
+		return nil
 	case "Back":
 		return nil
 	}
@@ -771,6 +1625,110 @@ func runSettings(app Application, config *config.Config) error {
 	return nil
 }
 
+// changeColorTheme lets the user pick a status color palette, previewing each one
+func changeColorTheme(app Application, config *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🎨 Color Theme"))
+	fmt.Println()
+	for _, name := range theme.Names {
+		theme.Apply(name, config.TextStatusSymbols)
+		fmt.Printf("%s:\n", theme.Describe(name))
+		fmt.Printf("  %s  %s  %s  %s\n",
+			theme.Status(theme.OK, "Setup Complete"),
+			theme.Status(theme.Info, "Not Set Up"),
+			theme.Status(theme.Warning, "Setup Broken"),
+			theme.Status(theme.Failure, "Setup Incomplete"))
+	}
+	theme.Apply(config.ColorTheme, config.TextStatusSymbols)
+	fmt.Println()
+
+	var items []string
+	for _, name := range theme.Names {
+		items = append(items, theme.Describe(name))
+	}
+	prompt := promptui.Select{
+		Label:    "Select a color theme",
+		Items:    items,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return
+	}
+
+	config.ColorTheme = theme.Names[index]
+	theme.Apply(config.ColorTheme, config.TextStatusSymbols)
+	if err := app.GetConfig().Save(config); err != nil {
+		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		utils.Pause()
+	}
+}
+
+// showStepTimings prints the aggregated durations of setup, update and repair steps on this machine
+func showStepTimings(app Application) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("⏱️  Step Timings"))
+	fmt.Println()
+
+	store, err := timing.Load(app.GetConfig().GetTimingsFile())
+	if err != nil {
+		fmt.Printf("❌ Failed to read step timings: %v\n", err)
+		utils.Pause()
+		return
+	}
+	if len(store) == 0 {
+		fmt.Println("No operations have been timed on this machine yet.")
+		utils.Pause()
+		return
+	}
+
+	fmt.Printf("  %-10s %6s %10s %10s %10s\n", "Step", "Runs", "Average", "Max", "Last")
+	for _, name := range store.Names() {
+		stats := store[name]
+		fmt.Printf("  %-10s %6d %10s %10s %10s\n", name, stats.Count,
+			timing.FormatDuration(seconds(stats.AverageSeconds())),
+			timing.FormatDuration(seconds(stats.MaxSeconds)),
+			timing.FormatDuration(seconds(stats.LastSeconds)))
+	}
+	fmt.Println()
+	utils.Pause()
+}
+
+// seconds converts a float number of seconds to a duration
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
+
+// toggleContextMenu installs or removes the "Configure Unreal project for Git" folder context menu entry
+func toggleContextMenu() {
+	if explorer.IsInstalled() {
+		if err := explorer.Uninstall(); err != nil {
+			fmt.Printf("❌ Failed to remove context menu entry: %v\n", err)
+		} else {
+			fmt.Println("✅ Context menu entry removed.")
+		}
+		utils.Pause()
+		return
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		fmt.Printf("❌ Could not locate the executable: %v\n", err)
+		utils.Pause()
+		return
+	}
+	fmt.Printf("This adds \"%s\" to the right-click menu of folders in Explorer.\n", explorer.MenuLabel)
+	fmt.Println("If you move the executable later, install the entry again from here.")
+	if !utils.Confirm("Install it now?") {
+		return
+	}
+	if err := explorer.Install(exePath); err != nil {
+		fmt.Printf("❌ Failed to install context menu entry: %v\n", err)
+	} else {
+		fmt.Println("✅ Context menu entry installed.")
+	}
+	utils.Pause()
+}
+
 // runManageCustomEnginePaths shows options to manage custom engine paths
 func runManageCustomEnginePaths(app Application, config *config.Config) error {
 	for {
@@ -787,6 +1745,8 @@ func runManageCustomEnginePaths(app Application, config *config.Config) error {
 			addCustomEnginePath(app, config)
 		case "Delete Custom Engine Path":
 			deleteCustomEnginePath(app, config)
+		case "Edit Scan Options":
+			editScanRootOptions(app, config)
 		case "Back":
 			return nil
 		}
@@ -804,7 +1764,8 @@ func showManageCustomEnginePathsMenu(app Application, config *config.Config) (st
 	} else {
 		fmt.Println("Current custom engine paths:")
 		for i, root := range config.CustomEngineRoots {
-			fmt.Printf("  %d. %s\n", i+1, root)
+			fmt.Printf("  %d. %s\n", i+1, root.Path)
+			fmt.Printf("     %s\n", root.Describe())
 		}
 	}
 	fmt.Println()
@@ -812,6 +1773,7 @@ func showManageCustomEnginePathsMenu(app Application, config *config.Config) (st
 	items := []string{
 		"Add Custom Engine Path",
 		"Delete Custom Engine Path",
+		"Edit Scan Options",
 		"Back",
 	}
 
@@ -823,41 +1785,40 @@ func showManageCustomEnginePathsMenu(app Application, config *config.Config) (st
 		Stdout:   &utils.BellSkipper{},
 	}
 
-	_, result, err := prompt.Run()
+	_, result, err := selectWithHelp(&prompt, helpEnginePaths)
 	return result, err
 }
 
 // addCustomEnginePath allows the user to add a new custom engine path
-func addCustomEnginePath(app Application, config *config.Config) {
+func addCustomEnginePath(app Application, cfg *config.Config) {
 	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("➕ Add Custom Engine Path"))
 	fmt.Println()
+	fmt.Println("Pick the folder that holds your engines, e.g. the one with the UE_5.x folders.")
+	fmt.Println()
 
-	fmt.Print("Enter path to scan: ")
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Scan()
-	newRoot := strings.TrimSpace(scanner.Text())
-
-	// Handle quoted paths by removing quotes if present
-	newRoot = strings.Trim(newRoot, "\"")
+	prompt := utils.PathPrompt{
+		Label:       "Enter path to scan: ",
+		HistoryFile: app.GetConfig().GetHistoryFile("engine_paths"),
+		Validate:    utils.ValidateDirectory,
+	}
+	newRoot, err := utils.BrowseDirectory(prompt, "")
+	if err != nil || newRoot == "" {
+		return
+	}
 
-	if newRoot != "" {
-		// Check if path already exists
-		for _, existingRoot := range config.CustomEngineRoots {
-			if existingRoot == newRoot {
-				fmt.Printf("⚠️  Path '%s' is already configured.\n", newRoot)
-				utils.Pause()
-				return
-			}
-		}
+	// Check if path already exists
+	if config.HasScanRoot(cfg.CustomEngineRoots, newRoot) {
+		fmt.Printf("⚠️  Path '%s' is already configured.\n", newRoot)
+		utils.Pause()
+		return
+	}
 
-		config.CustomEngineRoots = append(config.CustomEngineRoots, newRoot)
-		if err := app.GetConfig().Save(config); err != nil {
-			fmt.Printf("❌ Failed to save configuration: %v\n", err)
-		} else {
-			fmt.Printf("✅ Custom engine path added: %s\n", newRoot)
-		}
+	cfg.CustomEngineRoots = append(cfg.CustomEngineRoots, config.ScanRoot{Path: newRoot})
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
 	} else {
-		fmt.Println("❌ Empty path not allowed.")
+		fmt.Printf("✅ Custom engine path added: %s\n", newRoot)
+		fmt.Println("   Use \"Edit Scan Options\" to change its depth, patterns or network timeout.")
 	}
 
 	utils.Pause()
@@ -879,13 +1840,12 @@ func deleteCustomEnginePath(app Application, config *config.Config) {
 
 	// Show current paths with numbers
 	for i, root := range config.CustomEngineRoots {
-		fmt.Printf("  %d. %s\n", i+1, root)
+		fmt.Printf("  %d. %s\n", i+1, root.Path)
 	}
 	fmt.Println()
 
 	fmt.Print("Enter path number to delete (or 0 to cancel): ")
-	var choice int
-	fmt.Scanln(&choice)
+	choice, _ := strconv.Atoi(utils.ReadLine())
 
 	if choice == 0 {
 		return
@@ -898,8 +1858,8 @@ func deleteCustomEnginePath(app Application, config *config.Config) {
 	}
 
 	// Confirm deletion
-	pathToDelete := config.CustomEngineRoots[choice-1]
-	if !utils.Confirm(fmt.Sprintf("Are you sure you want to delete '%s'?", pathToDelete)) {
+	pathToDelete := config.CustomEngineRoots[choice-1].Path
+	if !utils.ConfirmDestructive(fmt.Sprintf("Are you sure you want to delete '%s'?", pathToDelete)) {
 		return
 	}
 
@@ -916,72 +1876,295 @@ func deleteCustomEnginePath(app Application, config *config.Config) {
 }
 
 // runSetupForEngine sets up a specific engine
-func runSetupForEngine(app Application, config *config.Config, enginePath, engineVersion string) error {
-	fmt.Printf("Setting up UE %s...\n", engineVersion)
+func runSetupForEngine(app Application, config *config.Config, enginePath, engineVersion, worktreeSubdir string) error {
+	if err := SetupEngine(app, config, enginePath, engineVersion, worktreeSubdir); err != nil {
+		return err
+	}
+	utils.Pause()
+	return nil
+}
+
+// SetupEngine clones the plugin if needed, creates the engine's worktree, links and builds it.
+// Unlike the menu it doesn't wait for Enter afterwards, so it also serves the setup command.
+func SetupEngine(app Application, config *config.Config, enginePath, engineVersion, worktreeSubdir string) error {
+	return setupEngine(app, config, enginePath, engineVersion, worktreeSubdir, false)
+}
+
+// setupEngine runs SetupEngine, recording each completed step in the setup journal. With resume
+// set it continues the engine's interrupted setup, skipping the steps that completed and whose
+// result is still in place.
+func setupEngine(app Application, config *config.Config, enginePath, engineVersion, worktreeSubdir string, resume bool) (err error) {
+	if err := policy.CheckEngineChanges(config); err != nil {
+		return err
+	}
+	keepLink, err := otherUserLinkKept(app, config, enginePath)
+	if err != nil {
+		return err
+	}
+	if !offerMinGit(app) {
+		return fmt.Errorf("git is not available; install Git for Windows or the portable MinGit")
+	}
+	if !confirmBuildPaths(app, enginePath, worktreeSubdir) {
+		return fmt.Errorf("setup cancelled: move the listed folders to paths with only ASCII characters first")
+	}
+	if !confirmNetworkEngine(app, enginePath) {
+		return fmt.Errorf("setup cancelled: the engine is on a network path")
+	}
+
+	build := newEngineBuild(enginePath, engineVersion, worktreeSubdir)
+	build.journal = loadJournal(app)
+	if previous := build.journal.Get(enginePath); resume && previous != nil {
+		resumed := *previous
+		build.resumed = &resumed
+		events.Printf("Resuming the setup of UE %s...\n", engineVersion)
+	} else {
+		events.Printf("Setting up UE %s...\n", engineVersion)
+		journalWarn(build.journal.Begin(enginePath, engineVersion, worktreeSubdir))
+	}
+	defer recordTimings(app, build.rec)
+	defer func() {
+		if err != nil {
+			journalWarn(build.journal.Fail(enginePath, err))
+		}
+		err = reportOutcome(app, config, telemetry.OpSetup, engineVersion, build.rec.All(), err)
+	}()
+
+	if err := prepareSetup(app, config, build, keepLink); err != nil {
+		return err
+	}
+	buildEngines(app, config, []*engineBuild{build}, true)
+	if build.err != nil {
+		return fmt.Errorf("failed to build plugin: %v", build.err)
+	}
+	finishSetup(app, config, build)
+	recordTimings(app, build.rec)
+	return nil
+}
+
+// SetupEngines sets up several engines like SetupEngine, one after the other up to their builds,
+// which then run side by side. It returns one error per engine, nil for those set up.
+func SetupEngines(app Application, config *config.Config, engines []detection.SetupStatus) []error {
+	errs := make([]error, len(engines))
+	if err := policy.CheckEngineChanges(config); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	if !offerMinGit(app) {
+		for i := range errs {
+			errs[i] = fmt.Errorf("git is not available; install Git for Windows or the portable MinGit")
+		}
+		return errs
+	}
+
+	jr := loadJournal(app)
+	rec := timing.NewRecorder()
+	defer recordTimings(app, rec)
+	report := func(i int, build *engineBuild, err error) {
+		if err != nil {
+			journalWarn(jr.Fail(build.enginePath, err))
+		}
+		rec.Merge(build.rec)
+		errs[i] = reportOutcome(app, config, telemetry.OpSetup, build.engineVersion, build.rec.All(), err)
+	}
+
+	var builds []*engineBuild
+	var indexes []int
+	for i, status := range engines {
+		build := newEngineBuild(status.EnginePath, status.EngineVersion, status.WorktreeSubdir)
+		build.journal = jr
+		keepLink, err := otherUserLinkKept(app, config, status.EnginePath)
+		switch {
+		case err != nil:
+		case !confirmBuildPaths(app, status.EnginePath, status.WorktreeSubdir):
+			err = fmt.Errorf("setup cancelled: move the listed folders to paths with only ASCII characters first")
+		case !confirmNetworkEngine(app, status.EnginePath):
+			err = fmt.Errorf("setup cancelled: the engine is on a network path")
+		default:
+			events.Printf("Setting up UE %s...\n", status.EngineVersion)
+			journalWarn(jr.Begin(status.EnginePath, status.EngineVersion, status.WorktreeSubdir))
+			err = prepareSetup(app, config, build, keepLink)
+		}
+		if err != nil {
+			report(i, build, err)
+			continue
+		}
+		builds = append(builds, build)
+		indexes = append(indexes, i)
+	}
+
+	buildEngines(app, config, builds, true)
+	for n, build := range builds {
+		if build.err != nil {
+			report(indexes[n], build, fmt.Errorf("failed to build plugin: %v", build.err))
+			continue
+		}
+		finishSetup(app, config, build)
+		report(indexes[n], build, nil)
+	}
+	return errs
+}
+
+// prepareSetup clones the plugin if needed and creates, links and readies an engine's worktree
+// for its plugin to be built, timing the steps in the build's recorder and journaling them
+func prepareSetup(app Application, config *config.Config, build *engineBuild, keepLink bool) error {
+	rec := build.rec
+	enginePath, engineVersion, worktreeSubdir := build.enginePath, build.engineVersion, build.worktreeSubdir
+
+	// Missing build tools would otherwise only show at the build, after the clone, worktree and link
+	if err := app.GetEngine().MissingBuildPrerequisites(enginePath); err != nil {
+		return fmt.Errorf("%w\n   Run \"%s doctor\" to check everything setting up needs", err, filepath.Base(os.Args[0]))
+	}
 
 	// Ensure origin repository exists
 	if !app.GetGit().IsOriginCloned() {
-		fmt.Println("Cloning origin repository...")
-		if err := app.GetGit().CloneOrigin(); err != nil {
-			return fmt.Errorf("failed to clone origin repository: %v", err)
+		events.Println("Cloning origin repository...")
+		err := withGitRecovery(app, config, "", func() error {
+			return rec.Time(timing.StepClone, func() error {
+				return app.GetGit().CloneOrigin(config.MirrorURL)
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clone origin repository: %w", err)
 		}
+		syncTrackedRemotes(app, config, true)
 	}
+	build.completed(journal.StepClone)
 
-	// Create worktree
-	if err := app.GetGit().CreateWorktree(engineVersion, config.DefaultRemoteBranch, config.PinnedCommitSHA); err != nil {
-		return fmt.Errorf("failed to create worktree: %v", err)
+	// A resumed setup whose worktree is still there keeps the branch it was created from
+	if build.resumed != nil {
+		build.upstreamBranch = build.resumed.UpstreamBranch
 	}
+	if build.resumes(journal.StepWorktree) && app.GetGit().WorktreeExists(worktreeSubdir) {
+		events.Println("✓ The worktree was already created")
+	} else {
+		chosen, ok := confirmBranchCompatibility(app, config, engineVersion, worktreeSubdir)
+		if !ok {
+			return fmt.Errorf("setup cancelled: the plugin branch does not support UE %s", engineVersion)
+		}
+		branch := config.TrackedBranch(worktreeSubdir)
+		if chosen != "" {
+			branch = chosen
+			build.upstreamBranch = chosen
+			if build.journal != nil {
+				journalWarn(build.journal.UpstreamBranch(enginePath, chosen))
+			}
+		}
+		err := withGitRecovery(app, config, worktreeSubdir, func() error {
+			return rec.Time(timing.StepWorktree, func() error {
+				return app.GetGit().CreateWorktree(worktreeSubdir, branch, config.TrackedPin(worktreeSubdir), localPatches(app, config, worktreeSubdir))
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create worktree: %w", err)
+		}
+	}
+	build.completed(journal.StepWorktree)
 
-	// Create junction (needed before building)
-	worktreePath := app.GetGit().GetWorktreePath(engineVersion)
-	if err := app.GetPlugin().CreateJunction(enginePath, worktreePath); err != nil {
-		return fmt.Errorf("failed to create junction: %v", err)
+	// Create junction (needed before building), unless it stays on another user's setup
+	worktreePath := app.GetGit().GetWorktreePath(worktreeSubdir)
+	switch {
+	case keepLink:
+	case build.resumes(journal.StepJunction) && app.GetPlugin().VerifyJunction(enginePath, worktreePath):
+		events.Println("✓ The plugin was already linked into the engine")
+	default:
+		err := rec.Time(timing.StepJunction, func() error {
+			return app.GetPlugin().CreateJunction(enginePath, worktreePath)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create junction: %v", err)
+		}
 	}
+	build.completed(journal.StepJunction)
 
-	// Always disable stock plugin before building to avoid name collision
+	// Always disable stock plugin before building to avoid name collision. A resumed setup may
+	// have disabled it already, and the engine must still be recorded as changed by the tool.
+	if build.resumed != nil && build.resumed.StockDisabled {
+		build.stockDisabled = true
+	}
 	if app.GetEngine().CheckPluginCollision(enginePath) {
 		if err := app.GetEngine().DisableStockPlugin(enginePath); err != nil {
 			return fmt.Errorf("failed to disable stock plugin: %v", err)
 		}
+		build.stockDisabled = true
+		if build.journal != nil {
+			journalWarn(build.journal.StockDisabled(enginePath))
+		}
 	}
+	build.completed(journal.StepStockPlugin)
 
-	// Build plugin
-	if err := app.GetPlugin().BuildForEngine(enginePath, worktreePath); err != nil {
-		return fmt.Errorf("failed to build plugin: %v", err)
+	if !config.SkipPluginCacheCleanup {
+		cleanPluginCaches(app, config, enginePath, engineVersion)
 	}
-
-	fmt.Printf("✅ UE %s setup complete!\n", engineVersion)
-	utils.Pause()
 	return nil
 }
 
+// finishSetup records an engine whose plugin was built as managed and closes its journal entry
+func finishSetup(app Application, config *config.Config, build *engineBuild) {
+	build.completed(journal.StepBuild)
+	err := recordManagedEngine(app, config, build.enginePath, build.engineVersion, build.worktreeSubdir, build.stockDisabled)
+	// A plugin branch picked for the engine during the setup is only recorded once it succeeded
+	if eng := app.GetConfig().GetEngineByPath(config, build.enginePath); err == nil && eng != nil && build.upstreamBranch != "" {
+		eng.UpstreamBranch = build.upstreamBranch
+		err = app.GetConfig().Save(config)
+	}
+	if err != nil {
+		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+	}
+	if build.journal != nil {
+		journalWarn(build.journal.Finish(build.enginePath))
+	}
+	events.Printf("✅ UE %s setup complete!\n", build.engineVersion)
+}
+
 // runUpdateForEngine updates a specific engine
-func runUpdateForEngine(app Application, config *config.Config, enginePath, engineVersion string) error {
-	fmt.Printf("Checking for updates for UE %s...\n", engineVersion)
+func runUpdateForEngine(app Application, config *config.Config, enginePath, engineVersion, worktreeSubdir string) (err error) {
+	events.Printf("Checking for updates for UE %s...\n", engineVersion)
 
 	// Check if there are updates available
-	updateInfo, err := app.GetGit().GetUpdateInfo(engineVersion, config.DefaultRemoteBranch, config.PinnedCommitSHA)
+	updateInfo, err := app.GetGit().GetUpdateInfo(worktreeSubdir, config.TrackedBranch(worktreeSubdir), config.TrackedPin(worktreeSubdir))
 	if err != nil {
 		return fmt.Errorf("failed to check for updates: %v", err)
 	}
 
-	if updateInfo.CommitsAhead == 0 {
-		fmt.Printf("✅ UE %s is already up to date!\n", engineVersion)
-		fmt.Printf("   Local commit: %s\n", updateInfo.LocalSHA[:8])
+	patchesOutdated := localPatchesOutdated(app, config, worktreeSubdir)
+	if updateInfo.CommitsAhead == 0 && !patchesOutdated {
+		events.Printf("✅ UE %s is already up to date!\n", engineVersion)
+		events.Printf("   Local commit: %s\n", git.ShortSHA(updateInfo.LocalSHA))
 		utils.Pause()
 		return nil
 	}
 
-	fmt.Printf("📥 Updates available: %d commits behind\n", updateInfo.CommitsAhead)
-	fmt.Printf("   Local commit:  %s\n", updateInfo.LocalSHA[:8])
-	fmt.Printf("   Remote commit: %s\n", updateInfo.RemoteSHA[:8])
-	fmt.Printf("   Compare: %s\n", updateInfo.CompareURL)
-	fmt.Println()
+	if updateInfo.CommitsAhead > 0 {
+		events.Printf("📥 Updates available: %d commits behind\n", updateInfo.CommitsAhead)
+	} else {
+		events.Println("📥 Local patches need to be re-applied")
+	}
+	events.Printf("   Local commit:  %s\n", git.ShortSHA(updateInfo.LocalSHA))
+	events.Printf("   Remote commit: %s\n", git.ShortSHA(updateInfo.RemoteSHA))
+	if patchesOutdated {
+		events.Println("   Local patches changed and will be re-applied")
+	}
+	if updateInfo.LocalCommits > 0 {
+		events.Printf("   Local commits on %s: %d (replayed on top of the update)\n", git.EngineBranch(worktreeSubdir), updateInfo.LocalCommits)
+	}
+	events.Printf("   Compare: %s\n", updateInfo.CompareURL)
+	events.Println()
+
+	rec := timing.NewRecorder()
+	defer recordTimings(app, rec)
+	defer func() { err = reportOutcome(app, config, telemetry.OpUpdate, engineVersion, rec.All(), err) }()
 
 	// Update worktree
-	fmt.Println("Updating worktree...")
-	if err := app.GetGit().UpdateWorktree(engineVersion, config.DefaultRemoteBranch, config.PinnedCommitSHA); err != nil {
-		return fmt.Errorf("failed to update worktree: %v", err)
+	events.Println("Updating worktree...")
+	err = withGitRecovery(app, config, worktreeSubdir, func() error {
+		return rec.Time(timing.StepWorktree, func() error {
+			return app.GetGit().UpdateWorktree(worktreeSubdir, config.TrackedBranch(worktreeSubdir), config.TrackedPin(worktreeSubdir), localPatches(app, config, worktreeSubdir))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update worktree: %w", err)
 	}
 
 	// Ensure stock plugin is disabled before rebuilding
@@ -991,221 +2174,438 @@ func runUpdateForEngine(app Application, config *config.Config, enginePath, engi
 		}
 	}
 
+	if !config.SkipPluginCacheCleanup {
+		cleanPluginCaches(app, config, enginePath, engineVersion)
+	}
+
 	// Rebuild plugin
-	fmt.Println("Rebuilding plugin...")
-	worktreePath := app.GetGit().GetWorktreePath(engineVersion)
-	if err := app.GetPlugin().BuildForEngine(enginePath, worktreePath); err != nil {
+	events.Println("Rebuilding plugin...")
+	worktreePath := app.GetGit().GetWorktreePath(worktreeSubdir)
+	err = rec.Time(timing.StepBuild, func() error {
+		return buildPlugin(app, enginePath, worktreePath)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to rebuild plugin: %v", err)
 	}
 
-	fmt.Printf("✅ UE %s updated successfully! (%d commits applied)\n", engineVersion, updateInfo.CommitsAhead)
+	if err := recordManagedEngine(app, config, enginePath, engineVersion, worktreeSubdir, false); err != nil {
+		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+	}
+
+	recordTimings(app, rec)
+	events.Printf("✅ UE %s updated successfully! (%d commits applied)\n", engineVersion, updateInfo.CommitsAhead)
 	utils.Pause()
 	return nil
 }
 
 // runRepairForEngine repairs a specific engine
-func runRepairForEngine(app Application, config *config.Config, enginePath, engineVersion string) error {
-	fmt.Printf("Repairing UE %s...\n", engineVersion)
+func runRepairForEngine(app Application, config *config.Config, enginePath, engineVersion, worktreeSubdir string) error {
+	if err := RepairEngine(app, config, enginePath, engineVersion, worktreeSubdir); err != nil {
+		return err
+	}
+	utils.Pause()
+	return nil
+}
+
+// RepairEngine recreates whatever is missing of an engine's setup and rebuilds the plugin when
+// its binaries are missing, damaged or out of date, without waiting for Enter afterwards
+func RepairEngine(app Application, config *config.Config, enginePath, engineVersion, worktreeSubdir string) (err error) {
+	keepLink, err := otherUserLinkKept(app, config, enginePath)
+	if err != nil {
+		return err
+	}
+	events.Printf("Repairing UE %s...\n", engineVersion)
 
 	// Check what needs repair
-	status := app.GetDetection().DetectEngineSetupStatus(enginePath, engineVersion)
+	status := app.GetDetection().DetectEngineSetupStatus(enginePath, engineVersion, worktreeSubdir)
+	if built, current, changed := app.GetDetection().EngineHotfix(config, enginePath); changed {
+		events.Printf("The engine was updated from %s to %s; rebuilding the plugin against it.\n", built, current)
+		status.BinariesStale = true
+	}
+
+	rec := timing.NewRecorder()
+	defer recordTimings(app, rec)
+	defer func() { err = reportOutcome(app, config, telemetry.OpRepair, engineVersion, rec.All(), err) }()
 
 	// Recreate worktree if missing
 	if !status.WorktreeExists {
-		if err := app.GetGit().CreateWorktree(engineVersion, config.DefaultRemoteBranch, config.PinnedCommitSHA); err != nil {
-			return fmt.Errorf("failed to create worktree: %v", err)
+		err := withGitRecovery(app, config, worktreeSubdir, func() error {
+			return rec.Time(timing.StepWorktree, func() error {
+				return app.GetGit().CreateWorktree(worktreeSubdir, config.TrackedBranch(worktreeSubdir), config.TrackedPin(worktreeSubdir), localPatches(app, config, worktreeSubdir))
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create worktree: %w", err)
 		}
 	}
 
-	// Recreate junction if missing or invalid (needed before building)
-	if !status.JunctionExists || !status.JunctionValid {
+	// Recreate junction if missing or invalid (needed before building), unless it stays on
+	// another user's setup
+	if (!status.JunctionExists || !status.JunctionValid) && !keepLink {
 		// Remove existing junction first
 		pluginLinkPath := app.GetPlugin().GetPluginLinkPath(enginePath)
 		app.GetPlugin().RemoveJunction(pluginLinkPath)
 
 		// Create new junction
-		if err := app.GetPlugin().CreateJunction(enginePath, app.GetGit().GetWorktreePath(engineVersion)); err != nil {
+		err := rec.Time(timing.StepJunction, func() error {
+			return app.GetPlugin().CreateJunction(enginePath, app.GetGit().GetWorktreePath(worktreeSubdir))
+		})
+		if err != nil {
 			return fmt.Errorf("failed to create junction: %v", err)
 		}
 	}
 
 	// Ensure stock plugin is disabled before any rebuild
+	stockDisabled := false
 	if app.GetEngine().CheckPluginCollision(enginePath) {
 		if err := app.GetEngine().DisableStockPlugin(enginePath); err != nil {
 			return fmt.Errorf("failed to disable stock plugin: %v", err)
 		}
+		stockDisabled = true
 	}
 
-	// Rebuild plugin if binaries missing
-	if !status.BinariesExist {
-		worktreePath := app.GetGit().GetWorktreePath(engineVersion)
-		if err := app.GetPlugin().BuildForEngine(enginePath, worktreePath); err != nil {
+	// Rebuild plugin if binaries are missing, damaged or were built from another commit
+	if !status.BinariesExist || status.BinariesStale || status.BinariesCorrupted {
+		if !config.SkipPluginCacheCleanup {
+			cleanPluginCaches(app, config, enginePath, engineVersion)
+		}
+		worktreePath := app.GetGit().GetWorktreePath(worktreeSubdir)
+		err := rec.Time(timing.StepBuild, func() error {
+			return buildPlugin(app, enginePath, worktreePath)
+		})
+		if err != nil {
 			return fmt.Errorf("failed to build plugin: %v", err)
 		}
 	}
 	// Stock plugin already ensured disabled above
 
-	fmt.Printf("✅ UE %s repaired successfully!\n", engineVersion)
-	utils.Pause()
+	if err := recordManagedEngine(app, config, enginePath, engineVersion, worktreeSubdir, stockDisabled); err != nil {
+		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+	}
+
+	journalWarn(loadJournal(app).Finish(enginePath))
+	recordTimings(app, rec)
+	events.Printf("✅ UE %s repaired successfully!\n", engineVersion)
 	return nil
 }
 
 // runUninstallForEngine uninstalls a specific engine
-func runUninstallForEngine(app Application, config *config.Config, enginePath, engineVersion string) error {
-	fmt.Printf("Uninstalling UE %s...\n", engineVersion)
+func runUninstallForEngine(app Application, config *config.Config, enginePath, engineVersion, worktreeSubdir string) error {
+	if err := UninstallEngine(app, config, enginePath, engineVersion, worktreeSubdir); err != nil {
+		return err
+	}
+	utils.Pause()
+	return nil
+}
 
-	// Remove junction
-	pluginLinkPath := app.GetPlugin().GetPluginLinkPath(enginePath)
-	if err := app.GetPlugin().RemoveJunction(pluginLinkPath); err != nil {
-		return fmt.Errorf("failed to remove junction: %v", err)
+// UninstallEngine removes an engine's plugin link and worktrees and re-enables its stock plugin,
+// and removes the plugin repository along with the last setup. It doesn't wait for Enter
+// afterwards, so it also serves the uninstall command.
+func UninstallEngine(app Application, config *config.Config, enginePath, engineVersion, worktreeSubdir string) error {
+	keepLink, err := otherUserLinkKept(app, config, enginePath)
+	if err != nil {
+		return err
+	}
+	events.Printf("Uninstalling UE %s...\n", engineVersion)
+
+	// Remove junction, unless it is another user's
+	if !keepLink {
+		pluginLinkPath := app.GetPlugin().GetPluginLinkPath(enginePath)
+		if err := app.GetPlugin().RemoveJunction(pluginLinkPath); err != nil {
+			return fmt.Errorf("failed to remove junction: %v", err)
+		}
 	}
 
 	// Remove worktree
-	if err := app.GetGit().RemoveWorktree(engineVersion); err != nil {
+	if err := app.GetGit().RemoveWorktree(worktreeSubdir); err != nil {
 		return fmt.Errorf("failed to remove worktree: %v", err)
 	}
+	if eng := app.GetConfig().GetEngineByPath(config, enginePath); eng != nil && eng.AlternateWorktreeSubdir != "" {
+		if err := app.GetGit().RemoveWorktree(eng.AlternateWorktreeSubdir); err != nil {
+			fmt.Printf("⚠️  Failed to remove the second plugin worktree %s: %v\n", eng.AlternateWorktreeSubdir, err)
+		}
+	}
 
-	// Re-enable stock plugin
-	if err := app.GetEngine().EnableStockPlugin(enginePath); err != nil {
+	// Re-enable stock plugin, unless another user's setup still needs it off; that setup is all
+	// that is left of the engine's, so it counts as adopted from now on
+	if keepLink {
+		adoptOtherUserLink(config, enginePath, app.GetDetection().LinkOwner(enginePath))
+	} else if err := app.GetEngine().EnableStockPlugin(enginePath); err != nil {
 		return fmt.Errorf("failed to re-enable stock plugin: %v", err)
 	}
+	offerPluginCacheCleanup(app, config, enginePath, engineVersion)
+
+	app.GetConfig().RemoveEngine(config, enginePath)
+	if err := app.GetConfig().Save(config); err != nil {
+		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+	}
 
-	fmt.Printf("✅ UE %s uninstalled successfully!\n", engineVersion)
+	journalWarn(loadJournal(app).Finish(enginePath))
+	events.Printf("✅ UE %s uninstalled successfully!\n", engineVersion)
 
 	// Check if this was the last engine, and if so, remove origin repo
-	statuses, err := app.GetDetection().DetectSetupStatus(config.CustomEngineRoots)
+	statuses, err := app.GetDetection().DetectSetupStatus(config)
 	if err == nil {
 		remainingSetups := 0
 		for _, status := range statuses {
-			if status.IsSetupComplete {
+			if status.IsSetupComplete && !status.UsesOtherUserSetup() {
 				remainingSetups++
 			}
 		}
 
-		if remainingSetups == 0 {
-			fmt.Println("This was the last engine setup. Removing origin repository...")
-			if err := app.GetGit().RemoveOrigin(); err != nil {
-				fmt.Printf("Warning: Failed to remove origin repository: %v\n", err)
-			} else {
-				fmt.Println("✅ Origin repository removed.")
-			}
+		if remainingSetups == 0 {
+			events.Println("This was the last engine setup. Removing origin repository...")
+			if err := app.GetGit().RemoveOrigin(); err != nil {
+				fmt.Printf("Warning: Failed to remove origin repository: %v\n", err)
+			} else {
+				events.Println("✅ Origin repository removed.")
+			}
+		}
+	}
+	return nil
+}
+
+// showConfiguration displays the current configuration
+func showConfiguration(config *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("📋 Current Configuration"))
+	fmt.Println()
+	fmt.Printf("Default Remote Branch: %s\n", config.DefaultRemoteBranch)
+	fmt.Printf("Pinned Commit SHA: %s\n", config.PinnedCommitSHA)
+	fmt.Printf("Origin Directory: %s\n", config.OriginDir)
+	fmt.Printf("Worktrees Directory: %s\n", config.WorktreesDir)
+	fmt.Println("Custom Engine Roots:")
+	for _, root := range config.CustomEngineRoots {
+		fmt.Printf("  %s (%s)\n", root.Path, root.Describe())
+	}
+	if len(config.PromptDefaults) > 0 {
+		fmt.Println("Prompt Defaults:")
+		prompts := make([]string, 0, len(config.PromptDefaults))
+		for prompt := range config.PromptDefaults {
+			prompts = append(prompts, prompt)
+		}
+		sort.Strings(prompts)
+		for _, prompt := range prompts {
+			fmt.Printf("  %s → %s\n", prompt, config.PromptDefaults[prompt])
+		}
+	}
+	fmt.Printf("Managed Engines: %d\n", len(config.Engines))
+	fmt.Println()
+
+	for i, eng := range config.Engines {
+		fmt.Printf("  %d. UE %s at %s\n", i+1, eng.EngineVersion, eng.EnginePath)
+		fmt.Printf("     Worktree: %s\n", eng.WorktreeSubdir)
+		fmt.Printf("     Branch: %s\n", eng.Branch)
+		fmt.Printf("     Stock Plugin Disabled: %t\n", eng.StockPluginDisabledByTool)
+		fmt.Println()
+	}
+
+	utils.Pause()
+}
+
+// changeBranch allows the user to change the tracked branch
+func changeBranch(app Application, config *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🌿 Change Tracked Branch"))
+	fmt.Println()
+
+	fmt.Printf("Current branch: %s\n", config.DefaultRemoteBranch)
+	if config.PinnedCommitSHA != "" {
+		fmt.Printf("Pinned commit:  %s\n", config.PinnedCommitSHA)
+	}
+	fmt.Println()
+
+	refs, err := loadTrackableRefs(app, config, false)
+	for {
+		if err != nil {
+			fmt.Printf("❌ Could not list the remote branches, so the branch can't be changed right now:\n%v\n", err)
+			utils.Pause()
+			return
+		}
+
+		kind, name, ok := selectTrackableRef(app, config, refs)
+		if !ok {
+			return
+		}
+		switch kind {
+		case "refresh":
+			refs, err = loadTrackableRefs(app, config, true)
+			continue
+		case "tag":
+			pinToTag(app, config, name)
+		default:
+			switchTrackedBranch(app, config, name)
+		}
+		return
+	}
+}
+
+// loadTrackableRefs returns the branches and tags from the last fetch, fetching first when
+// refresh is set. Without a clone the branches are queried from the remote directly.
+func loadTrackableRefs(app Application, config *config.Config, refresh bool) (*git.RemoteRefs, error) {
+	if !app.GetGit().IsOriginCloned() {
+		fmt.Print("Loading remote branches... ")
+		refs, err := app.GetGit().ListRemoteRefs(config.MirrorURL)
+		fmt.Println()
+		return refs, err
+	}
+	if refresh {
+		fmt.Println("Fetching latest branches and tags...")
+		if err := withGitRecovery(app, config, "", func() error { return app.GetGit().FetchAll(config.MirrorURL) }); err != nil {
+			fmt.Printf("⚠️  Fetch failed, showing the branches from the last fetch: %v\n", err)
+		}
+	}
+	return app.GetGit().CachedRemoteRefs()
+}
+
+// selectTrackableRef shows a searchable list of branches and tags and returns the kind
+// ("branch", "tag" or "refresh") and name of the selection
+func selectTrackableRef(app Application, config *config.Config, refs *git.RemoteRefs) (string, string, bool) {
+	type refItem struct {
+		kind, name, label string
+	}
+	var refItems []refItem
+	for _, branch := range refs.Branches {
+		label := branch
+		if branch == config.DefaultRemoteBranch {
+			label += " (current)"
 		}
+		refItems = append(refItems, refItem{"branch", branch, label})
+	}
+	// Newest tags tend to sort last; list them first
+	for i := len(refs.Tags) - 1; i >= 0; i-- {
+		refItems = append(refItems, refItem{"tag", refs.Tags[i], "tag: " + refs.Tags[i]})
 	}
+	if app.GetGit().IsOriginCloned() {
+		refItems = append(refItems, refItem{"refresh", "", "↻ Refresh from remote"})
+	}
+	refItems = append(refItems, refItem{"cancel", "", "Cancel"})
 
-	utils.Pause()
-	return nil
+	labels := make([]string, len(refItems))
+	for i, item := range refItems {
+		labels[i] = item.label
+	}
+
+	prompt := promptui.Select{
+		Label:             "Select branch or tag to track (type to filter)",
+		Items:             labels,
+		Size:              12,
+		HideHelp:          true,
+		StartInSearchMode: true,
+		Searcher: func(input string, index int) bool {
+			return strings.Contains(strings.ToLower(labels[index]), strings.ToLower(strings.TrimSpace(input)))
+		},
+		Stdout: &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil || index < 0 || index >= len(refItems) || refItems[index].kind == "cancel" {
+		return "", "", false
+	}
+	return refItems[index].kind, refItems[index].name, true
 }
 
-// ShowWhatIsThis displays information about what this tool does
-func ShowWhatIsThis() {
-	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("ℹ️  What is UE Git Plugin Manager?"))
-	fmt.Println()
+// switchTrackedBranch warns about the rebuild a branch switch causes and saves it on confirmation
+func switchTrackedBranch(app Application, config *config.Config, newBranch string) {
+	if newBranch == config.DefaultRemoteBranch {
+		return
+	}
 
-	fmt.Println("This tool automates the setup of Git source control for multiple Unreal Engine installations.")
-	fmt.Println("It was created because manually setting up Git source control in UE is complex and error-prone.")
 	fmt.Println()
-
-	fmt.Println(color.New(color.FgYellow, color.Bold).Sprint("Why was this tool created?"))
-	fmt.Println("• Manual setup involves multiple complex steps that are easy to forget")
-	fmt.Println("• This setup is typically done infrequently, so the process needs to be re-learned each time")
-	fmt.Println("• Teams constantly change Unreal Engine versions, requiring updates and rebuilds")
-	fmt.Println("• Setting up on multiple team members' computers is time-consuming and error-prone")
+	if strings.TrimSpace(config.PinnedCommitSHA) != "" {
+		fmt.Printf("⚠️  Setups are pinned to commit %s, which takes precedence over the branch.\n", config.PinnedCommitSHA)
+		fmt.Println("   The new branch is only used once the pin is removed (pinned_commit_sha in config.json).")
+	} else {
+		fmt.Printf("⚠️  Switching to %s changes the plugin source for every managed engine.\n", newBranch)
+		fmt.Println("   Each engine will need \"Update Setup\", which checks out the new branch and rebuilds")
+		fmt.Println("   the plugin. If the branch doesn't continue from the current one, the update stops")
+		fmt.Println("   and offers to reset the worktree to it.")
+	}
+	if len(config.Engines) > 0 {
+		fmt.Printf("   Managed engines affected: %d\n", len(config.Engines))
+	}
 	fmt.Println()
 
-	fmt.Println(color.New(color.FgYellow, color.Bold).Sprint("What does the setup do?"))
-	fmt.Println("The setup process performs the following technical steps:")
-	fmt.Println()
-	fmt.Println("1. Git Repository Management:")
-	fmt.Println("   • Clones the UEGitPlugin repository from GitHub")
-	fmt.Println("   • Creates Git worktrees for each UE version (separate working directories)")
-	fmt.Println("   • Fetches updates from the remote repository")
-	fmt.Println("   • Why separate directories? Each UE version may need different plugin versions")
-	fmt.Println("     or configurations, and worktrees allow us to maintain version-specific")
-	fmt.Println("     branches while sharing the same Git history and updates.")
-	fmt.Println()
-	fmt.Println("2. Plugin Integration:")
-	fmt.Println("   • Builds the plugin for each specific UE version")
-	fmt.Println("   • Creates Windows junctions (symbolic links) from UE's plugin directory to the worktree")
-	fmt.Println("   • Links: UE_5.X/Engine/Plugins/UEGitPlugin_PB → worktrees/UE_5.X")
-	fmt.Println()
-	fmt.Println("3. Plugin Configuration:")
-	fmt.Println("   • Disables the stock Git plugin to prevent conflicts")
-	fmt.Println("   • Ensures the custom plugin is properly registered")
-	fmt.Println()
-	fmt.Println("4. Multi-Version Support:")
-	fmt.Println("   • Manages separate worktrees for each UE version")
-	fmt.Println("   • Handles different binary requirements per UE version")
-	fmt.Println("   • Maintains version-specific configurations")
-	fmt.Println()
+	if !utils.ConfirmStep(fmt.Sprintf("Track %s instead of %s?", newBranch, config.DefaultRemoteBranch)) {
+		return
+	}
 
-	fmt.Println(color.New(color.FgRed, color.Bold).Sprint("⚠️  Important Assumptions"))
-	fmt.Println("This tool makes several assumptions that may change in the future:")
-	fmt.Println()
-	fmt.Println("1. Unreal Engine Structure:")
-	fmt.Println("   • UE installs in 'C:\\Program Files\\Epic Games\\UE_X.X' format")
-	fmt.Println("   • Plugin directory is at 'Engine/Plugins/UEGitPlugin_PB'")
-	fmt.Println("   • Stock Git plugin is located at 'Engine/Plugins/Developer/GitSourceControl'")
-	fmt.Println()
-	fmt.Println("2. Plugin Repository:")
-	fmt.Println("   • UEGitPlugin repository structure remains consistent")
-	fmt.Println("   • Plugin builds with standard UE build system")
-	fmt.Println("   • Binary output goes to 'Binaries/Win64/' directory")
-	fmt.Println("   • Main DLL is named 'UnrealEditor-GitSourceControl.dll'")
-	fmt.Println()
-	fmt.Println("3. Windows System:")
-	fmt.Println("   • 'fsutil reparsepoint query' command is available")
-	fmt.Println("   • Git is installed and accessible via command line")
-	fmt.Println("   • Junction creation works without admin privileges on modern Windows")
-	fmt.Println()
-	fmt.Println("4. File System:")
-	fmt.Println("   • User has write access to UE installation directories")
-	fmt.Println("   • User config directory is accessible (%APPDATA%\\ue-git-plugin-manager)")
-	fmt.Println("   • No antivirus interference with junction creation")
-	fmt.Println()
-	fmt.Println("If any of these assumptions change, the tool may need updates.")
-	fmt.Println("Check this list when troubleshooting setup issues.")
+	config.DefaultRemoteBranch = newBranch
+	if err := app.GetConfig().Save(config); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
+	} else {
+		fmt.Println("✅ Branch updated!")
+	}
+	utils.Pause()
 }
 
-// showConfiguration displays the current configuration
-func showConfiguration(config *config.Config) {
-	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("📋 Current Configuration"))
+// pinToTag pins setups to the commit a release tag points to
+func pinToTag(app Application, config *config.Config, tag string) {
+	sha, err := app.GetGit().ResolveTag(tag)
+	if err != nil {
+		fmt.Printf("❌ Failed to resolve tag %s: %v\n", tag, err)
+		utils.Pause()
+		return
+	}
+	if sha == config.PinnedCommitSHA {
+		fmt.Printf("Setups are already pinned to %s.\n", tag)
+		utils.Pause()
+		return
+	}
+
 	fmt.Println()
-	fmt.Printf("Default Remote Branch: %s\n", config.DefaultRemoteBranch)
-	fmt.Printf("Pinned Commit SHA: %s\n", config.PinnedCommitSHA)
-	fmt.Printf("Origin Directory: %s\n", config.OriginDir)
-	fmt.Printf("Worktrees Directory: %s\n", config.WorktreesDir)
-	fmt.Printf("Custom Engine Roots: %v\n", config.CustomEngineRoots)
-	fmt.Printf("Managed Engines: %d\n", len(config.Engines))
+	fmt.Printf("⚠️  Pinning to %s checks out commit %s on every managed engine when it is updated,\n", tag, git.ShortSHA(sha))
+	fmt.Println("   whichever branch is tracked, and rebuilds the plugin for each engine.")
 	fmt.Println()
-
-	for i, eng := range config.Engines {
-		fmt.Printf("  %d. UE %s at %s\n", i+1, eng.EngineVersion, eng.EnginePath)
-		fmt.Printf("     Worktree: %s\n", eng.WorktreeSubdir)
-		fmt.Printf("     Branch: %s\n", eng.Branch)
-		fmt.Printf("     Stock Plugin Disabled: %t\n", eng.StockPluginDisabledByTool)
-		fmt.Println()
+	if !utils.ConfirmStep(fmt.Sprintf("Pin setups to %s?", tag)) {
+		return
 	}
 
+	config.PinnedCommitSHA = sha
+	if err := app.GetConfig().Save(config); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
+	} else {
+		fmt.Printf("✅ Pinned to %s (%s)\n", tag, git.ShortSHA(sha))
+	}
 	utils.Pause()
 }
 
-// changeBranch allows the user to change the tracked branch
-func changeBranch(app Application, config *config.Config) {
-	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🌿 Change Tracked Branch"))
+// changeMirror sets or clears the studio-local mirror used for clone and fetch
+func changeMirror(app Application, config *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🪞 Repository Mirror"))
+	fmt.Println()
+	fmt.Println("A mirror of the plugin repository on your network is used for clone and fetch,")
+	fmt.Println("with GitHub as the fallback when the mirror can't be reached.")
 	fmt.Println()
 
-	fmt.Printf("Current branch: %s\n", config.DefaultRemoteBranch)
-	fmt.Print("Enter new branch name: ")
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Scan()
-	newBranch := strings.TrimSpace(scanner.Text())
-
-	if newBranch != "" {
-		config.DefaultRemoteBranch = newBranch
-		app.GetConfig().Save(config)
-		fmt.Println("✅ Branch updated!")
+	if config.MirrorURL != "" {
+		fmt.Printf("Current mirror: %s\n", config.MirrorURL)
+	} else {
+		fmt.Println("Current mirror: none (GitHub only)")
+	}
+	fmt.Print("Enter mirror URL (\"-\" to clear, empty to keep): ")
+	input := utils.ReadLine()
+
+	switch input {
+	case "":
+		utils.Pause()
+		return
+	case "-":
+		config.MirrorURL = ""
+	default:
+		fmt.Print("Checking mirror... ")
+		if err := app.GetGit().CheckRemote(input); err != nil {
+			fmt.Printf("⚠️  Not reachable right now: %v\n", err)
+			fmt.Println("   It will be saved anyway; GitHub is used while the mirror is unavailable.")
+		} else {
+			fmt.Println("✅ Reachable")
+		}
+		config.MirrorURL = input
 	}
 
+	if err := app.GetConfig().Save(config); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
+	} else {
+		fmt.Println("✅ Mirror updated!")
+	}
 	utils.Pause()
 }
 
@@ -1214,6 +2614,7 @@ func rescanEngines(app Application, config *config.Config) {
 	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🔍 Rescanning for Engines"))
 	fmt.Println()
 
+	app.GetDetection().ForgetDiscoveredEngines()
 	engines, err := app.GetEngine().DiscoverEngines(config.CustomEngineRoots)
 	if err != nil {
 		fmt.Printf("❌ Failed to rescan engines: %v\n", err)
@@ -1272,8 +2673,7 @@ func reEnableStockPlugin(app Application, config *config.Config) {
 
 	// Let user select an engine
 	fmt.Print("Enter engine number (or 0 to cancel): ")
-	var choice int
-	fmt.Scanln(&choice)
+	choice, _ := strconv.Atoi(utils.ReadLine())
 
 	if choice < 1 || choice > len(config.Engines) {
 		fmt.Println("Invalid selection.")
@@ -1309,6 +2709,8 @@ func reEnableStockPlugin(app Application, config *config.Config) {
 		if err := app.GetConfig().Save(config); err != nil {
 			fmt.Printf("Warning: Failed to update configuration: %v\n", err)
 		}
+
+		offerPluginCacheCleanup(app, config, selectedEngine.EnginePath, selectedEngine.EngineVersion)
 	}
 
 	utils.Pause()
@@ -1322,7 +2724,7 @@ func fixPluginCollision(app Application, config *config.Config) {
 	for _, eng := range config.Engines {
 		if app.GetEngine().CheckPluginCollision(eng.EnginePath) {
 			fmt.Printf("UE %s: Collision detected\n", eng.EngineVersion)
-			if utils.Confirm("Disable stock Git plugin?") {
+			if utils.ConfirmStep("Disable stock Git plugin?") {
 				if err := app.GetEngine().DisableStockPlugin(eng.EnginePath); err != nil {
 					fmt.Printf("❌ Failed: %v\n", err)
 				} else {
@@ -1343,7 +2745,7 @@ func repairBrokenSetup(app Application, config *config.Config) {
 	fmt.Println()
 
 	// Find engines that need repair
-	needingSetup, err := app.GetDetection().FindEnginesNeedingSetup(config.CustomEngineRoots)
+	needingSetup, err := app.GetDetection().FindEnginesNeedingSetup(config)
 	if err != nil {
 		fmt.Printf("❌ Failed to detect engines needing repair: %v\n", err)
 		utils.Pause()
@@ -1363,7 +2765,7 @@ func repairBrokenSetup(app Application, config *config.Config) {
 		fmt.Println()
 	}
 
-	if !utils.Confirm("Would you like to attempt to repair these engines?") {
+	if !utils.ConfirmStep("Would you like to attempt to repair these engines?") {
 		return
 	}
 
@@ -1374,7 +2776,7 @@ func repairBrokenSetup(app Application, config *config.Config) {
 		// Check if worktree exists, if not create it
 		if !status.WorktreeExists {
 			fmt.Printf("  Creating worktree... ")
-			if err := app.GetGit().CreateWorktree(status.EngineVersion, config.DefaultRemoteBranch, config.PinnedCommitSHA); err != nil {
+			if err := app.GetGit().CreateWorktree(status.WorktreeSubdir, config.TrackedBranch(status.WorktreeSubdir), config.TrackedPin(status.WorktreeSubdir), localPatches(app, config, status.WorktreeSubdir)); err != nil {
 				fmt.Printf("❌ Failed: %v\n", err)
 				continue
 			}
@@ -1384,7 +2786,7 @@ func repairBrokenSetup(app Application, config *config.Config) {
 		// Check if junction exists and is valid, if not create/fix it
 		if !status.JunctionExists || !status.JunctionValid {
 			fmt.Printf("  Creating/fixing junction... ")
-			worktreePath := app.GetGit().GetWorktreePath(status.EngineVersion)
+			worktreePath := app.GetGit().GetWorktreePath(status.WorktreeSubdir)
 			if err := app.GetPlugin().CreateJunction(status.EnginePath, worktreePath); err != nil {
 				fmt.Printf("❌ Failed: %v\n", err)
 				continue
@@ -1392,11 +2794,11 @@ func repairBrokenSetup(app Application, config *config.Config) {
 			fmt.Printf("✅ Done\n")
 		}
 
-		// Check if binaries exist, if not rebuild them
-		if !status.BinariesExist {
+		// Rebuild binaries if they are missing, corrupted or stale
+		if !status.BinariesExist || status.BinariesStale || status.BinariesCorrupted {
 			fmt.Printf("  Rebuilding plugin... ")
-			worktreePath := app.GetGit().GetWorktreePath(status.EngineVersion)
-			if err := app.GetPlugin().BuildForEngine(status.EnginePath, worktreePath); err != nil {
+			worktreePath := app.GetGit().GetWorktreePath(status.WorktreeSubdir)
+			if err := buildPlugin(app, status.EnginePath, worktreePath); err != nil {
 				fmt.Printf("❌ Failed: %v\n", err)
 				continue
 			}
@@ -1429,6 +2831,9 @@ func runDiagnostics(app Application, config *config.Config) {
 	// Check Git availability
 	if app.GetGit().IsGitAvailable() {
 		version, _ := app.GetGit().GetGitVersion()
+		if app.GetGit().IsUsingMinGit() {
+			version += " (portable MinGit)"
+		}
 		fmt.Printf("✅ Git: %s\n", version)
 	} else {
 		fmt.Println("❌ Git: Not available")
@@ -1441,10 +2846,17 @@ func runDiagnostics(app Application, config *config.Config) {
 		fmt.Println("❌ Origin repository: Not cloned")
 	}
 
+	// Legacy console code pages show text symbols instead of emoji
+	if console.UTF8() {
+		fmt.Println("✅ Console: UTF-8")
+	} else {
+		fmt.Println(theme.Label(theme.Warning, "Console: legacy code page, using text symbols (switch to Windows Terminal or enable UTF-8 for emoji)"))
+	}
+
 	// Use detection system for comprehensive status
 	fmt.Println()
 	fmt.Println("Engine Setup Status:")
-	statuses, err := app.GetDetection().DetectSetupStatus(config.CustomEngineRoots)
+	statuses, err := app.GetDetection().DetectSetupStatus(config)
 	if err != nil {
 		fmt.Printf("❌ Failed to detect setup status: %v\n", err)
 	} else {
@@ -1457,6 +2869,16 @@ func runDiagnostics(app Application, config *config.Config) {
 			}
 			fmt.Printf("  Binaries: %s\n", getStatusIcon(status.BinariesExist))
 			fmt.Printf("  Stock Plugin: %s\n", GetStockPluginStatusIcon(status.StockPluginStatus))
+			for _, prereq := range app.GetEngine().CheckBuildPrerequisites(status.EnginePath) {
+				icon := getStatusIcon(prereq.Found)
+				if prereq.Unsure {
+					icon = theme.Label(theme.Warning, "Unsure")
+				}
+				fmt.Printf("  %s: %s %s\n", prereq.Name, icon, prereq.Detail)
+				if !prereq.Found && prereq.DownloadURL != "" {
+					fmt.Printf("    Download: %s\n", prereq.DownloadURL)
+				}
+			}
 
 			if len(status.Issues) > 0 {
 				fmt.Println("  Issues:")
@@ -1469,7 +2891,7 @@ func runDiagnostics(app Application, config *config.Config) {
 	}
 
 	// Show engines that need attention
-	needingSetup, err := app.GetDetection().FindEnginesNeedingSetup(config.CustomEngineRoots)
+	needingSetup, err := app.GetDetection().FindEnginesNeedingSetup(config)
 	if err == nil && len(needingSetup) > 0 {
 		fmt.Println("⚠️  Engines needing setup:")
 		for _, status := range needingSetup {
@@ -1502,8 +2924,7 @@ func rebuildPluginForEngine(app Application, config *config.Config) {
 
 	fmt.Println()
 	fmt.Print("Enter engine number (or 0 to cancel): ")
-	var choice int
-	fmt.Scanln(&choice)
+	choice, _ := strconv.Atoi(utils.ReadLine())
 
 	if choice < 1 || choice > len(config.Engines) {
 		fmt.Println("Invalid selection.")
@@ -1512,7 +2933,7 @@ func rebuildPluginForEngine(app Application, config *config.Config) {
 	}
 
 	selectedEngine := config.Engines[choice-1]
-	worktreePath := app.GetGit().GetWorktreePath(selectedEngine.EngineVersion)
+	worktreePath := app.GetGit().GetWorktreePath(engineSubdir(selectedEngine))
 
 	fmt.Printf("Rebuilding plugin for UE %s...\n", selectedEngine.EngineVersion)
 	fmt.Printf("  Engine path: %s\n", selectedEngine.EnginePath)
@@ -1527,7 +2948,7 @@ func rebuildPluginForEngine(app Application, config *config.Config) {
 		}
 	}
 
-	if err := app.GetPlugin().BuildForEngine(selectedEngine.EnginePath, worktreePath); err != nil {
+	if err := buildPlugin(app, selectedEngine.EnginePath, worktreePath); err != nil {
 		fmt.Printf("❌ Failed to rebuild plugin: %v\n", err)
 	} else {
 		fmt.Printf("✅ Plugin rebuilt successfully for UE %s\n", selectedEngine.EngineVersion)
@@ -1542,18 +2963,26 @@ func runProjectToolsMenu(app Application) error {
 			"Repair My Locks",
 			"Show Current Project Locks",
 			"Run Project Setup Wizard",
+			"Open Project in Editor",
+			"Source Control Smoke Test",
+			"Check Repository Health",
+			"Plugin as Project Submodule",
+			"Plugin Settings",
+			"Status Branches",
+			"Project Plugin Overrides",
+			"Manage Registered Projects",
 			"Back",
 		}
 
 		prompt := promptui.Select{
 			Label:    "Project Tools",
 			Items:    items,
-			Size:     10,
+			Size:     13,
 			HideHelp: true,
 			Stdout:   &utils.BellSkipper{},
 		}
 
-		_, choice, err := prompt.Run()
+		_, choice, err := selectWithHelp(&prompt, helpProjectTools)
 		if err != nil {
 			if err == promptui.ErrInterrupt {
 				return nil
@@ -1574,6 +3003,38 @@ func runProjectToolsMenu(app Application) error {
 			if err := runProjectConfigurator(app); err != nil {
 				return err
 			}
+		case "Open Project in Editor":
+			if err := runOpenProjectInEditor(app); err != nil {
+				return err
+			}
+		case "Source Control Smoke Test":
+			if err := runSourceControlSmokeTest(app); err != nil {
+				return err
+			}
+		case "Check Repository Health":
+			if err := runProjectHealthCheck(app); err != nil {
+				return err
+			}
+		case "Plugin as Project Submodule":
+			if err := runProjectSubmodule(app); err != nil {
+				return err
+			}
+		case "Plugin Settings":
+			if err := runPluginSettings(app); err != nil {
+				return err
+			}
+		case "Status Branches":
+			if err := runStatusBranches(app); err != nil {
+				return err
+			}
+		case "Project Plugin Overrides":
+			if err := runProjectOverrides(app); err != nil {
+				return err
+			}
+		case "Manage Registered Projects":
+			if err := runManageProjects(app); err != nil {
+				return err
+			}
 		case "Back":
 			return nil
 		}
@@ -1583,41 +3044,73 @@ func runProjectToolsMenu(app Application) error {
 }
 
 func promptForProjectRoot(app Application) (string, error) {
-	fmt.Print("Enter or paste the project folder path: ")
-	scanner := bufio.NewScanner(os.Stdin)
-	if !scanner.Scan() {
-		return "", fmt.Errorf("no input received")
-	}
-
-	input := strings.TrimSpace(scanner.Text())
-	input = strings.Trim(input, "\"")
-	if input == "" {
-		exeDir := app.GetConfig().GetExeDir()
-		if strings.TrimSpace(exeDir) == "" {
-			cwd, err := os.Getwd()
-			if err != nil {
-				return "", err
-			}
-			exeDir = cwd
+	// Suggest registered and recently opened projects before falling back to a typed path
+	suggestions := suggestedProjectRoots(app)
+	if len(suggestions) > 0 {
+		const manualEntry = "Enter a path manually..."
+		items := append(append([]string{}, suggestions...), manualEntry)
+		prompt := promptui.Select{
+			Label:    "Select a project",
+			Items:    items,
+			Size:     10,
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		index, _, err := utils.RunSelect(&prompt)
+		if err != nil {
+			return "", err
+		}
+		if index < len(suggestions) {
+			return projectconfig.DetectProjectRoot(suggestions[index])
 		}
-		fmt.Printf("No path entered, using executable folder: %s\n", exeDir)
-		input = exeDir
 	}
-	if input == "." {
+
+	defaultDir := app.GetConfig().GetUserFilesDir()
+	if strings.TrimSpace(defaultDir) == "" {
 		cwd, err := os.Getwd()
 		if err != nil {
 			return "", err
 		}
-		input = cwd
+		defaultDir = cwd
 	}
 
-	cleanPath := filepath.Clean(input)
-	root, err := projectconfig.DetectProjectRoot(cleanPath)
+	prompt := utils.PathPrompt{
+		Label:       "Enter or paste the project folder path: ",
+		HistoryFile: app.GetConfig().GetHistoryFile("project_paths"),
+		Default:     defaultDir,
+		Validate: func(path string) error {
+			_, err := projectconfig.DetectProjectRoot(path)
+			return err
+		},
+	}
+	path, err := prompt.Run()
 	if err != nil {
 		return "", err
 	}
+	return projectconfig.DetectProjectRoot(path)
+}
+
+// suggestedProjectRoots returns registered projects followed by projects recently opened in the editor
+func suggestedProjectRoots(app Application) []string {
+	var roots []string
+	seen := make(map[string]bool)
+	add := func(root string) {
+		key := utils.PathKey(root)
+		if !seen[key] {
+			seen[key] = true
+			roots = append(roots, root)
+		}
+	}
 
-	return root, nil
+	if cfg, err := loadConfigOrDefault(app); err == nil {
+		for _, project := range cfg.Projects {
+			add(project.Path)
+		}
+	}
+	for _, root := range projects.RecentProjects() {
+		add(root)
+	}
+	return roots
 }
 
 func runShowCurrentProjectLocks(app Application) error {
@@ -1741,7 +3234,240 @@ func formatLockTimestamp(value string) string {
 	return parsed.Local().Format("2006-01-02 15:04:05")
 }
 
-// runProjectConfigurator starts the Configure project wizard
+// RunForPath opens the flow for a project or engine folder given on the command line or
+// dropped onto the executable: the Configure project wizard or the engine's setup options
+func RunForPath(app Application, path string) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+	if readOnly || cfg.ViewerMode {
+		return fmt.Errorf("viewer mode is read-only; open the menu to check status")
+	}
+
+	if eng, ok := app.GetEngine().InspectEngine(path); ok {
+		subdir := config.WorktreeSubdirFor(cfg, eng.Path, eng.Version)
+		status := app.GetDetection().DetectEngineSetupStatus(eng.Path, eng.Version, subdir)
+		return runEngineEditOptions(app, cfg, status)
+	}
+
+	// Accept the .uproject file itself as well as its folder
+	if strings.EqualFold(filepath.Ext(path), ".uproject") {
+		path = filepath.Dir(path)
+	}
+	if root, err := projectconfig.DetectProjectRoot(path); err == nil {
+		printProjectConfiguratorIntro()
+		fmt.Printf("Project folder: %s\n\n", root)
+		return configureProjectAt(app, root)
+	}
+
+	return fmt.Errorf("%s is not an Unreal project or engine folder", path)
+}
+
+// runProjectConfigurator starts the Configure project wizard, warning first if the project's engine isn't set up
 func runProjectConfigurator(app Application) error {
-	return projectconfig.RunWizard()
+	printProjectConfiguratorIntro()
+
+	prompt := promptui.Select{
+		Label:    "Which project?",
+		Items:    []string{"Existing project", "New project (scaffold an empty folder)"},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return nil
+	}
+	if index == 1 {
+		return runProjectScaffold(app)
+	}
+
+	root, err := promptForProjectRoot(app)
+	if err != nil {
+		return fmt.Errorf("invalid project path: %w", err)
+	}
+
+	return configureProjectAt(app, root)
+}
+
+// printProjectConfiguratorIntro prints the Configure project wizard header
+func printProjectConfiguratorIntro() {
+	fmt.Println("🔧 Configure Unreal Project")
+	fmt.Println()
+	fmt.Println("This wizard will help set up .gitattributes, .gitignore, and Unreal INI settings for your project.")
+	fmt.Println()
+}
+
+// configureProjectAt runs the Configure project wizard for a known project root
+func configureProjectAt(app Application, root string) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+
+	warnIfProjectEngineNotSetUp(app, cfg, root)
+	offerProjectRegistration(app, cfg, root)
+	fmt.Println()
+	offerGitLFS(app)
+
+	if err := projectconfig.ConfigureProject(root); err != nil {
+		return err
+	}
+	offerCIWorkflow(app, cfg, root)
+
+	if _, err := projects.Read(root); err == nil {
+		fmt.Println()
+		if utils.Confirm("Open the project in the editor now to check that source control connects?") {
+			return openProjectInEditor(app, cfg, root)
+		}
+	}
+	return nil
+}
+
+// loadConfigOrDefault loads the configuration for menus that are not handed one
+func loadConfigOrDefault(app Application) (*config.Config, error) {
+	if !app.GetConfig().Exists() {
+		return app.GetConfig().CreateDefault(), nil
+	}
+	cfg, err := app.GetConfig().Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %v", err)
+	}
+	return cfg, nil
+}
+
+// warnIfProjectEngineNotSetUp prints a warning when the project's engine doesn't have the plugin set up
+func warnIfProjectEngineNotSetUp(app Application, cfg *config.Config, root string) {
+	info, err := projects.Read(root)
+	if err != nil || info.EngineAssociation == "" {
+		return
+	}
+
+	statuses, err := app.GetDetection().DetectSetupStatus(cfg)
+	if err != nil {
+		return
+	}
+
+	found := false
+	for _, status := range statuses {
+		if !info.UsesEngine(status.EnginePath, status.EngineVersion) {
+			continue
+		}
+		found = true
+		if !status.IsSetupComplete {
+			fmt.Printf("⚠️  %s uses UE %s (%s), which does not have the Git plugin set up yet.\n", info.Name, status.EngineVersion, status.EnginePath)
+			fmt.Println("   Use \"Edit Setup\" from the main menu to set it up before opening the project.")
+		}
+	}
+	if !found {
+		fmt.Printf("⚠️  %s uses engine %q, which was not found on this machine.\n", info.Name, info.EngineAssociation)
+	}
+}
+
+// offerProjectRegistration offers to remember a project so status can show which engine it uses
+func offerProjectRegistration(app Application, cfg *config.Config, root string) {
+	for _, project := range cfg.Projects {
+		if utils.SamePath(project.Path, root) {
+			return
+		}
+	}
+	if !utils.ConfirmStep("Register this project so status shows which engine it uses?") {
+		return
+	}
+	registerProject(app, cfg, root)
+}
+
+// registerProject adds a project to the configuration and saves it
+func registerProject(app Application, cfg *config.Config, root string) {
+	project := config.Project{Path: root, Name: filepath.Base(root)}
+	if info, err := projects.Read(root); err == nil {
+		project.Name = info.Name
+	}
+	app.GetConfig().AddProject(cfg, project)
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Registered project %s\n", project.Name)
+}
+
+// runManageProjects lists registered projects with their engines and lets the user add or remove them
+func runManageProjects(app Application) error {
+	for {
+		cfg, err := loadConfigOrDefault(app)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("📁 Registered Projects"))
+		fmt.Println()
+		if len(cfg.Projects) == 0 {
+			fmt.Println("No projects registered.")
+		}
+		for _, project := range cfg.Projects {
+			engineText := "unknown engine"
+			if info, err := projects.Read(project.Path); err != nil {
+				engineText = fmt.Sprintf("⚠️  %v", err)
+			} else if info.EngineAssociation != "" {
+				engineText = "UE " + info.EngineAssociation
+			}
+			fmt.Printf("  - %s (%s)\n    %s\n", project.Name, engineText, project.Path)
+		}
+		fmt.Println()
+
+		prompt := promptui.Select{
+			Label:    "Registered Projects",
+			Items:    []string{"Add Project", "Remove Project", "Back"},
+			Size:     10,
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		_, choice, err := selectWithHelp(&prompt, helpProjects)
+		if err != nil {
+			if err == promptui.ErrInterrupt {
+				return nil
+			}
+			return err
+		}
+
+		switch choice {
+		case "Add Project":
+			root, err := promptForProjectRoot(app)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				utils.Pause()
+				continue
+			}
+			registerProject(app, cfg, root)
+			warnIfProjectEngineNotSetUp(app, cfg, root)
+			utils.Pause()
+		case "Remove Project":
+			if len(cfg.Projects) == 0 {
+				continue
+			}
+			var items []string
+			for _, project := range cfg.Projects {
+				items = append(items, fmt.Sprintf("%s (%s)", project.Name, project.Path))
+			}
+			items = append(items, "Cancel")
+			removePrompt := promptui.Select{
+				Label:    "Select a project to remove",
+				Items:    items,
+				Size:     10,
+				HideHelp: true,
+				Stdout:   &utils.BellSkipper{},
+			}
+			index, _, err := utils.RunSelect(&removePrompt)
+			if err != nil || index >= len(cfg.Projects) {
+				continue
+			}
+			app.GetConfig().RemoveProject(cfg, cfg.Projects[index].Path)
+			if err := app.GetConfig().Save(cfg); err != nil {
+				fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+			}
+		case "Back":
+			return nil
+		}
+		fmt.Println()
+	}
 }
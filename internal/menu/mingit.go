@@ -0,0 +1,48 @@
+package menu
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// offerMinGit explains that Git is missing and offers to install the portable MinGit into the
+// base dir, from a zip shipped next to the exe when there is one. It reports whether git is now available.
+func offerMinGit(app Application) bool {
+	if app.GetGit().IsGitAvailable() {
+		return true
+	}
+	fmt.Println(color.YellowString("⚠️  Git was not found on this machine."))
+	fmt.Println("The plugin is downloaded and updated with Git. You can install Git for Windows")
+	fmt.Println("(https://git-scm.com/download/win), or let this tool use a portable MinGit that")
+	fmt.Printf("only it uses, stored in %s.\n", app.GetGit().MinGitDir())
+	fmt.Println()
+
+	bundled := git.FindBundledMinGit(app.GetConfig().GetExeDir())
+	question := "Download portable MinGit from the Git for Windows releases on GitHub?"
+	if bundled != "" {
+		question = fmt.Sprintf("Install portable MinGit from %s?", filepath.Base(bundled))
+	}
+	if !utils.ConfirmStep(question) {
+		return false
+	}
+
+	if bundled == "" {
+		fmt.Println("📥 Downloading MinGit...")
+	} else {
+		fmt.Println("📦 Extracting MinGit...")
+	}
+	if err := app.GetGit().InstallMinGit(bundled); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		utils.Pause()
+		return false
+	}
+	version, _ := app.GetGit().GetGitVersion()
+	fmt.Printf("✅ Portable MinGit installed (%s)\n", version)
+	utils.Pause()
+	return true
+}
@@ -0,0 +1,27 @@
+package menu
+
+import (
+	"fmt"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// confirmNetworkEngine explains what changes when an engine lives on a network share and asks
+// before the plugin is copied into it, since every machine using the share sees that copy
+func confirmNetworkEngine(app Application, enginePath string) bool {
+	if !app.GetPlugin().UsesCopyMode(enginePath) {
+		return true
+	}
+
+	fmt.Printf("🌐 %s is on a network path.\n", enginePath)
+	fmt.Println("   Junctions can't be created on a network share, and a link there pointing at this PC's")
+	fmt.Println("   plugin worktree would only resolve on this PC. The plugin is copied into the engine's")
+	fmt.Println("   Plugins folder instead and refreshed after every build.")
+	fmt.Println()
+	fmt.Println("   - Every machine that opens the engine from this share uses the copy built here")
+	fmt.Println("   - Refreshing fails while an editor on any machine has the plugin loaded")
+	fmt.Println("   - Engines on mapped drives are recorded by their UNC path, since drive letters")
+	fmt.Println("     differ between users and are not visible to elevated processes")
+	fmt.Println()
+	return utils.ConfirmStep("Copy the plugin into this engine?")
+}
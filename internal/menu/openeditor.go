@@ -0,0 +1,100 @@
+package menu
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/projects"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// runOpenProjectInEditor opens a project in the editor of the engine it is associated with,
+// checking the plugin setup first, to confirm end to end that source control connects
+func runOpenProjectInEditor(app Application) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+
+	root, err := promptForProjectRoot(app)
+	if err != nil {
+		return fmt.Errorf("invalid project path: %w", err)
+	}
+	return openProjectInEditor(app, cfg, root)
+}
+
+// projectEngineStatus reads a project and finds the setup status of the engine it is associated with
+func projectEngineStatus(app Application, cfg *config.Config, root string) (projects.Info, detection.SetupStatus, error) {
+	info, err := projects.Read(root)
+	if err != nil {
+		return info, detection.SetupStatus{}, err
+	}
+	if info.EngineAssociation == "" {
+		return info, detection.SetupStatus{}, fmt.Errorf("%s has no engine association; open it once with the engine you want and try again", filepath.Base(info.UProjectPath))
+	}
+
+	statuses, err := app.GetDetection().DetectSetupStatus(cfg)
+	if err != nil {
+		return info, detection.SetupStatus{}, fmt.Errorf("failed to detect setup status: %v", err)
+	}
+	for _, status := range statuses {
+		if info.UsesEngine(status.EnginePath, status.EngineVersion) {
+			return info, status, nil
+		}
+	}
+	return info, detection.SetupStatus{}, fmt.Errorf("%s uses engine %q, which was not found on this machine", info.Name, info.EngineAssociation)
+}
+
+// openProjectInEditor verifies the plugin setup of a project's engine and launches the editor with it
+func openProjectInEditor(app Application, cfg *config.Config, root string) error {
+	info, status, err := projectEngineStatus(app, cfg, root)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Project: %s\n", info.UProjectPath)
+	fmt.Printf("Engine:  UE %s (%s)\n", status.EngineVersion, status.EnginePath)
+	fmt.Println()
+
+	if sub, err := app.GetGit().GetProjectSubmodule(root); err == nil && sub != nil {
+		// The project carries its own copy of the plugin, so the engine-level setup doesn't matter
+		if !sub.IsInitialized() {
+			fmt.Printf("⚠️  The plugin submodule %s is not checked out; the editor will not find the plugin.\n", sub.Path)
+			if !utils.Confirm("Open the project anyway?") {
+				return nil
+			}
+		} else {
+			fmt.Printf("✅ The project uses the plugin from its submodule %s\n", sub.Path)
+		}
+	} else {
+		problems := append([]string{}, status.Issues...)
+		problems = append(problems, status.EditorLoadIssues...)
+		if status.IsSetupComplete && len(problems) == 0 {
+			fmt.Println("✅ Plugin setup verified")
+		} else {
+			if !status.IsSetupComplete {
+				fmt.Printf("⚠️  UE %s does not have a working Git plugin setup:\n", status.EngineVersion)
+			} else {
+				fmt.Println("⚠️  The editor may not load the plugin:")
+			}
+			for _, problem := range problems {
+				fmt.Printf("   - %s\n", problem)
+			}
+			fmt.Println("   Use \"Edit Setup\" from the main menu to set it up or repair it.")
+			if !utils.Confirm("Open the project anyway?") {
+				return nil
+			}
+		}
+	}
+
+	if err := app.GetEngine().LaunchEditor(status.EnginePath, info.UProjectPath); err != nil {
+		return err
+	}
+	fmt.Println("🚀 Editor starting...")
+	fmt.Println("   Once it has loaded, check the Revision Control status in the bottom-right corner, or use")
+	fmt.Println("   \"Connect to Revision Control\" and choose Git, to confirm source control connects.")
+	utils.Pause()
+	return nil
+}
@@ -0,0 +1,139 @@
+package menu
+
+import (
+	"fmt"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// otherUserLinkKept reports whether an engine's plugin link belongs to another Windows user's
+// setup this user chose to coexist with, so setup and repair must leave the link alone. A link
+// to another user's setup nobody decided about is an error: replacing it without asking would
+// silently break that user's setup.
+func otherUserLinkKept(app Application, cfg *config.Config, enginePath string) (bool, error) {
+	owner := app.GetDetection().LinkOwner(enginePath)
+	if owner == "" {
+		return false, nil
+	}
+	switch cfg.OtherUserSetupFor(enginePath, owner) {
+	case config.OtherUserCoexist:
+		return true, nil
+	case config.OtherUserAdopt:
+		return false, fmt.Errorf("the engine at %s uses %s's setup; choose Replace in Edit Setup to set up your own", enginePath, owner)
+	}
+	return false, fmt.Errorf("the plugin link of the engine at %s points to %s's setup; choose to adopt, replace or coexist with it in Edit Setup first", enginePath, owner)
+}
+
+// adoptOtherUserLink records that an engine uses owner's setup, e.g. once this user's own setup
+// next to it was uninstalled
+func adoptOtherUserLink(cfg *config.Config, enginePath, owner string) {
+	cfg.SetOtherUserSetup(enginePath, owner, config.OtherUserAdopt)
+}
+
+// runOtherUserSetup explains that an engine is linked to another Windows user's setup and lets
+// this user adopt it, replace it with their own, or keep both
+func runOtherUserSetup(app Application, cfg *config.Config, status detection.SetupStatus) error {
+	owner := status.LinkOwner
+	fmt.Printf("\n👥 The plugin link of UE %s points to %s's setup:\n", status.EngineVersion, owner)
+	fmt.Printf("   %s\n", status.JunctionTarget)
+	fmt.Printf("%s set this engine up with this tool from their own Windows account. The engine has\n", owner)
+	fmt.Println("one plugin link, so every user of this machine loads the build it points to.")
+	switch status.LinkOwnerChoice {
+	case config.OtherUserAdopt:
+		fmt.Printf("You adopted it: %s's updates are what you get.\n", owner)
+	case config.OtherUserCoexist:
+		fmt.Println("You keep your own setup next to it, without the link.")
+	}
+	fmt.Println()
+
+	adoptItem := fmt.Sprintf("Adopt - use %s's setup and remove yours for this engine", owner)
+	replaceItem := "Replace - point the link at your own setup"
+	coexistItem := fmt.Sprintf("Coexist - keep %s's link and your own setup side by side", owner)
+	prompt := promptui.Select{
+		Label:    "What would you like to do?",
+		Items:    []string{adoptItem, replaceItem, coexistItem, "Back"},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	_, choice, err := selectWithHelp(&prompt, helpOtherUser)
+	if err != nil {
+		if err == promptui.ErrInterrupt {
+			return nil
+		}
+		return err
+	}
+
+	switch choice {
+	case adoptItem:
+		return adoptOtherUserSetup(app, cfg, status)
+	case replaceItem:
+		return replaceOtherUserSetup(app, cfg, status)
+	case coexistItem:
+		cfg.SetOtherUserSetup(status.EnginePath, owner, config.OtherUserCoexist)
+		if err := app.GetConfig().Save(cfg); err != nil {
+			return fmt.Errorf("failed to save configuration: %v", err)
+		}
+		fmt.Printf("✅ The link stays on %s's setup; updates and repairs of UE %s only touch your own worktree.\n", owner, status.EngineVersion)
+		if !status.WorktreeExists {
+			fmt.Println("   Run Repair Setup to create and build your own worktree.")
+		}
+		utils.Pause()
+	}
+	return nil
+}
+
+// adoptOtherUserSetup leaves the engine on another user's setup and removes this user's own
+// worktrees for it, without touching the link or the stock plugin the other setup relies on
+func adoptOtherUserSetup(app Application, cfg *config.Config, status detection.SetupStatus) error {
+	if status.WorktreeExists && !utils.ConfirmDestructive(fmt.Sprintf("Remove your own worktree for UE %s?", status.EngineVersion)) {
+		return nil
+	}
+	if status.WorktreeExists {
+		if err := app.GetGit().RemoveWorktree(status.WorktreeSubdir); err != nil {
+			return fmt.Errorf("failed to remove worktree: %v", err)
+		}
+	}
+	if eng := app.GetConfig().GetEngineByPath(cfg, status.EnginePath); eng != nil {
+		if eng.AlternateWorktreeSubdir != "" {
+			if err := app.GetGit().RemoveWorktree(eng.AlternateWorktreeSubdir); err != nil {
+				fmt.Printf("⚠️  Failed to remove the second plugin worktree %s: %v\n", eng.AlternateWorktreeSubdir, err)
+			}
+		}
+		app.GetConfig().RemoveEngine(cfg, status.EnginePath)
+	}
+
+	adoptOtherUserLink(cfg, status.EnginePath, status.LinkOwner)
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	fmt.Printf("✅ UE %s now uses %s's setup; ask them to update it.\n", status.EngineVersion, status.LinkOwner)
+	utils.Pause()
+	return nil
+}
+
+// replaceOtherUserSetup points the engine's link at this user's own setup, creating or repairing
+// it. The other user's worktree stays in their profile; their tool will report the engine as
+// linked to this user's setup.
+func replaceOtherUserSetup(app Application, cfg *config.Config, status detection.SetupStatus) error {
+	fmt.Printf("Every user of this machine, %s included, will load your build of the plugin in UE %s.\n", status.LinkOwner, status.EngineVersion)
+	if !utils.ConfirmDestructive(fmt.Sprintf("Replace %s's link with your own setup?", status.LinkOwner)) {
+		return nil
+	}
+
+	cfg.SetOtherUserSetup(status.EnginePath, status.LinkOwner, "")
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	if err := app.GetPlugin().RemoveJunction(app.GetPlugin().GetPluginLinkPath(status.EnginePath)); err != nil {
+		return fmt.Errorf("failed to remove %s's link: %v", status.LinkOwner, err)
+	}
+
+	if status.WorktreeExists {
+		return runRepairForEngine(app, cfg, status.EnginePath, status.EngineVersion, status.WorktreeSubdir)
+	}
+	return runSetupForEngine(app, cfg, status.EnginePath, status.EngineVersion, status.WorktreeSubdir)
+}
@@ -0,0 +1,139 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/logging"
+	"ue-git-plugin-manager/internal/manifest"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// importTeammateSettings copies the settings of a teammate's machine, read from a manifest they
+// exported or their shared data folder, after showing what changes. Engines are then set up as
+// usual, with the branch, mirror, fork and engine search paths already in place.
+func importTeammateSettings(app Application, cfg *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("👥 Import Settings from a Teammate"))
+	fmt.Println()
+	fmt.Println("Point this at a manifest a teammate exported (Settings → \"Export Machine Manifest\"),")
+	fmt.Println("or at a folder they shared that holds one or their config.json.")
+	fmt.Println()
+
+	path, err := utils.PathPrompt{
+		Label:       "Manifest or shared folder: ",
+		HistoryFile: app.GetConfig().GetHistoryFile("pair_import_paths"),
+		Validate: func(path string) error {
+			_, err := os.Stat(path)
+			return err
+		},
+	}.Run()
+	if err != nil {
+		return
+	}
+	m, err := manifest.LoadPeer(path)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("Settings from %s (%s):\n", m.Machine, formatLockTimestamp(m.CreatedUTC))
+	changes := settingsChanges(cfg, m.Settings)
+	if len(changes) == 0 {
+		fmt.Println("✅ This machine already has the same settings.")
+		return
+	}
+	for _, change := range changes {
+		fmt.Printf("  - %s\n", change)
+	}
+	for _, file := range m.Settings.PatchFiles {
+		if _, err := os.Stat(file); err != nil {
+			fmt.Printf("⚠️  Patch file %s can't be read from this machine and is left out; copy it over and add it in Settings → \"Local Patches\"\n", file)
+		}
+	}
+	if m.URLsDropped {
+		fmt.Println("ℹ️  URLs with a password are never copied; set a password-protected mirror or remote by hand.")
+	}
+	fmt.Println()
+	if !utils.ConfirmStep("Import these settings?") {
+		return
+	}
+
+	m.Settings.ApplyTo(cfg, app.GetConfig().GetBaseDir())
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
+		return
+	}
+	fmt.Println("✅ Settings imported")
+	if len(m.Engines) > 0 {
+		fmt.Printf("   To also set up the %d engine(s) of %s at the same plugin commits, run: %s apply \"%s\"\n",
+			len(m.Engines), m.Machine, filepath.Base(os.Args[0]), path)
+	} else {
+		fmt.Println("   Set up your engines with \"Install Setup\" from the main menu.")
+	}
+}
+
+// settingsChanges describes what importing the settings would change in a configuration
+func settingsChanges(cfg *config.Config, s manifest.Settings) []string {
+	var changes []string
+	if s.DefaultRemoteBranch != "" && s.DefaultRemoteBranch != cfg.DefaultRemoteBranch {
+		changes = append(changes, fmt.Sprintf("Tracked branch: %s → %s", cfg.DefaultRemoteBranch, s.DefaultRemoteBranch))
+	}
+	if !strings.EqualFold(s.PinnedCommitSHA, cfg.PinnedCommitSHA) {
+		if s.PinnedCommitSHA == "" {
+			changes = append(changes, "Pinned commit: removed")
+		} else {
+			changes = append(changes, fmt.Sprintf("Pinned commit: %s", s.PinnedCommitSHA))
+		}
+	}
+	if s.MirrorURL != "" && s.MirrorURL != cfg.MirrorURL {
+		changes = append(changes, fmt.Sprintf("Repository mirror: %s", logging.Redact(s.MirrorURL)))
+	}
+	for _, remote := range s.Remotes {
+		if current := cfg.RemoteURLs()[remote.Name]; current != remote.URL {
+			changes = append(changes, fmt.Sprintf("Tracked remote %s: %s", remote.Name, logging.Redact(remote.URL)))
+		}
+	}
+	for _, root := range s.CustomEngineRoots {
+		if !config.HasScanRoot(cfg.CustomEngineRoots, root.Path) {
+			changes = append(changes, fmt.Sprintf("Engine search path: %s", root.Path))
+		}
+	}
+	if strings.Join(s.PatchFiles, "\n") != strings.Join(cfg.PatchFiles, "\n") || s.PatchesBranch != cfg.PatchesBranch {
+		changes = append(changes, fmt.Sprintf("Local patches: %d file(s)%s", len(s.PatchFiles), patchesBranchSuffix(s.PatchesBranch)))
+	}
+	for version, branch := range s.BranchCompatibility {
+		if cfg.BranchCompatibility[version] != branch {
+			changes = append(changes, fmt.Sprintf("Plugin branch for UE %s: %s", version, branch))
+		}
+	}
+	for _, option := range s.PluginIniOptions {
+		if !manifest.HasIniOption(cfg.PluginIniOptions, option) {
+			changes = append(changes, fmt.Sprintf("Project plugin setting: %s", option.Label))
+		}
+	}
+	if s.SkipPluginCacheCleanup != cfg.SkipPluginCacheCleanup {
+		if s.SkipPluginCacheCleanup {
+			changes = append(changes, "Plugin cache cleanup: Off")
+		} else {
+			changes = append(changes, "Plugin cache cleanup: On")
+		}
+	}
+	if s.ColorTheme != cfg.ColorTheme || s.TextStatusSymbols != cfg.TextStatusSymbols {
+		changes = append(changes, "Status colors and symbols")
+	}
+	return changes
+}
+
+// patchesBranchSuffix names the patches branch after the patch file count, if there is one
+func patchesBranchSuffix(branch string) string {
+	if branch == "" {
+		return ""
+	}
+	return " and branch " + branch
+}
@@ -0,0 +1,150 @@
+package menu
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/journal"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/timing"
+)
+
+// engineBuild is an engine whose worktree is ready for its plugin to be built
+type engineBuild struct {
+	enginePath     string
+	engineVersion  string
+	worktreeSubdir string
+	stockDisabled  bool             // The stock plugin was disabled while preparing the build
+	upstreamBranch string           // Plugin branch chosen for the engine during the setup, recorded once it succeeds
+	rec            *timing.Recorder // Steps of this engine only, merged by the caller
+	err            error            // Why the build failed, set by buildEngines
+	journal        *journal.Journal // Records the setup's completed steps; nil outside setups
+	resumed        *journal.Setup   // The interrupted setup being resumed, or nil
+}
+
+// newEngineBuild starts the build record of an engine
+func newEngineBuild(enginePath, engineVersion, worktreeSubdir string) *engineBuild {
+	return &engineBuild{enginePath: enginePath, engineVersion: engineVersion, worktreeSubdir: worktreeSubdir, rec: timing.NewRecorder()}
+}
+
+// buildEngines compiles the plugin for every engine, up to cfg.BuildWorkers() at the same time.
+// While several build, RunUAT's output is interleaved line by line with each line prefixed by
+// its engine. With interactive set, builds whose binaries were locked by a running program offer
+// the retry prompt one at a time once all builds finished; otherwise they keep the error.
+func buildEngines(app Application, cfg *config.Config, builds []*engineBuild, interactive bool) {
+	workers := cfg.BuildWorkers()
+	if workers > len(builds) {
+		workers = len(builds)
+	}
+	if workers <= 1 {
+		for _, build := range builds {
+			events.Printf("Compiling plugin for UE %s...\n", build.engineVersion)
+			worktreePath := app.GetGit().GetWorktreePath(build.worktreeSubdir)
+			build.err = build.rec.Time(timing.StepBuild, func() error {
+				if interactive {
+					return buildPlugin(app, build.enginePath, worktreePath)
+				}
+				return app.GetPlugin().BuildForEngine(build.enginePath, worktreePath)
+			})
+		}
+		return
+	}
+
+	events.Printf("Compiling the plugin for %d engines, %d at a time...\n", len(builds), workers)
+	previous := runner.SetLimit(runner.KindBuild, workers)
+	defer runner.SetLimit(runner.KindBuild, previous)
+
+	var output sync.Mutex
+	queue := make(chan *engineBuild)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for build := range queue {
+				// RunUAT's output and the build's own messages both carry the engine
+				scope := "UE " + build.engineVersion
+				out := &prefixWriter{mu: &output, w: events.Output(), prefix: "[" + scope + "] "}
+				worktreePath := app.GetGit().GetWorktreePath(build.worktreeSubdir)
+				build.rec.SetScope(scope)
+				build.err = build.rec.Time(timing.StepBuild, func() error {
+					return app.GetPlugin().BuildForEngineTo(build.enginePath, worktreePath, out, events.Reporter{Scope: scope})
+				})
+				out.Flush()
+			}
+		}()
+	}
+	for _, build := range builds {
+		queue <- build
+	}
+	close(queue)
+	wg.Wait()
+
+	if !interactive {
+		return
+	}
+	for _, build := range builds {
+		var locked *plugin.LockedError
+		if !errors.As(build.err, &locked) {
+			continue
+		}
+		fmt.Printf("\nUE %s\n", build.engineVersion)
+		worktreePath := app.GetGit().GetWorktreePath(build.worktreeSubdir)
+		build.err = build.rec.Time(timing.StepBuild, func() error {
+			return retryLockedBuild(app, build.enginePath, worktreePath, build.err)
+		})
+	}
+}
+
+// nextBuildWorkers cycles the Parallel Builds setting through 1, 2, ... up to the maximum and
+// back to one build at a time
+func nextBuildWorkers(cfg *config.Config) int {
+	return cfg.BuildWorkers()%config.MaxParallelBuilds + 1
+}
+
+// prefixWriter writes whole lines to w, each starting with prefix. Writers sharing mu never
+// split each other's lines.
+type prefixWriter struct {
+	mu      *sync.Mutex
+	w       io.Writer
+	prefix  string
+	pending []byte // Start of a line not ended yet
+}
+
+// Write buffers p and writes every line it completes
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.pending = append(p.pending, data...)
+	end := bytes.LastIndexByte(p.pending, '\n')
+	if end < 0 {
+		return len(data), nil
+	}
+	var lines bytes.Buffer
+	for _, line := range bytes.SplitAfter(p.pending[:end+1], []byte("\n")) {
+		if len(line) > 0 {
+			lines.WriteString(p.prefix)
+			lines.Write(line)
+		}
+	}
+	p.pending = append(p.pending[:0], p.pending[end+1:]...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.w.Write(lines.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Flush writes a last line that didn't end with a newline
+func (p *prefixWriter) Flush() {
+	if len(p.pending) == 0 {
+		return
+	}
+	p.Write([]byte("\n"))
+}
@@ -0,0 +1,172 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// skippedUpdateSteps holds, per worktree, the patches and commits the user chose to skip after
+// a conflict; they are left out until the operation being recovered finishes
+var skippedUpdateSteps = map[string][]string{}
+
+// localPatches returns the configured local patches for a worktree with patch file paths made absolute
+func localPatches(app Application, cfg *config.Config, subdir string) git.LocalPatches {
+	patches := git.LocalPatches{Branch: cfg.PatchesBranch, Skip: skippedUpdateSteps[subdir]}
+	for _, file := range cfg.PatchFiles {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(app.GetConfig().GetBaseDir(), file)
+		}
+		patches.Files = append(patches.Files, file)
+	}
+	return patches
+}
+
+// localPatchesOutdated reports whether a worktree needs an update to re-apply changed local patches
+func localPatchesOutdated(app Application, cfg *config.Config, subdir string) bool {
+	outdated, err := app.GetGit().PatchesOutdated(subdir, cfg.TrackedBranch(subdir), cfg.TrackedPin(subdir), localPatches(app, cfg, subdir))
+	if err != nil {
+		fmt.Printf("⚠️  Could not check local patches for %s: %v\n", subdir, err)
+		return false
+	}
+	return outdated
+}
+
+// runLocalPatches manages the patch files and patches branch applied on top of upstream
+func runLocalPatches(app Application, cfg *config.Config) {
+	for {
+		fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🩹 Local Patches"))
+		fmt.Println()
+		fmt.Println("Small studio fixes to the plugin, applied on top of upstream in every engine's")
+		fmt.Println("worktree and re-applied on each update. Use patch files (git format-patch output")
+		fmt.Println("or plain diffs) and/or a branch in the plugin repository or on the mirror.")
+		fmt.Println()
+
+		if len(cfg.PatchFiles) == 0 {
+			fmt.Println("Patch files: none")
+		} else {
+			fmt.Println("Patch files, applied in this order:")
+			for i, file := range cfg.PatchFiles {
+				fmt.Printf("  %d. %s\n", i+1, file)
+			}
+		}
+		if cfg.PatchesBranch != "" {
+			fmt.Printf("Patches branch: %s\n", cfg.PatchesBranch)
+		} else {
+			fmt.Println("Patches branch: none")
+		}
+		fmt.Println()
+
+		items := []string{"Add Patch File"}
+		if len(cfg.PatchFiles) > 0 {
+			items = append(items, "Remove Patch File")
+		}
+		items = append(items, "Set Patches Branch")
+		if cfg.PatchesBranch != "" {
+			items = append(items, "Clear Patches Branch")
+		}
+		items = append(items, "Back")
+
+		prompt := promptui.Select{
+			Label:    "Select an option",
+			Items:    items,
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		_, choice, err := selectWithHelp(&prompt, helpPatches)
+		if err != nil || choice == "Back" {
+			return
+		}
+
+		changed := false
+		switch choice {
+		case "Add Patch File":
+			changed = addPatchFile(app, cfg)
+		case "Remove Patch File":
+			removePrompt := promptui.Select{
+				Label:    "Select patch file to remove",
+				Items:    cfg.PatchFiles,
+				HideHelp: true,
+				Stdout:   &utils.BellSkipper{},
+			}
+			if index, _, err := utils.RunSelect(&removePrompt); err == nil {
+				cfg.PatchFiles = append(cfg.PatchFiles[:index], cfg.PatchFiles[index+1:]...)
+				changed = true
+			}
+		case "Set Patches Branch":
+			changed = setPatchesBranch(app, cfg)
+		case "Clear Patches Branch":
+			cfg.PatchesBranch = ""
+			changed = true
+		}
+
+		if changed {
+			if err := app.GetConfig().Save(cfg); err != nil {
+				fmt.Printf("❌ Failed to save configuration: %v\n", err)
+			} else {
+				fmt.Println("✅ Saved. Each engine picks up the change on its next \"Update Setup\".")
+			}
+			utils.Pause()
+		}
+		app.GetUtils().ClearScreen()
+	}
+}
+
+// addPatchFile prompts for a patch file and appends it to the list
+func addPatchFile(app Application, cfg *config.Config) bool {
+	path, err := utils.PathPrompt{
+		Label:       "Patch file: ",
+		HistoryFile: app.GetConfig().GetHistoryFile("patch_file"),
+		Validate: func(path string) error {
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("file does not exist: %s", path)
+			}
+			if info.IsDir() {
+				return fmt.Errorf("path is a directory: %s", path)
+			}
+			return nil
+		},
+	}.Run()
+	if err != nil {
+		return false
+	}
+
+	// Keep files inside the data directory relative so the config can be shared between machines
+	if rel, err := filepath.Rel(app.GetConfig().GetBaseDir(), path); err == nil && !strings.HasPrefix(rel, "..") {
+		path = rel
+	}
+	for _, existing := range cfg.PatchFiles {
+		if strings.EqualFold(existing, path) {
+			fmt.Println("This patch file is already in the list.")
+			return false
+		}
+	}
+	cfg.PatchFiles = append(cfg.PatchFiles, path)
+	return true
+}
+
+// setPatchesBranch asks for the patches branch and checks that it exists in the plugin repository
+func setPatchesBranch(app Application, cfg *config.Config) bool {
+	fmt.Print("Patches branch (local branch in repo-origin, or a branch on the mirror): ")
+	branch := utils.ReadLine()
+	if branch == "" {
+		return false
+	}
+	if _, err := app.GetGit().ResolveCommit(branch); err != nil {
+		if _, remoteErr := app.GetGit().ResolveCommit("origin/" + branch); remoteErr != nil {
+			fmt.Printf("❌ Branch %s was not found in the plugin repository (fetch first if it is new): %v\n", branch, err)
+			return false
+		}
+	}
+	cfg.PatchesBranch = branch
+	return true
+}
@@ -0,0 +1,106 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/theme"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// runEnginePluginAudit lists the non-stock plugins in every detected engine so unexpected ones
+// on shared machines stand out, flagging those missing from the approved list
+func runEnginePluginAudit(app Application, cfg *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🔍 Engine Plugin Audit"))
+	fmt.Println()
+	fmt.Println("Plugins in each engine's Engine\\Plugins folder that did not ship with the engine.")
+	fmt.Println()
+
+	engines, err := app.GetEngine().DiscoverEngines(cfg.CustomEngineRoots)
+	if err != nil {
+		fmt.Printf("❌ Failed to discover engines: %v\n", err)
+		utils.Pause()
+		return
+	}
+
+	var found, unapproved []string
+	for _, eng := range engines {
+		plugins := app.GetPlugin().AuditEnginePlugins(eng.Path)
+		fmt.Printf("UE %s (%s)\n", eng.Version, eng.Path)
+		if len(plugins) == 0 {
+			fmt.Println("  No non-stock plugins")
+		}
+		for _, p := range plugins {
+			kind := theme.OK
+			note := ""
+			if !cfg.PluginApproved(p.Name) {
+				kind = theme.Warning
+				note = " — not on the approved list"
+				unapproved = appendUnique(unapproved, p.Name)
+			}
+			fmt.Printf("  %s%s\n", theme.Status(kind, describeAuditedPlugin(p)), note)
+			found = appendUnique(found, p.Name)
+		}
+		fmt.Println()
+	}
+
+	if readOnly {
+		utils.Pause()
+		return
+	}
+	switch {
+	case len(cfg.ApprovedEnginePlugins) == 0 && len(found) > 0:
+		if utils.Confirm(fmt.Sprintf("Save these %d plugin(s) as the approved list so new ones stand out?", len(found))) {
+			cfg.ApprovedEnginePlugins = found
+			saveApprovedPlugins(app, cfg)
+		}
+	case len(unapproved) > 0:
+		fmt.Printf("Not approved: %s\n", strings.Join(unapproved, ", "))
+		if utils.Confirm("Add them to the approved list?") {
+			cfg.ApprovedEnginePlugins = append(cfg.ApprovedEnginePlugins, unapproved...)
+			saveApprovedPlugins(app, cfg)
+		}
+	default:
+		utils.Pause()
+	}
+}
+
+// describeAuditedPlugin formats one audited plugin as a single line
+func describeAuditedPlugin(p plugin.AuditedPlugin) string {
+	line := p.Name
+	if p.Version != "" {
+		line += " " + p.Version
+	}
+	line += fmt.Sprintf(" — %s, %s", p.Source, p.Dir)
+	if p.CreatedBy != "" {
+		line += ", by " + p.CreatedBy
+	}
+	if p.LinkTarget != "" {
+		line += " → " + p.LinkTarget
+	}
+	return line
+}
+
+// saveApprovedPlugins saves the approved engine plugin list
+func saveApprovedPlugins(app Application, cfg *config.Config) {
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
+	} else {
+		fmt.Printf("✅ Approved list saved (%d plugins)\n", len(cfg.ApprovedEnginePlugins))
+	}
+	utils.Pause()
+}
+
+// appendUnique appends a name unless the list already contains it, ignoring case
+func appendUnique(list []string, name string) []string {
+	for _, existing := range list {
+		if strings.EqualFold(existing, name) {
+			return list
+		}
+	}
+	return append(list, name)
+}
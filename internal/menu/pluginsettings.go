@@ -0,0 +1,115 @@
+package menu
+
+import (
+	"fmt"
+
+	"ue-git-plugin-manager/internal/projectconfig"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// runPluginSettings shows UEGitPlugin's and the editor's source control options for a project and
+// writes the chosen values into the right ini files
+func runPluginSettings(app Application) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("⚙️  Plugin Settings"))
+	fmt.Println()
+	root, err := promptForProjectRoot(app)
+	if err != nil {
+		return fmt.Errorf("invalid project path: %w", err)
+	}
+	options := append(append([]projectconfig.IniOption{}, projectconfig.PluginOptions...), cfg.PluginIniOptions...)
+
+	for {
+		app.GetUtils().ClearScreen()
+		fmt.Printf("Project: %s\n", root)
+		fmt.Println("Changes take effect the next time the editor starts.")
+		fmt.Println()
+
+		items := make([]string, 0, len(options)+1)
+		for _, option := range options {
+			items = append(items, fmt.Sprintf("%s: %s", option.Label, describeOptionValue(option, root)))
+		}
+		items = append(items, "Back")
+
+		prompt := promptui.Select{
+			Label:    "Select a setting to change",
+			Items:    items,
+			Size:     len(items),
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		index, _, err := selectWithHelp(&prompt, helpPluginSettings)
+		if err != nil || index == len(options) {
+			return nil
+		}
+		if err := editPluginOption(options[index], root); err != nil {
+			fmt.Printf("❌ Failed to update %s: %v\n", options[index].File, err)
+			utils.Pause()
+		}
+	}
+}
+
+// describeOptionValue shows an option's value in a project, marking defaults
+func describeOptionValue(option projectconfig.IniOption, root string) string {
+	if value, ok := option.Current(root); ok {
+		return value
+	}
+	if option.Default == "" {
+		return "(not set)"
+	}
+	return option.Default + " (default)"
+}
+
+// editPluginOption changes one option: bools and flags are toggled, text is typed in, and any
+// option can be reset to its default
+func editPluginOption(option projectconfig.IniOption, root string) error {
+	fmt.Println()
+	fmt.Println(option.Label)
+	if option.Help != "" {
+		fmt.Println(option.Help)
+	}
+	fmt.Printf("Stored as %s in [%s] of %s\n", option.Key, option.Section, option.File)
+	fmt.Println()
+
+	current, set := option.Current(root)
+	if !set {
+		current = option.Default
+	}
+
+	const resetItem = "Reset to default"
+	changeItem := "Change value"
+	if option.Type == projectconfig.OptionBool || option.Type == projectconfig.OptionFlag {
+		changeItem = "Set to " + option.Toggled(current)
+	}
+	prompt := promptui.Select{
+		Label:    "Action",
+		Items:    []string{changeItem, resetItem, "Cancel"},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	_, choice, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return nil
+	}
+
+	switch choice {
+	case resetItem:
+		return option.Reset(root)
+	case changeItem:
+		if option.Type == projectconfig.OptionBool || option.Type == projectconfig.OptionFlag {
+			return option.Set(root, option.Toggled(current))
+		}
+		fmt.Printf("New value (empty keeps %q): ", current)
+		if value := utils.ReadLine(); value != "" {
+			return option.Set(root, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package menu
+
+import (
+	"fmt"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/timing"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// runPluginVersions keeps a stable and a candidate plugin version built side by side for one
+// engine and switches the plugin link between them, so a new version can be tried in the editor
+// and dropped again without a rebuild
+func runPluginVersions(app Application, cfg *config.Config, status detection.SetupStatus) error {
+	eng := app.GetConfig().GetEngineByPath(cfg, status.EnginePath)
+	if eng == nil {
+		return fmt.Errorf("UE %s is not managed by this tool", status.EngineVersion)
+	}
+	if app.GetPlugin().UsesCopyMode(eng.EnginePath) {
+		return fmt.Errorf("UE %s is on a network path and gets a copy of the plugin; switching versions needs a linked plugin", status.EngineVersion)
+	}
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprintf("🔁 UE %s Plugin Versions", status.EngineVersion))
+	fmt.Println()
+	fmt.Printf("In use:   %s\n", describeVersion(app, cfg, eng.WorktreeSubdir))
+	if eng.AlternateWorktreeSubdir != "" {
+		fmt.Printf("Inactive: %s\n", describeVersion(app, cfg, eng.AlternateWorktreeSubdir))
+	} else {
+		fmt.Println("Inactive: none")
+		fmt.Println()
+		fmt.Println("A candidate version is built in its own worktree next to the one in use. Switching")
+		fmt.Println("only re-points the plugin link, so you can flip between them without rebuilding.")
+	}
+	fmt.Println()
+
+	var options []string
+	if eng.AlternateWorktreeSubdir == "" {
+		options = []string{"Create Candidate Version"}
+	} else {
+		other := versionRole(eng.AlternateWorktreeSubdir)
+		options = []string{
+			"Switch to " + other,
+			"Update " + other,
+			"Remove " + other,
+		}
+	}
+	options = append(options, "Back")
+
+	prompt := promptui.Select{
+		Label:    "What would you like to do?",
+		Items:    options,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	index, choice, err := selectWithHelp(&prompt, helpPluginVersions)
+	if err != nil {
+		if err == promptui.ErrInterrupt {
+			return nil
+		}
+		return err
+	}
+	if choice == "Back" {
+		return nil
+	}
+
+	switch {
+	case eng.AlternateWorktreeSubdir == "":
+		err = createCandidateVersion(app, cfg, eng)
+	case index == 0:
+		err = switchPluginVersion(app, cfg, eng)
+	case index == 1:
+		err = updateInactiveVersion(app, cfg, eng)
+	case index == 2:
+		err = removeInactiveVersion(app, cfg, eng)
+	}
+	if err != nil {
+		return err
+	}
+	utils.Pause()
+	return nil
+}
+
+// versionRole names the version a worktree holds
+func versionRole(subdir string) string {
+	if config.IsCandidateWorktree(subdir) {
+		return "Candidate"
+	}
+	return "Stable"
+}
+
+// describeVersion summarizes a version's worktree, the branch it follows and the commit it is on
+func describeVersion(app Application, cfg *config.Config, subdir string) string {
+	tracked := cfg.TrackedBranch(subdir)
+	if pin := cfg.TrackedPin(subdir); pin != "" {
+		tracked = fmt.Sprintf("%s, pinned", tracked)
+	}
+	head := "not checked out"
+	if sha, err := app.GetGit().GetHeadSHA(subdir); err == nil {
+		head = git.ShortSHA(sha)
+	}
+	return fmt.Sprintf("%-9s %s (%s) at %s", versionRole(subdir), subdir, tracked, head)
+}
+
+// createCandidateVersion asks for the branch to try, checks out its current commit next to the
+// version in use and builds it. The link keeps pointing at the version in use.
+func createCandidateVersion(app Application, cfg *config.Config, eng *config.Engine) error {
+	branch, err := selectUpstreamBranch(app, "Select the branch to try (type to filter)")
+	if err != nil || branch == "" {
+		return err
+	}
+	sha, err := app.GetGit().ResolveCommit(app.GetGit().TrackingRef(branch))
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %v", branch, err)
+	}
+
+	subdir := config.CandidateWorktreeSubdir(eng.WorktreeSubdir)
+	eng.AlternateWorktreeSubdir = subdir
+	eng.AlternateUpstreamBranch = branch
+	eng.AlternatePinnedCommitSHA = sha
+	if err := buildInactiveVersion(app, cfg, eng, true); err != nil {
+		eng.AlternateWorktreeSubdir, eng.AlternateUpstreamBranch, eng.AlternatePinnedCommitSHA = "", "", ""
+		// The candidate isn't recorded, so nothing would ever clean up its worktree
+		if removeErr := app.GetGit().RemoveWorktree(subdir); removeErr != nil {
+			fmt.Printf("⚠️  Failed to remove the candidate worktree %s: %v\n", subdir, removeErr)
+		}
+		return err
+	}
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	fmt.Printf("✅ Candidate built from %s at %s. Switch to it to try it in the editor.\n", branch, git.ShortSHA(sha))
+	return nil
+}
+
+// updateInactiveVersion moves the version not in use to its latest commit and rebuilds it. A
+// candidate is held at the commit it was built from, so it moves to the tip of its branch.
+func updateInactiveVersion(app Application, cfg *config.Config, eng *config.Engine) error {
+	syncTrackedRemotes(app, cfg, false)
+	if err := withGitRecovery(app, cfg, "", func() error { return app.GetGit().FetchAll(cfg.MirrorURL) }); err != nil {
+		return fmt.Errorf("failed to fetch updates: %w", err)
+	}
+	if config.IsCandidateWorktree(eng.AlternateWorktreeSubdir) {
+		sha, err := app.GetGit().ResolveCommit(app.GetGit().TrackingRef(eng.AlternateUpstreamBranch))
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %v", eng.AlternateUpstreamBranch, err)
+		}
+		eng.AlternatePinnedCommitSHA = sha
+	}
+	if err := buildInactiveVersion(app, cfg, eng, false); err != nil {
+		return err
+	}
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	fmt.Printf("✅ %s version rebuilt.\n", versionRole(eng.AlternateWorktreeSubdir))
+	return nil
+}
+
+// buildInactiveVersion creates or updates the worktree of the version not in use and builds it
+func buildInactiveVersion(app Application, cfg *config.Config, eng *config.Engine, create bool) error {
+	subdir := eng.AlternateWorktreeSubdir
+	rec := timing.NewRecorder()
+	defer recordTimings(app, rec)
+
+	err := withGitRecovery(app, cfg, subdir, func() error {
+		return rec.Time(timing.StepWorktree, func() error {
+			patches := localPatches(app, cfg, subdir)
+			if create || !app.GetGit().WorktreeExists(subdir) {
+				return app.GetGit().CreateWorktree(subdir, cfg.TrackedBranch(subdir), cfg.TrackedPin(subdir), patches)
+			}
+			return app.GetGit().UpdateWorktree(subdir, cfg.TrackedBranch(subdir), cfg.TrackedPin(subdir), patches)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prepare the %s worktree: %w", versionRole(subdir), err)
+	}
+
+	worktreePath := app.GetGit().GetWorktreePath(subdir)
+	err = rec.Time(timing.StepBuild, func() error {
+		return buildPlugin(app, eng.EnginePath, worktreePath)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build the %s version: %v", versionRole(subdir), err)
+	}
+	return nil
+}
+
+// switchPluginVersion points the plugin link at the version not in use. The editor loads the
+// plugin's binaries at startup, so it has to be closed for the switch.
+func switchPluginVersion(app Application, cfg *config.Config, eng *config.Engine) error {
+	activePath := app.GetGit().GetWorktreePath(eng.WorktreeSubdir)
+	if locks := app.GetPlugin().LockedBinaries(activePath); len(locks) > 0 {
+		fmt.Println("🔒 The plugin in use is loaded by:")
+		for _, process := range (&plugin.LockedError{Locks: locks}).Processes() {
+			fmt.Printf("   - %s\n", process)
+		}
+		fmt.Println("   Close these programs (save your work in the editor first), then switch again.")
+		return nil
+	}
+
+	target := app.GetDetection().DetectEngineSetupStatus(eng.EnginePath, eng.EngineVersion, eng.AlternateWorktreeSubdir)
+	if !target.WorktreeExists || !target.BinariesExist || target.BinariesStale || target.BinariesCorrupted {
+		fmt.Printf("❌ The %s version needs a rebuild first; choose \"Update %s\".\n", versionRole(eng.AlternateWorktreeSubdir), versionRole(eng.AlternateWorktreeSubdir))
+		return nil
+	}
+
+	if err := app.GetPlugin().CreateJunction(eng.EnginePath, app.GetGit().GetWorktreePath(eng.AlternateWorktreeSubdir)); err != nil {
+		return fmt.Errorf("failed to switch the plugin link: %v", err)
+	}
+	eng.SwapAlternate()
+	eng.Branch = git.EngineBranch(eng.WorktreeSubdir)
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	fmt.Printf("✅ UE %s now loads the %s version. Start the editor to try it.\n", eng.EngineVersion, versionRole(eng.WorktreeSubdir))
+	return nil
+}
+
+// removeInactiveVersion deletes the worktree of the version not in use. Removing the stable
+// version keeps the candidate as the only one.
+func removeInactiveVersion(app Application, cfg *config.Config, eng *config.Engine) error {
+	role := versionRole(eng.AlternateWorktreeSubdir)
+	if !utils.ConfirmDestructive(fmt.Sprintf("Remove the %s version in %s?", role, eng.AlternateWorktreeSubdir)) {
+		return nil
+	}
+	if err := app.GetGit().RemoveWorktree(eng.AlternateWorktreeSubdir); err != nil {
+		return fmt.Errorf("failed to remove worktree: %v", err)
+	}
+	eng.AlternateWorktreeSubdir, eng.AlternateUpstreamBranch, eng.AlternatePinnedCommitSHA = "", "", ""
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	fmt.Printf("✅ %s version removed.\n", role)
+	return nil
+}
@@ -0,0 +1,80 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/projecthealth"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// maxHealthPaths caps how many affected paths are listed per problem
+const maxHealthPaths = 10
+
+// runProjectHealthCheck looks through a project's repository for case conflicts, LFS files that
+// weren't downloaded or were committed outside LFS, and mangled assets, and suggests fixes
+func runProjectHealthCheck(app Application) error {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🩺 Repository Health Check"))
+	fmt.Println()
+	fmt.Println("Looks for paths that differ only in case, LFS files that were never downloaded or were")
+	fmt.Println("committed outside LFS, and assets corrupted by line ending conversion. Nothing is changed.")
+	fmt.Println()
+
+	root, err := promptForProjectRoot(app)
+	if err != nil {
+		return fmt.Errorf("invalid project path: %w", err)
+	}
+	fmt.Println("⏳ Checking the repository...")
+	issues, err := projecthealth.Check(root)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		utils.Pause()
+		return nil
+	}
+	fmt.Println()
+	if len(issues) == 0 {
+		fmt.Println("✅ No problems found.")
+		utils.Pause()
+		return nil
+	}
+
+	notPulled := false
+	for _, issue := range issues {
+		fmt.Printf("❌ %s (%d)\n", issue.Summary, len(issue.Paths))
+		for i, path := range issue.Paths {
+			if i == maxHealthPaths {
+				fmt.Printf("     … and %d more\n", len(issue.Paths)-maxHealthPaths)
+				break
+			}
+			fmt.Printf("     %s\n", path)
+		}
+		fmt.Println("   To fix:")
+		for i, step := range issue.Fix {
+			fmt.Printf("   %d. %s\n", i+1, step)
+		}
+		fmt.Println()
+		if issue.Kind == projecthealth.KindLFSNotPulled {
+			notPulled = true
+		}
+	}
+
+	if notPulled && utils.ConfirmStep("Download the missing LFS content now (git lfs pull)?") {
+		_, err := runner.Run(context.Background(), runner.Command{
+			Name:   "git",
+			Args:   []string{"-C", root, "lfs", "pull"},
+			Stdout: os.Stdout,
+			Stderr: os.Stderr,
+		})
+		if err != nil {
+			fmt.Printf("❌ git lfs pull failed: %v\n", err)
+		} else {
+			fmt.Println("✅ LFS content downloaded")
+		}
+	}
+	utils.Pause()
+	return nil
+}
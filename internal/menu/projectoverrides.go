@@ -0,0 +1,152 @@
+package menu
+
+import (
+	"fmt"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/projects"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// offerResolveProjectOverrides asks what to do about registered projects that carry their own
+// copy of the plugin, which hides the engine plugin this tool keeps up to date
+func offerResolveProjectOverrides(app Application, cfg *config.Config) bool {
+	asked := false
+	for _, override := range app.GetDetection().FindProjectOverrides(cfg) {
+		if override.Kept || !usesManagedEngine(app, cfg, override.ProjectRoot) {
+			continue
+		}
+		asked = true
+		resolveProjectOverride(app, cfg, override)
+		fmt.Println()
+	}
+	if asked {
+		utils.Pause()
+	}
+	return asked
+}
+
+// usesManagedEngine reports whether a project opens with an engine that has the plugin linked by this tool
+func usesManagedEngine(app Application, cfg *config.Config, root string) bool {
+	info, err := projects.Read(root)
+	if err != nil {
+		return false
+	}
+	for _, eng := range cfg.Engines {
+		if info.UsesEngine(eng.EnginePath, eng.EngineVersion) && app.GetPlugin().JunctionExists(app.GetPlugin().GetPluginLinkPath(eng.EnginePath)) {
+			return true
+		}
+	}
+	return false
+}
+
+// runProjectOverrides lists the plugin copies in every registered project, including kept ones
+func runProjectOverrides(app Application) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🧩 Project Plugin Overrides"))
+	fmt.Println()
+	fmt.Println("The editor loads a GitSourceControl plugin in a project's Plugins folder instead of the")
+	fmt.Println("engine's, so engine-level setups and updates don't reach that project.")
+	fmt.Println()
+
+	overrides := app.GetDetection().FindProjectOverrides(cfg)
+	if len(overrides) == 0 {
+		fmt.Println("✅ No registered project has its own copy of the plugin.")
+		utils.Pause()
+		return nil
+	}
+
+	items := make([]string, 0, len(overrides)+1)
+	for _, override := range overrides {
+		item := fmt.Sprintf("%s: %s", override.Project, override.Dir)
+		if override.Kept {
+			item += " (kept)"
+		}
+		items = append(items, item)
+	}
+	items = append(items, "Back")
+	prompt := promptui.Select{
+		Label:    "Select a plugin copy",
+		Items:    items,
+		Size:     10,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil || index == len(overrides) {
+		return nil
+	}
+	resolveProjectOverride(app, cfg, overrides[index])
+	utils.Pause()
+	return nil
+}
+
+// resolveProjectOverride disables or removes a project's copy of the plugin, or records that the
+// user wants to keep it
+func resolveProjectOverride(app Application, cfg *config.Config, override detection.ProjectOverride) {
+	fmt.Printf("⚠️  Project %s has its own copy of the plugin in %s.\n", override.Project, override.Dir)
+	fmt.Println("   The editor uses it instead of the engine's plugin, so updates made here don't reach this project.")
+
+	const (
+		disableItem = "Disable the project's copy and use the engine plugin"
+		removeItem  = "Remove the plugin submodule and use the engine plugin"
+		keepItem    = "Keep the project's copy and stop warning about it"
+		laterItem   = "Decide later"
+	)
+	items := []string{disableItem}
+	if override.Submodule {
+		items = []string{removeItem}
+	}
+	if !override.Kept {
+		items = append(items, keepItem)
+	}
+	items = append(items, laterItem)
+
+	prompt := promptui.Select{
+		Label:    "What would you like to do?",
+		Items:    items,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	_, choice, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return
+	}
+
+	switch choice {
+	case disableItem:
+		if err := app.GetPlugin().DisableProjectPluginCopy(override.Dir); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		fmt.Println("✅ Disabled the project's copy. If it is committed, commit the change so teammates use the engine plugin too.")
+	case removeItem:
+		sub, err := app.GetGit().GetProjectSubmodule(override.ProjectRoot)
+		if err == nil && sub == nil {
+			err = fmt.Errorf("the project has no plugin submodule")
+		}
+		if err == nil {
+			err = app.GetGit().RemoveProjectSubmodule(sub)
+		}
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		fmt.Println("✅ Plugin submodule removed. Commit the change to share it with the team.")
+	case keepItem:
+		cfg.KeptPluginOverrides = append(cfg.KeptPluginOverrides, override.Dir)
+		if err := app.GetConfig().Save(cfg); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+			return
+		}
+		fmt.Println("✅ Kept. It is still listed under \"Project Plugin Overrides\".")
+	}
+}
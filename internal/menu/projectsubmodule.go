@@ -0,0 +1,185 @@
+package menu
+
+import (
+	"fmt"
+	"net/url"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/projects"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// runProjectSubmodule manages the plugin as a git submodule of a game project, an alternative
+// to the engine-level junction that pins the plugin in the project's own history
+func runProjectSubmodule(app Application) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("📦 Plugin as Project Submodule"))
+	fmt.Println()
+	fmt.Println("Adds the plugin to the project under " + git.ProjectPluginDir + " as a git submodule instead of")
+	fmt.Println("linking it into the engine, so every checkout of the project gets the same plugin commit.")
+	fmt.Println()
+
+	root, err := promptForProjectRoot(app)
+	if err != nil {
+		return fmt.Errorf("invalid project path: %w", err)
+	}
+
+	for {
+		app.GetUtils().ClearScreen()
+		sub, err := app.GetGit().GetProjectSubmodule(root)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Project: %s\n", root)
+		if sub == nil {
+			fmt.Println("Plugin submodule: not added")
+		} else {
+			printProjectSubmodule(sub)
+		}
+		fmt.Println()
+
+		var items []string
+		if sub == nil {
+			items = append(items, "Add Plugin Submodule")
+		} else {
+			if !sub.IsInitialized() {
+				items = append(items, "Check Out Submodule")
+			}
+			items = append(items, "Update to Latest", "Pin to Commit or Tag", "Remove Plugin Submodule")
+		}
+		items = append(items, "Back")
+
+		prompt := promptui.Select{
+			Label:    "What would you like to do?",
+			Items:    items,
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		_, choice, err := selectWithHelp(&prompt, helpProjectSubmodule)
+		if err != nil || choice == "Back" {
+			return nil
+		}
+
+		switch choice {
+		case "Add Plugin Submodule":
+			err = addProjectSubmodule(app, cfg, root)
+		case "Check Out Submodule":
+			err = app.GetGit().InitProjectSubmodule(sub)
+		case "Update to Latest":
+			err = updateProjectSubmodule(app, sub, "")
+		case "Pin to Commit or Tag":
+			fmt.Print("Enter commit SHA or tag: ")
+			if rev := utils.ReadLine(); rev != "" {
+				err = updateProjectSubmodule(app, sub, rev)
+			}
+		case "Remove Plugin Submodule":
+			if !utils.ConfirmDestructive(fmt.Sprintf("Remove %s from the project? Local changes in it are lost.", sub.Path)) {
+				continue
+			}
+			if err = app.GetGit().RemoveProjectSubmodule(sub); err == nil {
+				fmt.Println("✅ Plugin submodule removed. Commit the change to share it with the team.")
+			}
+		}
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+		}
+		utils.Pause()
+	}
+}
+
+// printProjectSubmodule prints where the project's plugin submodule points
+func printProjectSubmodule(sub *git.ProjectSubmodule) {
+	fmt.Printf("Plugin submodule: %s\n", sub.Path)
+	fmt.Printf("  URL:    %s\n", sub.URL)
+	fmt.Printf("  Branch: %s\n", sub.Branch)
+	if sub.PinnedSHA != "" {
+		fmt.Printf("  Pinned: %s\n", git.ShortSHA(sub.PinnedSHA))
+	}
+	switch {
+	case !sub.IsInitialized():
+		fmt.Println("  " + color.YellowString("Not checked out on this machine"))
+	case sub.CheckedOutSHA != sub.PinnedSHA:
+		fmt.Printf("  %s\n", color.YellowString("Checked out %s, which differs from the pin", git.ShortSHA(sub.CheckedOutSHA)))
+	case sub.CommitsBehind > 0:
+		fmt.Printf("  %d commit(s) behind origin/%s as of the last update\n", sub.CommitsBehind, sub.Branch)
+	}
+}
+
+// addProjectSubmodule asks for the repository URL and adds the plugin submodule at the tool's pinned commit
+func addProjectSubmodule(app Application, cfg *config.Config, root string) error {
+	warnEngineLevelPlugin(app, cfg, root)
+
+	// Everyone who clones the project needs to reach this URL, so it is committed in .gitmodules
+	fmt.Printf("Repository URL to record in .gitmodules (empty for %s): ", git.UpstreamURL)
+	repoURL := utils.ReadLine()
+	if repoURL == "" {
+		repoURL = git.UpstreamURL
+	}
+	if parsed, err := url.Parse(repoURL); err == nil && parsed.User != nil {
+		if _, hasPassword := parsed.User.Password(); hasPassword {
+			return fmt.Errorf("the URL contains a password, which would be committed to the project; use a URL without it")
+		}
+	}
+
+	pin := cfg.PinnedCommitSHA
+	if pin != "" && !utils.Confirm(fmt.Sprintf("Pin to %s, the commit engine setups use? (No pins to the tip of %s)", git.ShortSHA(pin), cfg.DefaultRemoteBranch)) {
+		pin = ""
+	}
+
+	fmt.Println("📥 Adding plugin submodule...")
+	sub, err := app.GetGit().AddProjectSubmodule(root, repoURL, cfg.DefaultRemoteBranch, pin)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Added %s at %s.\n", sub.Path, git.ShortSHA(sub.PinnedSHA))
+	fmt.Println("   The change is staged; commit .gitmodules and the submodule to share it with the team.")
+	fmt.Println("   Teammates run \"git submodule update --init\" (or \"Check Out Submodule\" here) after pulling.")
+	fmt.Println("   The plugin is compiled with the project's C++ code; Blueprint-only projects need its binaries committed or built.")
+	return nil
+}
+
+// updateProjectSubmodule fetches and pins the submodule to rev, or to the tip of its branch when rev is empty
+func updateProjectSubmodule(app Application, sub *git.ProjectSubmodule, rev string) error {
+	previous := sub.PinnedSHA
+	fmt.Println("📥 Fetching plugin submodule...")
+	if err := app.GetGit().UpdateProjectSubmodule(sub, rev); err != nil {
+		return err
+	}
+	updated, err := app.GetGit().GetProjectSubmodule(sub.RepoRoot)
+	if err != nil || updated == nil {
+		return err
+	}
+	if updated.PinnedSHA == previous {
+		fmt.Printf("✅ Already at %s\n", git.ShortSHA(updated.PinnedSHA))
+		return nil
+	}
+	fmt.Printf("✅ Pinned to %s (was %s). Commit the submodule change to share it with the team.\n", git.ShortSHA(updated.PinnedSHA), git.ShortSHA(previous))
+	return nil
+}
+
+// warnEngineLevelPlugin warns when the project's engine already has the plugin linked by this tool,
+// since the editor would then find two plugins named GitSourceControl
+func warnEngineLevelPlugin(app Application, cfg *config.Config, root string) {
+	info, err := projects.Read(root)
+	if err != nil {
+		return
+	}
+	for _, eng := range cfg.Engines {
+		if !info.UsesEngine(eng.EnginePath, eng.EngineVersion) || !app.GetPlugin().JunctionExists(app.GetPlugin().GetPluginLinkPath(eng.EnginePath)) {
+			continue
+		}
+		fmt.Printf("⚠️  UE %s, used by this project, also has the plugin linked at engine level.\n", eng.EngineVersion)
+		fmt.Println("   Remove that setup (\"Edit Setup\" → the engine → \"Uninstall Setup\") on machines that use the")
+		fmt.Println("   submodule, so the editor doesn't find two copies of the plugin.")
+		fmt.Println()
+	}
+}
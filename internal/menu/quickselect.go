@@ -0,0 +1,64 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// menuAction is a menu entry with an optional quick-action key
+type menuAction struct {
+	Key   string
+	Label string
+}
+
+// String renders the entry with its key, e.g. "[u] Update All Engines"
+func (a menuAction) String() string {
+	if a.Key == "" {
+		return "    " + a.Label
+	}
+	return fmt.Sprintf("[%s] %s", a.Key, a.Label)
+}
+
+// quickSelect shows a menu that starts in search mode: typing an entry's key and pressing
+// Enter runs it, longer input filters by label, and the arrow keys work as usual. A [?] entry
+// shows the help page for topic and then the menu again.
+func quickSelect(label, topic string, actions []menuAction) (string, error) {
+	actions = append(actions, menuAction{Key: "?", Label: "Help"})
+	prompt := promptui.Select{
+		Label:             label + " (type a [key] or text to filter)",
+		Items:             actions,
+		Size:              12,
+		HideHelp:          true,
+		StartInSearchMode: true,
+		Searcher: func(input string, index int) bool {
+			query := strings.ToLower(strings.TrimSpace(input))
+			action := actions[index]
+			if len(query) == 1 {
+				return strings.EqualFold(action.Key, query)
+			}
+			return strings.Contains(strings.ToLower(action.Label), query)
+		},
+		Stdout: &utils.BellSkipper{},
+	}
+
+	for {
+		index, _, err := utils.RunSelect(&prompt)
+		if err != nil {
+			return "", err
+		}
+		if index < 0 || index >= len(actions) {
+			return "", nil
+		}
+		if index < len(actions)-1 {
+			return actions[index].Label, nil
+		}
+		fmt.Println()
+		showHelp(topic)
+		utils.Pause()
+		fmt.Println()
+	}
+}
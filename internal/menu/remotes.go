@@ -0,0 +1,249 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// runTrackedRemotes manages the additional remotes of the plugin repository that engines can track
+func runTrackedRemotes(app Application, cfg *config.Config) {
+	for {
+		fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🔀 Tracked Remotes"))
+		fmt.Println()
+		fmt.Println("Besides origin, the plugin repository can fetch from more remotes, such as upstream")
+		fmt.Println("and a studio fork. Each engine follows a branch on one of them, so upstream changes")
+		fmt.Println("can be tried on one engine before they are merged into the fork.")
+		fmt.Println()
+
+		fmt.Printf("  %-12s %s\n", git.OriginRemote, originDescription(cfg))
+		for _, remote := range cfg.Remotes {
+			fmt.Printf("  %-12s %s\n", remote.Name, remote.URL)
+		}
+		fmt.Println()
+
+		items := []string{"Add Remote"}
+		if len(cfg.Remotes) > 0 {
+			items = append(items, "Remove Remote")
+		}
+		items = append(items, "Back")
+
+		prompt := promptui.Select{
+			Label:    "Select an option",
+			Items:    items,
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		_, choice, err := selectWithHelp(&prompt, helpRemotes)
+		if err != nil || choice == "Back" {
+			return
+		}
+
+		changed := false
+		switch choice {
+		case "Add Remote":
+			changed = addTrackedRemote(app, cfg)
+		case "Remove Remote":
+			changed = removeTrackedRemote(app, cfg)
+		}
+
+		if changed {
+			if err := app.GetConfig().Save(cfg); err != nil {
+				fmt.Printf("❌ Failed to save configuration: %v\n", err)
+			} else {
+				fmt.Println("✅ Saved. Pick the remote an engine follows under its \"Branch & Pin\" options.")
+			}
+		}
+		utils.Pause()
+		app.GetUtils().ClearScreen()
+	}
+}
+
+// originDescription describes where origin is fetched from
+func originDescription(cfg *config.Config) string {
+	if cfg.MirrorURL != "" {
+		return fmt.Sprintf("%s (mirror of %s)", cfg.MirrorURL, git.UpstreamURL)
+	}
+	return git.UpstreamURL
+}
+
+// addTrackedRemote asks for a remote, adds it to the plugin repository and fetches it
+func addTrackedRemote(app Application, cfg *config.Config) bool {
+	fmt.Print("Remote name (e.g. studio): ")
+	name := utils.ReadLine()
+	if name == "" {
+		return false
+	}
+	if err := git.ValidRemoteName(name); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return false
+	}
+	fmt.Print("Remote URL: ")
+	url := utils.ReadLine()
+	if url == "" {
+		return false
+	}
+
+	fmt.Print("Checking remote... ")
+	if err := app.GetGit().CheckRemote(url); err != nil {
+		fmt.Printf("❌ Not reachable: %v\n", err)
+		return false
+	}
+	fmt.Println("✅ Reachable")
+
+	if err := app.GetGit().AddRemote(name, url); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return false
+	}
+	fmt.Printf("Fetching %s...\n", name)
+	if err := app.GetGit().FetchRemote(name); err != nil {
+		fmt.Printf("⚠️  Fetch failed; it is retried with every update: %v\n", err)
+	}
+
+	for i := range cfg.Remotes {
+		if cfg.Remotes[i].Name == name {
+			cfg.Remotes[i].URL = url
+			return true
+		}
+	}
+	cfg.Remotes = append(cfg.Remotes, config.Remote{Name: name, URL: url})
+	return true
+}
+
+// removeTrackedRemote removes a remote no engine follows anymore
+func removeTrackedRemote(app Application, cfg *config.Config) bool {
+	names := make([]string, len(cfg.Remotes))
+	for i, remote := range cfg.Remotes {
+		names[i] = remote.Name
+	}
+	prompt := promptui.Select{
+		Label:    "Select remote to remove",
+		Items:    names,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return false
+	}
+	name := names[index]
+
+	var followers []string
+	for _, eng := range cfg.Engines {
+		if remote, _ := app.GetGit().SplitBranch(eng.UpstreamBranch); eng.UpstreamBranch != "" && remote == name {
+			followers = append(followers, "UE "+eng.EngineVersion)
+		}
+	}
+	if remote, _ := app.GetGit().SplitBranch(cfg.DefaultRemoteBranch); remote == name {
+		followers = append(followers, "the default branch")
+	}
+	if len(followers) > 0 {
+		fmt.Printf("❌ %s is still tracked by %s; switch them to another remote first\n", name, strings.Join(followers, ", "))
+		return false
+	}
+
+	if err := app.GetGit().RemoveRemote(name); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	cfg.Remotes = append(cfg.Remotes[:index], cfg.Remotes[index+1:]...)
+	return true
+}
+
+// printRemoteUpdates shows, for every remote with the engine's branch, how many commits it has
+// beyond the upstream commit the engine is on
+func printRemoteUpdates(app Application, cfg *config.Config, subdir string) {
+	if len(app.GetGit().Remotes()) == 0 {
+		return
+	}
+	updates, err := app.GetGit().GetRemoteUpdates(subdir, cfg.TrackedBranch(subdir))
+	if err != nil || len(updates) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Updates per remote, as of the last fetch:")
+	for _, update := range updates {
+		marker := " "
+		if update.Tracked {
+			marker = "*"
+		}
+		fmt.Printf("  %s %-24s %d new commit(s)\n", marker, app.GetGit().TrackingRef(update.Branch), update.CommitsAhead)
+	}
+}
+
+// selectTrackedRemote asks which remote to pick a branch from; origin is returned when there is
+// only origin to choose from
+func selectTrackedRemote(app Application) (string, bool) {
+	remotes := append([]string{git.OriginRemote}, app.GetGit().Remotes()...)
+	if len(remotes) == 1 {
+		return git.OriginRemote, true
+	}
+	prompt := promptui.Select{
+		Label:    "Select remote",
+		Items:    remotes,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	_, remote, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return "", false
+	}
+	return remote, true
+}
+
+// syncTrackedRemotes adds the configured remotes to the plugin repository, so a config copied
+// from another machine tracks the same remotes. With fetch set they are fetched as well.
+func syncTrackedRemotes(app Application, cfg *config.Config, fetch bool) {
+	if len(cfg.Remotes) == 0 {
+		return
+	}
+	if err := app.GetGit().SyncRemotes(cfg.RemoteURLs()); err != nil {
+		fmt.Printf("⚠️  Could not add the tracked remotes: %v\n", err)
+		return
+	}
+	if !fetch {
+		return
+	}
+	for _, remote := range cfg.Remotes {
+		if err := app.GetGit().FetchRemote(remote.Name); err != nil {
+			fmt.Printf("⚠️  Fetch from remote %s failed: %v\n", remote.Name, err)
+		}
+	}
+}
+
+// selectUpstreamBranch asks for a remote and one of its branches and returns the branch in the
+// form the config stores, or "" when cancelled
+func selectUpstreamBranch(app Application, label string) (string, error) {
+	remote, ok := selectTrackedRemote(app)
+	if !ok {
+		return "", nil
+	}
+	branches, err := app.GetGit().CachedRemoteBranches(remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to list branches: %v", err)
+	}
+	if len(branches) == 0 {
+		return "", fmt.Errorf("%s has no branches yet; run \"Update Setup\" to fetch it", remote)
+	}
+	prompt := promptui.Select{
+		Label:             label,
+		Items:             branches,
+		Size:              12,
+		HideHelp:          true,
+		StartInSearchMode: true,
+		Searcher: func(input string, index int) bool {
+			return strings.Contains(strings.ToLower(branches[index]), strings.ToLower(strings.TrimSpace(input)))
+		},
+		Stdout: &utils.BellSkipper{},
+	}
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil || index < 0 {
+		return "", nil
+	}
+	return git.QualifiedBranch(remote, branches[index]), nil
+}
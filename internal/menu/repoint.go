@@ -0,0 +1,89 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/policy"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// RepointJunctions points every managed engine's plugin link at the engine's worktree in the
+// current data directory, for after the data directory was moved or restored from a backup.
+// Links that are already correct are left alone.
+func RepointJunctions(app Application) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+	if err := policy.CheckEngineChanges(cfg); err != nil {
+		return err
+	}
+	if len(cfg.Engines) == 0 {
+		events.Println("No managed engines.")
+		return nil
+	}
+
+	repointed, failed := 0, 0
+	for i, eng := range cfg.Engines {
+		subdir := engineSubdir(eng)
+		worktreePath := app.GetGit().GetWorktreePath(subdir)
+		switch {
+		case !dirExists(eng.EnginePath):
+			fmt.Printf("⏭️  UE %s: engine folder %s not found; use \"Re-link Moved Engines\"\n", eng.EngineVersion, eng.EnginePath)
+			continue
+		case !app.GetGit().WorktreeExists(subdir):
+			fmt.Printf("❌ UE %s: worktree %s not found; use \"Repair Setup\"\n", eng.EngineVersion, worktreePath)
+			failed++
+			continue
+		}
+
+		// The worktree's link back to the origin repository is absolute too
+		if err := app.GetGit().RepairWorktree(subdir); err != nil {
+			fmt.Printf("⚠️  UE %s: %v\n", eng.EngineVersion, err)
+		}
+
+		if app.GetPlugin().VerifyJunction(eng.EnginePath, worktreePath) {
+			events.Printf("✅ UE %s already points to %s\n", eng.EngineVersion, worktreePath)
+			continue
+		}
+		events.Printf("🔗 UE %s → %s\n", eng.EngineVersion, worktreePath)
+		if err := app.GetPlugin().CreateJunction(eng.EnginePath, worktreePath); err != nil {
+			fmt.Printf("❌ UE %s: %v\n", eng.EngineVersion, err)
+			failed++
+			continue
+		}
+		cfg.Engines[i].PluginLinkPath = app.GetPlugin().GetPluginLinkPath(eng.EnginePath)
+		repointed++
+	}
+
+	if repointed > 0 {
+		if err := app.GetConfig().Save(cfg); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+	}
+	events.Printf("\n%d link(s) re-pointed.\n", repointed)
+	if failed > 0 {
+		return fmt.Errorf("%d engine(s) could not be re-pointed", failed)
+	}
+	return nil
+}
+
+// runRepointJunctions runs RepointJunctions from the Settings menu
+func runRepointJunctions(app Application) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🔗 Re-point Plugin Links"))
+	fmt.Println()
+	if err := RepointJunctions(app); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	utils.Pause()
+}
+
+// dirExists reports whether path is an existing directory
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,123 @@
+package menu
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// maintainRepository compacts the plugin repository, reports its size before and after, and
+// records when it ran
+func maintainRepository(app Application, cfg *config.Config) error {
+	events.Println("🧰 Compacting the plugin repository...")
+	before := app.GetGit().OriginSize()
+	if err := app.GetGit().Maintain(); err != nil {
+		return err
+	}
+	after := app.GetGit().OriginSize()
+	events.Printf("   Repository size: %s → %s\n", utils.FormatSize(before), utils.FormatSize(after))
+
+	cfg.LastRepoMaintenanceUTC = time.Now().UTC().Format(time.RFC3339)
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	return nil
+}
+
+// maybeMaintainRepository compacts the plugin repository after a fetch once the configured
+// number of days has passed. A failure only warns, since the update itself is unaffected.
+func maybeMaintainRepository(app Application, cfg *config.Config) {
+	if !app.GetGit().IsOriginCloned() || !cfg.RepoMaintenanceDue(time.Now()) {
+		return
+	}
+	if err := maintainRepository(app, cfg); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+}
+
+// describeRepoMaintenance returns the maintenance interval for the settings menu
+func describeRepoMaintenance(cfg *config.Config) string {
+	days := cfg.RepoMaintenanceInterval()
+	if days == 0 {
+		return "Off"
+	}
+	return fmt.Sprintf("Every %d days", days)
+}
+
+// runRepositoryMaintenance shows the plugin repository's size and maintenance schedule, and
+// compacts it or changes the schedule on request
+func runRepositoryMaintenance(app Application, cfg *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🧰 Repository Maintenance"))
+	fmt.Println()
+	if !app.GetGit().IsOriginCloned() {
+		fmt.Println("The plugin repository has not been cloned yet.")
+		utils.Pause()
+		return
+	}
+
+	fmt.Println("Every fetch can leave objects behind that no branch needs anymore. Maintenance")
+	fmt.Println("expires them once they are two weeks old, along with old reflog entries, and repacks")
+	fmt.Println("the repository. It runs after an update's fetch once the interval has passed.")
+	fmt.Println()
+	fmt.Printf("Repository size: %s\n", utils.FormatSize(app.GetGit().OriginSize()))
+	fmt.Printf("Schedule:        %s\n", describeRepoMaintenance(cfg))
+	if last, err := time.Parse(time.RFC3339, cfg.LastRepoMaintenanceUTC); err == nil {
+		fmt.Printf("Last run:        %s\n", last.Local().Format("Mon Jan 2 2006 15:04"))
+	} else {
+		fmt.Println("Last run:        never")
+	}
+	fmt.Println()
+
+	const (
+		runItem      = "Run Maintenance Now"
+		intervalItem = "Change Interval"
+	)
+	prompt := promptui.Select{
+		Label:    "What would you like to do?",
+		Items:    []string{runItem, intervalItem, "Back"},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	_, choice, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return
+	}
+
+	switch choice {
+	case runItem:
+		if err := maintainRepository(app, cfg); err != nil {
+			fmt.Printf("❌ %v\n", err)
+		} else {
+			fmt.Println("✅ Repository maintenance complete.")
+		}
+		utils.Pause()
+	case intervalItem:
+		fmt.Print("Enter days between runs (0 to turn off, empty to keep): ")
+		input := utils.ReadLine()
+		if input == "" {
+			return
+		}
+		days, err := strconv.Atoi(input)
+		if err != nil || days < 0 {
+			fmt.Println("❌ Enter a number of days, or 0 to turn maintenance off.")
+			utils.Pause()
+			return
+		}
+		if days == 0 {
+			days = -1
+		}
+		cfg.RepoMaintenanceDays = days
+		if err := app.GetConfig().Save(cfg); err != nil {
+			fmt.Printf("Warning: Failed to save configuration: %v\n", err)
+		}
+		fmt.Printf("✅ Repository maintenance: %s\n", describeRepoMaintenance(cfg))
+		utils.Pause()
+	}
+}
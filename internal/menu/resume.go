@@ -0,0 +1,138 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/journal"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// loadJournal reads the setup journal. One that can't be read is replaced by an empty one, so
+// setups go on; only their earlier progress is lost.
+func loadJournal(app Application) *journal.Journal {
+	jr, err := journal.Load(app.GetConfig().GetSetupJournalFile())
+	if err != nil {
+		fmt.Printf("Warning: Failed to read the setup journal: %v\n", err)
+	}
+	return jr
+}
+
+// journalWarn reports a journal that couldn't be written; the setup goes on, it just can't be resumed
+func journalWarn(err error) {
+	if err != nil {
+		fmt.Printf("Warning: Failed to update the setup journal: %v\n", err)
+	}
+}
+
+// resumes reports whether a step completed in the interrupted setup being resumed
+func (b *engineBuild) resumes(step string) bool {
+	return b.resumed != nil && b.resumed.Done(step)
+}
+
+// completed journals a step of the engine's setup that succeeded
+func (b *engineBuild) completed(step string) {
+	if b.journal != nil {
+		journalWarn(b.journal.Complete(b.enginePath, step))
+	}
+}
+
+// interruptedSetups returns the setups that stopped before they finished. Engines that were set
+// up, repaired or removed since are dropped from the journal, and so are setups that stopped
+// before their worktree existed, since starting over loses nothing.
+func interruptedSetups(app Application, cfg *config.Config) []journal.Setup {
+	jr, err := journal.Load(app.GetConfig().GetSetupJournalFile())
+	if err != nil {
+		return nil
+	}
+	var interrupted []journal.Setup
+	for _, setup := range append([]journal.Setup(nil), jr.Setups...) {
+		if app.GetConfig().GetEngineByPath(cfg, setup.EnginePath) != nil {
+			journalWarn(jr.Finish(setup.EnginePath))
+			continue
+		}
+		if !setup.Resumable() {
+			journalWarn(jr.Finish(setup.EnginePath))
+			continue
+		}
+		if _, ok := app.GetEngine().InspectEngine(setup.EnginePath); !ok {
+			continue // Possibly a drive that isn't connected; kept for when it is
+		}
+		interrupted = append(interrupted, setup)
+	}
+	return interrupted
+}
+
+// describeInterruptedSetup prints how far an interrupted setup came and why it stopped
+func describeInterruptedSetup(setup journal.Setup) {
+	fmt.Printf("UE %s (%s)\n", setup.EngineVersion, setup.EnginePath)
+	if len(setup.Completed) > 0 {
+		var done []string
+		for _, step := range setup.Completed {
+			done = append(done, journal.Label(step))
+		}
+		fmt.Printf("   Done: %s\n", strings.Join(done, ", "))
+	}
+	if next := setup.NextStep(); next != "" {
+		fmt.Printf("   Stopped before it could %s\n", journal.Label(next))
+	}
+	if setup.LastError != "" {
+		fmt.Printf("   Last error: %s\n", setup.LastError)
+	}
+}
+
+// runResumeSetups lists the interrupted setups and resumes the one picked
+func runResumeSetups(app Application, cfg *config.Config) error {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("⏯️  Resume Interrupted Setup"))
+	fmt.Println()
+
+	setups := interruptedSetups(app, cfg)
+	if len(setups) == 0 {
+		fmt.Println("✅ No setup was interrupted.")
+		utils.Pause()
+		return nil
+	}
+	for _, setup := range setups {
+		describeInterruptedSetup(setup)
+		fmt.Println()
+	}
+
+	setup := setups[0]
+	if len(setups) > 1 {
+		var items []string
+		for _, s := range setups {
+			items = append(items, fmt.Sprintf("UE %s (%s)", s.EngineVersion, s.EnginePath))
+		}
+		items = append(items, "Back")
+		prompt := promptui.Select{
+			Label:    "Which setup should continue?",
+			Items:    items,
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		index, _, err := utils.RunSelect(&prompt)
+		if err != nil || index == len(setups) {
+			if err == promptui.ErrInterrupt {
+				return nil
+			}
+			return err
+		}
+		setup = setups[index]
+	} else if !utils.ConfirmStep(fmt.Sprintf("Resume the setup of UE %s?", setup.EngineVersion)) {
+		return nil
+	}
+	return resumeSetupForEngine(app, cfg, setup)
+}
+
+// resumeSetupForEngine continues an engine's interrupted setup and waits for Enter afterwards
+func resumeSetupForEngine(app Application, cfg *config.Config, setup journal.Setup) error {
+	if err := setupEngine(app, cfg, setup.EnginePath, setup.EngineVersion, setup.WorktreeSubdir, true); err != nil {
+		return err
+	}
+	utils.Pause()
+	return nil
+}
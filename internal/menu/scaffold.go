@@ -0,0 +1,51 @@
+package menu
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"ue-git-plugin-manager/internal/projectconfig"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// runProjectScaffold prepares an empty folder for a brand-new project, then runs the setup wizard on it
+func runProjectScaffold(app Application) error {
+	fmt.Println("Creates Config/ with starter INI files, a README placeholder and the Git files in an")
+	fmt.Println("empty folder, so the repository is ready before the Unreal project is created in it.")
+	fmt.Println()
+
+	prompt := utils.PathPrompt{
+		Label:       "Enter the new project folder (empty or not yet created): ",
+		HistoryFile: app.GetConfig().GetHistoryFile("project_paths"),
+		Validate:    projectconfig.CheckScaffoldTarget,
+	}
+	root, err := prompt.Run()
+	if err == promptui.ErrAbort {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("invalid project path: %w", err)
+	}
+
+	if err := projectconfig.ScaffoldProject(root); err != nil {
+		return err
+	}
+	fmt.Println()
+	offerGitLFS(app)
+
+	if err := projectconfig.ConfigureProject(root); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. In the Unreal Project Browser, create the project with %s as the location\n", filepath.Dir(root))
+	fmt.Printf("     and %s as the name. If the editor refuses the existing folder, create the project\n", filepath.Base(root))
+	fmt.Println("     elsewhere and move its files into it.")
+	fmt.Println("  2. Fill in README.md and commit the files with \"git add -A\" and \"git commit\".")
+	fmt.Println("  3. Register it under \"Manage Registered Projects\" once the .uproject exists.")
+	utils.Pause()
+	return nil
+}
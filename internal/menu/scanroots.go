@@ -0,0 +1,135 @@
+package menu
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// editScanRootOptions changes the scan depth, include/exclude patterns and network timeout of a custom engine path
+func editScanRootOptions(app Application, cfg *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("⚙️  Edit Scan Options"))
+	fmt.Println()
+
+	if len(cfg.CustomEngineRoots) == 0 {
+		fmt.Println("No custom engine paths configured.")
+		utils.Pause()
+		return
+	}
+
+	var items []string
+	for _, root := range cfg.CustomEngineRoots {
+		items = append(items, root.Path)
+	}
+	prompt := promptui.Select{
+		Label:    "Select a custom engine path",
+		Items:    items,
+		Size:     10,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return
+	}
+	root := cfg.CustomEngineRoots[index]
+
+	fmt.Println()
+	fmt.Println("Patterns are globs matched against folder names or paths relative to the root,")
+	fmt.Println("e.g. \"UE_5.*\", \"*-CL*\" or \"Archive/*\". Include patterns replace the UE_x.y naming")
+	fmt.Println("rule; excluded folders are skipped with everything below them.")
+	fmt.Println()
+
+	ask := func(label, current string) string {
+		fmt.Printf("%s [%s] (\"-\" to clear, empty to keep): ", label, current)
+		return utils.ReadLine()
+	}
+
+	switch input := ask("Scan depth", strconv.Itoa(root.Depth())); input {
+	case "":
+	case "-":
+		root.MaxDepth = 0
+	default:
+		depth, err := strconv.Atoi(input)
+		if err != nil || depth < 0 {
+			fmt.Println("❌ Depth must be a whole number of folder levels.")
+			utils.Pause()
+			return
+		}
+		root.MaxDepth = depth
+	}
+
+	for _, list := range []struct {
+		label    string
+		patterns *[]string
+	}{
+		{"Include patterns, comma separated", &root.Include},
+		{"Exclude patterns, comma separated", &root.Exclude},
+	} {
+		input := ask(list.label, strings.Join(*list.patterns, ", "))
+		switch input {
+		case "":
+			continue
+		case "-":
+			*list.patterns = nil
+			continue
+		}
+		patterns, err := parsePatterns(input)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			utils.Pause()
+			return
+		}
+		*list.patterns = patterns
+	}
+
+	if utils.IsNetworkPath(root.Path) {
+		fmt.Println()
+		fmt.Println("This is a network path. Its scan is abandoned after the timeout so an offline")
+		fmt.Println("share doesn't hold up the menu; -1 never scans it.")
+		switch input := ask("Network timeout in seconds", strconv.Itoa(int(root.NetworkTimeout().Seconds()))); input {
+		case "":
+		case "-":
+			root.NetworkTimeoutSeconds = 0
+		default:
+			seconds, err := strconv.Atoi(input)
+			if err != nil || seconds < -1 {
+				fmt.Println("❌ Timeout must be a number of seconds, or -1.")
+				utils.Pause()
+				return
+			}
+			root.NetworkTimeoutSeconds = seconds
+		}
+	}
+
+	cfg.CustomEngineRoots[index] = root
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
+	} else {
+		fmt.Printf("✅ Scan options saved: %s\n", root.Describe())
+	}
+	utils.Pause()
+}
+
+// parsePatterns splits a comma separated list of glob patterns and checks their syntax
+func parsePatterns(input string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(input, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
@@ -0,0 +1,56 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+
+	"ue-git-plugin-manager/internal/selfupdate"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// runCheckToolUpdates looks up the latest release of this tool and, after asking, replaces the
+// exe with it once its checksum is verified
+func runCheckToolUpdates() {
+	if selfupdate.Version == "dev" {
+		fmt.Println("ℹ️  This is a development build; it isn't updated from releases.")
+		utils.Pause()
+		return
+	}
+
+	fmt.Println("🔍 Checking for tool updates...")
+	release, err := selfupdate.Latest()
+	if err != nil {
+		fmt.Printf("❌ Failed to check for tool updates: %v\n", err)
+		utils.Pause()
+		return
+	}
+	if !release.IsNewer() {
+		fmt.Printf("✅ You have the latest version (%s).\n", selfupdate.Version)
+		utils.Pause()
+		return
+	}
+
+	fmt.Printf("📦 Version %s is available (you have %s)\n", release.Version, selfupdate.Version)
+	fmt.Printf("   Release notes: %s\n", release.PageURL)
+	exePath, err := os.Executable()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		utils.Pause()
+		return
+	}
+	if err := selfupdate.CanApply(exePath); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		utils.Pause()
+		return
+	}
+	if !utils.Confirm(fmt.Sprintf("Download and install version %s?", release.Version)) {
+		return
+	}
+
+	if err := release.Apply(exePath); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	} else {
+		fmt.Printf("✅ Updated to %s. Restart the tool to use it.\n", release.Version)
+	}
+	utils.Pause()
+}
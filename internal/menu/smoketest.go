@@ -0,0 +1,77 @@
+package menu
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/smoketest"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// runSourceControlSmokeTest runs the editor in commandlet mode against a project to confirm the Git
+// provider initializes and the remote is reachable, and records the result for the status screens
+func runSourceControlSmokeTest(app Application) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🧪 Source Control Smoke Test"))
+	fmt.Println()
+	fmt.Println("Runs the project's engine without a window to confirm the Git provider starts and the")
+	fmt.Println("project's remote answers. This loads the editor modules and can take a few minutes.")
+	fmt.Println()
+
+	root, err := promptForProjectRoot(app)
+	if err != nil {
+		return fmt.Errorf("invalid project path: %w", err)
+	}
+	info, status, err := projectEngineStatus(app, cfg, root)
+	if err != nil {
+		return err
+	}
+	if !status.IsSetupComplete {
+		fmt.Printf("⚠️  UE %s does not have a complete plugin setup; the test will likely fail.\n", status.EngineVersion)
+	}
+
+	fmt.Printf("⏳ Running UE %s against %s...\n", status.EngineVersion, filepath.Base(info.UProjectPath))
+	result := smoketest.Run(status.EnginePath, info.UProjectPath)
+	fmt.Println()
+
+	if result.ProviderOK {
+		fmt.Println("✅ Git source control provider initialized")
+	} else {
+		fmt.Println("❌ Git source control provider did not initialize")
+	}
+	if result.RemoteOK {
+		fmt.Println("✅ Project remote is reachable")
+	} else {
+		fmt.Println("❌ Project remote is not reachable")
+	}
+	if result.Detail != "" {
+		fmt.Printf("   %s\n", result.Detail)
+	}
+	if len(result.LogLines) > 0 && !result.ProviderOK {
+		fmt.Println()
+		fmt.Println("Source control log:")
+		fmt.Println("   " + strings.Join(result.LogLines, "\n   "))
+	}
+	fmt.Println()
+
+	// The result is shown in status next to the project, so it is only kept for registered projects
+	offerProjectRegistration(app, cfg, root)
+	for i, project := range cfg.Projects {
+		if utils.SamePath(project.Path, root) {
+			check := result.Check(status.EngineVersion)
+			cfg.Projects[i].SourceControlCheck = &check
+			if err := app.GetConfig().Save(cfg); err != nil {
+				fmt.Printf("⚠️  Failed to save the result: %v\n", err)
+			}
+		}
+	}
+	utils.Pause()
+	return nil
+}
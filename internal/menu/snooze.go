@@ -0,0 +1,119 @@
+package menu
+
+import (
+	"fmt"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// snoozeTarget is an engine whose available updates can be postponed
+type snoozeTarget struct {
+	worktreeSubdir string
+	engineVersion  string
+}
+
+// snoozeDays are the reminder delays offered by "Remind me later"
+var snoozeDays = []int{1, 3, 7, 14}
+
+// confirmUpdateOrSnooze asks whether to update now, and lets the user postpone the reminder for
+// some or all engines instead. It reports whether the update should go ahead.
+func confirmUpdateOrSnooze(app Application, cfg *config.Config, targets []snoozeTarget) (bool, error) {
+	const (
+		updateItem = "Update now"
+		snoozeItem = "Remind me later"
+		cancelItem = "Cancel"
+	)
+	if utils.SkipsRoutineConfirmations() {
+		return true, nil
+	}
+	prompt := promptui.Select{
+		Label:    "Would you like to update now?",
+		Items:    []string{updateItem, snoozeItem, cancelItem},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	_, choice, err := utils.RunSelect(&prompt)
+	if err != nil {
+		if err == promptui.ErrInterrupt {
+			return false, nil
+		}
+		return false, err
+	}
+	switch choice {
+	case updateItem:
+		return true, nil
+	case snoozeItem:
+		return false, snoozeUpdates(app, cfg, targets)
+	}
+	return false, nil
+}
+
+// snoozeUpdates asks which engines to postpone and for how long, then saves the reminder
+func snoozeUpdates(app Application, cfg *config.Config, targets []snoozeTarget) error {
+	chosen := targets
+	if len(targets) > 1 {
+		items := []string{"All listed engines"}
+		for _, target := range targets {
+			items = append(items, fmt.Sprintf("UE %s", target.engineVersion))
+		}
+		prompt := promptui.Select{
+			Label:    "Snooze updates for",
+			Items:    items,
+			Size:     10,
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		utils.PreselectDefault(&prompt)
+		index, _, err := utils.RunSelect(&prompt)
+		if err != nil {
+			if err == promptui.ErrInterrupt {
+				return nil
+			}
+			return err
+		}
+		if index > 0 {
+			chosen = targets[index-1 : index]
+		}
+	}
+
+	var items []string
+	for _, days := range snoozeDays {
+		if days == 1 {
+			items = append(items, "1 day")
+		} else {
+			items = append(items, fmt.Sprintf("%d days", days))
+		}
+	}
+	prompt := promptui.Select{
+		Label:    "Remind me again in",
+		Items:    items,
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil {
+		if err == promptui.ErrInterrupt {
+			return nil
+		}
+		return err
+	}
+
+	until := time.Now().AddDate(0, 0, snoozeDays[index])
+	for _, target := range chosen {
+		cfg.SnoozeUpdates(target.worktreeSubdir, until)
+	}
+	if err := app.GetConfig().Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %v", err)
+	}
+	for _, target := range chosen {
+		fmt.Printf("⏰ Updates for UE %s snoozed until %s\n", target.engineVersion, until.Format("Mon Jan 2 15:04"))
+	}
+	utils.Pause()
+	return nil
+}
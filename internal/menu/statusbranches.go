@@ -0,0 +1,102 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"ue-git-plugin-manager/internal/projectconfig"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// runStatusBranches sets up UEGitPlugin's status branches for a project: it records the branch
+// names in the project's config, creates missing branches on the remote and shows the module
+// code that registers them with the plugin
+func runStatusBranches(app Application) error {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🚦 Status Branches"))
+	fmt.Println()
+	fmt.Println("Status branches hold commits your CI has tested, e.g. \"promoted\". The editor then warns")
+	fmt.Println("when an asset was changed on one of them after your checkout, before you edit it too.")
+	fmt.Println()
+
+	root, err := promptForProjectRoot(app)
+	if err != nil {
+		return fmt.Errorf("invalid project path: %w", err)
+	}
+	gitMgr := app.GetGit()
+	remote, err := gitMgr.ProjectRemote(root)
+	if err != nil {
+		return err
+	}
+
+	current := projectconfig.StatusBranchNames(root)
+	suggested := strings.Join(current, ", ")
+	if suggested == "" {
+		suggested = projectconfig.DefaultStatusBranch
+	}
+	fmt.Println()
+	fmt.Printf("Status branch names, most stable first, comma separated (empty keeps %q): ", suggested)
+	input := utils.ReadLine()
+	if input == "" {
+		input = suggested
+	}
+	var names []string
+	for _, name := range strings.Split(input, ",") {
+		if name = strings.TrimPrefix(strings.TrimSpace(name), "origin/"); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no status branch names given")
+	}
+
+	fmt.Printf("🔄 Fetching %s...\n", remote)
+	if err := gitMgr.FetchProject(root); err != nil {
+		return err
+	}
+	var missing []string
+	for _, name := range names {
+		if gitMgr.ProjectRemoteBranchExists(root, name) {
+			fmt.Printf("✅ origin/%s already exists\n", name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		base := projectconfig.CurrentBranch(root)
+		fmt.Println()
+		prompt := promptui.Select{
+			Label:    fmt.Sprintf("Create %s on the remote from origin/%s?", strings.Join(missing, ", "), base),
+			Items:    []string{"Yes, create them", "No, I'll create them myself"},
+			HideHelp: true,
+			Stdout:   &utils.BellSkipper{},
+		}
+		utils.PreselectDefault(&prompt)
+		if index, _, err := utils.RunSelect(&prompt); err == nil && index == 0 {
+			for _, name := range missing {
+				if err := gitMgr.CreateProjectRemoteBranch(root, name, base); err != nil {
+					fmt.Printf("❌ %v\n", err)
+					continue
+				}
+				fmt.Printf("✅ Created origin/%s\n", name)
+			}
+		}
+	}
+
+	if err := projectconfig.SetStatusBranchNames(root, names); err != nil {
+		return fmt.Errorf("failed to update %s: %v", projectconfig.StatusBranchFile, err)
+	}
+	fmt.Printf("✅ Listed the status branches in %s [%s]\n", projectconfig.StatusBranchFile, projectconfig.StatusBranchSection)
+	fmt.Println()
+	fmt.Println("UEGitPlugin only learns about status branches from code. If your editor module doesn't")
+	fmt.Println("register them yet, add this to its StartupModule() and commit it with the ini change:")
+	fmt.Println()
+	fmt.Print(projectconfig.StatusBranchSnippet)
+	fmt.Println()
+	fmt.Println("Then have CI push tested commits to the first branch, e.g. git push origin HEAD:" + names[0])
+	utils.Pause()
+	return nil
+}
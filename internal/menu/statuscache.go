@@ -0,0 +1,77 @@
+package menu
+
+import (
+	"fmt"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/theme"
+)
+
+// summaryRefresh is a main menu summary being detected in the background
+type summaryRefresh struct {
+	done    chan struct{}
+	summary string
+	err     error
+}
+
+// statusRefresh is the running or finished background detection, if any
+var statusRefresh *summaryRefresh
+
+// statusChanged is set after a menu action that may have changed what the summary shows, so the
+// next summary is detected before it is shown instead of taken from the cache
+var statusChanged bool
+
+// mainMenuSummary returns the engine summary for the main menu. Unless an action may have changed
+// it, the summary of the last detection is shown right away and a new one runs in the background
+// for the next time the menu is drawn.
+func mainMenuSummary(app Application, cfg *config.Config) (string, error) {
+	if r := statusRefresh; r != nil {
+		select {
+		case <-r.done:
+			statusRefresh = nil
+			if !statusChanged {
+				return r.summary, r.err
+			}
+		default:
+			if !statusChanged {
+				if summary, checked, err := app.GetDetection().GetCachedSimpleSetupSummary(cfg); err == nil {
+					return summary + cachedSummaryNote(checked), nil
+				}
+			}
+			<-r.done
+			statusRefresh = nil
+		}
+	}
+
+	if !statusChanged {
+		if summary, checked, err := app.GetDetection().GetCachedSimpleSetupSummary(cfg); err == nil {
+			r := &summaryRefresh{done: make(chan struct{})}
+			statusRefresh = r
+			go func() {
+				r.summary, r.err = app.GetDetection().GetSimpleSetupSummary(cfg)
+				close(r.done)
+			}()
+			return summary + cachedSummaryNote(checked), nil
+		}
+	}
+	statusChanged = false
+	return app.GetDetection().GetSimpleSetupSummary(cfg)
+}
+
+// settleStatusRefresh waits for a background detection before an action that inspects or changes
+// the setup, so the two never run at the same time
+func settleStatusRefresh(changesStatus bool) {
+	if statusRefresh != nil {
+		<-statusRefresh.done
+	}
+	if changesStatus {
+		statusChanged = true
+	}
+}
+
+// cachedSummaryNote says how old a summary taken from the cache is
+func cachedSummaryNote(checked time.Time) string {
+	age := time.Since(checked).Round(time.Second)
+	return theme.Subdued(fmt.Sprintf("Checked %s ago; refreshing in the background.\n", age))
+}
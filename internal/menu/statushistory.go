@@ -0,0 +1,65 @@
+package menu
+
+import (
+	"fmt"
+
+	"ue-git-plugin-manager/internal/detection"
+	"ue-git-plugin-manager/internal/theme"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// statusHistoryShown is how many of the latest changes "Status History" lists
+const statusHistoryShown = 30
+
+// printBrokenSince says when a broken engine was last seen working and what changed then
+func printBrokenSince(history []detection.HistoryEntry, status detection.SetupStatus) {
+	if !status.IsBroken {
+		return
+	}
+	entry, ok := detection.BrokenSince(history, status.EnginePath)
+	if !ok {
+		return
+	}
+	fmt.Printf("  Broken since %s: %s\n", entry.TimeUTC.Local().Format("2006-01-02 15:04"), entry.Describe())
+}
+
+// runStatusHistory lists the latest changes in the detected state of every engine, so a
+// breakage can be matched with what happened on the machine at the time
+func runStatusHistory(app Application) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("📈 Status History"))
+	fmt.Println()
+
+	history, err := app.GetDetection().StatusHistory()
+	if err != nil {
+		fmt.Printf("❌ Failed to read the status history: %v\n", err)
+		utils.Pause()
+		return
+	}
+	if len(history) == 0 {
+		fmt.Println("No changes recorded yet. Every status check records the engines whose state changed.")
+		utils.Pause()
+		return
+	}
+
+	start := 0
+	if len(history) > statusHistoryShown {
+		start = len(history) - statusHistoryShown
+		fmt.Println(theme.Subdued(fmt.Sprintf("Latest %d of %d changes, newest first\n", statusHistoryShown, len(history))))
+	}
+	for i := len(history) - 1; i >= start; i-- {
+		entry := history[i]
+		symbol := theme.Symbol(theme.Info)
+		switch entry.State {
+		case detection.HistoryComplete:
+			symbol = theme.Symbol(theme.OK)
+		case detection.HistoryBroken:
+			symbol = theme.Symbol(theme.Warning)
+		}
+		fmt.Printf("%s %s  UE %s  %s\n", symbol, entry.TimeUTC.Local().Format("2006-01-02 15:04"), entry.EngineVersion, entry.Describe())
+	}
+	fmt.Println()
+	fmt.Printf("Full history: %s\n", detection.StatusHistoryPath(app.GetConfig().GetBaseDir()))
+	utils.Pause()
+}
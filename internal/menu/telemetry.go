@@ -0,0 +1,100 @@
+package menu
+
+import (
+	"fmt"
+	"net/url"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/issues"
+	"ue-git-plugin-manager/internal/logging"
+	"ue-git-plugin-manager/internal/telemetry"
+	"ue-git-plugin-manager/internal/timing"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+)
+
+// reportOutcome finishes an operation on one engine: it returns the error the operation failed
+// with, if any, with its error code attached, and queues the outcome and tries to send the queue
+// in the background when the user opted in to telemetry. Operations that stopped before their
+// first step, such as a cancelled setup, aren't reported.
+func reportOutcome(app Application, cfg *config.Config, operation, engineVersion string, steps []timing.StepDuration, err error) error {
+	err = issues.Wrap(steps, err)
+	if !cfg.TelemetryEnabled || cfg.TelemetryURL == "" || len(steps) == 0 {
+		return err
+	}
+	queue := app.GetConfig().GetTelemetryQueueFile()
+	if queueErr := telemetry.Queue(queue, telemetry.NewEvent(operation, engineVersion, steps, err)); queueErr != nil {
+		logging.Printf("telemetry: failed to queue an event: %v", queueErr)
+		return err
+	}
+	telemetry.SendInBackground(cfg.TelemetryURL, queue)
+	return err
+}
+
+// printIssueCode prints the error code of a failure and where it is documented, so it ends up in
+// the support ticket along with the message
+func printIssueCode(cfg *config.Config, err error) {
+	if line := issues.Describe(cfg.SupportDocsURL, err); line != "" {
+		fmt.Println(line)
+	}
+}
+
+// toggleTelemetry turns the sending of anonymous operation outcomes on, after showing exactly
+// what is sent and asking for the studio's endpoint, or off, discarding what wasn't sent yet
+func toggleTelemetry(app Application, cfg *config.Config) {
+	if cfg.TelemetryEnabled {
+		cfg.TelemetryEnabled = false
+		if err := telemetry.Discard(app.GetConfig().GetTelemetryQueueFile()); err != nil {
+			fmt.Printf("⚠️  Failed to delete unsent events: %v\n", err)
+		}
+		if err := app.GetConfig().Save(cfg); err != nil {
+			fmt.Printf("❌ Failed to save configuration: %v\n", err)
+		} else {
+			fmt.Println("✅ Telemetry is off; nothing more will be sent.")
+		}
+		utils.Pause()
+		return
+	}
+
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("📊 Telemetry"))
+	fmt.Println()
+	fmt.Println("Helps whoever maintains this tool at your studio see which setups, updates and")
+	fmt.Println("repairs fail most often. After each of them, this is sent:")
+	fmt.Println("  • the operation and the engine version, e.g. update of UE 5.4")
+	fmt.Println("  • whether it succeeded, and if not a failure category such as build/timeout")
+	fmt.Println("  • how long the clone, worktree, link and build steps took, in seconds")
+	fmt.Println("  • the day it happened")
+	fmt.Println("Never sent: your name, the machine's name, paths, error messages or repository URLs.")
+	fmt.Println()
+
+	if cfg.TelemetryURL != "" {
+		fmt.Printf("Enter your studio's endpoint URL (empty for %s): ", cfg.TelemetryURL)
+	} else {
+		fmt.Print("Enter your studio's endpoint URL: ")
+	}
+	endpoint := utils.ReadLine()
+	if endpoint == "" {
+		endpoint = cfg.TelemetryURL
+	}
+	if endpoint == "" {
+		return
+	}
+	if parsed, err := url.Parse(endpoint); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		fmt.Println("❌ Enter an http:// or https:// URL.")
+		utils.Pause()
+		return
+	}
+	if !utils.ConfirmConsent(fmt.Sprintf("Send anonymous operation outcomes to %s?", endpoint)) {
+		return
+	}
+
+	cfg.TelemetryEnabled = true
+	cfg.TelemetryURL = endpoint
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
+	} else {
+		fmt.Println("✅ Telemetry is on. Turn it off here at any time.")
+	}
+	utils.Pause()
+}
@@ -0,0 +1,300 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/maintenance"
+	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/policy"
+	"ue-git-plugin-manager/internal/telemetry"
+	"ue-git-plugin-manager/internal/timing"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+)
+
+// UpdateUnattended updates and rebuilds every managed engine without asking questions, for the
+// update command run from Task Scheduler. It never rebuilds while an Unreal editor is running and,
+// when scheduled is set, only inside the configured maintenance window; either case returns an
+// error wrapping maintenance.ErrDeferred so the next run can try again. enginePaths limits the
+// update to those managed engines; empty updates all of them.
+func UpdateUnattended(app Application, scheduled bool, enginePaths []string) error {
+	cfg, err := loadConfigOrDefault(app)
+	if err != nil {
+		return err
+	}
+	if err := policy.CheckEngineChanges(cfg); err != nil {
+		return err
+	}
+
+	// Outside the window nothing is fetched, so a frequent schedule stays cheap
+	if err := checkMaintenanceWindow(cfg, scheduled); err != nil {
+		return err
+	}
+	if !app.GetGit().IsOriginCloned() {
+		return fmt.Errorf("the plugin repository has not been cloned; run the setup first")
+	}
+
+	events.Println("🔄 Checking for updates...")
+	syncTrackedRemotes(app, cfg, false)
+	if err := app.GetGit().FetchAll(cfg.MirrorURL); err != nil {
+		return fmt.Errorf("failed to fetch updates: %w", err)
+	}
+	maybeMaintainRepository(app, cfg)
+
+	rec := timing.NewRecorder()
+	defer recordTimings(app, rec)
+	updated, failed := 0, 0
+	var pending []config.Engine
+	for _, eng := range append([]config.Engine(nil), cfg.Engines...) {
+		if len(enginePaths) > 0 && !containsEnginePath(enginePaths, eng.EnginePath) {
+			continue
+		}
+		subdir := engineSubdir(eng)
+		info, err := app.GetGit().GetUpdateInfo(subdir, cfg.TrackedBranch(subdir), cfg.TrackedPin(subdir))
+		if err != nil {
+			fmt.Printf("❌ UE %s: failed to check for updates: %v\n", eng.EngineVersion, err)
+			failed++
+			continue
+		}
+		// A rebuild deferred by a locked DLL left the worktree updated but the binaries behind, and
+		// binaries built before an engine hotfix may no longer load
+		built, current, hotfixed := app.GetDetection().EngineHotfix(cfg, eng.EnginePath)
+		if info.CommitsAhead == 0 && !hotfixed && !localPatchesOutdated(app, cfg, subdir) &&
+			!app.GetDetection().DetectEngineSetupStatus(eng.EnginePath, eng.EngineVersion, subdir).BinariesStale {
+			events.Printf("✅ UE %s is up to date\n", eng.EngineVersion)
+			continue
+		}
+		if hotfixed {
+			events.Printf("UE %s needs a rebuild for the engine update from %s to %s\n", eng.EngineVersion, built, current)
+		} else {
+			events.Printf("UE %s has %d new commits\n", eng.EngineVersion, info.CommitsAhead)
+		}
+		pending = append(pending, eng)
+	}
+
+	// Checked again after the fetch: it can outlast the window or an artist can start the editor
+	if len(pending) > 0 {
+		if err := checkMaintenanceWindow(cfg, scheduled); err != nil {
+			return err
+		}
+		if err := checkNoEditorRunning(app); err != nil {
+			return err
+		}
+	}
+
+	// The worktrees share one repository, so they are updated one at a time; the builds run side by side
+	var builds []*engineBuild
+	for _, eng := range pending {
+		build := newEngineBuild(eng.EnginePath, eng.EngineVersion, engineSubdir(eng))
+		events.Printf("Updating UE %s...\n", eng.EngineVersion)
+		if err := prepareEngineUnattended(app, cfg, build); err != nil {
+			rec.Merge(build.rec)
+			err = reportOutcome(app, cfg, telemetry.OpUpdate, eng.EngineVersion, build.rec.All(), err)
+			fmt.Printf("❌ UE %s: %v\n", eng.EngineVersion, err)
+			printIssueCode(cfg, err)
+			failed++
+			continue
+		}
+		builds = append(builds, build)
+	}
+	buildEngines(app, cfg, builds, false)
+
+	// A locked DLL defers only its own engine; the others are still reported
+	var deferred []string
+	for _, build := range builds {
+		rec.Merge(build.rec)
+		err := build.err
+		if err != nil {
+			err = fmt.Errorf("failed to rebuild plugin: %w", err)
+		} else {
+			err = recordManagedEngine(app, cfg, build.enginePath, build.engineVersion, build.worktreeSubdir, false)
+		}
+		err = reportOutcome(app, cfg, telemetry.OpUpdate, build.engineVersion, build.rec.All(), err)
+		switch {
+		case err != nil && errors.Is(err, plugin.ErrBinariesLocked):
+			// The worktree is updated; the next run sees the stale binaries and builds again
+			fmt.Printf("⏸️  UE %s: %v\n", build.engineVersion, err)
+			deferred = append(deferred, "UE "+build.engineVersion)
+		case err != nil:
+			fmt.Printf("❌ UE %s: %v\n", build.engineVersion, err)
+			printIssueCode(cfg, err)
+			failed++
+		default:
+			events.Printf("✅ UE %s updated\n", build.engineVersion)
+			updated++
+		}
+	}
+
+	// A failure needs someone to look at it, so it is reported over a rebuild the next run retries
+	if failed > 0 {
+		return fmt.Errorf("%d engine(s) could not be updated", failed)
+	}
+	events.Printf("🎉 %d engine(s) updated\n", updated)
+	if len(deferred) > 0 {
+		return fmt.Errorf("%w: plugin binaries in use for %s", maintenance.ErrDeferred, strings.Join(deferred, ", "))
+	}
+	return nil
+}
+
+// containsEnginePath reports whether an engine is among paths
+func containsEnginePath(paths []string, enginePath string) bool {
+	for _, path := range paths {
+		if utils.SamePath(path, enginePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareEngineUnattended moves one engine's worktree to its tracked commit and readies the
+// engine for the plugin to be rebuilt
+func prepareEngineUnattended(app Application, cfg *config.Config, build *engineBuild) error {
+	subdir := build.worktreeSubdir
+	err := build.rec.Time(timing.StepWorktree, func() error {
+		return app.GetGit().UpdateWorktree(subdir, cfg.TrackedBranch(subdir), cfg.TrackedPin(subdir), localPatches(app, cfg, subdir))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update worktree: %w", err)
+	}
+
+	if app.GetEngine().CheckPluginCollision(build.enginePath) {
+		if err := app.GetEngine().DisableStockPlugin(build.enginePath); err != nil {
+			return fmt.Errorf("failed to disable stock plugin: %v", err)
+		}
+	}
+	if !cfg.SkipPluginCacheCleanup {
+		cleanPluginCaches(app, cfg, build.enginePath, build.engineVersion)
+	}
+	return nil
+}
+
+// checkMaintenanceWindow defers a scheduled run that starts outside the configured window
+func checkMaintenanceWindow(cfg *config.Config, scheduled bool) error {
+	if !scheduled || cfg.MaintenanceWindow == "" {
+		return nil
+	}
+	window, err := maintenance.Parse(cfg.MaintenanceWindow)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if window.Contains(now) {
+		return nil
+	}
+	return fmt.Errorf("%w: outside the maintenance window %s (next opens %s)", maintenance.ErrDeferred, window, window.Next(now).Format("Mon Jan 2 15:04"))
+}
+
+// checkNoEditorRunning defers the rebuild while an Unreal editor is open, since it locks the
+// plugin binaries and the artist would lose their session to a long compile
+func checkNoEditorRunning(app Application) error {
+	running, err := app.GetEngine().RunningEditors()
+	if err != nil {
+		return fmt.Errorf("%w: could not check for running editors: %v", maintenance.ErrDeferred, err)
+	}
+	if len(running) > 0 {
+		return fmt.Errorf("%w: an Unreal editor is running (%s)", maintenance.ErrDeferred, strings.Join(running, ", "))
+	}
+	return nil
+}
+
+// changeMaintenanceWindow sets the daily time range in which scheduled updates may rebuild
+func changeMaintenanceWindow(app Application, cfg *config.Config) {
+	fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🌙 Maintenance Window"))
+	fmt.Println()
+	fmt.Println("\"update --scheduled\" (e.g. from Task Scheduler) only updates and rebuilds engines")
+	fmt.Println("inside this daily window, and never while an Unreal editor is running.")
+	fmt.Println()
+
+	if cfg.MaintenanceWindow != "" {
+		fmt.Printf("Current window: %s\n", cfg.MaintenanceWindow)
+	} else {
+		fmt.Println("Current window: none (any time)")
+	}
+	fmt.Print("Enter window as HH:MM-HH:MM, e.g. 02:00-05:00 (\"-\" to clear, empty to keep): ")
+	input := utils.ReadLine()
+
+	switch input {
+	case "":
+		utils.Pause()
+		return
+	case "-":
+		cfg.MaintenanceWindow = ""
+	default:
+		window, err := maintenance.Parse(input)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			utils.Pause()
+			return
+		}
+		cfg.MaintenanceWindow = window.String()
+	}
+
+	if err := app.GetConfig().Save(cfg); err != nil {
+		fmt.Printf("❌ Failed to save configuration: %v\n", err)
+	} else {
+		fmt.Println("✅ Maintenance window updated!")
+	}
+	utils.Pause()
+}
+
+// offerHotfixRebuild asks to rebuild the plugin for engines that were patched since it was built,
+// e.g. from 5.4.3 to 5.4.4. With a maintenance window the rebuild can be left to the scheduled
+// update instead. It reports whether anything was shown.
+func offerHotfixRebuild(app Application, cfg *config.Config) bool {
+	type hotfixed struct {
+		eng            config.Engine
+		built, current string
+	}
+	var engines []hotfixed
+	for _, eng := range cfg.Engines {
+		if built, current, changed := app.GetDetection().EngineHotfix(cfg, eng.EnginePath); changed {
+			engines = append(engines, hotfixed{eng, built, current})
+		}
+	}
+	if len(engines) == 0 {
+		return false
+	}
+
+	fmt.Println("🩹 Engines were updated since the plugin was built for them:")
+	for _, h := range engines {
+		fmt.Printf("   UE %s: %s → %s\n", h.eng.EngineVersion, h.built, h.current)
+	}
+	fmt.Println("   Plugin binaries built against the previous patch sometimes fail to load.")
+	fmt.Println()
+
+	later := "Later"
+	if cfg.MaintenanceWindow != "" {
+		later = fmt.Sprintf("Rebuild in the Maintenance Window (%s)", cfg.MaintenanceWindow)
+	}
+	prompt := promptui.Select{
+		Label:    "Rebuild the plugin now?",
+		Items:    []string{"Rebuild Now", later},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil || index != 0 {
+		if err == nil && cfg.MaintenanceWindow != "" {
+			fmt.Println("The scheduled \"update --scheduled\" task rebuilds them in the window.")
+			utils.Pause()
+		}
+		return true
+	}
+
+	for _, h := range engines {
+		subdir := engineSubdir(h.eng)
+		if err := runRepairForEngine(app, cfg, h.eng.EnginePath, h.eng.EngineVersion, subdir); err != nil {
+			fmt.Printf("❌ UE %s: %v\n", h.eng.EngineVersion, err)
+			utils.Pause()
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// EngineMetrics holds the metric values for a single managed engine
+type EngineMetrics struct {
+	Version       string
+	Path          string
+	SetupComplete bool
+	CommitsBehind int
+	LastUpdated   time.Time
+}
+
+// Snapshot is the set of metrics collected in one run
+type Snapshot struct {
+	Engines     []EngineMetrics
+	CollectedAt time.Time
+}
+
+// Broken returns the number of engines whose setup is not complete
+func (s *Snapshot) Broken() int {
+	broken := 0
+	for _, eng := range s.Engines {
+		if !eng.SetupComplete {
+			broken++
+		}
+	}
+	return broken
+}
+
+// Render formats the snapshot in the Prometheus text exposition format
+func (s *Snapshot) Render() string {
+	var b strings.Builder
+
+	writeHeader(&b, "uegpm_engines_managed", "Number of engines managed by UE Git Plugin Manager.")
+	fmt.Fprintf(&b, "uegpm_engines_managed %d\n", len(s.Engines))
+
+	writeHeader(&b, "uegpm_engines_broken", "Number of managed engines whose setup is broken.")
+	fmt.Fprintf(&b, "uegpm_engines_broken %d\n", s.Broken())
+
+	writeHeader(&b, "uegpm_engine_setup_complete", "Whether the plugin setup of an engine is complete (1) or not (0).")
+	for _, eng := range s.Engines {
+		fmt.Fprintf(&b, "uegpm_engine_setup_complete{%s} %d\n", engineLabels(eng), boolToInt(eng.SetupComplete))
+	}
+
+	writeHeader(&b, "uegpm_engine_commits_behind", "Number of plugin commits the engine's worktree is behind its target.")
+	for _, eng := range s.Engines {
+		fmt.Fprintf(&b, "uegpm_engine_commits_behind{%s} %d\n", engineLabels(eng), eng.CommitsBehind)
+	}
+
+	writeHeader(&b, "uegpm_engine_last_update_timestamp_seconds", "Unix time the plugin was last installed or updated for the engine.")
+	for _, eng := range s.Engines {
+		if eng.LastUpdated.IsZero() {
+			continue
+		}
+		fmt.Fprintf(&b, "uegpm_engine_last_update_timestamp_seconds{%s} %d\n", engineLabels(eng), eng.LastUpdated.Unix())
+	}
+
+	writeHeader(&b, "uegpm_collected_timestamp_seconds", "Unix time these metrics were collected.")
+	fmt.Fprintf(&b, "uegpm_collected_timestamp_seconds %d\n", s.CollectedAt.Unix())
+
+	return b.String()
+}
+
+// WriteTextfile writes the snapshot for the node_exporter textfile collector.
+// The file is written to a temporary name and renamed so the collector never reads a partial file.
+func WriteTextfile(path string, s *Snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(s.Render()), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Push sends the snapshot to a Prometheus Pushgateway, grouped by job and host name
+func Push(gatewayURL, job string, s *Snapshot) error {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	target := fmt.Sprintf("%s/metrics/job/%s/instance/%s",
+		strings.TrimRight(gatewayURL, "/"), url.PathEscape(job), url.PathEscape(host))
+
+	req, err := http.NewRequest(http.MethodPut, target, bytes.NewBufferString(s.Render()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("pushgateway returned %s", resp.Status)
+	}
+	return nil
+}
+
+func writeHeader(b *strings.Builder, name, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+}
+
+func engineLabels(eng EngineMetrics) string {
+	return fmt.Sprintf(`version="%s",path="%s"`, escapeLabel(eng.Version), escapeLabel(eng.Path))
+}
+
+// escapeLabel escapes a label value; engine paths contain backslashes on Windows
+func escapeLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -3,5 +3,5 @@ package new_project_example_config_files
 import "embed"
 
 // FS contains the embedded project configuration templates.
-//go:embed .gitattributes common.gitignore with_plugin_binaries.gitignore without_plugin_binaries.gitignore
+//go:embed .gitattributes common.gitignore with_plugin_binaries.gitignore without_plugin_binaries.gitignore scaffold_DefaultEngine.ini scaffold_DefaultEditorPerProjectUserSettings.ini scaffold_README.md ci_github.yml.tmpl ci_azure.yml.tmpl pre-commit.tmpl
 var FS embed.FS
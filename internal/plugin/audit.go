@@ -0,0 +1,142 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// Where an audited plugin came from
+const (
+	SourceThisTool    = "UE Git Plugin Manager"
+	SourceMarketplace = "Marketplace / Fab"
+	SourceLinked      = "linked folder"
+	SourceThirdParty  = "third-party"
+)
+
+// AuditedPlugin is a plugin in an engine's Plugins folder that didn't ship with the engine
+type AuditedPlugin struct {
+	Name         string `json:"name"` // Descriptor file name without .uplugin
+	FriendlyName string `json:"friendly_name,omitempty"`
+	Version      string `json:"version,omitempty"`
+	CreatedBy    string `json:"created_by,omitempty"`
+	Dir          string `json:"dir"` // Plugin folder relative to Engine\Plugins
+	Source       string `json:"source"`
+	LinkTarget   string `json:"link_target,omitempty"`
+}
+
+// pluginDescriptor holds the .uplugin fields the audit reports
+type pluginDescriptor struct {
+	FriendlyName   string `json:"FriendlyName"`
+	VersionName    string `json:"VersionName"`
+	CreatedBy      string `json:"CreatedBy"`
+	MarketplaceURL string `json:"MarketplaceURL"`
+	FabURL         string `json:"FabURL"`
+}
+
+// skipPluginDirs are folders that never contain plugin descriptors; skipping them keeps walks fast
+var skipPluginDirs = map[string]bool{
+	"binaries": true, "intermediate": true, "source": true, "content": true,
+	"resources": true, "config": true, "shaders": true, "saved": true,
+}
+
+// walkPluginDescriptors calls visit for every .uplugin file under an engine's Plugins folder.
+// Folders for which skip returns true are not entered. Linked folders (junctions and symlinks)
+// are not followed; visitLink is called for them instead when it is not nil.
+func walkPluginDescriptors(pluginsDir string, skip func(dir string) bool, visit func(upluginPath string), visitLink func(dir string)) {
+	filepath.WalkDir(pluginsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != pluginsDir && (skip(path) || skipPluginDirs[strings.ToLower(entry.Name())]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type()&(fs.ModeSymlink|fs.ModeIrregular) != 0 {
+			if visitLink != nil && !skip(path) {
+				visitLink(path)
+			}
+			return nil
+		}
+		if strings.EqualFold(filepath.Ext(path), ".uplugin") {
+			visit(path)
+		}
+		return nil
+	})
+}
+
+// AuditEnginePlugins lists the plugins under an engine's Plugins folder that didn't ship with
+// the engine: Marketplace/Fab installs, linked folders such as ours, and third-party copies
+func (m *Manager) AuditEnginePlugins(enginePath string) []AuditedPlugin {
+	pluginsDir := filepath.Join(enginePath, "Engine", "Plugins")
+	ourLink := m.GetPluginLinkPath(enginePath)
+	marketplaceDir := filepath.Join(pluginsDir, "Marketplace")
+
+	var plugins []AuditedPlugin
+	add := func(upluginPath, source, linkTarget string) {
+		descriptor := readPluginDescriptor(upluginPath)
+		dir, _ := filepath.Rel(pluginsDir, filepath.Dir(upluginPath))
+		plugins = append(plugins, AuditedPlugin{
+			Name:         strings.TrimSuffix(filepath.Base(upluginPath), filepath.Ext(upluginPath)),
+			FriendlyName: descriptor.FriendlyName,
+			Version:      descriptor.VersionName,
+			CreatedBy:    descriptor.CreatedBy,
+			Dir:          dir,
+			Source:       source,
+			LinkTarget:   linkTarget,
+		})
+	}
+
+	walkPluginDescriptors(pluginsDir, func(string) bool { return false }, func(upluginPath string) {
+		descriptor := readPluginDescriptor(upluginPath)
+		switch {
+		case strings.HasPrefix(strings.ToLower(upluginPath), strings.ToLower(marketplaceDir)+string(filepath.Separator)):
+			add(upluginPath, SourceMarketplace, "")
+		case descriptor.MarketplaceURL != "" || descriptor.FabURL != "":
+			add(upluginPath, SourceMarketplace, "")
+		case !strings.Contains(descriptor.CreatedBy, "Epic Games"):
+			add(upluginPath, SourceThirdParty, "")
+		}
+	}, func(dir string) {
+		// A linked plugin keeps its descriptor at the root of the link's target
+		source := SourceLinked
+		if utils.SamePath(dir, ourLink) {
+			source = SourceThisTool
+		}
+		target, _ := m.GetJunctionTarget(dir)
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.uplugin"))
+		if len(matches) == 0 {
+			rel, _ := filepath.Rel(pluginsDir, dir)
+			plugins = append(plugins, AuditedPlugin{Name: filepath.Base(dir), Dir: rel, Source: source, LinkTarget: target})
+			return
+		}
+		for _, upluginPath := range matches {
+			add(upluginPath, source, target)
+		}
+	})
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return strings.ToLower(plugins[i].Dir) < strings.ToLower(plugins[j].Dir)
+	})
+	return plugins
+}
+
+// readPluginDescriptor parses a .uplugin file, returning empty fields when it can't be read
+func readPluginDescriptor(upluginPath string) pluginDescriptor {
+	var descriptor pluginDescriptor
+	data, err := os.ReadFile(upluginPath)
+	if err != nil {
+		return descriptor
+	}
+	// Descriptors saved by the editor often start with a UTF-8 BOM
+	json.Unmarshal(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), &descriptor)
+	return descriptor
+}
@@ -0,0 +1,115 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// maxCapturedBuildLog bounds the build output kept for diagnosis; failures are reported near the end
+const maxCapturedBuildLog = 4 << 20
+
+// buildFailure is a known UBT/UAT failure signature and how to fix it
+type buildFailure struct {
+	needles  []string       // Lower-case fragments of the log that identify the failure
+	detail   *regexp.Regexp // Optional pattern whose first group is named in the guidance
+	guidance string         // Fix suggestion; %s is replaced by the detail match when there is one
+}
+
+// buildFailures are checked in order; every matching signature is reported
+var buildFailures = []buildFailure{
+	{
+		needles: []string{
+			"unable to find installation of windows", "windows sdk must be installed",
+			"could not find windows sdk", "no valid windows sdk", "windows sdk not found",
+		},
+		guidance: "The Windows SDK is missing. In the Visual Studio Installer, choose Modify → Individual components and install a Windows 10 or 11 SDK, then build again.",
+	},
+	{
+		needles: []string{
+			"no valid visual c++ toolchain", "unable to find valid c++ toolchain",
+			"no visual c++ installation was found",
+		},
+		guidance: "No usable Visual C++ toolchain was found. Install the \"Desktop development with C++\" and \"Game development with C++\" workloads in the Visual Studio Installer.",
+	},
+	{
+		needles:  []string{"lnk1104"},
+		detail:   regexp.MustCompile(`(?i)LNK1104: cannot open file '([^']+)'`),
+		guidance: "The linker could not write %s because another process has it open. Close every Unreal Editor using this engine (and Live Coding), wait for antivirus scans to finish, then build again.",
+	},
+	{
+		needles: []string{
+			"not enough space on the disk", "there is not enough space", "disk full", "lnk1180",
+			"no space left on device",
+		},
+		guidance: "The disk ran out of space during the build. Free some space on the drive holding the engine and %s, then build again.",
+	},
+	{
+		needles:  []string{"non-ascii", "illegal characters in path", "invalid character in path"},
+		guidance: "A build path contains non-ASCII characters, which UBT and MSVC can't handle. Move the engine or the data directory to a path with only ASCII characters.",
+	},
+	{
+		needles:  []string{"c1060", "compiler is out of heap space", "out of memory"},
+		guidance: "The compiler ran out of memory. Close other applications, or increase the Windows page file, then build again.",
+	},
+}
+
+// diagnoseBuildLog returns fix suggestions for the known failure signatures in a build log.
+// The paths used by the build are also checked for non-ASCII characters, which UBT reports unclearly.
+func diagnoseBuildLog(log string, worktreePath string, buildPaths ...string) []string {
+	lower := strings.ToLower(log)
+	var suggestions []string
+	for _, failure := range buildFailures {
+		matched := false
+		for _, needle := range failure.needles {
+			if strings.Contains(lower, needle) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		guidance := failure.guidance
+		if strings.Contains(guidance, "%s") {
+			detail := filepath.Dir(worktreePath)
+			if failure.detail != nil {
+				detail = "the output file"
+				if match := failure.detail.FindStringSubmatch(log); match != nil {
+					detail = match[1]
+				}
+			}
+			guidance = fmt.Sprintf(guidance, detail)
+		}
+		suggestions = append(suggestions, guidance)
+	}
+
+	for _, path := range append([]string{worktreePath}, buildPaths...) {
+		if utils.HasNonASCIICharacters(path) {
+			suggestions = append(suggestions, fmt.Sprintf("The path %s contains non-ASCII characters, which UBT and MSVC can't handle. Move it to a path with only ASCII characters.", path))
+		}
+	}
+	return suggestions
+}
+
+// tailBuffer keeps the last maxCapturedBuildLog bytes written to it
+type tailBuffer struct {
+	buf bytes.Buffer
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf.Write(p)
+	if over := t.buf.Len() - maxCapturedBuildLog; over > 0 {
+		t.buf.Next(over)
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	return t.buf.String()
+}
@@ -0,0 +1,60 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// BuildPath is a folder a plugin build reads from or writes to
+type BuildPath struct {
+	Role string
+	Path string
+}
+
+// BuildPaths lists every folder involved in building the plugin against an engine
+func (m *Manager) BuildPaths(enginePath, worktreePath string) []BuildPath {
+	return []BuildPath{
+		{"Engine", enginePath},
+		{"Plugin worktree", worktreePath},
+		{"Plugin link", m.GetPluginLinkPath(enginePath)},
+		{"Build output", filepath.Join(worktreePath, "_Built")},
+		{"Temporary files (TEMP)", os.Getenv("TEMP")},
+		{"Temporary files (TMP)", os.Getenv("TMP")},
+	}
+}
+
+// NonASCIIBuildPaths returns the build paths containing characters UBT and MSVC can't handle
+func (m *Manager) NonASCIIBuildPaths(enginePath, worktreePath string) []BuildPath {
+	var paths []BuildPath
+	for _, path := range m.BuildPaths(enginePath, worktreePath) {
+		if utils.HasNonASCIICharacters(path.Path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// buildTempDir returns an ASCII-only folder for the compiler's temporary files, used instead of
+// TEMP when that is under a user profile with a non-ASCII name
+func buildTempDir() string {
+	programData := os.Getenv("ProgramData")
+	if programData == "" || utils.HasNonASCIICharacters(programData) {
+		programData = filepath.Join("C:\\", "ProgramData")
+	}
+	return filepath.Join(programData, "ue-git-plugin-manager", "tmp")
+}
+
+// buildEnv returns the environment for RunUAT, moving TEMP and TMP to an ASCII-only folder when needed.
+// The second result is that folder, or "" when the inherited environment is used unchanged.
+func buildEnv() ([]string, string) {
+	if !utils.HasNonASCIICharacters(os.Getenv("TEMP")) && !utils.HasNonASCIICharacters(os.Getenv("TMP")) {
+		return nil, ""
+	}
+	tempDir := buildTempDir()
+	if err := os.MkdirAll(tempDir, 0755); err != nil {
+		return nil, ""
+	}
+	return append(os.Environ(), "TEMP="+tempDir, "TMP="+tempDir), tempDir
+}
@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PluginCacheDirs returns the existing Intermediate folders that can hold stale
+// GitSourceControl module manifests for an engine and the given project roots
+func (m *Manager) PluginCacheDirs(enginePath string, projectRoots []string) []string {
+	// The stock plugin's Intermediate folder ships with launcher installs and is left alone
+	candidates := []string{
+		// Our plugin's intermediates, left from a build against the previous commit
+		filepath.Join(m.GetPluginLinkPath(enginePath), "Intermediate"),
+	}
+
+	for _, root := range projectRoots {
+		buildDir := filepath.Join(root, "Intermediate", "Build")
+		// Compiled build rules still reference the plugin location they were built with
+		candidates = append(candidates, filepath.Join(buildDir, "BuildRules"))
+		candidates = append(candidates, findModuleDirs(buildDir, ModuleName, 5)...)
+	}
+
+	var dirs []string
+	for _, dir := range candidates {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// CleanPluginCaches removes the given cache folders and returns the ones it deleted
+func (m *Manager) CleanPluginCaches(dirs []string) ([]string, error) {
+	var removed []string
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return removed, err
+		}
+		removed = append(removed, dir)
+	}
+	return removed, nil
+}
+
+// findModuleDirs returns folders named after a module up to maxDepth levels below root
+func findModuleDirs(root, moduleName string, maxDepth int) []string {
+	var dirs []string
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() || path == root {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		depth := len(strings.Split(rel, string(filepath.Separator)))
+		if strings.EqualFold(entry.Name(), moduleName) {
+			dirs = append(dirs, path)
+			return filepath.SkipDir
+		}
+		if depth >= maxDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return dirs
+}
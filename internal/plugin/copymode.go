@@ -0,0 +1,159 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// copyStagingSuffix is the suffix of the folder a plugin copy is made in before it is swapped in
+const copyStagingSuffix = ".copying"
+
+// copyMarkerFile records, inside a copied plugin folder, which worktree it was copied from
+const copyMarkerFile = ".ue-git-plugin-manager-copy"
+
+// copySkipDirs are worktree folders that aren't needed by the editor and aren't copied
+var copySkipDirs = []string{".git", "Intermediate", "Saved", "_Built"}
+
+type copyMarker struct {
+	Source    string `json:"source"`
+	Machine   string `json:"machine"`
+	SyncedUTC string `json:"synced_utc"`
+}
+
+// UsesCopyMode reports whether the plugin has to be copied into an engine instead of linked.
+// Junctions can't be created on network shares, and a symbolic link on a share pointing at this
+// PC's worktree only resolves here, if remote-to-local link evaluation is even enabled.
+func (m *Manager) UsesCopyMode(enginePath string) bool {
+	return utils.IsNetworkPath(enginePath)
+}
+
+// IsCopyInstall reports whether path is a plugin folder copied by this tool rather than a link
+func (m *Manager) IsCopyInstall(path string) bool {
+	if fi, err := os.Lstat(path); err != nil || fi.Mode()&os.ModeSymlink != 0 {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(path, copyMarkerFile))
+	return err == nil
+}
+
+// copySource returns the worktree a copied plugin folder was made from
+func (m *Manager) copySource(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(path, copyMarkerFile))
+	if err != nil {
+		return "", err
+	}
+	var marker copyMarker
+	if err := json.Unmarshal(data, &marker); err != nil {
+		return "", fmt.Errorf("invalid copy marker in %s: %v", path, err)
+	}
+	return marker.Source, nil
+}
+
+// InstallCopy copies the worktree into the engine's plugin folder, replacing a previous copy or
+// link. It is repeated after every build so the engine sees the new binaries.
+func (m *Manager) InstallCopy(enginePath, worktreePath string) error {
+	pluginsDir := filepath.Join(enginePath, "Engine", "Plugins")
+	if !m.CheckWriteAccess(pluginsDir) {
+		return fmt.Errorf("no write access to %s on the network share - ask for write permission on the share (running as administrator does not help)", pluginsDir)
+	}
+	if _, err := os.Stat(worktreePath); err != nil {
+		return fmt.Errorf("worktree path does not exist: %s", worktreePath)
+	}
+
+	// Copy next to the destination first and swap it in with two renames, as installBinaries
+	// does, so a failed copy or replace leaves the current plugin in place
+	pluginLinkPath := m.GetPluginLinkPath(enginePath)
+	m.recoverCopySwap(pluginLinkPath)
+	staging := pluginLinkPath + copyStagingSuffix
+	os.RemoveAll(staging)
+	if err := copyWorktree(worktreePath, staging); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to copy the plugin to %s: %v", staging, err)
+	}
+	host, _ := os.Hostname()
+	marker, _ := json.MarshalIndent(copyMarker{Source: worktreePath, Machine: host, SyncedUTC: time.Now().UTC().Format(time.RFC3339)}, "", "  ")
+	if err := os.WriteFile(filepath.Join(staging, copyMarkerFile), marker, 0644); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to write copy marker: %v", err)
+	}
+
+	previous := pluginLinkPath + previousSuffix
+	if _, err := os.Lstat(pluginLinkPath); err == nil {
+		if err := os.Rename(pluginLinkPath, previous); err != nil {
+			os.RemoveAll(staging)
+			return fmt.Errorf("failed to replace %s (is an editor using this engine open on another machine?): %v", pluginLinkPath, err)
+		}
+	}
+	if err := os.Rename(staging, pluginLinkPath); err != nil {
+		// Put the previous plugin back so the engine keeps working
+		os.Rename(previous, pluginLinkPath)
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to move the copied plugin into place: %v", err)
+	}
+	m.removeCopyOrLink(previous)
+	return nil
+}
+
+// recoverCopySwap puts a previous plugin back when an earlier InstallCopy was interrupted between
+// its two renames, and removes leftover folders, which the engine would otherwise load as plugins
+func (m *Manager) recoverCopySwap(pluginLinkPath string) {
+	previous := pluginLinkPath + previousSuffix
+	if _, err := os.Lstat(previous); err == nil {
+		if _, err := os.Lstat(pluginLinkPath); os.IsNotExist(err) {
+			os.Rename(previous, pluginLinkPath)
+		} else {
+			m.removeCopyOrLink(previous)
+		}
+	}
+	os.RemoveAll(pluginLinkPath + copyStagingSuffix)
+}
+
+// removeCopyOrLink removes a copied plugin folder, or a link without touching its target
+func (m *Manager) removeCopyOrLink(path string) error {
+	if m.IsCopyInstall(path) {
+		return os.RemoveAll(path)
+	}
+	return m.ForceRemovePath(path)
+}
+
+// copyWorktree copies a worktree without its git metadata and build leftovers
+func copyWorktree(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if skipWhenCopying(entry.Name()) {
+			continue
+		}
+		from := filepath.Join(src, entry.Name())
+		to := filepath.Join(dst, entry.Name())
+		if entry.IsDir() {
+			err = copyDir(from, to)
+		} else {
+			err = copyFile(from, to)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func skipWhenCopying(name string) bool {
+	for _, skip := range copySkipDirs {
+		if strings.EqualFold(name, skip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// integrityFile records, in the worktree, the hashes of the binaries produced by the last build
+const integrityFile = ".ue-git-plugin-manager-binaries.json"
+
+// integrityRehashAfter is how often unchanged-looking binaries are hashed again, so a modification
+// that kept the size and timestamp is still found
+const integrityRehashAfter = 24 * time.Hour
+
+type binaryHash struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+	ModUTC string `json:"mod_utc"`
+}
+
+type integrityRecord struct {
+	Files       map[string]binaryHash `json:"files"` // Keyed by path relative to Binaries\Win64
+	VerifiedUTC string                `json:"verified_utc"`
+}
+
+// binariesDir returns the folder holding a worktree's built plugin binaries
+func binariesDir(worktreePath string) string {
+	return filepath.Join(worktreePath, "Binaries", "Win64")
+}
+
+// HasBinaryHashes reports whether the hashes of a worktree's binaries were recorded
+func (m *Manager) HasBinaryHashes(worktreePath string) bool {
+	_, err := os.Stat(filepath.Join(worktreePath, integrityFile))
+	return err == nil
+}
+
+// RecordBinaryHashes hashes every file in a worktree's Binaries\Win64 folder, so later changes
+// to them can be detected by VerifyBinaries
+func (m *Manager) RecordBinaryHashes(worktreePath string) error {
+	record := integrityRecord{Files: make(map[string]binaryHash)}
+	dir := binariesDir(worktreePath)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		sum, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		record.Files[rel] = binaryHash{SHA256: sum, Size: info.Size(), ModUTC: info.ModTime().UTC().Format(time.RFC3339Nano)}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash plugin binaries: %v", err)
+	}
+	record.VerifiedUTC = time.Now().UTC().Format(time.RFC3339)
+	return writeIntegrityRecord(worktreePath, record)
+}
+
+// VerifyBinaries compares a worktree's binaries with the hashes recorded after the last build and
+// describes every file that is missing or changed, e.g. after an antivirus quarantined it or a copy
+// was interrupted. Files whose size and timestamp are unchanged are only hashed again once a day.
+// It returns nil when no hashes were recorded.
+func (m *Manager) VerifyBinaries(worktreePath string) []string {
+	data, err := os.ReadFile(filepath.Join(worktreePath, integrityFile))
+	if err != nil {
+		return nil
+	}
+	var record integrityRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return []string{fmt.Sprintf("%s is unreadable: %v", integrityFile, err)}
+	}
+
+	rehash := true
+	if verified, err := time.Parse(time.RFC3339, record.VerifiedUTC); err == nil {
+		rehash = time.Since(verified) > integrityRehashAfter
+	}
+
+	names := make([]string, 0, len(record.Files))
+	for name := range record.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dir := binariesDir(worktreePath)
+	var problems []string
+	for _, name := range names {
+		want := record.Files[name]
+		info, err := os.Stat(filepath.Join(dir, name))
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s is missing", name))
+			continue
+		case info.Size() != want.Size:
+			problems = append(problems, fmt.Sprintf("%s was modified (size changed)", name))
+			continue
+		case !rehash && info.ModTime().UTC().Format(time.RFC3339Nano) == want.ModUTC:
+			continue
+		}
+		if sum, err := hashFile(filepath.Join(dir, name)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s can't be read: %v", name, err))
+		} else if sum != want.SHA256 {
+			problems = append(problems, fmt.Sprintf("%s was modified (content changed)", name))
+		}
+	}
+
+	if rehash && len(problems) == 0 {
+		record.VerifiedUTC = time.Now().UTC().Format(time.RFC3339)
+		writeIntegrityRecord(worktreePath, record)
+	}
+	return problems
+}
+
+// writeIntegrityRecord saves the binary hashes of a worktree
+func writeIntegrityRecord(worktreePath string, record integrityRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(worktreePath, integrityFile), data, 0644)
+}
+
+// hashFile returns the hex SHA-256 of a file
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
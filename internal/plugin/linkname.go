@@ -0,0 +1,86 @@
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+const (
+	// DefaultLinkName is the folder the plugin is linked as under Engine/Plugins
+	DefaultLinkName = "UEGitPlugin_PB"
+	// StockLinkName names the link like the stock plugin, for teams whose docs and .uplugin
+	// references expect a GitSourceControl folder
+	StockLinkName = "GitSourceControl"
+)
+
+var (
+	linkNamesMu sync.RWMutex
+	linkNames   = map[string]string{}
+)
+
+// SetLinkNames applies the link names chosen per engine, keyed by engine path. Engines left out
+// use DefaultLinkName.
+func SetLinkNames(names map[string]string) {
+	linkNamesMu.Lock()
+	defer linkNamesMu.Unlock()
+	linkNames = make(map[string]string, len(names))
+	for enginePath, name := range names {
+		linkNames[utils.PathKey(enginePath)] = name
+	}
+}
+
+// SetLinkName changes the link name of one engine; an empty name goes back to DefaultLinkName
+func SetLinkName(enginePath, name string) {
+	linkNamesMu.Lock()
+	defer linkNamesMu.Unlock()
+	if name == "" || name == DefaultLinkName {
+		delete(linkNames, utils.PathKey(enginePath))
+		return
+	}
+	linkNames[utils.PathKey(enginePath)] = name
+}
+
+// LinkName returns the folder name the plugin is linked as in an engine
+func LinkName(enginePath string) string {
+	linkNamesMu.RLock()
+	defer linkNamesMu.RUnlock()
+	if name, ok := linkNames[utils.PathKey(enginePath)]; ok && name != "" {
+		return name
+	}
+	return DefaultLinkName
+}
+
+// CheckLinkName reports why the plugin can't be linked as name in an engine: the name must be
+// a single folder name, must not be or contain the stock plugin's folder, and must not replace
+// anything under Engine/Plugins other than the plugin's current link.
+func (m *Manager) CheckLinkName(enginePath, name string) error {
+	if name == "" || name == "." || name == ".." || strings.TrimSpace(name) != name || strings.HasSuffix(name, ".") {
+		return fmt.Errorf("%q is not a valid folder name", name)
+	}
+	if strings.ContainsAny(name, `<>:"/\|?*`) {
+		return fmt.Errorf("a folder name can't contain any of <>:\"/\\|?*")
+	}
+
+	linkPath := filepath.Join(enginePath, "Engine", "Plugins", name)
+	stockPath := filepath.Join(enginePath, "Engine", "Plugins", "Developer", "GitSourceControl")
+	// Only the Developer folder holds the stock plugin among the names a single folder can take
+	if utils.SamePath(linkPath, filepath.Dir(stockPath)) {
+		return fmt.Errorf("%s would replace the stock plugin at %s", linkPath, stockPath)
+	}
+
+	if utils.SamePath(linkPath, m.GetPluginLinkPath(enginePath)) {
+		return nil
+	}
+	info := m.InspectLink(linkPath)
+	if info.Exists {
+		if info.IsLink || info.IsCopy {
+			return fmt.Errorf("%s is already a link to %s", linkPath, info.Target)
+		}
+		return fmt.Errorf("%s already exists", linkPath)
+	}
+	return nil
+}
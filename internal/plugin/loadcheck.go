@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// ModuleName is the editor module the plugin provides; the stock plugin uses the same name
+const ModuleName = "GitSourceControl"
+
+// EditorLoadCheck describes whether the editor will load our plugin build
+type EditorLoadCheck struct {
+	EngineBuildID  string   // BuildId from the engine's UnrealEditor.modules
+	PluginBuildID  string   // BuildId from the worktree's UnrealEditor.modules
+	BuildIDMatches bool     // False when the editor would refuse the binaries and ask to rebuild
+	MissingModules []string // DLLs listed in the plugin's .modules file that do not exist
+	Conflicts      []string // Other plugin folders in the engine declaring the same module
+}
+
+// Issues returns a human-readable description of every problem found
+func (c EditorLoadCheck) Issues() []string {
+	var issues []string
+	if !c.BuildIDMatches {
+		issues = append(issues, fmt.Sprintf("Plugin binaries were built for a different engine build (BuildId %s, engine %s) - the editor will not load them", c.PluginBuildID, c.EngineBuildID))
+	}
+	for _, dll := range c.MissingModules {
+		issues = append(issues, fmt.Sprintf("Plugin module listed but missing: %s", dll))
+	}
+	for _, dir := range c.Conflicts {
+		issues = append(issues, fmt.Sprintf("Another plugin also provides the %s module and may be loaded instead: %s", ModuleName, dir))
+	}
+	return issues
+}
+
+// modulesManifest is the UnrealEditor.modules file UBT writes next to built binaries
+type modulesManifest struct {
+	BuildID string            `json:"BuildId"`
+	Modules map[string]string `json:"Modules"`
+}
+
+// CheckEditorLoad inspects the engine and worktree manifests to confirm the editor
+// will load the binaries in our worktree rather than refusing them or picking up a stale copy
+func (m *Manager) CheckEditorLoad(enginePath, worktreePath string) EditorLoadCheck {
+	check := EditorLoadCheck{BuildIDMatches: true}
+
+	pluginBinaries := filepath.Join(worktreePath, "Binaries", "Win64")
+	pluginManifest, pluginErr := readModulesManifest(filepath.Join(pluginBinaries, "UnrealEditor.modules"))
+	engineManifest, engineErr := readModulesManifest(filepath.Join(enginePath, "Engine", "Binaries", "Win64", "UnrealEditor.modules"))
+
+	if pluginErr == nil {
+		check.PluginBuildID = pluginManifest.BuildID
+		for _, dll := range pluginManifest.Modules {
+			if _, err := os.Stat(filepath.Join(pluginBinaries, dll)); err != nil {
+				check.MissingModules = append(check.MissingModules, dll)
+			}
+		}
+	}
+	if engineErr == nil {
+		check.EngineBuildID = engineManifest.BuildID
+	}
+	// Only compare when both sides could be read; a missing manifest is reported elsewhere
+	if check.PluginBuildID != "" && check.EngineBuildID != "" {
+		check.BuildIDMatches = check.PluginBuildID == check.EngineBuildID
+	}
+
+	check.Conflicts = m.findConflictingPlugins(enginePath)
+	return check
+}
+
+// findConflictingPlugins returns enabled plugin folders under Engine/Plugins, other than our
+// link and the stock plugin, whose descriptor declares the GitSourceControl module
+func (m *Manager) findConflictingPlugins(enginePath string) []string {
+	pluginsDir := filepath.Join(enginePath, "Engine", "Plugins")
+	ourLink := m.GetPluginLinkPath(enginePath)
+	stockDir := filepath.Join(pluginsDir, "Developer", "GitSourceControl")
+
+	var conflicts []string
+	walkPluginDescriptors(pluginsDir, func(dir string) bool {
+		return utils.SamePath(dir, ourLink) || utils.SamePath(dir, stockDir)
+	}, func(upluginPath string) {
+		if declaresModule(upluginPath, ModuleName) {
+			conflicts = append(conflicts, filepath.Dir(upluginPath))
+		}
+	}, nil)
+	return conflicts
+}
+
+// readModulesManifest parses an UnrealEditor.modules file
+func readModulesManifest(path string) (modulesManifest, error) {
+	var manifest modulesManifest
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return manifest, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return manifest, nil
+}
+
+// declaresModule reports whether a .uplugin descriptor lists the named module
+func declaresModule(upluginPath, moduleName string) bool {
+	data, err := os.ReadFile(upluginPath)
+	if err != nil {
+		return false
+	}
+	var descriptor struct {
+		Modules []struct {
+			Name string `json:"Name"`
+		} `json:"Modules"`
+	}
+	// Descriptors saved by the editor often start with a UTF-8 BOM
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if err := json.Unmarshal(data, &descriptor); err != nil {
+		return false
+	}
+	for _, module := range descriptor.Modules {
+		if strings.EqualFold(module.Name, moduleName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrBinariesLocked is returned, wrapped in a *LockedError, when plugin binaries can't be replaced
+// because a running process has them open
+var ErrBinariesLocked = errors.New("plugin binaries are in use")
+
+// FileLock is a file that a running process has open
+type FileLock struct {
+	Path      string
+	Processes []string // e.g. "UnrealEditor.exe (PID 1234)"
+}
+
+// LockedError lists the locked binaries that stopped a build from installing its output
+type LockedError struct {
+	Locks []FileLock
+	Built bool // The build finished; only installing its output is left, with InstallBuiltBinaries
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%v by %s", ErrBinariesLocked, strings.Join(e.Processes(), ", "))
+}
+
+func (e *LockedError) Unwrap() error {
+	return ErrBinariesLocked
+}
+
+// Processes returns every process holding one of the locked files, once each
+func (e *LockedError) Processes() []string {
+	seen := make(map[string]bool)
+	var processes []string
+	for _, lock := range e.Locks {
+		for _, process := range lock.Processes {
+			if !seen[process] {
+				seen[process] = true
+				processes = append(processes, process)
+			}
+		}
+	}
+	sort.Strings(processes)
+	return processes
+}
+
+// LockedBinaries returns the files in a worktree's Binaries\Win64 folder that a running process
+// has open, typically the editor holding UnrealEditor-GitSourceControl.dll
+func (m *Manager) LockedBinaries(worktreePath string) []FileLock {
+	var files []string
+	filepath.WalkDir(binariesDir(worktreePath), func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if len(files) == 0 {
+		return nil
+	}
+	return lockingProcesses(files)
+}
+
+// checkBinariesUnlocked returns a *LockedError when the worktree's binaries can't be replaced
+func (m *Manager) checkBinariesUnlocked(worktreePath string, built bool) error {
+	if locks := m.LockedBinaries(worktreePath); len(locks) > 0 {
+		return &LockedError{Locks: locks, Built: built}
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package plugin
+
+// lockingProcesses reports no locks; mandatory file locking only exists on Windows
+func lockingProcesses(files []string) []FileLock {
+	return nil
+}
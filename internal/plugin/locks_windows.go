@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	rstrtmgr                = windows.NewLazySystemDLL("rstrtmgr.dll")
+	procRmStartSession      = rstrtmgr.NewProc("RmStartSession")
+	procRmRegisterResources = rstrtmgr.NewProc("RmRegisterResources")
+	procRmGetList           = rstrtmgr.NewProc("RmGetList")
+	procRmEndSession        = rstrtmgr.NewProc("RmEndSession")
+)
+
+// rmProcessInfo mirrors RM_PROCESS_INFO from restartmanager.h
+type rmProcessInfo struct {
+	ProcessID        uint32
+	ProcessStartTime windows.Filetime
+	AppName          [256]uint16
+	ServiceShortName [64]uint16
+	ApplicationType  uint32
+	AppStatus        uint32
+	TSSessionID      uint32
+	Restartable      int32
+}
+
+// lockingProcesses asks the Restart Manager which processes have each file open. Files that can
+// be opened for writing aren't asked about, which keeps the usual, unlocked case fast.
+func lockingProcesses(files []string) []FileLock {
+	var locks []FileLock
+	for _, file := range files {
+		f, err := os.OpenFile(file, os.O_RDWR, 0)
+		if err == nil {
+			f.Close()
+			continue
+		}
+		if !errors.Is(err, windows.ERROR_SHARING_VIOLATION) && !errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+			continue
+		}
+		processes := restartManagerList(file)
+		if len(processes) == 0 {
+			// Locked by something the Restart Manager can't name, e.g. a process of another user
+			processes = []string{"an unknown process"}
+		}
+		locks = append(locks, FileLock{Path: file, Processes: processes})
+	}
+	return locks
+}
+
+// restartManagerList returns the processes holding a file, as "name (PID n)"
+func restartManagerList(file string) []string {
+	if procRmStartSession.Find() != nil {
+		return nil
+	}
+	var session uint32
+	key := make([]uint16, 33) // CCH_RM_SESSION_KEY + 1
+	if ret, _, _ := procRmStartSession.Call(uintptr(unsafe.Pointer(&session)), 0, uintptr(unsafe.Pointer(&key[0]))); ret != 0 {
+		return nil
+	}
+	defer procRmEndSession.Call(uintptr(session))
+
+	name, err := windows.UTF16PtrFromString(file)
+	if err != nil {
+		return nil
+	}
+	if ret, _, _ := procRmRegisterResources.Call(uintptr(session), 1, uintptr(unsafe.Pointer(&name)), 0, 0, 0, 0); ret != 0 {
+		return nil
+	}
+
+	infos := make([]rmProcessInfo, 8)
+	for {
+		var needed uint32
+		count := uint32(len(infos))
+		var reasons uint32
+		ret, _, _ := procRmGetList.Call(uintptr(session), uintptr(unsafe.Pointer(&needed)), uintptr(unsafe.Pointer(&count)),
+			uintptr(unsafe.Pointer(&infos[0])), uintptr(unsafe.Pointer(&reasons)))
+		if ret == uintptr(windows.ERROR_MORE_DATA) {
+			infos = make([]rmProcessInfo, needed+1)
+			continue
+		}
+		if ret != 0 {
+			return nil
+		}
+		var processes []string
+		for _, info := range infos[:count] {
+			processes = append(processes, fmt.Sprintf("%s (PID %d)", windows.UTF16ToString(info.AppName[:]), info.ProcessID))
+		}
+		return processes
+	}
+}
@@ -1,7 +1,7 @@
 package plugin
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
@@ -9,7 +9,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"syscall"
+
+	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
 )
 
 // Manager handles plugin linking and junction management
@@ -26,7 +30,12 @@ func New(exeDir string) *Manager {
 
 // CreateJunction creates a junction from the engine's plugin directory to the worktree
 func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
-	pluginLinkPath := filepath.Join(enginePath, "Engine", "Plugins", "UEGitPlugin_PB")
+	pluginLinkPath := m.GetPluginLinkPath(enginePath)
+
+	if m.UsesCopyMode(enginePath) {
+		events.Info("🌐 Engine is on a network path; copying the plugin instead of linking it")
+		return m.InstallCopy(enginePath, worktreePath)
+	}
 
 	// Check if we have write access to the engine directory
 	if !m.CheckWriteAccess(filepath.Join(enginePath, "Engine", "Plugins")) {
@@ -34,7 +43,7 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 	}
 
 	// Check for existing junction using language-independent methods
-	fmt.Printf("  Checking for existing junction at: %s\n", pluginLinkPath)
+	events.Debug("Checking for existing junction at: %s", pluginLinkPath)
 
 	// First check if the symlink itself exists using Lstat (doesn't follow symlinks)
 	// This is critical - Stat() follows symlinks, so if target doesn't exist, it returns "does not exist"
@@ -47,23 +56,23 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 
 	if lstatInfo, lstatErr = os.Lstat(pluginLinkPath); lstatErr == nil {
 		pathExists = true
-		fmt.Printf("  📁 Path exists (via Lstat): isDir=%t\n", lstatInfo.IsDir())
+		events.Debug("📁 Path exists (via Lstat): isDir=%t", lstatInfo.IsDir())
 
 		// Try to read it as a link (works even if target doesn't exist)
 		if target, readErr := os.Readlink(pluginLinkPath); readErr == nil && target != "" {
 			readlinkTarget = target
-			fmt.Printf("  🔗 Path is readable as link, target: %s\n", target)
+			events.Debug("🔗 Path is readable as link, target: %s", target)
 			junctionExists = true
 		}
 	} else {
-		fmt.Printf("  📁 Path does not exist (via Lstat): %v\n", lstatErr)
+		events.Debug("📁 Path does not exist (via Lstat): %v", lstatErr)
 	}
 
 	// If Lstat didn't find it, try JunctionExists (which also uses Lstat now)
 	if !junctionExists {
 		junctionExists = m.JunctionExists(pluginLinkPath)
 		if junctionExists {
-			fmt.Printf("  ✅ Junction detected via JunctionExists()\n")
+			events.Debug("Junction detected via JunctionExists()")
 			// Try to get the target
 			if target, readErr := os.Readlink(pluginLinkPath); readErr == nil && target != "" {
 				readlinkTarget = target
@@ -73,31 +82,29 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 
 	// If junction exists, check if it points to the correct location
 	if junctionExists && readlinkTarget != "" {
-		expectedAbs, _ := filepath.Abs(worktreePath)
-		targetAbs, _ := filepath.Abs(readlinkTarget)
-		if expectedAbs != targetAbs {
-			fmt.Printf("  ⚠️  Junction exists but points to wrong location (%s, expected %s)\n", readlinkTarget, worktreePath)
-			fmt.Printf("  Removing old junction to recreate with correct target...\n")
+		if !utils.SamePath(readlinkTarget, worktreePath) {
+			events.Debug("Junction exists but points to wrong location (%s, expected %s)", readlinkTarget, worktreePath)
+			events.Debug("Removing old junction to recreate with correct target...")
 			// Try multiple removal methods
 			removed := false
 			// First try Go's os.Remove (works well for symlinks)
 			if err := os.Remove(pluginLinkPath); err == nil {
-				fmt.Printf("  ✅ Junction removed using os.Remove()\n")
+				events.Debug("Junction removed using os.Remove()")
 				removed = true
 			} else {
-				fmt.Printf("  ⚠️  os.Remove() failed: %v, trying RemoveJunction()...\n", err)
+				events.Debug("os.Remove() failed: %v, trying RemoveJunction()...", err)
 				// If that fails, try RemoveJunction
 				if err := m.RemoveJunction(pluginLinkPath); err == nil {
-					fmt.Printf("  ✅ Junction removed using RemoveJunction()\n")
+					events.Debug("Junction removed using RemoveJunction()")
 					removed = true
 				} else {
-					fmt.Printf("  ⚠️  RemoveJunction() failed: %v, trying ForceRemovePath()...\n", err)
+					events.Debug("RemoveJunction() failed: %v, trying ForceRemovePath()...", err)
 					// If that fails, try force removal
 					if err := m.ForceRemovePath(pluginLinkPath); err == nil {
-						fmt.Printf("  ✅ Junction removed using ForceRemovePath()\n")
+						events.Debug("Junction removed using ForceRemovePath()")
 						removed = true
 					} else {
-						fmt.Printf("  ⚠️  ForceRemovePath() failed: %v\n", err)
+						events.Debug("ForceRemovePath() failed: %v", err)
 					}
 				}
 			}
@@ -108,7 +115,7 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 			}
 
 			if removed {
-				fmt.Printf("  ✅ Old junction removed successfully\n")
+				events.Debug("Old junction removed successfully")
 			} else {
 				return fmt.Errorf("failed to remove old junction pointing to wrong location: all removal methods failed")
 			}
@@ -116,7 +123,7 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 			junctionExists = false
 			pathExists = false
 		} else {
-			fmt.Printf("  ✅ Junction exists and points to correct location\n")
+			events.Debug("Junction exists and points to correct location")
 			// Junction is valid, no need to recreate
 			return nil
 		}
@@ -124,25 +131,25 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 
 	// If junction exists or path exists, try to remove it
 	if junctionExists || pathExists {
-		fmt.Printf("  ✅ Existing junction or path found, removing...\n")
+		events.Debug("Existing junction or path found, removing...")
 		removed := false
 		// First try Go's os.Remove (works well for symlinks)
 		if err := os.Remove(pluginLinkPath); err == nil {
-			fmt.Printf("  ✅ Junction removed using os.Remove()\n")
+			events.Debug("Junction removed using os.Remove()")
 			removed = true
 		} else {
 			osRemoveErr := err
-			fmt.Printf("  ⚠️  os.Remove() failed: %v, trying RemoveJunction()...\n", osRemoveErr)
+			events.Debug("os.Remove() failed: %v, trying RemoveJunction()...", osRemoveErr)
 			// If that fails, try RemoveJunction
 			if err := m.RemoveJunction(pluginLinkPath); err == nil {
-				fmt.Printf("  ✅ Junction removed using RemoveJunction()\n")
+				events.Debug("Junction removed using RemoveJunction()")
 				removed = true
 			} else {
 				removeJunctionErr := err
-				fmt.Printf("  ⚠️  RemoveJunction() failed: %v, trying ForceRemovePath()...\n", removeJunctionErr)
+				events.Debug("RemoveJunction() failed: %v, trying ForceRemovePath()...", removeJunctionErr)
 				// If that fails, try force removal
 				if err := m.ForceRemovePath(pluginLinkPath); err == nil {
-					fmt.Printf("  ✅ Junction removed using ForceRemovePath()\n")
+					events.Debug("Junction removed using ForceRemovePath()")
 					removed = true
 				} else {
 					forceRemoveErr := err
@@ -157,10 +164,10 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 		}
 
 		if removed {
-			fmt.Printf("  ✅ Existing junction removed successfully\n")
+			events.Debug("Existing junction removed successfully")
 		}
 	} else {
-		fmt.Printf("  ✅ No existing junction found\n")
+		events.Debug("No existing junction found")
 	}
 
 	// Verify worktree exists
@@ -182,7 +189,7 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 	// Double-check the path right before creating the junction
 	// If it still exists, try force removal one more time
 	if _, err := os.Stat(pluginLinkPath); err == nil {
-		fmt.Printf("  ⚠️  Path still exists, attempting force removal...\n")
+		events.Debug("Path still exists, attempting force removal...")
 		if removeErr := m.ForceRemovePath(pluginLinkPath); removeErr != nil {
 			return fmt.Errorf("path still exists after removal attempts: %s (force removal failed: %v)", pluginLinkPath, removeErr)
 		}
@@ -194,16 +201,10 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 
 	// Create the junction using mklink
 	// Try /D first (directory symbolic link), fall back to /J (junction) if that fails
-	mklinkCmd := exec.Command("cmd", "/c", "mklink", "/D", pluginLinkPath, worktreePath)
-
-	// Capture both stdout and stderr
-	var stdout, stderr bytes.Buffer
-	mklinkCmd.Stdout = &stdout
-	mklinkCmd.Stderr = &stderr
-
-	err := mklinkCmd.Run()
-	outputStr := stdout.String()
-	errorStr := stderr.String()
+	mklink := runner.Command{Name: "cmd", Args: []string{"/c", "mklink", "/D", pluginLinkPath, worktreePath}}
+	linkResult, err := runner.Run(context.Background(), mklink)
+	outputStr := linkResult.Stdout
+	errorStr := linkResult.Stderr
 	needsRetry := false
 
 	// Check if the command actually succeeded
@@ -212,7 +213,7 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 		// First check if the path exists (might be "already exists" error)
 		if _, statErr := os.Stat(pluginLinkPath); statErr == nil {
 			// Path exists - check if it's a valid junction/symlink
-			fmt.Printf("  ⚠️  mklink failed but path exists, checking if it's a valid junction...\n")
+			events.Debug("mklink failed but path exists, checking if it's a valid junction...")
 
 			// Try multiple methods to detect junction
 			isJunction := m.JunctionExists(pluginLinkPath)
@@ -230,7 +231,7 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 				readlinkTarget = target
 				// If we can read it as a link, it's definitely a junction/symlink
 				if !isJunction && !isSymlink {
-					fmt.Printf("  🔗 Path is readable as link (target: %s), treating as junction\n", target)
+					events.Debug("🔗 Path is readable as link (target: %s), treating as junction", target)
 					isJunction = true
 				}
 			}
@@ -246,15 +247,13 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 				}
 
 				if targetErr == nil {
-					expectedAbs, _ := filepath.Abs(worktreePath)
-					targetAbs, _ := filepath.Abs(target)
-					if expectedAbs == targetAbs {
+					if utils.SamePath(target, worktreePath) {
 						// Junction exists and points to the right place - this is success!
-						fmt.Printf("  ✅ Junction already exists and points to correct target (despite mklink error)\n")
+						events.Debug("Junction already exists and points to correct target (despite mklink error)")
 						// Continue to verification below (which will pass)
 					} else {
 						// Junction exists but points to wrong place - need to recreate
-						fmt.Printf("  ⚠️  Junction exists but points to wrong target (%s, expected %s), removing...\n", target, worktreePath)
+						events.Debug("Junction exists but points to wrong target (%s, expected %s), removing...", target, worktreePath)
 						if removeErr := m.ForceRemovePath(pluginLinkPath); removeErr != nil {
 							return fmt.Errorf("junction exists at %s but points to wrong target and could not be removed: %v", pluginLinkPath, removeErr)
 						}
@@ -263,13 +262,13 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 					}
 				} else {
 					// Can't read target, but it's a junction - assume it's valid
-					fmt.Printf("  ✅ Junction exists (could not read target, but appears valid)\n")
+					events.Debug("Junction exists (could not read target, but appears valid)")
 					// Continue to verification below
 				}
 			} else {
 				// Path exists but is not a junction - this is the "already exists" case
 				// Try to remove it and retry creation once
-				fmt.Printf("  ⚠️  Path exists but is not a junction, attempting removal...\n")
+				events.Debug("Path exists but is not a junction, attempting removal...")
 				if removeErr := m.ForceRemovePath(pluginLinkPath); removeErr != nil {
 					return fmt.Errorf("path exists at %s but is not a junction and could not be removed: %v", pluginLinkPath, removeErr)
 				}
@@ -287,7 +286,7 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 				// Path exists but mklink reported error - this is unusual
 				// Try to verify if it's a valid junction
 				if m.JunctionExists(pluginLinkPath) {
-					fmt.Printf("  ✅ Junction was created despite error message\n")
+					events.Debug("Junction was created despite error message")
 					// Continue to verification below
 				} else {
 					return fmt.Errorf("path was created but is not a valid junction: %s", pluginLinkPath)
@@ -318,21 +317,16 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 
 	// If we need to retry creation (path was removed)
 	if needsRetry {
-		fmt.Printf("  Retrying junction creation...\n")
-		mklinkCmd = exec.Command("cmd", "/c", "mklink", "/D", pluginLinkPath, worktreePath)
-		mklinkCmd.Stdout = &stdout
-		mklinkCmd.Stderr = &stderr
-		stdout.Reset()
-		stderr.Reset()
-		err = mklinkCmd.Run()
-		outputStr = stdout.String()
-		errorStr = stderr.String()
+		events.Debug("Retrying junction creation...")
+		linkResult, err = runner.Run(context.Background(), mklink)
+		outputStr = linkResult.Stdout
+		errorStr = linkResult.Stderr
 
 		if err != nil {
 			// Check if it was created despite error
 			if _, statErr := os.Stat(pluginLinkPath); statErr == nil {
 				if m.JunctionExists(pluginLinkPath) {
-					fmt.Printf("  ✅ Junction created successfully on retry (despite error message)\n")
+					events.Debug("Junction created successfully on retry (despite error message)")
 					// Continue to verification below
 				} else {
 					return fmt.Errorf("path was created but is not a valid junction: %s", pluginLinkPath)
@@ -356,119 +350,31 @@ func (m *Manager) CreateJunction(enginePath, worktreePath string) error {
 		if rerr != nil {
 			return fmt.Errorf("could not read symlink target: %v", rerr)
 		}
-		expectedAbs, _ := filepath.Abs(worktreePath)
-		targetAbs, _ := filepath.Abs(target)
-		if expectedAbs != targetAbs {
-			return fmt.Errorf("symlink target mismatch: got %s, want %s", targetAbs, expectedAbs)
+		if !utils.SamePath(target, worktreePath) {
+			return fmt.Errorf("symlink target mismatch: got %s, want %s", utils.NormalizePath(target), utils.NormalizePath(worktreePath))
+		}
+	} else {
+		// 3) Otherwise verify it's a junction/reparse point and points to the worktree
+		if !m.JunctionExists(pluginLinkPath) {
+			return fmt.Errorf("created path is not a junction or symlink: %s", pluginLinkPath)
 		}
-		return nil
-	}
-
-	// 3) Otherwise verify it's a junction/reparse point and points to the worktree
-	if !m.JunctionExists(pluginLinkPath) {
-		return fmt.Errorf("created path is not a junction or symlink: %s", pluginLinkPath)
-	}
 
-	if !m.VerifyJunction(enginePath, worktreePath) {
-		return fmt.Errorf("junction does not point to expected target: %s", worktreePath)
+		if !m.VerifyJunction(enginePath, worktreePath) {
+			return fmt.Errorf("junction does not point to expected target: %s", worktreePath)
+		}
 	}
 
+	// The steps above are diagnostics for the log; on success the user only needs this line
+	events.Success("Linked %s to %s", pluginLinkPath, worktreePath)
 	return nil
 }
 
 // JunctionExists checks if a junction or symlink exists at the given path
 // This function accepts both junctions and directory symlinks (created with mklink /D)
 func (m *Manager) JunctionExists(path string) bool {
-	// First check if the path exists at all using Lstat (doesn't follow symlinks)
-	// This is critical - Stat() follows symlinks, so if target doesn't exist, it returns "does not exist"
-	// Lstat() checks if the symlink itself exists, regardless of target
-	fileInfo, err := os.Lstat(path)
-	if os.IsNotExist(err) {
-		return false
-	}
-
-	// Check if it's a symlink first (works for both file and directory symlinks)
-	if fileInfo.Mode()&os.ModeSymlink != 0 {
-		// It's a symlink - try to read it to confirm
-		if target, err := os.Readlink(path); err == nil && target != "" {
-			return true
-		}
-	}
-
-	// If it exists, check if it's a directory (junctions appear as directories)
-	// But also check if it can be read as a link (might be a directory symlink)
-	if fileInfo.IsDir() {
-		// Try os.Readlink first (works for both symlinks and junctions in Go)
-		// This is the most reliable method and works across locales
-		if target, err := os.Readlink(path); err == nil && target != "" {
-			return true
-		}
-
-		// Try the simple method (fsutil) - works better with broken junctions
-		isJunction := m.IsJunctionSimple(path)
-
-		// If simple method fails, try the Windows API method
-		if !isJunction {
-			isJunction = m.IsJunction(path)
-		}
-
-		return isJunction
-	}
-
-	// If it's not a directory and not a symlink, it's not a junction/symlink
-	return false
-}
-
-// IsJunction checks if a path is a junction (reparse point)
-func (m *Manager) IsJunction(path string) bool {
-	// Use Windows API to check if the path is a reparse point
-	pathPtr, err := syscall.UTF16PtrFromString(path)
-	if err != nil {
-		return false
-	}
-
-	handle, err := syscall.CreateFile(
-		pathPtr,
-		syscall.GENERIC_READ,
-		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
-		nil,
-		syscall.OPEN_EXISTING,
-		syscall.FILE_FLAG_OPEN_REPARSE_POINT|syscall.FILE_FLAG_BACKUP_SEMANTICS,
-		0,
-	)
-	if err != nil {
-		return false
-	}
-	defer syscall.CloseHandle(handle)
-
-	var reparseData [1024]byte
-	var bytesReturned uint32
-
-	err = syscall.DeviceIoControl(
-		handle,
-		FSCTL_GET_REPARSE_POINT,
-		nil,
-		0,
-		&reparseData[0],
-		uint32(len(reparseData)),
-		&bytesReturned,
-		nil,
-	)
-
-	return err == nil
-}
-
-// IsJunctionSimple uses a simpler method to detect junctions
-func (m *Manager) IsJunctionSimple(path string) bool {
-	// Use fsutil to check if it's a junction
-	cmd := exec.Command("fsutil", "reparsepoint", "query", path)
-	output, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-
-	// If fsutil returns output, it's a reparse point
-	return len(output) > 0
+	// Engines on network shares get a copy of the plugin, which stands in for the junction
+	info := m.InspectLink(path)
+	return info.IsLink || info.IsCopy
 }
 
 // RemoveJunction removes a junction
@@ -476,21 +382,14 @@ func (m *Manager) RemoveJunction(path string) error {
 	if !m.JunctionExists(path) {
 		return nil // Already removed
 	}
+	if m.IsCopyInstall(path) {
+		return os.RemoveAll(path)
+	}
 
 	// Use rmdir to remove the junction
-	cmd := exec.Command("cmd", "/c", "rmdir", path)
-
-	// Capture both stdout and stderr
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
-	outputStr := stdout.String()
-	errorStr := stderr.String()
-
+	result, err := runner.Run(context.Background(), runner.Command{Name: "cmd", Args: []string{"/c", "rmdir", path}})
 	if err != nil {
-		return fmt.Errorf("failed to remove junction: %v, output: %s, error: %s", err, outputStr, errorStr)
+		return fmt.Errorf("failed to remove junction: %v, output: %s, error: %s", err, result.Stdout, result.Stderr)
 	}
 
 	return nil
@@ -499,23 +398,12 @@ func (m *Manager) RemoveJunction(path string) error {
 // ForceRemovePath attempts to remove a path using multiple methods
 func (m *Manager) ForceRemovePath(path string) error {
 	// Try rmdir first (for junctions)
-	cmd := exec.Command("cmd", "/c", "rmdir", path)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	err := cmd.Run()
-
-	if err == nil {
+	if _, err := runner.CombinedOutput("cmd", "/c", "rmdir", path); err == nil {
 		return nil
 	}
 
 	// Try rmdir /s /q (for directories with contents)
-	cmd = exec.Command("cmd", "/c", "rmdir", "/s", "/q", path)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	err = cmd.Run()
-
-	if err == nil {
+	if _, err := runner.CombinedOutput("cmd", "/c", "rmdir", "/s", "/q", path); err == nil {
 		return nil
 	}
 
@@ -524,77 +412,28 @@ func (m *Manager) ForceRemovePath(path string) error {
 
 // GetJunctionTarget gets the target path of a junction or symbolic link
 func (m *Manager) GetJunctionTarget(path string) (string, error) {
-	// Check if it's either a junction or symbolic link
-	if !m.IsJunction(path) && !m.IsJunctionSimple(path) {
+	info := m.InspectLink(path)
+	if info.IsCopy {
+		return m.copySource(path)
+	}
+	if !info.IsLink {
 		return "", fmt.Errorf("path is not a junction or symbolic link")
 	}
-
-	// Try using Go's built-in Readlink function first (simpler approach)
-	target, err := os.Readlink(path)
-	if err == nil {
-		return target, nil
-	}
-
-	// Fallback: try using dir command to get the target
-	cmd := exec.Command("cmd", "/c", "dir", path)
-	output, err := cmd.Output()
-	dirStr := string(output)
-
-	if err == nil {
-		// Look for the target in the dir output (format: <JUNCTION> UEGitPlugin_PB [target_path])
-		lines := strings.Split(dirStr, "\n")
-		for _, line := range lines {
-			if strings.Contains(line, "UEGitPlugin_PB") && strings.Contains(line, "[") && strings.Contains(line, "]") {
-				// Extract the target path between [ and ]
-				start := strings.Index(line, "[")
-				end := strings.Index(line, "]")
-				if start != -1 && end != -1 && end > start {
-					target := strings.TrimSpace(line[start+1 : end])
-					return target, nil
-				}
-			}
-		}
+	if info.Target == "" {
+		return "", fmt.Errorf("could not read junction/symbolic link target")
 	}
-
-	return "", fmt.Errorf("could not parse junction/symbolic link target")
+	return info.Target, nil
 }
 
 // VerifyJunction verifies that a junction points to the correct worktree
 func (m *Manager) VerifyJunction(enginePath, expectedWorktreePath string) bool {
-	pluginLinkPath := filepath.Join(enginePath, "Engine", "Plugins", "UEGitPlugin_PB")
-
-	if !m.JunctionExists(pluginLinkPath) {
-		return false
-	}
-
-	target, err := m.GetJunctionTarget(pluginLinkPath)
-	if err != nil {
-		return false
-	}
-
-	// Normalize paths for comparison
-	expectedAbs, _ := filepath.Abs(expectedWorktreePath)
-	targetAbs, _ := filepath.Abs(target)
-
-	return expectedAbs == targetAbs
+	info := m.InspectLink(m.GetPluginLinkPath(enginePath))
+	return (info.IsLink || info.IsCopy) && utils.SamePath(info.Target, expectedWorktreePath)
 }
 
-// GetPluginLinkPath returns the plugin link path for an engine
+// GetPluginLinkPath returns the plugin link path for an engine, named as chosen for it with SetLinkNames
 func (m *Manager) GetPluginLinkPath(enginePath string) string {
-	return filepath.Join(enginePath, "Engine", "Plugins", "UEGitPlugin_PB")
-}
-
-// CheckWriteAccess checks if we have write access to a directory
-func (m *Manager) CheckWriteAccess(path string) bool {
-	// Try to create a temporary file in the directory
-	tempFile := filepath.Join(path, ".ue-git-plugin-manager-test")
-	file, err := os.Create(tempFile)
-	if err != nil {
-		return false
-	}
-	file.Close()
-	os.Remove(tempFile)
-	return true
+	return filepath.Join(enginePath, "Engine", "Plugins", LinkName(enginePath))
 }
 
 // Windows API constants for reparse point handling
@@ -606,68 +445,127 @@ const (
 // copies the produced Binaries back into the worktree so the engine
 // can load them via the junction.
 func (m *Manager) BuildForEngine(enginePath, worktreePath string) error {
+	return m.BuildForEngineTo(enginePath, worktreePath, events.Output(), events.Reporter{})
+}
+
+// BuildForEngineTo is BuildForEngine with RunUAT's output streamed to out and the build's own
+// messages emitted through report, e.g. both prefixed with the engine while several engines
+// build at the same time
+func (m *Manager) BuildForEngineTo(enginePath, worktreePath string, out io.Writer, report events.Reporter) error {
 	uat := filepath.Join(enginePath, "Engine", "Build", "BatchFiles", "RunUAT.bat")
 	if _, err := os.Stat(uat); err != nil {
 		return fmt.Errorf("RunUAT not found at %s", uat)
 	}
+	// RunUAT fails with cryptic errors when .NET or the C++ toolchain is missing; say which one up front
+	if err := engine.New().MissingBuildPrerequisites(enginePath); err != nil {
+		return err
+	}
 
-	uplugin := filepath.Join(worktreePath, "GitSourceControl.uplugin")
+	uplugin := filepath.Join(worktreePath, UPluginFileName)
 	if _, err := os.Stat(uplugin); err != nil {
 		return fmt.Errorf("uplugin not found at %s", uplugin)
 	}
+	// A running editor holding the old DLL would make the copy fail after a long build
+	if err := m.checkBinariesUnlocked(worktreePath, false); err != nil {
+		return err
+	}
+	// An install cut short (e.g. by a power loss) left the old binaries aside; put them back
+	// so the editor has working ones while this build runs
+	if restored, err := m.RecoverInterruptedInstall(worktreePath); err != nil {
+		return err
+	} else if restored {
+		report.Info("Restored the binaries an interrupted install had moved aside")
+	}
+
+	// Stamp the commit into VersionName so the editor's plugin window shows the exact build
+	if err := m.StampVersionName(worktreePath); err != nil {
+		report.Warn("Could not stamp plugin version: %v", err)
+	}
 
 	buildOut := filepath.Join(worktreePath, "_Built")
 	_ = os.RemoveAll(buildOut) // clean previous packaged output
 
 	// Build: call UAT directly with proper working directory
 	// On Windows, use cmd /c to properly handle paths with spaces
-	var cmd *exec.Cmd
+	// Builds take one runner slot each, so no more engines compile at once than the build limit allows
+	cmd := runner.Command{Kind: runner.KindBuild}
 	if strings.Contains(uat, " ") {
 		// Path contains spaces, use cmd /c with proper argument handling
 		// First change to the engine directory, then execute the batch file
-		cmd = exec.Command("cmd", "/c",
+		cmd.Name = "cmd"
+		cmd.Args = []string{"/c",
 			"cd", "/d", enginePath, "&&",
 			uat, "BuildPlugin",
 			fmt.Sprintf("-Plugin=%s", uplugin),
 			fmt.Sprintf("-Package=%s", buildOut),
 			"-Rocket",
-			"-TargetPlatforms=Win64")
+			"-TargetPlatforms=Win64"}
 	} else {
 		// Path has no spaces, can execute directly
-		cmd = exec.Command(uat, "BuildPlugin",
+		cmd.Name = uat
+		cmd.Args = []string{"BuildPlugin",
 			fmt.Sprintf("-Plugin=%s", uplugin),
 			fmt.Sprintf("-Package=%s", buildOut),
 			"-Rocket",
-			"-TargetPlatforms=Win64")
+			"-TargetPlatforms=Win64"}
 		// Set working directory to the engine directory for proper UAT execution
 		cmd.Dir = enginePath
 	}
 
-	// Debug: print the command being executed
 	if strings.Contains(uat, " ") {
-		fmt.Printf("Executing: cmd /c cd /d \"%s\" && \"%s\" BuildPlugin -Plugin=\"%s\" -Package=\"%s\" -Rocket -TargetPlatforms=Win64\n",
+		report.Debug("Executing: cmd /c cd /d \"%s\" && \"%s\" BuildPlugin -Plugin=\"%s\" -Package=\"%s\" -Rocket -TargetPlatforms=Win64",
 			enginePath, uat, uplugin, buildOut)
 	} else {
-		fmt.Printf("Executing: \"%s\" BuildPlugin -Plugin=\"%s\" -Package=\"%s\" -Rocket -TargetPlatforms=Win64\n",
+		report.Debug("Executing: \"%s\" BuildPlugin -Plugin=\"%s\" -Package=\"%s\" -Rocket -TargetPlatforms=Win64",
 			uat, uplugin, buildOut)
-		fmt.Printf("Working directory: %s\n", enginePath)
+		report.Debug("Working directory: %s", enginePath)
+	}
+
+	// Compiler temporary files break under a non-ASCII user profile; other paths can't be moved here
+	if env, tempDir := buildEnv(); env != nil {
+		cmd.Env = env
+		report.Warn("TEMP contains non-ASCII characters; using %s for this build", tempDir)
+	}
+	for _, path := range m.NonASCIIBuildPaths(enginePath, worktreePath) {
+		if path.Path != os.Getenv("TEMP") && path.Path != os.Getenv("TMP") {
+			report.Warn("%s path contains non-ASCII characters and may break the build: %s", path.Role, path.Path)
+		}
 	}
 
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	// Keep the output as it streams so a failure can be matched against known causes
+	var captured tailBuffer
+	output := io.MultiWriter(out, &captured)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if _, err := runner.Run(context.Background(), cmd); err != nil {
+		if suggestions := diagnoseBuildLog(captured.String(), worktreePath, enginePath); len(suggestions) > 0 {
+			for _, suggestion := range suggestions {
+				report.Warn("Likely cause: %s", suggestion)
+			}
+		}
 		return fmt.Errorf("BuildPlugin failed (see output above): %w", err)
 	}
 
-	// Debug: explore the build output structure
-	fmt.Printf("Debug - Build output structure:\n")
-	fmt.Printf("  Build output directory: %s\n", buildOut)
+	return m.installBuiltBinaries(enginePath, worktreePath, report)
+}
+
+// InstallBuiltBinaries copies the output of the last BuildPlugin run into the worktree's Binaries
+// folder. It is called by BuildForEngine, and on its own to retry after a *LockedError whose
+// build finished.
+func (m *Manager) InstallBuiltBinaries(enginePath, worktreePath string) error {
+	return m.installBuiltBinaries(enginePath, worktreePath, events.Reporter{})
+}
+
+// installBuiltBinaries is InstallBuiltBinaries with its messages emitted through report
+func (m *Manager) installBuiltBinaries(enginePath, worktreePath string, report events.Reporter) error {
+	buildOut := filepath.Join(worktreePath, "_Built")
 
-	// List contents of build output directory
+	// List the build output, which --verbose shows when the binaries aren't where expected
+	report.Debug("Build output directory: %s", buildOut)
 	if entries, err := os.ReadDir(buildOut); err == nil {
-		fmt.Printf("  Build output contents:\n")
+		report.Debug("Build output contents:")
 		for _, entry := range entries {
-			fmt.Printf("    - %s (%s)\n", entry.Name(), func() string {
+			report.Debug("  - %s (%s)", entry.Name(), func() string {
 				if entry.IsDir() {
 					return "directory"
 				}
@@ -675,16 +573,16 @@ func (m *Manager) BuildForEngine(enginePath, worktreePath string) error {
 			}())
 		}
 	} else {
-		fmt.Printf("  Could not read build output directory: %v\n", err)
+		report.Debug("Could not read build output directory: %v", err)
 	}
 
 	// Try to find the actual binaries location
 	// Based on the actual UAT output structure, binaries are at _Built/Binaries/Win64/
 	src := filepath.Join(buildOut, "Binaries", "Win64")
-	fmt.Printf("  Looking for binaries at: %s\n", src)
+	report.Debug("Looking for binaries at: %s", src)
 
 	if _, err := os.Stat(src); err != nil {
-		fmt.Printf("  ❌ Expected path does not exist: %v\n", err)
+		report.Debug("Expected path does not exist: %v", err)
 
 		// Try alternative paths as fallback
 		altPaths := []string{
@@ -695,38 +593,53 @@ func (m *Manager) BuildForEngine(enginePath, worktreePath string) error {
 		}
 
 		for _, altPath := range altPaths {
-			fmt.Printf("  Trying alternative path: %s\n", altPath)
+			report.Debug("Trying alternative path: %s", altPath)
 			if _, err := os.Stat(altPath); err == nil {
-				fmt.Printf("  ✅ Found binaries at: %s\n", altPath)
+				report.Debug("Found binaries at: %s", altPath)
 				src = altPath
 				break
 			} else {
-				fmt.Printf("  ❌ Not found: %v\n", err)
+				report.Debug("Not found: %v", err)
 			}
 		}
 	} else {
-		fmt.Printf("  ✅ Found binaries at expected path\n")
+		report.Debug("Found binaries at expected path")
 	}
 
-	dst := filepath.Join(worktreePath, "Binaries", "Win64")
-	fmt.Printf("  Copying from: %s\n", src)
-	fmt.Printf("  Copying to: %s\n", dst)
-
-	if err := copyDir(src, dst); err != nil {
-		return fmt.Errorf("failed to copy built binaries: %w", err)
+	// The editor may have been started during the build; copying now would leave a half-replaced folder
+	if err := m.checkBinariesUnlocked(worktreePath, true); err != nil {
+		return err
 	}
 
-	// Debug: verify the final structure
-	fmt.Printf("  ✅ Binaries copied successfully\n")
-	fmt.Printf("  Final plugin structure:\n")
-	fmt.Printf("    Plugin file: %s\n", filepath.Join(worktreePath, "GitSourceControl.uplugin"))
-	fmt.Printf("    Binaries: %s\n", dst)
+	dst := binariesDir(worktreePath)
+	report.Debug("Copying from: %s", src)
+	report.Debug("Copying to: %s", dst)
+
+	if err := installBinaries(src, dst); err != nil {
+		return err
+	}
+	report.Success("Built binaries installed in %s", dst)
 
-	// List the copied binaries
+	report.Debug("Final plugin structure:")
+	report.Debug("  Plugin file: %s", filepath.Join(worktreePath, "GitSourceControl.uplugin"))
+	report.Debug("  Binaries: %s", dst)
 	if entries, err := os.ReadDir(dst); err == nil {
-		fmt.Printf("    Copied files:\n")
+		report.Debug("  Copied files:")
 		for _, entry := range entries {
-			fmt.Printf("      - %s\n", entry.Name())
+			report.Debug("    - %s", entry.Name())
+		}
+	}
+
+	// Later status checks compare against these to notice quarantined or damaged files
+	if err := m.RecordBinaryHashes(worktreePath); err != nil {
+		report.Warn("%v", err)
+	}
+
+	// A copied plugin on a network engine only sees the new binaries once it is refreshed
+	if m.IsCopyInstall(m.GetPluginLinkPath(enginePath)) {
+		report.Info("Refreshing the plugin copy in %s", m.GetPluginLinkPath(enginePath))
+		if err := m.InstallCopy(enginePath, worktreePath); err != nil {
+			return err
 		}
 	}
 
@@ -0,0 +1,40 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// disabledSuffix is appended to a .uplugin file to hide the plugin from the editor, as is done
+// for the engine's stock Git plugin
+const disabledSuffix = ".disabled"
+
+// FindProjectPluginCopies returns the folders under a project's Plugins folder holding an enabled
+// GitSourceControl plugin. The editor loads a project plugin instead of the engine plugin of the
+// same name, so any of these hides the engine's linked plugin from that project.
+func (m *Manager) FindProjectPluginCopies(projectRoot string) []string {
+	var dirs []string
+	walkPluginDescriptors(filepath.Join(projectRoot, "Plugins"), func(string) bool { return false }, func(upluginPath string) {
+		if strings.EqualFold(filepath.Base(upluginPath), UPluginFileName) {
+			dirs = append(dirs, filepath.Dir(upluginPath))
+		}
+	}, func(dir string) {
+		// A linked plugin keeps its descriptor at the root of the link's target
+		if _, err := os.Stat(filepath.Join(dir, UPluginFileName)); err == nil {
+			dirs = append(dirs, dir)
+		}
+	})
+	return dirs
+}
+
+// DisableProjectPluginCopy hides a project's copy of the plugin from the editor by renaming its
+// .uplugin file, so the engine's plugin is used again
+func (m *Manager) DisableProjectPluginCopy(dir string) error {
+	upluginPath := filepath.Join(dir, UPluginFileName)
+	if err := os.Rename(upluginPath, upluginPath+disabledSuffix); err != nil {
+		return fmt.Errorf("failed to disable %s: %v", upluginPath, err)
+	}
+	return nil
+}
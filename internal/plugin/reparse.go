@@ -0,0 +1,143 @@
+package plugin
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unicode/utf16"
+)
+
+// Reparse tags of the links the plugin folder can be
+const (
+	ioReparseTagMountPoint = 0xA0000003 // Junction (mklink /J)
+	ioReparseTagSymlink    = 0xA000000C // Directory symbolic link (mklink /D)
+
+	symlinkFlagRelative = 1
+
+	// maximumReparseDataBufferSize is the largest reparse buffer Windows hands out
+	maximumReparseDataBufferSize = 16 * 1024
+)
+
+// LinkInfo is what one native query found at a plugin link path
+type LinkInfo struct {
+	Exists bool   // Something is at the path
+	IsLink bool   // A junction or directory symlink
+	IsCopy bool   // A plugin copy made for an engine on a network share
+	Target string // Where the link points, or the worktree a copy was made from
+}
+
+// InspectLink resolves a plugin link path in one pass: a single Lstat and, for a reparse point,
+// one DeviceIoControl that returns both its type and its target. Detection uses this instead of
+// the separate existence, type and target checks, which started fsutil and cmd for each.
+func (m *Manager) InspectLink(path string) LinkInfo {
+	fileInfo, err := os.Lstat(path)
+	if err != nil {
+		return LinkInfo{}
+	}
+	info := LinkInfo{Exists: true}
+
+	if fileInfo.Mode()&(os.ModeSymlink|os.ModeIrregular) == 0 {
+		if m.IsCopyInstall(path) {
+			info.IsCopy = true
+			info.Target, _ = m.copySource(path)
+		}
+		return info
+	}
+
+	tag, target, err := readReparsePoint(path)
+	if err != nil || (tag != ioReparseTagMountPoint && tag != ioReparseTagSymlink) {
+		return info
+	}
+	info.IsLink = true
+	info.Target = target
+	return info
+}
+
+// readReparsePoint returns the reparse tag of a path and, for junctions and symlinks, their target.
+// The link itself is opened rather than followed, so broken links are read too.
+func readReparsePoint(path string) (uint32, string, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, "", err
+	}
+	// No access rights are needed to query a reparse point, which keeps this working on read-only shares
+	handle, err := syscall.CreateFile(
+		pathPtr,
+		0,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
+		nil,
+		syscall.OPEN_EXISTING,
+		syscall.FILE_FLAG_OPEN_REPARSE_POINT|syscall.FILE_FLAG_BACKUP_SEMANTICS,
+		0,
+	)
+	if err != nil {
+		return 0, "", err
+	}
+	defer syscall.CloseHandle(handle)
+
+	buffer := make([]byte, maximumReparseDataBufferSize)
+	var bytesReturned uint32
+	if err := syscall.DeviceIoControl(handle, FSCTL_GET_REPARSE_POINT, nil, 0, &buffer[0], uint32(len(buffer)), &bytesReturned, nil); err != nil {
+		return 0, "", err
+	}
+	return parseReparseData(buffer[:bytesReturned], path)
+}
+
+// parseReparseData decodes a REPARSE_DATA_BUFFER. Junctions and symlinks share the layout of the
+// name offsets; symlinks have a flags field before the path buffer.
+func parseReparseData(data []byte, path string) (uint32, string, error) {
+	if len(data) < 8 {
+		return 0, "", fmt.Errorf("reparse data of %s is too short", path)
+	}
+	tag := binary.LittleEndian.Uint32(data[0:4])
+
+	var pathBuffer []byte
+	var flags uint32
+	switch tag {
+	case ioReparseTagMountPoint:
+		if len(data) < 16 {
+			return tag, "", fmt.Errorf("junction data of %s is too short", path)
+		}
+		pathBuffer = data[16:]
+	case ioReparseTagSymlink:
+		if len(data) < 20 {
+			return tag, "", fmt.Errorf("symlink data of %s is too short", path)
+		}
+		flags = binary.LittleEndian.Uint32(data[16:20])
+		pathBuffer = data[20:]
+	default:
+		return tag, "", nil
+	}
+
+	substitute := utf16Slice(pathBuffer, binary.LittleEndian.Uint16(data[8:10]), binary.LittleEndian.Uint16(data[10:12]))
+	printName := utf16Slice(pathBuffer, binary.LittleEndian.Uint16(data[12:14]), binary.LittleEndian.Uint16(data[14:16]))
+
+	// The print name is the path as the user gave it; the substitute name is the NT form
+	target := printName
+	if target == "" {
+		target = strings.TrimPrefix(substitute, `\??\`)
+	}
+	if tag == ioReparseTagSymlink && flags&symlinkFlagRelative != 0 {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	if target == "" {
+		return tag, "", fmt.Errorf("%s has no link target", path)
+	}
+	return tag, target, nil
+}
+
+// utf16Slice decodes length bytes of UTF-16 starting at offset, or "" when they are out of range
+func utf16Slice(buffer []byte, offset, length uint16) string {
+	end := int(offset) + int(length)
+	if end > len(buffer) || length%2 != 0 {
+		return ""
+	}
+	units := make([]uint16, length/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(buffer[int(offset)+2*i:])
+	}
+	return string(utf16.Decode(units))
+}
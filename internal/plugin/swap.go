@@ -0,0 +1,99 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// Suffixes of the folders used while replacing a worktree's Binaries\Win64 folder
+const (
+	stagingSuffix  = ".staging"
+	previousSuffix = ".previous"
+)
+
+// How long an install waits for another instance installing into the same worktree, and when
+// its lock counts as left behind by a crash
+const (
+	installLockWait  = 2 * time.Minute
+	installLockStale = 10 * time.Minute
+)
+
+// installBinaries replaces the live binaries folder dst with a copy of src. The copy is made
+// into a staging folder next to dst and swapped in with two renames, so a failed or interrupted
+// copy leaves the old binaries untouched instead of a mix of old and new DLLs. It holds the
+// worktree's install lock, so no other instance recovers or swaps the folder at the same time.
+func installBinaries(src, dst string) error {
+	unlock, err := utils.AcquireLock(dst, installLockWait, installLockStale)
+	if err != nil {
+		return fmt.Errorf("failed to lock the binaries folder: %v", err)
+	}
+	defer unlock()
+	recoverBinariesSwap(dst)
+
+	staging := dst + stagingSuffix
+	os.RemoveAll(staging)
+	if err := copyDir(src, staging); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to copy built binaries: %w", err)
+	}
+
+	previous := dst + previousSuffix
+	if _, err := os.Stat(dst); err == nil {
+		if err := os.Rename(dst, previous); err != nil {
+			os.RemoveAll(staging)
+			return fmt.Errorf("failed to move the current binaries aside (are they in use?): %w", err)
+		}
+	}
+	if err := os.Rename(staging, dst); err != nil {
+		// Put the old binaries back so the editor keeps working
+		os.Rename(previous, dst)
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to move the new binaries into place: %w", err)
+	}
+	os.RemoveAll(previous)
+	return nil
+}
+
+// recoverBinariesSwap finishes or undoes a swap that was interrupted between its two renames,
+// and removes leftover staging folders. It reports whether the live folder had to be restored.
+func recoverBinariesSwap(dst string) bool {
+	previous := dst + previousSuffix
+	restored := false
+	if _, err := os.Stat(previous); err == nil {
+		if _, err := os.Stat(dst); os.IsNotExist(err) {
+			// The new binaries never made it into place; the staged copy may be incomplete
+			restored = os.Rename(previous, dst) == nil
+		} else {
+			os.RemoveAll(previous)
+		}
+	}
+	os.RemoveAll(dst + stagingSuffix)
+	return restored
+}
+
+// RecoverInterruptedInstall restores a worktree's previous binaries when an earlier install of
+// new ones was interrupted, and reports whether it did. It holds the install lock and is only
+// called when a build starts; status checks use InterruptedInstall, which changes nothing.
+func (m *Manager) RecoverInterruptedInstall(worktreePath string) (bool, error) {
+	dst := binariesDir(worktreePath)
+	unlock, err := utils.AcquireLock(dst, installLockWait, installLockStale)
+	if err != nil {
+		return false, fmt.Errorf("failed to lock the binaries folder: %v", err)
+	}
+	defer unlock()
+	return recoverBinariesSwap(dst), nil
+}
+
+// InterruptedInstall reports whether an install was cut short (e.g. by a power loss) after
+// moving the old binaries aside and before the new ones were in place
+func (m *Manager) InterruptedInstall(worktreePath string) bool {
+	dst := binariesDir(worktreePath)
+	if _, err := os.Stat(dst + previousSuffix); err != nil {
+		return false
+	}
+	_, err := os.Stat(dst)
+	return os.IsNotExist(err)
+}
@@ -0,0 +1,119 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// UPluginFileName is the plugin descriptor at the root of every worktree
+const UPluginFileName = "GitSourceControl.uplugin"
+
+// VersionStamp is the commit information stamped into the plugin descriptor
+type VersionStamp struct {
+	VersionName string // full VersionName as shown in the editor's plugin window
+	CommitSHA   string // abbreviated commit SHA the binaries were built from
+	CommitDate  string // commit date (YYYY-MM-DD)
+}
+
+var (
+	versionNamePattern   = regexp.MustCompile(`("VersionName"\s*:\s*")([^"]*)(")`)
+	engineVersionPattern = regexp.MustCompile(`"EngineVersion"\s*:\s*"([^"]*)"`)
+	stampPattern         = regexp.MustCompile(`\s*\(([0-9a-f]{7,40}), (\d{4}-\d{2}-\d{2})\)$`)
+)
+
+// StampVersionName appends the worktree's HEAD commit and date to the descriptor's
+// VersionName, replacing any earlier stamp, so the editor shows the exact plugin build
+func (m *Manager) StampVersionName(worktreePath string) error {
+	output, err := runner.Output("git", "-C", worktreePath, "log", "-1", "--format=%h %cs")
+	if err != nil {
+		return fmt.Errorf("failed to read worktree commit: %v", err)
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return fmt.Errorf("unexpected git log output: %q", strings.TrimSpace(string(output)))
+	}
+	sha, date := fields[0], fields[1]
+
+	upluginPath := filepath.Join(worktreePath, UPluginFileName)
+	data, err := os.ReadFile(upluginPath)
+	if err != nil {
+		return err
+	}
+	if !versionNamePattern.Match(data) {
+		return fmt.Errorf("VersionName not found in %s", upluginPath)
+	}
+
+	stamped := versionNamePattern.ReplaceAllStringFunc(string(data), func(match string) string {
+		parts := versionNamePattern.FindStringSubmatch(match)
+		base := stampPattern.ReplaceAllString(parts[2], "")
+		return fmt.Sprintf("%s%s (%s, %s)%s", parts[1], base, sha, date, parts[3])
+	})
+	return os.WriteFile(upluginPath, []byte(stamped), 0644)
+}
+
+// ReadVersionStamp reads the VersionName and any commit stamp back from the descriptor
+func (m *Manager) ReadVersionStamp(worktreePath string) (VersionStamp, error) {
+	data, err := os.ReadFile(filepath.Join(worktreePath, UPluginFileName))
+	if err != nil {
+		return VersionStamp{}, err
+	}
+	parts := versionNamePattern.FindStringSubmatch(string(data))
+	if parts == nil {
+		return VersionStamp{}, fmt.Errorf("VersionName not found")
+	}
+
+	stamp := VersionStamp{VersionName: parts[2]}
+	if match := stampPattern.FindStringSubmatch(parts[2]); match != nil {
+		stamp.CommitSHA = match[1]
+		stamp.CommitDate = match[2]
+	}
+	return stamp, nil
+}
+
+// DescriptorEngineVersion returns the EngineVersion a plugin descriptor was made for, or "" when
+// it doesn't name one
+func DescriptorEngineVersion(descriptor []byte) string {
+	if match := engineVersionPattern.FindSubmatch(descriptor); match != nil {
+		return strings.TrimSpace(string(match[1]))
+	}
+	return ""
+}
+
+// SupportsEngine reports whether a plugin made for descriptorVersion can be built for an engine
+// of engineVersion. A descriptor without an EngineVersion supports every engine; otherwise the
+// engine needs the same major version and at least the descriptor's minor version, since older
+// engines lack the APIs a newer plugin uses.
+func SupportsEngine(descriptorVersion, engineVersion string) bool {
+	wantMajor, wantMinor, ok := parseMajorMinor(descriptorVersion)
+	if !ok {
+		return true
+	}
+	major, minor, ok := parseMajorMinor(engineVersion)
+	if !ok {
+		return true
+	}
+	return major == wantMajor && minor >= wantMinor
+}
+
+// parseMajorMinor reads the "5.4" part of a version such as "5.4.0"
+func parseMajorMinor(version string) (int, int, bool) {
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
@@ -0,0 +1,62 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/events"
+)
+
+// probePrefix names the files the fallback write check creates. Older versions used the prefix
+// alone; newer ones add the process ID so concurrent checks don't remove each other's file.
+const probePrefix = ".ue-git-plugin-manager-test"
+
+// staleProbeAge is how old a probe file must be before it is treated as left behind. A check
+// removes its file within milliseconds, so anything older belongs to a process that died.
+const staleProbeAge = time.Minute
+
+// CheckWriteAccess checks if we have write access to a directory. The directory's security is
+// asked first, which writes nothing; only when that can't be answered is a probe file created.
+func (m *Manager) CheckWriteAccess(path string) bool {
+	m.CleanStaleProbeFiles(path)
+
+	if allowed, err := canCreateIn(path); err == nil {
+		return allowed
+	}
+
+	probe := filepath.Join(path, fmt.Sprintf("%s-%d", probePrefix, os.Getpid()))
+	file, err := os.Create(probe)
+	if err != nil {
+		return false
+	}
+	file.Close()
+	os.Remove(probe)
+	return true
+}
+
+// CleanStaleProbeFiles removes probe files a crashed or killed run left in a directory and
+// returns how many were removed
+func (m *Manager) CleanStaleProbeFiles(dir string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), probePrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < staleProbeAge {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err == nil {
+			events.Debug("Removed stale write check file %s", filepath.Join(dir, entry.Name()))
+			removed++
+		}
+	}
+	return removed
+}
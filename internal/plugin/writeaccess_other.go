@@ -0,0 +1,10 @@
+//go:build !windows
+
+package plugin
+
+import "errors"
+
+// canCreateIn can't read directory security here, so the caller falls back to a probe file
+func canCreateIn(dir string) (bool, error) {
+	return false, errors.New("directory access checks are only available on Windows")
+}
@@ -0,0 +1,41 @@
+package plugin
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows"
+)
+
+// Directory access rights from winnt.h
+const (
+	fileAddFile         = 0x0002
+	fileAddSubdirectory = 0x0004
+)
+
+// canCreateIn asks whether files and folders may be created in a directory by opening it with
+// those rights. Windows checks the folder's ACL and the share permissions when the handle is
+// opened, so nothing is written. Errors other than access denied are returned, so the caller
+// can fall back to a probe file.
+func canCreateIn(dir string) (bool, error) {
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return false, err
+	}
+	handle, err := windows.CreateFile(
+		path,
+		fileAddFile|fileAddSubdirectory,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil,
+		windows.OPEN_EXISTING,
+		windows.FILE_FLAG_BACKUP_SEMANTICS,
+		0,
+	)
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	windows.CloseHandle(handle)
+	return true, nil
+}
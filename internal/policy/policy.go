@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// registryKey is where IT can set the policy machine-wide, e.g. through Group Policy
+const registryKey = `HKLM\SOFTWARE\Policies\UEGitPluginManager`
+
+// ErrEngineChangesForbidden is returned by actions that would modify an engine install
+var ErrEngineChangesForbidden = errors.New("this machine's policy forbids modifying engine installs; " +
+	"use \"Plugin as Project Submodule\" under \"Configure project\" to install the plugin in a project instead")
+
+// machinePolicyEnabled caches the registry policy, which is read once per run
+var (
+	machinePolicyOnce    sync.Once
+	machinePolicyEnabled bool
+)
+
+// ProjectLevelOnly reports whether the tool is restricted to project-level installation and
+// project configuration, from project_level_only in config.json or the machine-wide policy
+func ProjectLevelOnly(cfg *config.Config) bool {
+	machinePolicyOnce.Do(func() {
+		machinePolicyEnabled = readMachinePolicy()
+	})
+	return cfg.ProjectLevelOnly || machinePolicyEnabled
+}
+
+// CheckEngineChanges returns ErrEngineChangesForbidden when engine installs must not be modified
+func CheckEngineChanges(cfg *config.Config) error {
+	if ProjectLevelOnly(cfg) {
+		return ErrEngineChangesForbidden
+	}
+	return nil
+}
+
+// readMachinePolicy reads the ProjectLevelOnly DWORD of the machine-wide policy key
+func readMachinePolicy() bool {
+	output, err := runner.Output("reg", "query", registryKey, "/v", "ProjectLevelOnly")
+	if err != nil {
+		return false
+	}
+
+	// Output lines look like: "    ProjectLevelOnly    REG_DWORD    0x1"
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "ProjectLevelOnly" && fields[1] == "REG_DWORD" {
+			return fields[2] != "0x0"
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package projectconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	templates "ue-git-plugin-manager/internal/new_project_example_config_files"
+
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// CI systems a workflow file can be generated for
+const (
+	CIGitHubActions  = "GitHub Actions"
+	CIAzurePipelines = "Azure Pipelines"
+)
+
+// ciTemplates maps each CI system to its embedded template and where the file goes in the project
+var ciTemplates = map[string]struct{ template, dest string }{
+	CIGitHubActions:  {"ci_github.yml.tmpl", filepath.Join(".github", "workflows", "unreal-editor-build.yml")},
+	CIAzurePipelines: {"ci_azure.yml.tmpl", "azure-pipelines.yml"},
+}
+
+// CIParams are the values a CI workflow is generated with
+type CIParams struct {
+	ProjectName   string // .uproject name without extension; the editor target is <name>Editor
+	UProjectFile  string // .uproject file name relative to the repository root
+	EngineVersion string
+	EnginePath    string // Engine install folder, assumed to be the same on the build machine
+	Branch        string // Branch that triggers builds
+	HasCode       bool   // Whether the project has C++ modules to build, otherwise Blueprints are compiled
+}
+
+// CIWorkflowPath returns where the workflow for a CI system is written in the project
+func CIWorkflowPath(root, system string) string {
+	return filepath.Join(root, ciTemplates[system].dest)
+}
+
+// WriteCIWorkflow generates the workflow for a CI system in the project, refusing to replace an existing one
+func WriteCIWorkflow(root, system string, params CIParams) (string, error) {
+	entry, ok := ciTemplates[system]
+	if !ok {
+		return "", fmt.Errorf("unknown CI system: %s", system)
+	}
+	dest := filepath.Join(root, entry.dest)
+	if _, err := os.Stat(dest); err == nil {
+		return "", fmt.Errorf("%s already exists", entry.dest)
+	}
+
+	data, err := templates.FS.ReadFile(entry.template)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(entry.template).Parse(string(data))
+	if err != nil {
+		return "", err
+	}
+	// Values land in single-quoted YAML strings, where a quote is escaped by doubling it
+	quoted := params
+	quoted.EnginePath = strings.ReplaceAll(params.EnginePath, "'", "''")
+	quoted.UProjectFile = strings.ReplaceAll(params.UProjectFile, "'", "''")
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, quoted); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(dest, out.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", entry.dest, err)
+	}
+	return dest, nil
+}
+
+// CurrentBranch returns the checked-out branch of the project's repository, or "main" when it can't be read
+func CurrentBranch(root string) string {
+	output, err := runner.Output("git", "-C", root, "symbolic-ref", "--short", "HEAD")
+	if branch := strings.TrimSpace(output); err == nil && branch != "" {
+		return branch
+	}
+	return "main"
+}
@@ -0,0 +1,129 @@
+package projectconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
+
+	templates "ue-git-plugin-manager/internal/new_project_example_config_files"
+
+	"github.com/manifoldco/promptui"
+)
+
+// assetHookMarker identifies a pre-commit hook written by this tool, so it can be replaced safely
+const assetHookMarker = "Asset check installed by UE Git Plugin Manager"
+
+// assetHookParams are the values the pre-commit template is generated with
+type assetHookParams struct {
+	Marker      string
+	MaxSizeMB   int64
+	MaxBytes    int64
+	PatternList string
+	PatternArgs string
+}
+
+// lockedAssetPatterns returns the patterns the .gitattributes template marks as lockable LFS assets
+// (*.uasset and *.umap), so the hook checks the same files the attributes route through LFS
+func lockedAssetPatterns() ([]string, error) {
+	lines, err := readEmbeddedLines(".gitattributes")
+	if err != nil {
+		return nil, err
+	}
+	var patterns []string
+	for pattern, attrs := range parseAttributes(lines) {
+		if attrs == "lock" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns, nil
+}
+
+// InstallAssetHook writes a pre-commit hook that blocks commits of Unreal assets that aren't LFS
+// pointers or exceed maxSizeMB (0 for no limit). An existing hook not written by this tool is kept.
+func InstallAssetHook(root string, maxSizeMB int64) (string, error) {
+	result, err := runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"rev-parse", "--git-path", "hooks"}, Dir: root})
+	if err != nil {
+		return "", fmt.Errorf("failed to find the hooks folder: %v", err)
+	}
+	hooksDir := strings.TrimSpace(result.Stdout)
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(root, hooksDir)
+	}
+	hookPath := filepath.Join(hooksDir, "pre-commit")
+	if existing, err := os.ReadFile(hookPath); err == nil && !bytes.Contains(existing, []byte(assetHookMarker)) {
+		return "", fmt.Errorf("%s already exists and was not written by this tool; merge the asset check into it manually", hookPath)
+	}
+
+	patterns, err := lockedAssetPatterns()
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(patterns)
+	params := assetHookParams{
+		Marker:      assetHookMarker,
+		MaxSizeMB:   maxSizeMB,
+		MaxBytes:    maxSizeMB * 1024 * 1024,
+		PatternList: strings.Join(patterns, ", "),
+		PatternArgs: "'" + strings.Join(patterns, "' '") + "'",
+	}
+
+	data, err := templates.FS.ReadFile("pre-commit.tmpl")
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New("pre-commit").Parse(string(data))
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, params); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(hookPath, out.Bytes(), 0755); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", hookPath, err)
+	}
+	return hookPath, nil
+}
+
+// promptAssetHook offers to install the pre-commit asset check and asks for its size limit
+func promptAssetHook(root string) error {
+	if _, err := os.Stat(filepath.Join(root, ".git")); os.IsNotExist(err) {
+		return nil
+	}
+
+	limits := []string{"Don't install", "Block assets over 100 MB", "Block assets over 500 MB", "LFS check only, no size limit"}
+	sizes := []int64{0, 100, 500, 0}
+	prompt := promptui.Select{
+		Label:  "Install a pre-commit hook that blocks .uasset/.umap files not stored in Git LFS?",
+		Items:  limits,
+		Stdout: &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	index, _, err := utils.RunSelect(&prompt)
+	if err != nil {
+		return err
+	}
+	if index == 0 {
+		return nil
+	}
+
+	hookPath, err := InstallAssetHook(root, sizes[index])
+	if err != nil {
+		fmt.Printf("⚠️  Pre-commit hook not installed: %v\n", err)
+		return nil
+	}
+	fmt.Printf("✅ Installed the asset check in %s (hooks are per clone; run the wizard on each machine)\n", hookPath)
+	return nil
+}
@@ -0,0 +1,174 @@
+package projectconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
+
+	"github.com/manifoldco/promptui"
+)
+
+// commitTemplateName is the commit message template written into the repository's git directory
+const commitTemplateName = "uegpm-commit-template.txt"
+
+// Where a Git identity is saved
+const (
+	identityProject = "This project only"
+	identityGlobal  = "Every repository on this PC"
+)
+
+// configureGitIdentity offers to set the name and email commits in the project are authored with
+// when git has none. Git otherwise makes one up from the Windows account and PC name, and the
+// commit shows up as "unknown <user@pc>".
+func configureGitIdentity(root string) error {
+	if _, err := os.Stat(filepath.Join(root, ".git")); os.IsNotExist(err) {
+		return nil
+	}
+	name := gitConfigValue(root, "user.name")
+	email := gitConfigValue(root, "user.email")
+	if name != "" && email != "" {
+		events.Info("Commits are authored as %s <%s>", name, email)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println("Git doesn't know your name and email yet. Commits made without them show up as")
+	fmt.Println("\"unknown <user@pc>\" and can't be traced back to you.")
+	if !utils.ConfirmStep("Set the name and email your commits are authored with?") {
+		return nil
+	}
+	// Every answer is collected before anything is written, so a prompt left unanswered never
+	// leaves a name without an email
+	if name = askIdentityValue("Your full name", name); name == "" {
+		return nil
+	}
+	for {
+		email = askIdentityValue("Your work email", email)
+		if email == "" {
+			return nil
+		}
+		if strings.Contains(email, "@") && !strings.ContainsAny(email, " <>") {
+			break
+		}
+		fmt.Println("❌ That doesn't look like an email address.")
+		email = ""
+	}
+
+	prompt := promptui.Select{
+		Label:    "Save it for",
+		Items:    []string{identityProject, identityGlobal},
+		HideHelp: true,
+		Stdout:   &utils.BellSkipper{},
+	}
+	utils.PreselectDefault(&prompt)
+	_, scope, err := utils.RunSelect(&prompt)
+	if err != nil {
+		events.Info("Git identity not saved")
+		return nil
+	}
+	scopeFlag := "--local"
+	if scope == identityGlobal {
+		scopeFlag = "--global"
+	}
+	// A name saved without its email is put back the way it was
+	previousName, hadName := scopedGitConfigValue(root, scopeFlag, "user.name")
+	if err := setGitConfig(root, scopeFlag, "user.name", name); err != nil {
+		return err
+	}
+	if err := setGitConfig(root, scopeFlag, "user.email", email); err != nil {
+		if hadName {
+			setGitConfig(root, scopeFlag, "user.name", previousName)
+		} else {
+			runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"config", scopeFlag, "--unset", "user.name"}, Dir: root})
+		}
+		return err
+	}
+	// Without an identity git now stops and asks for one instead of inventing it
+	if err := setGitConfig(root, "--local", "user.useConfigOnly", "true"); err != nil {
+		return err
+	}
+	events.Success("Commits are authored as %s <%s>", name, email)
+
+	if utils.ConfirmStep("Add a Signed-off-by line for you to new commit messages (commit template)?") {
+		if err := installSignOffTemplate(root, name, email); err != nil {
+			events.Warn("%v", err)
+		}
+	}
+	return nil
+}
+
+// askIdentityValue reads one line, offering the current value or the configured prompt default
+// when Enter is pressed on an empty line
+func askIdentityValue(label, current string) string {
+	if current == "" {
+		current, _ = utils.PromptDefault(label)
+	}
+	if current != "" {
+		fmt.Printf("%s [%s]: ", label, current)
+	} else {
+		fmt.Printf("%s (empty to skip): ", label)
+	}
+	if value := utils.ReadLine(); value != "" {
+		return value
+	}
+	return current
+}
+
+// installSignOffTemplate writes a commit message template ending in the user's Signed-off-by
+// line and makes it the project's commit.template. A template set up by someone else is kept.
+func installSignOffTemplate(root, name, email string) error {
+	result, err := runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"rev-parse", "--git-path", commitTemplateName}, Dir: root})
+	if err != nil {
+		return fmt.Errorf("failed to find the git directory: %v", err)
+	}
+	templatePath := strings.TrimSpace(result.Stdout)
+	if !filepath.IsAbs(templatePath) {
+		templatePath = filepath.Join(root, templatePath)
+	}
+	if existing := gitConfigValue(root, "commit.template"); existing != "" && !strings.EqualFold(filepath.Base(existing), commitTemplateName) {
+		return fmt.Errorf("commit.template is already set to %s; add \"Signed-off-by: %s <%s>\" to it yourself", existing, name, email)
+	}
+
+	template := fmt.Sprintf("\n\n# Describe the change above. Lines starting with # are left out.\nSigned-off-by: %s <%s>\n", name, email)
+	if err := os.WriteFile(templatePath, []byte(template), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", templatePath, err)
+	}
+	if err := setGitConfig(root, "--local", "commit.template", filepath.ToSlash(templatePath)); err != nil {
+		return err
+	}
+	events.Success("Commit messages start from %s", templatePath)
+	return nil
+}
+
+// gitConfigValue returns a git setting as it applies in the project, or "" when it isn't set
+func gitConfigValue(root, key string) string {
+	result, err := runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"config", "--get", key}, Dir: root})
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(result.Stdout)
+}
+
+// setGitConfig writes a git setting in the given scope (--local or --global)
+func setGitConfig(root, scope, key, value string) error {
+	result, err := runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"config", scope, key, value}, Dir: root})
+	if err != nil {
+		return fmt.Errorf("failed to set git %s: %v\nOutput: %s", key, err, result.Combined)
+	}
+	return nil
+}
+
+// scopedGitConfigValue returns a git setting as it is saved in one scope (--local or --global)
+func scopedGitConfigValue(root, scope, key string) (string, bool) {
+	result, err := runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"config", scope, "--get", key}, Dir: root})
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(result.Stdout), true
+}
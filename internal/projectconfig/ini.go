@@ -24,7 +24,8 @@ func promptIniAnswers() (IniAnswers, error) {
 	ans := IniAnswers{}
 	// Q1
 	q1 := promptui.Select{Label: "Automatically track new files?", Items: []string{"Yes", "No"}, Stdout: &utils.BellSkipper{}}
-	_, r1, err := q1.Run()
+	utils.PreselectDefault(&q1)
+	_, r1, err := utils.RunSelect(&q1)
 	if err != nil {
 		return ans, err
 	}
@@ -33,7 +34,8 @@ func promptIniAnswers() (IniAnswers, error) {
 
 	// Q2
 	q2 := promptui.Select{Label: "Checkout style", Items: []string{"Automatically check on modification", "Ask user to check on modification"}, Stdout: &utils.BellSkipper{}}
-	_, r2, err := q2.Run()
+	utils.PreselectDefault(&q2)
+	_, r2, err := utils.RunSelect(&q2)
 	if err != nil {
 		return ans, err
 	}
@@ -42,7 +44,8 @@ func promptIniAnswers() (IniAnswers, error) {
 
 	// Q3
 	q3 := promptui.Select{Label: "Load checked packages for faster loading", Items: []string{"Yes", "No"}, Stdout: &utils.BellSkipper{}}
-	_, r3, err := q3.Run()
+	utils.PreselectDefault(&q3)
+	_, r3, err := utils.RunSelect(&q3)
 	if err != nil {
 		return ans, err
 	}
@@ -50,7 +53,8 @@ func promptIniAnswers() (IniAnswers, error) {
 
 	// Q4
 	q4 := promptui.Select{Label: "Skip Source Control check for editable packages", Items: []string{"Skip", "Do not skip"}, Stdout: &utils.BellSkipper{}}
-	_, r4, err := q4.Run()
+	utils.PreselectDefault(&q4)
+	_, r4, err := utils.RunSelect(&q4)
 	if err != nil {
 		return ans, err
 	}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	templates "ue-git-plugin-manager/internal/new_project_example_config_files"
+
+	"ue-git-plugin-manager/internal/events"
 )
 
 // DetectProjectRoot validates the project dir by presence of a .uproject or Content dir
@@ -227,18 +229,37 @@ func effectiveIgnoreMap(lines []string) map[string]bool {
 }
 
 func printConflictSummary(name string, conflicts []string) {
-	fmt.Printf("⚠️  Conflicts detected in %s (%d):\n", name, len(conflicts))
+	events.Warn("Conflicts detected in %s (%d):", name, len(conflicts))
 	for i, c := range conflicts {
 		if i >= 5 {
 			break
 		}
-		fmt.Printf("  - %s\n", c)
+		events.Info("  - %s", c)
 	}
-	fmt.Println("This file was not modified. Review and resolve conflicts manually.")
+	events.Info("This file was not modified. Review and resolve conflicts manually.")
+}
+
+// logsDir is where conflict reports are written; see SetLogsDir
+var logsDir = filepath.Join(os.TempDir(), "ue-git-plugin-manager", "logs")
+
+// SetLogsDir sets the folder conflict reports are written to. The exe's folder may not be
+// writable, so reports never go to the working directory.
+func SetLogsDir(dir string) {
+	logsDir = dir
+}
+
+// ProjectLogsDir returns the folder reports about a project are written to
+func ProjectLogsDir(root string) string {
+	return filepath.Join(logsDir, filepath.Base(filepath.Clean(root)))
 }
 
 func writeConflictsLog(root string, name string, conflicts []string) {
-	_ = os.MkdirAll("logs", 0755)
-	fname := fmt.Sprintf("logs/%s_conflicts_%d.txt", strings.TrimPrefix(name, "."), time.Now().Unix())
-	_ = os.WriteFile(fname, []byte(strings.Join(conflicts, "\n")), 0644)
+	dir := ProjectLogsDir(root)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	fname := filepath.Join(dir, fmt.Sprintf("%s_conflicts_%d.txt", strings.TrimPrefix(name, "."), time.Now().Unix()))
+	if err := os.WriteFile(fname, []byte(strings.Join(conflicts, "\n")), 0644); err == nil {
+		events.Info("Conflicts written to %s", fname)
+	}
 }
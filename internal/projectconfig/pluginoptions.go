@@ -0,0 +1,190 @@
+package projectconfig
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Option types, deciding how a value is edited and written
+const (
+	OptionText = "text" // Free text
+	OptionBool = "bool" // True/False
+	OptionFlag = "flag" // 1/0, used by console variables
+)
+
+// userSourceControlIni is where the editor keeps each user's source control provider settings
+const userSourceControlIni = "Saved/Config/WindowsEditor/SourceControlSettings.ini"
+
+// IniOption is a setting of UEGitPlugin or of the editor's source control integration, stored
+// as a key in one of the project's ini files
+type IniOption struct {
+	Label   string `json:"label"`
+	Help    string `json:"help,omitempty"`
+	File    string `json:"file"` // Relative to the project root, e.g. Config/DefaultEngine.ini
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Type    string `json:"type,omitempty"`    // OptionText (default), OptionBool or OptionFlag
+	Default string `json:"default,omitempty"` // What applies while the key is absent
+}
+
+// PluginOptions are the settings offered by the plugin settings screen, in display order
+var PluginOptions = []IniOption{
+	{
+		Label:   "Source control provider",
+		Help:    `"Git LFS 2" selects UEGitPlugin. Stored per user in the project's Saved folder.`,
+		File:    userSourceControlIni,
+		Section: "SourceControl.SourceControlSettings",
+		Key:     "Provider",
+		Default: "None",
+	},
+	{
+		Label:   "Use Git LFS file locking",
+		Help:    "Lock binary assets on checkout with git lfs lock. The LFS server must support locking.",
+		File:    userSourceControlIni,
+		Section: "GitSourceControl.GitSourceControlSettings",
+		Key:     "UsingGitLfsLocking",
+		Type:    OptionBool,
+		Default: "False",
+	},
+	{
+		Label:   "Git LFS user name",
+		Help:    "The name your locks are listed under on the LFS server, when it differs from git's user.name.",
+		File:    userSourceControlIni,
+		Section: "GitSourceControl.GitSourceControlSettings",
+		Key:     "LfsUserName",
+	},
+	{
+		Label:   "Git executable",
+		Help:    "Full path to git.exe. Leave unset to let the plugin find Git on the PATH.",
+		File:    userSourceControlIni,
+		Section: "GitSourceControl.GitSourceControlSettings",
+		Key:     "BinaryPath",
+	},
+	{
+		Label:   "Add new files to source control",
+		File:    "Config/DefaultEditorPerProjectUserSettings.ini",
+		Section: "/Script/UnrealEd.EditorLoadingSavingSettings",
+		Key:     "bSCCAutoAddNewFiles",
+		Type:    OptionBool,
+		Default: "True",
+	},
+	{
+		Label:   "Check out assets automatically on modification",
+		File:    "Config/DefaultEditorPerProjectUserSettings.ini",
+		Section: "/Script/UnrealEd.EditorLoadingSavingSettings",
+		Key:     "bAutomaticallyCheckoutOnAssetModification",
+		Type:    OptionBool,
+		Default: "False",
+	},
+	{
+		Label:   "Ask to check out assets on modification",
+		File:    "Config/DefaultEditorPerProjectUserSettings.ini",
+		Section: "/Script/UnrealEd.EditorLoadingSavingSettings",
+		Key:     "bPromptForCheckoutOnAssetModification",
+		Type:    OptionBool,
+		Default: "True",
+	},
+	{
+		Label:   "Load checked out packages on startup",
+		File:    "Config/DefaultEditorPerProjectUserSettings.ini",
+		Section: "/Script/UnrealEd.EditorPerProjectUserSettings",
+		Key:     "bAutoloadCheckedOutPackages",
+		Type:    OptionBool,
+		Default: "False",
+	},
+	{
+		Label:   "Skip source control check for editable packages",
+		Help:    "Speeds up saving, at the cost of not warning about packages changed by someone else.",
+		File:    "Config/DefaultEngine.ini",
+		Section: "SystemSettingsEditor",
+		Key:     "r.Editor.SkipSourceControlCheckForEditablePackages",
+		Type:    OptionFlag,
+		Default: "0",
+	},
+}
+
+// Path returns the ini file an option is stored in for a project
+func (o IniOption) Path(root string) string {
+	return filepath.Join(root, filepath.FromSlash(o.File))
+}
+
+// Current returns an option's value in a project and whether it is set there
+func (o IniOption) Current(root string) (string, bool) {
+	return readIniValue(o.Path(root), o.Section, o.Key)
+}
+
+// Set writes an option's value into the project
+func (o IniOption) Set(root, value string) error {
+	return upsertIni(o.Path(root), o.Section, o.Key, value)
+}
+
+// Reset removes an option from the project so its default applies again
+func (o IniOption) Reset(root string) error {
+	return removeIniKey(o.Path(root), o.Section, o.Key)
+}
+
+// Toggled returns the opposite of a bool or flag value
+func (o IniOption) Toggled(value string) string {
+	on := strings.EqualFold(value, "True") || value == "1"
+	if o.Type == OptionFlag {
+		if on {
+			return "0"
+		}
+		return "1"
+	}
+	return boolToUE(!on)
+}
+
+// readIniLines returns an ini file's lines without line endings
+func readIniLines(path string) []string {
+	var lines []string
+	if data, err := os.ReadFile(path); err == nil {
+		s := bufio.NewScanner(strings.NewReader(string(data)))
+		for s.Scan() {
+			lines = append(lines, strings.TrimRight(s.Text(), "\r"))
+		}
+	}
+	return lines
+}
+
+// findIniKey returns the line index of key in section, or -1
+func findIniKey(lines []string, section, key string) int {
+	inSection := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			inSection = trimmed == "["+section+"]"
+			continue
+		}
+		if !inSection || trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if kv := strings.SplitN(trimmed, "=", 2); len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// readIniValue returns the value of key in section and whether it is set
+func readIniValue(path, section, key string) (string, bool) {
+	lines := readIniLines(path)
+	i := findIniKey(lines, section, key)
+	if i < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(strings.SplitN(lines[i], "=", 2)[1]), true
+}
+
+// removeIniKey deletes key from section, leaving the rest of the file as it is
+func removeIniKey(path, section, key string) error {
+	lines := readIniLines(path)
+	i := findIniKey(lines, section, key)
+	if i < 0 {
+		return nil
+	}
+	lines = append(lines[:i], lines[i+1:]...)
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
@@ -0,0 +1,83 @@
+package projectconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	templates "ue-git-plugin-manager/internal/new_project_example_config_files"
+
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// scaffoldFiles lists the embedded starter templates and where they go in a new project
+var scaffoldFiles = []struct{ template, dest string }{
+	{"scaffold_DefaultEngine.ini", filepath.Join("Config", "DefaultEngine.ini")},
+	{"scaffold_DefaultEditorPerProjectUserSettings.ini", filepath.Join("Config", "DefaultEditorPerProjectUserSettings.ini")},
+	{"scaffold_README.md", "README.md"},
+}
+
+// CheckScaffoldTarget accepts a folder that doesn't exist yet or is empty apart from a .git folder
+func CheckScaffoldTarget(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("enter a folder path")
+	}
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("parent folder does not exist: %s", filepath.Dir(path))
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("path is not a directory: %s", path)
+	}
+	for _, e := range entries {
+		if e.Name() != ".git" {
+			return fmt.Errorf("folder is not empty (contains %s); use the wizard on an existing project instead", e.Name())
+		}
+	}
+	return nil
+}
+
+// ScaffoldProject prepares an empty folder for a new Unreal project before the .uproject exists:
+// a Git repository, Config/ with starter INI files and a README placeholder. The Git files and
+// INI settings are then added by ConfigureProject.
+func ScaffoldProject(root string) error {
+	if err := CheckScaffoldTarget(root); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", root, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, ".git")); os.IsNotExist(err) {
+		if result, err := runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"init"}, Dir: root}); err != nil {
+			return fmt.Errorf("failed to initialize the git repository: %v\nOutput: %s", err, result.Combined)
+		}
+		events.Success("Initialized a new git repository")
+	}
+
+	// The README refers to the project by its folder name, which the .uproject usually shares
+	name := filepath.Base(root)
+	for _, file := range scaffoldFiles {
+		dest := file.dest
+		data, err := templates.FS.ReadFile(file.template)
+		if err != nil {
+			return err
+		}
+		content := strings.ReplaceAll(string(data), "{{PROJECT}}", name)
+		path := filepath.Join(root, dest)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", dest, err)
+		}
+		events.Success("Created %s", dest)
+	}
+	return nil
+}
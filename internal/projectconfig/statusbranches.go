@@ -0,0 +1,81 @@
+package projectconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Where the status branch list is kept in a project. UEGitPlugin doesn't read it by itself:
+// the project's editor module passes it to RegisterStateBranches (see StatusBranchSnippet).
+const (
+	StatusBranchFile    = "Config/DefaultEditor.ini"
+	StatusBranchSection = "/Script/UEGitPluginManager.StatusBranches"
+	statusBranchKey     = "+BranchNames"
+)
+
+// DefaultStatusBranch is the branch suggested for CI-tested commits, as in Project Borealis
+const DefaultStatusBranch = "promoted"
+
+// StatusBranchNames returns the status branches listed in the project, without the origin/ prefix
+func StatusBranchNames(root string) []string {
+	var names []string
+	inSection := false
+	for _, line := range readIniLines(filepath.Join(root, filepath.FromSlash(StatusBranchFile))) {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			inSection = trimmed == "["+StatusBranchSection+"]"
+			continue
+		}
+		if kv := strings.SplitN(trimmed, "=", 2); inSection && len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), statusBranchKey) {
+			names = append(names, strings.TrimPrefix(strings.TrimSpace(kv[1]), "origin/"))
+		}
+	}
+	return names
+}
+
+// SetStatusBranchNames replaces the project's status branch list, most stable branch first
+func SetStatusBranchNames(root string, names []string) error {
+	path := filepath.Join(root, filepath.FromSlash(StatusBranchFile))
+	for {
+		lines := readIniLines(path)
+		if findIniKey(lines, StatusBranchSection, statusBranchKey) < 0 {
+			break
+		}
+		if err := removeIniKey(path, StatusBranchSection, statusBranchKey); err != nil {
+			return err
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	// upsertIni writes one key, so it adds the section and the first entry; the rest follow it
+	if err := upsertIni(path, StatusBranchSection, statusBranchKey, "origin/"+names[0]); err != nil {
+		return err
+	}
+	lines := readIniLines(path)
+	at := findIniKey(lines, StatusBranchSection, statusBranchKey) + 1
+	var entries []string
+	for _, name := range names[1:] {
+		entries = append(entries, fmt.Sprintf("%s=origin/%s", statusBranchKey, name))
+	}
+	lines = append(lines[:at], append(entries, lines[at:]...)...)
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// StatusBranchSnippet is the editor module code that hands the project's status branches to
+// UEGitPlugin, which only learns about them from code
+const StatusBranchSnippet = `#include "GitSourceControlModule.h"
+
+// In your editor module's StartupModule(). Add "GitSourceControl" to the module's
+// PrivateDependencyModuleNames in its .Build.cs.
+TArray<FString> StatusBranches;
+GConfig->GetArray(TEXT("` + StatusBranchSection + `"), TEXT("BranchNames"), StatusBranches, GEditorIni);
+if (StatusBranches.Num() > 0 && FModuleManager::Get().IsModuleLoaded("GitSourceControl"))
+{
+	FGitSourceControlModule& GitSourceControl = FModuleManager::GetModuleChecked<FGitSourceControlModule>("GitSourceControl");
+	GitSourceControl.GetProvider().RegisterStateBranches(StatusBranches, TEXT("Content/"));
+}
+`
@@ -1,36 +1,21 @@
 package projectconfig
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"ue-git-plugin-manager/internal/events"
+	"ue-git-plugin-manager/internal/runner"
 	"ue-git-plugin-manager/internal/utils"
 
 	"github.com/manifoldco/promptui"
 )
 
-// RunWizard orchestrates the Configure project flow
-func RunWizard() error {
-	fmt.Println("🔧 Configure Unreal Project")
-	fmt.Println()
-	fmt.Println("This wizard will help set up .gitattributes, .gitignore, and Unreal INI settings for your project.")
-	fmt.Println()
-
-	// Ask for project path
-	projectPath, err := promptForPath()
-	if err != nil {
-		return err
-	}
-
-	root, err := DetectProjectRoot(projectPath)
-	if err != nil {
-		return fmt.Errorf("invalid project path: %w", err)
-	}
-
+// ConfigureProject applies the Git and INI configuration to an already validated project root
+func ConfigureProject(root string) error {
 	// Explain plugin binaries choice
 	fmt.Println("Git handling for compiled plugin binaries:")
 	fmt.Println("- Include binaries: helpful for artists without build tools, increases repo size")
@@ -55,6 +40,16 @@ func RunWizard() error {
 		return err
 	}
 
+	// Name and email commits are authored with
+	if err := configureGitIdentity(root); err != nil {
+		return err
+	}
+
+	// Pre-commit asset check
+	if err := promptAssetHook(root); err != nil {
+		return err
+	}
+
 	// INI settings
 	answers, err := promptIniAnswers()
 	if err != nil {
@@ -69,32 +64,6 @@ func RunWizard() error {
 	return nil
 }
 
-func promptForPath() (string, error) {
-	fmt.Print("Enter or paste the project folder path: ")
-	reader := bufio.NewReader(os.Stdin)
-	p, _ := reader.ReadString('\n')
-	p = strings.TrimSpace(p)
-	p = strings.Trim(p, "\"")
-	if p == "" {
-		exePath, err := os.Executable()
-		if err == nil {
-			p = filepath.Dir(exePath)
-		} else {
-			cwd, cwdErr := os.Getwd()
-			if cwdErr == nil {
-				p = cwd
-			}
-		}
-		fmt.Printf("No path entered, using executable folder: %s\n", p)
-	}
-	p = filepath.Clean(p)
-	if p == "." { // allow current dir quickly
-		cwd, _ := os.Getwd()
-		p = cwd
-	}
-	return p, nil
-}
-
 func promptIncludeBinaries() (bool, error) {
 	prompt := promptui.Select{
 		Label:  "Include compiled plugin binaries in Git?",
@@ -102,7 +71,8 @@ func promptIncludeBinaries() (bool, error) {
 		Size:   5,
 		Stdout: &utils.BellSkipper{},
 	}
-	_, choice, err := prompt.Run()
+	utils.PreselectDefault(&prompt)
+	_, choice, err := utils.RunSelect(&prompt)
 	if err != nil {
 		return false, err
 	}
@@ -119,13 +89,11 @@ func configureGitHttpVersion(root string) error {
 	}
 
 	// Run git config --local http.version HTTP/1.1
-	cmd := exec.Command("git", "config", "--local", "http.version", "HTTP/1.1")
-	cmd.Dir = root
-	output, err := cmd.CombinedOutput()
+	result, err := runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"config", "--local", "http.version", "HTTP/1.1"}, Dir: root})
 	if err != nil {
-		return fmt.Errorf("failed to configure git http.version: %v\nOutput: %s", err, string(output))
+		return fmt.Errorf("failed to configure git http.version: %v\nOutput: %s", err, result.Combined)
 	}
 
-	fmt.Println("✅ Configured git http.version to HTTP/1.1 (required for Azure LFS)")
+	events.Success("Configured git http.version to HTTP/1.1 (required for Azure LFS)")
 	return nil
 }
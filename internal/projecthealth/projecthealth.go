@@ -0,0 +1,313 @@
+package projecthealth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// Kinds of repository health problems
+const (
+	KindCaseConflict  = "case-conflict"  // Paths that differ only in case; Windows can check out one of them
+	KindFolderCasing  = "folder-casing"  // One folder spelled several ways across paths
+	KindLFSNotPulled  = "lfs-not-pulled" // LFS files still holding their pointer instead of the content
+	KindLFSCommitted  = "lfs-committed"  // LFS pointers committed as regular files, outside LFS
+	KindLFSMissing    = "lfs-missing"    // Files committed without LFS that .gitattributes routes through it
+	KindLineEndings   = "line-endings"   // Assets git treats as text, converting their line endings
+	KindCorruptAssets = "corrupt-assets" // Assets without the Unreal package header
+)
+
+// lfsPointerPrefix starts every Git LFS pointer file
+const lfsPointerPrefix = "version https://git-lfs.github.com/spec/v1"
+
+// packageTag starts every .uasset and .umap file (0x9E2A83C1, little-endian)
+var packageTag = []byte{0xC1, 0x83, 0x2A, 0x9E}
+
+// Issue is one repository health problem, with the files it affects and how to fix it
+type Issue struct {
+	Kind    string
+	Summary string
+	Paths   []string // Relative to the project root, with forward slashes
+	Fix     []string // Suggested steps, in order
+}
+
+// Check looks through a project's repository for problems that break checkouts or assets on
+// Windows: paths that differ only in case, LFS files that were never downloaded or were committed
+// outside LFS, and assets mangled by line ending conversion
+func Check(root string) ([]Issue, error) {
+	output, err := runner.Output("git", "-C", root, "ls-files", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a git repository or git failed: %v", root, err)
+	}
+	files := splitNUL(output)
+
+	var issues []Issue
+	issues = append(issues, caseIssues(files)...)
+	issues = append(issues, assetIssues(root, files)...)
+	if issue, ok := lineEndingIssue(root); ok {
+		issues = append(issues, issue)
+	}
+	if issue, ok := lfsMissingIssue(root); ok {
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// caseIssues finds tracked paths that differ only in case, which Windows can't hold side by side,
+// and folders spelled with different casing, which Windows merges into one
+func caseIssues(files []string) []Issue {
+	byFile := make(map[string][]string)
+	folders := make(map[string]map[string]bool)
+	for _, file := range files {
+		byFile[strings.ToLower(file)] = append(byFile[strings.ToLower(file)], file)
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			key := strings.ToLower(dir)
+			if folders[key] == nil {
+				folders[key] = make(map[string]bool)
+			}
+			folders[key][dir] = true
+		}
+	}
+
+	var issues []Issue
+	var conflicts []string
+	for _, spellings := range byFile {
+		if len(spellings) > 1 {
+			conflicts = append(conflicts, strings.Join(spellings, " ↔ "))
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		issues = append(issues, Issue{
+			Kind:    KindCaseConflict,
+			Summary: "Files whose paths differ only in case; a Windows checkout keeps one and shows the other as modified",
+			Paths:   conflicts,
+			Fix: []string{
+				"Keep one spelling: git rm --cached \"<path to drop>\", then commit",
+				"If both files are needed, rename one: git mv \"<path>\" \"<new name>\"",
+			},
+		})
+	}
+
+	var casing []string
+	for _, spellings := range folders {
+		if len(spellings) > 1 {
+			var names []string
+			for name := range spellings {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			casing = append(casing, strings.Join(names, " ↔ "))
+		}
+	}
+	if len(casing) > 0 {
+		sort.Strings(casing)
+		issues = append(issues, Issue{
+			Kind:    KindFolderCasing,
+			Summary: "Folders spelled with different casing; Windows shows them as one, other systems as two",
+			Paths:   casing,
+			Fix: []string{
+				"Move the files to one spelling with git mv, e.g. git mv \"Content/maps/Level.umap\" \"Content/Maps/Level.umap\"",
+				"Check Config and Content references after renaming folders; redirectors may need fixing up in the editor",
+			},
+		})
+	}
+	return issues
+}
+
+// assetIssues reads the start of every tracked .uasset and .umap file and reports those still
+// holding an LFS pointer, and those without the Unreal package header
+func assetIssues(root string, files []string) []Issue {
+	var pointers, corrupt []string
+	for _, file := range files {
+		ext := strings.ToLower(path.Ext(file))
+		if ext != ".uasset" && ext != ".umap" {
+			continue
+		}
+		head, err := readHead(filepath.Join(root, filepath.FromSlash(file)), len(lfsPointerPrefix))
+		if err != nil || len(head) == 0 {
+			continue // Deleted in the working tree or unreadable; git status shows those
+		}
+		switch {
+		case bytes.HasPrefix(head, []byte(lfsPointerPrefix)):
+			pointers = append(pointers, file)
+		case !bytes.HasPrefix(head, packageTag):
+			corrupt = append(corrupt, file)
+		}
+	}
+
+	var issues []Issue
+	if len(pointers) > 0 {
+		notPulled, committed := splitByLFSFilter(root, pointers)
+		if len(notPulled) > 0 {
+			issues = append(issues, Issue{
+				Kind:    KindLFSNotPulled,
+				Summary: "LFS files that were never downloaded; the editor sees a small text file instead of the asset",
+				Paths:   notPulled,
+				Fix: []string{
+					"Make sure Git LFS is installed for your user: git lfs install",
+					"Download the content: git lfs pull",
+				},
+			})
+		}
+		if len(committed) > 0 {
+			issues = append(issues, Issue{
+				Kind:    KindLFSCommitted,
+				Summary: "LFS pointers committed as regular files, because .gitattributes doesn't route them through LFS",
+				Paths:   committed,
+				Fix: []string{
+					"Add the LFS rules with \"Run Project Setup Wizard\", or add e.g. *.uasset filter=lfs diff=lfs merge=lfs -text to .gitattributes",
+					"Re-add the files so git stores them as LFS pointers: git add --renormalize . and commit",
+					"Download the content: git lfs pull",
+				},
+			})
+		}
+	}
+	if len(corrupt) > 0 {
+		issues = append(issues, Issue{
+			Kind:    KindCorruptAssets,
+			Summary: "Assets without the Unreal package header, usually mangled by line ending conversion or a text merge",
+			Paths:   corrupt,
+			Fix: []string{
+				"Restore each from the last commit where it opened: git log -- \"<path>\", then git checkout <commit> -- \"<path>\"",
+				"Mark assets as binary in .gitattributes (the Project Setup Wizard does) so it doesn't happen again",
+			},
+		})
+	}
+	return issues
+}
+
+// splitByLFSFilter divides files into those .gitattributes routes through LFS and the others
+func splitByLFSFilter(root string, files []string) (lfs, other []string) {
+	result, err := runner.Run(context.Background(), runner.Command{
+		Name:  "git",
+		Args:  []string{"-C", root, "check-attr", "--stdin", "-z", "filter"},
+		Stdin: strings.NewReader(strings.Join(files, "\x00") + "\x00"),
+	})
+	filtered := make(map[string]bool)
+	if err == nil {
+		// Records are path, attribute and value, each ended by NUL
+		fields := splitNUL(result.Stdout)
+		for i := 0; i+2 < len(fields); i += 3 {
+			if fields[i+2] == "lfs" {
+				filtered[fields[i]] = true
+			}
+		}
+	}
+	for _, file := range files {
+		if filtered[file] {
+			lfs = append(lfs, file)
+		} else {
+			other = append(other, file)
+		}
+	}
+	return lfs, other
+}
+
+// lineEndingIssue reports assets that git treats as text, whose line endings it converts on
+// checkout or commit and so corrupts
+func lineEndingIssue(root string) (Issue, bool) {
+	output, err := runner.Output("git", "-C", root, "ls-files", "--eol", "-z", "--", "*.uasset", "*.umap")
+	if err != nil {
+		return Issue{}, false
+	}
+	var converted []string
+	for _, entry := range splitNUL(output) {
+		// Each entry is "i/<index> w/<worktree> attr/<attributes>" and the path after a tab
+		info, file, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) < 3 {
+			continue
+		}
+		index, attr := fields[0], fields[2]
+		switch {
+		case attr == "attr/-text":
+			// Marked binary; an LFS pointer in the index is text, which is fine
+		case attr == "attr/text":
+			converted = append(converted, file) // Forced to text, converted whatever it holds
+		case index == "i/crlf" || index == "i/mixed":
+			converted = append(converted, file) // Detected as text and committed with CRLF
+		}
+	}
+	if len(converted) == 0 {
+		return Issue{}, false
+	}
+	return Issue{
+		Kind:    KindLineEndings,
+		Summary: "Assets git treats as text; their line endings are converted, which corrupts them",
+		Paths:   converted,
+		Fix: []string{
+			"Mark assets as binary in .gitattributes, e.g. *.uasset binary (the Project Setup Wizard adds LFS rules that include -text)",
+			"Store them unconverted: git add --renormalize . and commit",
+			"Restore any asset that no longer opens from an earlier commit",
+		},
+	}, true
+}
+
+// lfsMissingIssue reports files committed as regular blobs although .gitattributes routes them
+// through LFS. It needs Git LFS 2.13 or later; with an older one the check is skipped.
+func lfsMissingIssue(root string) (Issue, bool) {
+	result, err := runner.Run(context.Background(), runner.Command{Name: "git", Args: []string{"-C", root, "lfs", "fsck", "--pointers"}})
+	if err == nil {
+		return Issue{}, false
+	}
+	var missing []string
+	for _, line := range strings.Split(result.Combined, "\n") {
+		// pointer: unexpectedGitObject: "Content/A.uasset" (treeish HEAD) should have been a pointer but was not
+		if !strings.Contains(line, "should have been a pointer") {
+			continue
+		}
+		if start := strings.Index(line, "\""); start >= 0 {
+			if end := strings.Index(line[start+1:], "\""); end >= 0 {
+				missing = append(missing, line[start+1:start+1+end])
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return Issue{}, false
+	}
+	return Issue{
+		Kind:    KindLFSMissing,
+		Summary: "Files committed without LFS although .gitattributes routes them through it; they bloat every clone",
+		Paths:   missing,
+		Fix: []string{
+			"Re-add them as LFS files: git add --renormalize . and commit",
+			"To also shrink the history, rewrite it with git lfs migrate import (coordinate with the team first)",
+		},
+	}, true
+}
+
+// readHead returns up to n bytes from the start of a file
+func readHead(file string, n int) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	head := make([]byte, n)
+	read, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	return head[:read], nil
+}
+
+// splitNUL splits NUL-separated git output, dropping the empty field after the last separator
+func splitNUL(output string) []string {
+	output = strings.TrimSuffix(output, "\x00")
+	if output == "" {
+		return nil
+	}
+	return strings.Split(output, "\x00")
+}
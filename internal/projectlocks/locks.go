@@ -4,8 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
+
+	"ue-git-plugin-manager/internal/runner"
 )
 
 type Lock struct {
@@ -330,9 +331,8 @@ func unlockLock(projectRoot string, lock Lock) error {
 
 func runGit(projectRoot string, args ...string) (string, error) {
 	fullArgs := append([]string{"-C", projectRoot}, args...)
-	cmd := exec.Command("git", fullArgs...)
-	output, err := cmd.CombinedOutput()
-	result := strings.TrimSpace(string(output))
+	output, err := runner.CombinedOutput("git", fullArgs...)
+	result := strings.TrimSpace(output)
 	if err != nil {
 		if result == "" {
 			return "", err
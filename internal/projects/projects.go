@@ -0,0 +1,128 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// buildsRegistryKey lists source-built engines by the identifier stored in EngineAssociation
+const buildsRegistryKey = `HKCU\Software\Epic Games\Unreal Engine\Builds`
+
+// Info describes an Unreal project on disk
+type Info struct {
+	Root              string
+	Name              string
+	UProjectPath      string
+	EngineAssociation string // "5.4" for launcher installs, a build identifier for source builds
+}
+
+// Read finds the .uproject file in a project folder and reads its engine association
+func Read(root string) (Info, error) {
+	info := Info{Root: root, Name: filepath.Base(root)}
+
+	matches, err := filepath.Glob(filepath.Join(root, "*.uproject"))
+	if err != nil {
+		return info, err
+	}
+	if len(matches) == 0 {
+		return info, fmt.Errorf("no .uproject file found in %s", root)
+	}
+	info.UProjectPath = matches[0]
+	info.Name = strings.TrimSuffix(filepath.Base(matches[0]), filepath.Ext(matches[0]))
+
+	data, err := os.ReadFile(info.UProjectPath)
+	if err != nil {
+		return info, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	var descriptor struct {
+		EngineAssociation string `json:"EngineAssociation"`
+	}
+	if err := json.Unmarshal(data, &descriptor); err != nil {
+		return info, fmt.Errorf("failed to parse %s: %v", info.UProjectPath, err)
+	}
+	info.EngineAssociation = descriptor.EngineAssociation
+	return info, nil
+}
+
+// UsesEngine reports whether the project is associated with the given engine install
+func (info Info) UsesEngine(enginePath, engineVersion string) bool {
+	association := strings.TrimSpace(info.EngineAssociation)
+	if association == "" {
+		return false
+	}
+	if association == engineVersion {
+		return true
+	}
+
+	buildPath := SourceBuildPath(association)
+	if buildPath == "" {
+		return false
+	}
+	return utils.SamePath(buildPath, enginePath)
+}
+
+// SourceBuildPath looks up the folder of a source-built engine registered by UnrealVersionSelector
+func SourceBuildPath(association string) string {
+	output, err := runner.Output("reg", "query", buildsRegistryKey, "/v", association)
+	if err != nil {
+		return ""
+	}
+
+	// Output lines look like: "    {GUID}    REG_SZ    D:/UE/Source"
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), "REG_SZ", 2)
+		if len(fields) == 2 && strings.TrimSpace(fields[0]) == association {
+			return filepath.FromSlash(strings.TrimSpace(fields[1]))
+		}
+	}
+	return ""
+}
+
+// recentProjectPattern matches .uproject paths in EditorSettings.ini, both the plain
+// RecentlyOpenedProjectFiles=<path> form and the (ProjectName="<path>",...) form of UE 5.1+
+var recentProjectPattern = regexp.MustCompile(`(?i)RecentlyOpenedProjectFiles=(?:\(ProjectName=")?([^",)\r\n]+\.uproject)`)
+
+// RecentProjects returns the folders of projects recently opened in any installed editor version
+func RecentProjects() []string {
+	localAppData := os.Getenv("LOCALAPPDATA")
+	if localAppData == "" {
+		return nil
+	}
+
+	settingsFiles, _ := filepath.Glob(filepath.Join(localAppData, "UnrealEngine", "*", "Saved", "Config", "WindowsEditor", "EditorSettings.ini"))
+	// Newer engine versions first so their lists take priority
+	sort.Sort(sort.Reverse(sort.StringSlice(settingsFiles)))
+
+	seen := make(map[string]bool)
+	var roots []string
+	for _, file := range settingsFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, match := range recentProjectPattern.FindAllStringSubmatch(string(data), -1) {
+			root := filepath.Dir(filepath.FromSlash(strings.TrimSpace(match[1])))
+			key := strings.ToLower(root)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, err := os.Stat(match[1]); err != nil {
+				continue
+			}
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
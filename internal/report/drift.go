@@ -0,0 +1,123 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/manifest"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// Kinds of difference between a machine and the golden manifest
+const (
+	DriftMissingEngine = "missing engine"
+	DriftBroken        = "broken"
+	DriftCommit        = "different commit"
+	DriftStockPlugin   = "stock plugin enabled"
+)
+
+// Drift is one way a machine differs from the golden manifest
+type Drift struct {
+	Machine       string
+	EngineVersion string
+	Kind          string
+	Detail        string
+}
+
+// CompareToGolden lists, for every machine, the golden engines that are missing, broken,
+// built from another plugin commit or still have the stock Git plugin enabled
+func CompareToGolden(golden manifest.Manifest, machines []MachineStatus) []Drift {
+	var drifts []Drift
+	for _, m := range machines {
+		for _, want := range golden.Engines {
+			add := func(kind, detail string) {
+				drifts = append(drifts, Drift{Machine: m.Machine, EngineVersion: want.Version, Kind: kind, Detail: detail})
+			}
+
+			eng, ok := findEngine(m.Engines, want)
+			switch {
+			case !ok:
+				add(DriftMissingEngine, "not installed")
+				continue
+			case eng.State == StateNotSetUp:
+				add(DriftMissingEngine, "plugin not set up")
+				continue
+			case eng.State == StateBroken:
+				add(DriftBroken, strings.Join(eng.Issues, "; "))
+			}
+
+			// Status files from older versions only have the checked-out commit
+			actual := eng.BaseSHA
+			if actual == "" {
+				actual = eng.LocalSHA
+			}
+			if !strings.EqualFold(actual, want.CommitSHA) {
+				add(DriftCommit, fmt.Sprintf("at %s, expected %s", git.ShortSHA(actual), git.ShortSHA(want.CommitSHA)))
+			}
+			if eng.StockPluginEnabled {
+				add(DriftStockPlugin, `Engine\Plugins\Developer\GitSourceControl is active`)
+			}
+		}
+	}
+	return drifts
+}
+
+// findEngine finds a machine's engine for a golden entry, preferring the same path
+func findEngine(engines []EngineStatus, want manifest.Engine) (EngineStatus, bool) {
+	for _, eng := range engines {
+		if utils.SamePath(eng.Path, want.Path) {
+			return eng, true
+		}
+	}
+	for _, eng := range engines {
+		if eng.Version == want.Version {
+			return eng, true
+		}
+	}
+	return EngineStatus{}, false
+}
+
+// RenderDriftMarkdown renders the comparison of machines against the golden manifest as Markdown
+func RenderDriftMarkdown(golden manifest.Manifest, machines []MachineStatus, drifts []Drift, now time.Time) string {
+	byMachine := make(map[string][]Drift)
+	for _, drift := range drifts {
+		byMachine[drift.Machine] = append(byMachine[drift.Machine], drift)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# UE Git Plugin Manager drift report\n\n")
+	fmt.Fprintf(&b, "Generated %s against the golden manifest from %s (%s) — %d machine(s), %d drifted.\n\n",
+		now.Format("2006-01-02 15:04"), golden.Machine, formatTimestamp(golden.CreatedUTC), len(machines), len(byMachine))
+
+	b.WriteString("Expected engines:\n\n")
+	for _, eng := range golden.Engines {
+		fmt.Fprintf(&b, "- UE %s at plugin commit `%s`\n", eng.Version, git.ShortSHA(eng.CommitSHA))
+	}
+
+	b.WriteString("\n| Machine | Collected | Drift |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, m := range machines {
+		result := "matches"
+		if count := len(byMachine[m.Machine]); count > 0 {
+			result = fmt.Sprintf("%d difference(s)", count)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", m.Machine, formatTimestamp(m.CollectedUTC), result)
+	}
+
+	for _, m := range machines {
+		if len(byMachine[m.Machine]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", m.Machine)
+		for _, drift := range byMachine[m.Machine] {
+			fmt.Fprintf(&b, "- UE %s: %s", drift.EngineVersion, drift.Kind)
+			if drift.Detail != "" {
+				fmt.Fprintf(&b, " — %s", drift.Detail)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
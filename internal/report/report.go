@@ -0,0 +1,262 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/timing"
+)
+
+// Engine states used in machine status files
+const (
+	StateComplete = "complete"
+	StateBroken   = "broken"
+	StateNotSetUp = "not_set_up"
+)
+
+// staleAfter is how old a machine status file can be before the report flags it
+const staleAfter = 8 * 24 * time.Hour
+
+// EngineStatus is the status of one engine as recorded in a machine status file
+type EngineStatus struct {
+	Version        string   `json:"version"`
+	Path           string   `json:"path"`
+	State          string   `json:"state"`
+	CommitsBehind  int      `json:"commits_behind"`
+	LocalSHA       string   `json:"local_sha,omitempty"`
+	BaseSHA        string   `json:"base_sha,omitempty"` // Upstream commit under local patches and commits
+	LastUpdatedUTC string   `json:"last_updated_utc,omitempty"`
+	Issues         []string `json:"issues,omitempty"`
+
+	StockPluginEnabled bool `json:"stock_plugin_enabled,omitempty"`
+}
+
+// MachineStatus is the status document a workstation writes for collection
+type MachineStatus struct {
+	Machine      string         `json:"machine"`
+	User         string         `json:"user"`
+	CollectedUTC string         `json:"collected_utc"`
+	Engines      []EngineStatus `json:"engines"`
+	StepTimings  timing.Store   `json:"step_timings,omitempty"`
+}
+
+// AverageBuild returns the machine's average plugin build time, or "-" if none was recorded
+func (m MachineStatus) AverageBuild() string {
+	stats, ok := m.StepTimings[timing.StepBuild]
+	if !ok || stats.Count == 0 {
+		return "-"
+	}
+	return timing.FormatDuration(time.Duration(stats.AverageSeconds() * float64(time.Second)))
+}
+
+// Broken returns the number of broken engines on the machine
+func (m MachineStatus) Broken() int {
+	count := 0
+	for _, eng := range m.Engines {
+		if eng.State == StateBroken {
+			count++
+		}
+	}
+	return count
+}
+
+// Outdated returns the number of engines with updates pending
+func (m MachineStatus) Outdated() int {
+	count := 0
+	for _, eng := range m.Engines {
+		if eng.CommitsBehind > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// IsStale reports whether the status file is older than a week
+func (m MachineStatus) IsStale(now time.Time) bool {
+	collected, err := time.Parse(time.RFC3339, m.CollectedUTC)
+	if err != nil {
+		return true
+	}
+	return now.Sub(collected) > staleAfter
+}
+
+// Health returns a short label for the machine's overall state
+func (m MachineStatus) Health(now time.Time) string {
+	switch {
+	case m.Broken() > 0:
+		return "Broken"
+	case m.Outdated() > 0:
+		return "Updates pending"
+	case m.IsStale(now):
+		return "Stale report"
+	default:
+		return "OK"
+	}
+}
+
+// WriteFile writes a machine status document as JSON
+func WriteFile(path string, status MachineStatus) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadDir reads every machine status JSON file in a directory
+func LoadDir(dir string) ([]MachineStatus, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var machines []MachineStatus
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var status MachineStatus
+		if err := json.Unmarshal(data, &status); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if status.Machine == "" {
+			status.Machine = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		machines = append(machines, status)
+	}
+
+	sort.Slice(machines, func(i, j int) bool {
+		return strings.ToLower(machines[i].Machine) < strings.ToLower(machines[j].Machine)
+	})
+	return machines, nil
+}
+
+// RenderMarkdown renders a fleet summary as Markdown
+func RenderMarkdown(machines []MachineStatus, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# UE Git Plugin Manager report\n\n")
+	fmt.Fprintf(&b, "Generated %s — %d machine(s), %d need attention.\n\n", now.Format("2006-01-02 15:04"), len(machines), needingAttention(machines, now))
+
+	b.WriteString("| Machine | User | Collected | Engines | Broken | Updates pending | Avg build | Health |\n")
+	b.WriteString("|---|---|---|---|---|---|---|---|\n")
+	for _, m := range machines {
+		fmt.Fprintf(&b, "| %s | %s | %s | %d | %d | %d | %s | %s |\n",
+			m.Machine, m.User, formatTimestamp(m.CollectedUTC), len(m.Engines), m.Broken(), m.Outdated(), m.AverageBuild(), m.Health(now))
+	}
+
+	for _, m := range machines {
+		if m.Health(now) == "OK" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", m.Machine)
+		for _, eng := range m.Engines {
+			fmt.Fprintf(&b, "- UE %s (`%s`): %s", eng.Version, eng.Path, describeEngine(eng))
+			if len(eng.Issues) > 0 {
+				fmt.Fprintf(&b, " — %s", strings.Join(eng.Issues, "; "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// RenderHTML renders a fleet summary as a standalone HTML page suitable for email
+func RenderHTML(machines []MachineStatus, now time.Time) (string, error) {
+	funcs := template.FuncMap{
+		"health":    func(m MachineStatus) string { return m.Health(now) },
+		"timestamp": formatTimestamp,
+		"describe":  describeEngine,
+		"join":      strings.Join,
+	}
+	tmpl, err := template.New("report").Funcs(funcs).Parse(htmlTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	data := struct {
+		Generated string
+		Machines  []MachineStatus
+		Attention int
+	}{
+		Generated: now.Format("2006-01-02 15:04"),
+		Machines:  machines,
+		Attention: needingAttention(machines, now),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func needingAttention(machines []MachineStatus, now time.Time) int {
+	count := 0
+	for _, m := range machines {
+		if m.Health(now) != "OK" {
+			count++
+		}
+	}
+	return count
+}
+
+func describeEngine(eng EngineStatus) string {
+	switch eng.State {
+	case StateBroken:
+		return "broken"
+	case StateNotSetUp:
+		return "not set up"
+	}
+	if eng.CommitsBehind > 0 {
+		return fmt.Sprintf("%d commit(s) behind", eng.CommitsBehind)
+	}
+	return "up to date"
+}
+
+func formatTimestamp(value string) string {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return parsed.Local().Format("2006-01-02 15:04")
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>UE Git Plugin Manager report</title>
+<style>
+body { font-family: Segoe UI, Arial, sans-serif; font-size: 14px; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>UE Git Plugin Manager report</h1>
+<p>Generated {{.Generated}} &mdash; {{len .Machines}} machine(s), {{.Attention}} need attention.</p>
+<table>
+<tr><th>Machine</th><th>User</th><th>Collected</th><th>Engines</th><th>Broken</th><th>Updates pending</th><th>Avg build</th><th>Health</th></tr>
+{{range .Machines}}<tr><td>{{.Machine}}</td><td>{{.User}}</td><td>{{timestamp .CollectedUTC}}</td><td>{{len .Engines}}</td><td>{{.Broken}}</td><td>{{.Outdated}}</td><td>{{.AverageBuild}}</td><td>{{health .}}</td></tr>
+{{end}}</table>
+{{range .Machines}}{{if ne (health .) "OK"}}
+<h2>{{.Machine}}</h2>
+<ul>
+{{range .Engines}}<li>UE {{.Version}} (<code>{{.Path}}</code>): {{describe .}}{{if .Issues}} &mdash; {{join .Issues "; "}}{{end}}</li>
+{{end}}</ul>
+{{end}}{{end}}
+</body>
+</html>
+`
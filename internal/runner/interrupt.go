@@ -0,0 +1,65 @@
+package runner
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+
+	"ue-git-plugin-manager/internal/console"
+	"ue-git-plugin-manager/internal/events"
+)
+
+// ErrCancelled is returned when a command was stopped because the user pressed Ctrl+C
+var ErrCancelled = errors.New("cancelled with Ctrl+C")
+
+// Commands waiting for a slot or running, and the channel closed when Ctrl+C cancels them. A
+// fresh channel replaces it afterwards, so commands started later run as usual.
+var (
+	interruptMu sync.Mutex
+	interrupt   = make(chan struct{})
+	active      int
+	cancelling  bool
+)
+
+// HandleInterrupts makes Ctrl+C cancel the commands running at the time instead of ending the
+// tool, which would leave RunUAT's compilers and git running behind it. The operation that ran
+// them fails with ErrCancelled and the tool goes on. Ctrl+C with nothing running, or a second one
+// while commands are still being stopped, quits as before.
+func HandleInterrupts() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		for range signals {
+			interruptMu.Lock()
+			if active == 0 || cancelling {
+				interruptMu.Unlock()
+				console.Reset()
+				os.Exit(130)
+			}
+			cancelling = true
+			close(interrupt)
+			interrupt = make(chan struct{})
+			interruptMu.Unlock()
+			events.Warn("Cancelling... press Ctrl+C again to quit")
+		}
+	}()
+}
+
+// begin counts a command as active and returns the channel closed when Ctrl+C cancels it
+func begin() <-chan struct{} {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+	active++
+	return interrupt
+}
+
+// end stops counting a command; once none are left, Ctrl+C may quit again
+func end() {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+	active--
+	if active == 0 {
+		cancelling = false
+	}
+}
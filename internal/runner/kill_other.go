@@ -0,0 +1,16 @@
+//go:build !windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// isolate leaves the command in this tool's process group
+func isolate(cmd *exec.Cmd) {}
+
+// killTree stops a process
+func killTree(process *os.Process) {
+	process.Kill()
+}
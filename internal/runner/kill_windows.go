@@ -0,0 +1,22 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// isolate starts a command in its own process group, so Ctrl+C reaches only this tool, which
+// then stops the command and its children together instead of racing them to exit
+func isolate(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// killTree stops a process and everything it started; cmd /c and RunUAT leave children behind
+// when only the direct process is killed
+func killTree(process *os.Process) {
+	if exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(process.Pid)).Run() != nil {
+		process.Kill()
+	}
+}
@@ -0,0 +1,299 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kinds of commands. Each kind has its own concurrency limit and default timeout.
+const (
+	KindSystem = "system" // reg, cmd, tasklist and other short Windows tools
+	KindGit    = "git"
+	KindBuild  = "build" // RunUAT and the editor
+)
+
+// limits is how many commands of each kind may run at the same time. Builds use every core, so
+// only one runs at a time unless SetLimit allows more; git is limited so background checks don't
+// starve the foreground.
+var limits = map[string]int{
+	KindSystem: 8,
+	KindGit:    4,
+	KindBuild:  1,
+}
+
+// defaultTimeouts apply when a command sets none. Git and builds legitimately run for a long time
+// on slow networks and machines, so they have none until SetTimeout gives them the configured one.
+var (
+	timeoutsMu      sync.Mutex
+	defaultTimeouts = map[string]time.Duration{
+		KindSystem: 5 * time.Minute,
+	}
+)
+
+// SetTimeout changes how long commands of a kind may run when they set no timeout of their own;
+// zero means no limit
+func SetTimeout(kind string, timeout time.Duration) {
+	timeoutsMu.Lock()
+	defer timeoutsMu.Unlock()
+	defaultTimeouts[kind] = timeout
+}
+
+// defaultTimeout returns how long commands of a kind may run when they set no timeout
+func defaultTimeout(kind string) time.Duration {
+	timeoutsMu.Lock()
+	defer timeoutsMu.Unlock()
+	return defaultTimeouts[kind]
+}
+
+// slots holds one semaphore per kind; a command runs while it holds a slot of its kind
+var slots = func() map[string]*semaphore {
+	s := make(map[string]*semaphore)
+	for kind, limit := range limits {
+		s[kind] = newSemaphore(limit)
+	}
+	return s
+}()
+
+// SetLimit changes how many commands of a kind may run at the same time and returns the previous
+// limit. Commands already running keep their slot; when the limit shrinks, waiting commands
+// start once enough of them finished.
+func SetLimit(kind string, limit int) int {
+	if limit < 1 {
+		limit = 1
+	}
+	return slots[kind].setLimit(limit)
+}
+
+// semaphore hands out up to limit slots; unlike a buffered channel, its limit can change while
+// slots are held or awaited
+type semaphore struct {
+	mu      sync.Mutex
+	limit   int
+	used    int
+	changed chan struct{} // Closed and replaced whenever a slot frees up or the limit changes
+}
+
+func newSemaphore(limit int) *semaphore {
+	return &semaphore{limit: limit, changed: make(chan struct{})}
+}
+
+// acquire waits for a free slot. It returns false, without a slot, when stop or cancelled closes first.
+func (s *semaphore) acquire(stop <-chan struct{}, cancelled <-chan struct{}) bool {
+	for {
+		s.mu.Lock()
+		if s.used < s.limit {
+			s.used++
+			s.mu.Unlock()
+			return true
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-stop:
+			return false
+		case <-cancelled:
+			return false
+		}
+	}
+}
+
+// release gives a slot back and wakes the commands waiting for one
+func (s *semaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.used--
+	s.notify()
+}
+
+// setLimit changes the limit and returns the previous one
+func (s *semaphore) setLimit(limit int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.limit
+	s.limit = limit
+	s.notify()
+	return previous
+}
+
+// notify wakes every waiter to check for a slot again; the caller holds s.mu
+func (s *semaphore) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// ErrTimeout is returned when a command ran past its timeout and was stopped
+var ErrTimeout = errors.New("command timed out")
+
+// Command is an external program to run
+type Command struct {
+	Name    string
+	Args    []string
+	Dir     string
+	Env     []string      // Full environment; nil inherits this process's
+	Stdin   io.Reader     // Nil for no input
+	Stdout  io.Writer     // Streams stdout here instead of capturing it
+	Stderr  io.Writer     // Streams stderr here instead of capturing it
+	Kind    string        // KindSystem, KindGit or KindBuild; empty picks KindGit for git and KindSystem otherwise
+	Timeout time.Duration // Zero uses the kind's default; negative means none
+	Capture bool          // With Stdout or Stderr set, capture the output too (it is always captured otherwise)
+}
+
+// Result is the captured output of a finished command
+type Result struct {
+	Stdout   string
+	Stderr   string
+	Combined string // Stdout and stderr interleaved as they were written
+	Duration time.Duration
+}
+
+// Run executes a command, waiting for a free slot of its kind first. When ctx is cancelled, the
+// timeout passes or Ctrl+C is pressed, the command and every process it started are stopped.
+func Run(ctx context.Context, c Command) (Result, error) {
+	kind := c.Kind
+	if kind == "" {
+		kind = kindOf(c.Name)
+	}
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout(kind)
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cancelled := begin()
+	defer end()
+
+	slot := slots[kind]
+	if !slot.acquire(ctx.Done(), cancelled) {
+		if ctx.Err() != nil {
+			return Result{}, interruption(ctx, c, timeout)
+		}
+		return Result{}, fmt.Errorf("%s: %w", c.Name, ErrCancelled)
+	}
+	defer slot.release()
+
+	cmd := exec.Command(c.Name, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Env = c.Env
+	cmd.Stdin = c.Stdin
+	isolate(cmd)
+
+	// os/exec copies stdout and stderr in separate goroutines, and both may feed the combined
+	// buffer or one caller writer, so every write of this command goes through one lock
+	var stdout, stderr, combined bytes.Buffer
+	var output sync.Mutex
+	capture := c.Capture || (c.Stdout == nil && c.Stderr == nil)
+	cmd.Stdout = &lockedWriter{mu: &output, w: outputWriter(c.Stdout, capture, &stdout, &combined)}
+	cmd.Stderr = &lockedWriter{mu: &output, w: outputWriter(c.Stderr, capture, &stderr, &combined)}
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return Result{}, err
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		killTree(cmd.Process)
+		<-done
+		err = interruption(ctx, c, timeout)
+	case <-cancelled:
+		killTree(cmd.Process)
+		<-done
+		err = fmt.Errorf("%s: %w", c.Name, ErrCancelled)
+	}
+	return Result{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Combined: combined.String(),
+		Duration: time.Since(start),
+	}, err
+}
+
+// Output runs a command and returns its stdout, like exec.Cmd.Output
+func Output(name string, args ...string) (string, error) {
+	result, err := Run(context.Background(), Command{Name: name, Args: args})
+	return result.Stdout, err
+}
+
+// CombinedOutput runs a command and returns its stdout and stderr together, like exec.Cmd.CombinedOutput
+func CombinedOutput(name string, args ...string) (string, error) {
+	result, err := Run(context.Background(), Command{Name: name, Args: args})
+	return result.Combined, err
+}
+
+// Start launches a program that outlives this tool, such as the editor or a browser, and returns
+// without waiting. Such programs don't count against any limit.
+func Start(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Release the process so no handle is kept for its lifetime
+	return cmd.Process.Release()
+}
+
+// kindOf picks the kind of a command from its program name
+func kindOf(name string) string {
+	base := strings.ToLower(strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)))
+	if base == "git" {
+		return KindGit
+	}
+	return KindSystem
+}
+
+// outputWriter returns where one output stream of a command goes
+func outputWriter(stream io.Writer, capture bool, own, combined *bytes.Buffer) io.Writer {
+	var writers []io.Writer
+	if stream != nil {
+		writers = append(writers, stream)
+	}
+	if capture {
+		writers = append(writers, own, combined)
+	}
+	if len(writers) == 1 {
+		return writers[0]
+	}
+	return io.MultiWriter(writers...)
+}
+
+// lockedWriter serializes writes that share a mutex, so output streams copied in parallel can
+// feed the same buffer
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// interruption describes why a command was stopped
+func interruption(ctx context.Context, c Command, timeout time.Duration) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if timeout > 0 {
+			return fmt.Errorf("%s did not finish within %s: %w", c.Name, timeout, ErrTimeout)
+		}
+		return fmt.Errorf("%s did not finish in time: %w", c.Name, ErrTimeout)
+	}
+	return ctx.Err()
+}
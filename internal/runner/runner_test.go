@@ -0,0 +1,85 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// chatty returns a command that writes lines to stdout and stderr in turn
+func chatty(lines int) Command {
+	n := strconv.Itoa(lines)
+	if runtime.GOOS == "windows" {
+		return Command{Name: "cmd", Args: []string{"/c", "for /l %i in (1,1," + n + ") do @(echo out& echo err 1>&2)"}}
+	}
+	return Command{Name: "sh", Args: []string{"-c", "i=0; while [ $i -lt " + n + " ]; do echo out; echo err >&2; i=$((i+1)); done"}}
+}
+
+// syncBuffer is a caller writer that checks writes never overlap
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	if !b.mu.TryLock() {
+		panic("overlapping writes to a caller writer")
+	}
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func TestRunCapturesBothStreams(t *testing.T) {
+	const lines = 200
+	tests := []struct {
+		name    string
+		stream  bool // Stream both outputs to one caller writer
+		capture bool
+		want    bool // Whether the output is captured in the result
+	}{
+		{name: "captured", want: true},
+		{name: "streamed", stream: true},
+		{name: "streamed and captured", stream: true, capture: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := chatty(lines)
+			c.Capture = tt.capture
+			var streamed syncBuffer
+			if tt.stream {
+				c.Stdout, c.Stderr = &streamed, &streamed
+			}
+			result, err := Run(context.Background(), c)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if tt.stream {
+				if got := strings.Count(streamed.buf.String(), "out"); got != lines {
+					t.Errorf("streamed %d stdout lines, want %d", got, lines)
+				}
+				if got := strings.Count(streamed.buf.String(), "err"); got != lines {
+					t.Errorf("streamed %d stderr lines, want %d", got, lines)
+				}
+			}
+			if !tt.want {
+				if result.Stdout != "" || result.Stderr != "" || result.Combined != "" {
+					t.Errorf("captured output although only streaming was asked for")
+				}
+				return
+			}
+			if got := strings.Count(result.Stdout, "out"); got != lines {
+				t.Errorf("Stdout has %d lines, want %d", got, lines)
+			}
+			if got := strings.Count(result.Stderr, "err"); got != lines {
+				t.Errorf("Stderr has %d lines, want %d", got, lines)
+			}
+			if got := strings.Count(result.Combined, "out") + strings.Count(result.Combined, "err"); got != 2*lines {
+				t.Errorf("Combined has %d lines, want %d", got, 2*lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package secret
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// prefix marks values that were encrypted for the current Windows user
+const prefix = "dpapi:"
+
+// IsProtected reports whether a stored value is encrypted
+func IsProtected(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Protect encrypts a value for storage; empty values stay empty
+func Protect(plain string) (string, error) {
+	if plain == "" || IsProtected(plain) {
+		return plain, nil
+	}
+	encrypted, err := encrypt([]byte(plain))
+	if err != nil {
+		return "", err
+	}
+	if encrypted == nil {
+		// No protection available on this platform
+		return plain, nil
+	}
+	return prefix + base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// Unprotect decrypts a stored value; values without the prefix are returned unchanged
+// so hand-edited plaintext entries keep working
+func Unprotect(stored string) (string, error) {
+	if !IsProtected(stored) {
+		return stored, nil
+	}
+	encrypted, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, prefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %v", err)
+	}
+	plain, err := decrypt(encrypted)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package secret
+
+import "errors"
+
+// encrypt returns nil on platforms without DPAPI; values are stored as plaintext
+func encrypt(data []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func decrypt(data []byte) ([]byte, error) {
+	return nil, errors.New("encrypted values can only be read on Windows")
+}
@@ -0,0 +1,46 @@
+package secret
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// encrypt protects data with DPAPI so only the current Windows user can read it
+func encrypt(data []byte) ([]byte, error) {
+	in := newBlob(data)
+	var out windows.DataBlob
+	if err := windows.CryptProtectData(in, nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		return nil, fmt.Errorf("CryptProtectData failed: %v", err)
+	}
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(out.Data)))
+	return copyBlob(out), nil
+}
+
+// decrypt reverses encrypt; it fails for data protected by another user or machine
+func decrypt(data []byte) ([]byte, error) {
+	in := newBlob(data)
+	var out windows.DataBlob
+	if err := windows.CryptUnprotectData(in, nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		return nil, fmt.Errorf("CryptUnprotectData failed (was the value saved by another user?): %v", err)
+	}
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(out.Data)))
+	return copyBlob(out), nil
+}
+
+func newBlob(data []byte) *windows.DataBlob {
+	if len(data) == 0 {
+		return &windows.DataBlob{}
+	}
+	return &windows.DataBlob{Size: uint32(len(data)), Data: &data[0]}
+}
+
+func copyBlob(blob windows.DataBlob) []byte {
+	if blob.Size == 0 {
+		return []byte{}
+	}
+	out := make([]byte, blob.Size)
+	copy(out, unsafe.Slice(blob.Data, blob.Size))
+	return out
+}
@@ -0,0 +1,217 @@
+package selfupdate
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is this build's version, set by build.bat from the VERSION file with
+// -ldflags "-X ue-git-plugin-manager/internal/selfupdate.Version=<version>"
+var Version = "dev"
+
+// releaseAPI describes the latest release of this tool
+const releaseAPI = "https://api.github.com/repos/benjavides/ue-git-plugin-manager/releases/latest"
+
+// exePrefix starts the name of the exe attached to every release, e.g.
+// UE-Git-Plugin-Manager-v1.0.8.exe
+const exePrefix = "UE-Git-Plugin-Manager-"
+
+// Release is the latest published version of the tool and the exe to download for it
+type Release struct {
+	Version  string // Without the leading "v"
+	PageURL  string // Release notes
+	ExeName  string
+	exeURL   string
+	checksum string // SHA-256 of the exe, hex; empty until read from the release
+	sumsURL  string // <exe>.sha256 asset, read when the release has no digest
+}
+
+// githubRelease is the part of the GitHub releases API response used here
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Assets  []struct {
+		Name        string `json:"name"`
+		DownloadURL string `json:"browser_download_url"`
+		Digest      string `json:"digest"` // "sha256:<hex>" on current GitHub releases
+	} `json:"assets"`
+}
+
+// Latest looks up the latest release. A release without a checksum for its exe is an error:
+// an exe that can't be verified is never installed.
+func Latest() (*Release, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(releaseAPI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub returned %s for the latest release", resp.Status)
+	}
+	var latest githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		return nil, fmt.Errorf("failed to read the latest release: %v", err)
+	}
+
+	release := &Release{Version: strings.TrimPrefix(latest.TagName, "v"), PageURL: latest.HTMLURL}
+	for _, asset := range latest.Assets {
+		if strings.HasPrefix(asset.Name, exePrefix) && strings.HasSuffix(strings.ToLower(asset.Name), ".exe") {
+			release.ExeName, release.exeURL = asset.Name, asset.DownloadURL
+			release.checksum, _ = strings.CutPrefix(asset.Digest, "sha256:")
+		}
+	}
+	if release.exeURL == "" {
+		return nil, fmt.Errorf("release %s has no exe to download", latest.TagName)
+	}
+	for _, asset := range latest.Assets {
+		if strings.EqualFold(asset.Name, release.ExeName+".sha256") {
+			release.sumsURL = asset.DownloadURL
+		}
+	}
+	if release.checksum == "" && release.sumsURL == "" {
+		return nil, fmt.Errorf("release %s has no checksum to verify the download against", latest.TagName)
+	}
+	return release, nil
+}
+
+// IsNewer reports whether the release is a later version than this build. Development builds
+// have no version to compare, so they are never offered an update.
+func (r *Release) IsNewer() bool {
+	return Version != "dev" && compareVersions(r.Version, Version) > 0
+}
+
+// Apply replaces the exe at exePath with the release's: it downloads the new exe next to it,
+// verifies its SHA-256, and swaps the two. Windows lets a running exe be renamed but not
+// overwritten, so the running one is moved aside to <exe>.old and removed on the next start.
+func (r *Release) Apply(exePath string) error {
+	client := &http.Client{Timeout: 10 * time.Minute}
+	want := r.checksum
+	if want == "" {
+		sum, err := readChecksum(client, r.sumsURL)
+		if err != nil {
+			return err
+		}
+		want = sum
+	}
+
+	newPath := exePath + ".new"
+	oldPath := exePath + ".old"
+	got, err := download(client, r.exeURL, newPath)
+	if err != nil {
+		os.Remove(newPath)
+		return fmt.Errorf("failed to download %s: %v", r.ExeName, err)
+	}
+	if !strings.EqualFold(got, want) {
+		os.Remove(newPath)
+		return fmt.Errorf("checksum of %s does not match the release; nothing was changed", r.ExeName)
+	}
+
+	os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		os.Remove(newPath)
+		return fmt.Errorf("failed to move the running exe aside: %v", err)
+	}
+	if err := os.Rename(newPath, exePath); err != nil {
+		// Put the working exe back so the tool still starts
+		os.Rename(oldPath, exePath)
+		os.Remove(newPath)
+		return fmt.Errorf("failed to install the new exe: %v", err)
+	}
+	return nil
+}
+
+// CleanUp removes the exe an update moved aside, once the new one runs
+func CleanUp(exePath string) {
+	os.Remove(exePath + ".old")
+	os.Remove(exePath + ".new")
+}
+
+// CanApply reports why the exe at exePath can't replace itself, or nil when it can. Installed
+// copies, e.g. under Program Files or from MSIX, are updated by their installer instead.
+func CanApply(exePath string) error {
+	dir := filepath.Dir(exePath)
+	probe, err := os.CreateTemp(dir, ".selfupdate-*")
+	if err != nil {
+		return fmt.Errorf("%s can't be written to; update this copy with the installer it came from", dir)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return nil
+}
+
+// download saves a file and returns its hex SHA-256
+func download(client *http.Client, url, path string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download returned %s", resp.Status)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), resp.Body); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readChecksum reads the hash from a .sha256 file, whose first word is the hex SHA-256
+func readChecksum(client *http.Client, url string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checksum download returned %s", resp.Status)
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	if scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 && len(fields[0]) == 64 {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("the release's checksum file is not a SHA-256")
+}
+
+// compareVersions compares dotted versions such as 1.0.10 and 1.0.9 number by number
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x > y {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package selfupdate
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.10", "1.0.9", 1},
+		{"1.0.9", "1.0.10", -1},
+		{"2.0", "1.9.9", 1},
+		{"1.0", "1.0.0", 0},
+		{"1.0", "1.0.1", -1},
+		{"1.1.0", "1.0", 1},
+		{"0.9", "1", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	defer func(version string) { Version = version }(Version)
+	tests := []struct {
+		build, release string
+		want           bool
+	}{
+		{"1.0.8", "1.0.9", true},
+		{"1.0.9", "1.0.10", true},
+		{"1.0.10", "1.0.9", false},
+		{"1.0.9", "1.0.9", false},
+		{"dev", "9.9.9", false}, // Development builds are never offered an update
+	}
+	for _, tt := range tests {
+		Version = tt.build
+		if got := (&Release{Version: tt.release}).IsNewer(); got != tt.want {
+			t.Errorf("build %s, release %s: IsNewer() = %v, want %v", tt.build, tt.release, got, tt.want)
+		}
+	}
+}
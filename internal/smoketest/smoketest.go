@@ -0,0 +1,181 @@
+package smoketest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/runner"
+)
+
+// Timeout bounds the editor run; loading a large project's modules can take several minutes
+const Timeout = 10 * time.Minute
+
+// maxLogLines caps how many source control log lines are kept for the report
+const maxLogLines = 20
+
+// maxLineLength is the longest editor output line that is looked at
+const maxLineLength = 1024 * 1024
+
+// remoteTimeout bounds the remote check; a remote that doesn't answer by then isn't usable
+const remoteTimeout = time.Minute
+
+// providerInitialized matches the line the editor logs once the Git provider is up, e.g.
+// "LogSourceControl: Display: Source control provider changed to Git" or the plugin's own
+// "LogSourceControl: Git version ... initialized"
+var providerInitialized = regexp.MustCompile(`(?i)LogSourceControl:.*\bGit\b.*\b(initiali[sz]ed|changed to|enabled)\b|LogSourceControl:.*\b(initiali[sz]ed|changed to|enabled)\b.*\bGit\b`)
+
+// Result is the outcome of a smoke test
+type Result struct {
+	ProviderOK bool
+	RemoteOK   bool
+	Detail     string
+	LogLines   []string // LogSourceControl lines the editor printed
+}
+
+// Check returns the result in the form stored in the configuration
+func (r Result) Check(engineVersion string) config.SourceControlCheck {
+	return config.SourceControlCheck{
+		CheckedUTC:    time.Now().UTC().Format(time.RFC3339),
+		EngineVersion: engineVersion,
+		ProviderOK:    r.ProviderOK,
+		RemoteOK:      r.RemoteOK,
+		Detail:        r.Detail,
+	}
+}
+
+// Run starts the engine's UnrealEditor-Cmd.exe in commandlet mode against a project with the Git
+// provider selected, and confirms from its log that the provider initialized without errors. It
+// then checks that the project's remote answers, as the editor does when it connects.
+func Run(enginePath, uprojectPath string) Result {
+	var result Result
+	var problems []string
+
+	log, err := runEditor(enginePath, uprojectPath)
+	result.LogLines = log.lines
+	switch {
+	case err != nil:
+		problems = append(problems, err.Error())
+	case log.errorLine != "":
+		problems = append(problems, "provider reported: "+strings.TrimSpace(log.errorLine[strings.Index(log.errorLine, "Error:")+len("Error:"):]))
+	case !log.initialized:
+		problems = append(problems, "the editor did not initialize the Git source control provider; is the Git plugin enabled for this engine?")
+	default:
+		result.ProviderOK = true
+	}
+
+	if err := checkRemote(filepath.Dir(uprojectPath)); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		result.RemoteOK = true
+	}
+
+	result.Detail = strings.Join(problems, "; ")
+	return result
+}
+
+// runEditor runs the SmokeTest commandlet, a short run that still loads the editor modules,
+// and returns what its log said about source control
+func runEditor(enginePath, uprojectPath string) (*sourceControlLog, error) {
+	log := &sourceControlLog{}
+	editorCmd := filepath.Join(enginePath, "Engine", "Binaries", "Win64", "UnrealEditor-Cmd.exe")
+	if _, err := os.Stat(editorCmd); err != nil {
+		return log, fmt.Errorf("UnrealEditor-Cmd.exe not found in %s", filepath.Dir(editorCmd))
+	}
+
+	// The editor's log is filtered as it streams rather than captured, since it runs to many
+	// megabytes. It isn't a plugin build, so it doesn't wait for the build slot.
+	_, err := runner.Run(context.Background(), runner.Command{
+		Name:    editorCmd,
+		Args:    []string{uprojectPath, "-run=SmokeTest", "-SCCProvider=Git", "-unattended", "-nopause", "-nullrhi", "-nosplash", "-stdout", "-FullStdOutLogOutput"},
+		Dir:     filepath.Dir(uprojectPath),
+		Stdout:  log,
+		Stderr:  log,
+		Kind:    runner.KindSystem,
+		Timeout: Timeout,
+	})
+	log.flush()
+
+	if errors.Is(err, runner.ErrTimeout) {
+		return log, fmt.Errorf("the editor did not finish within %s", Timeout)
+	}
+	// The commandlet's own exit code reflects its tests, not source control, so it is not an error here
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return log, fmt.Errorf("the editor failed: %v", err)
+	}
+	return log, nil
+}
+
+// sourceControlLog keeps the LogSourceControl lines of the output written to it, and notes
+// whether the Git provider initialized and the first error it reported
+type sourceControlLog struct {
+	partial     []byte
+	lines       []string
+	initialized bool
+	errorLine   string
+}
+
+func (l *sourceControlLog) Write(p []byte) (int, error) {
+	l.partial = append(l.partial, p...)
+	for {
+		i := bytes.IndexByte(l.partial, '\n')
+		if i < 0 {
+			break
+		}
+		l.add(string(l.partial[:i]))
+		l.partial = l.partial[i+1:]
+	}
+	// A line this long is not a log line worth keeping
+	if len(l.partial) > maxLineLength {
+		l.partial = l.partial[:0]
+	}
+	return len(p), nil
+}
+
+// flush keeps the last line when the output didn't end with a newline
+func (l *sourceControlLog) flush() {
+	l.add(string(l.partial))
+	l.partial = nil
+}
+
+func (l *sourceControlLog) add(line string) {
+	if !strings.Contains(line, "LogSourceControl") {
+		return
+	}
+	if len(l.lines) < maxLogLines {
+		l.lines = append(l.lines, strings.TrimSpace(line))
+	}
+	if providerInitialized.MatchString(line) {
+		l.initialized = true
+	}
+	if l.errorLine == "" && strings.Contains(line, "Error:") {
+		l.errorLine = line
+	}
+}
+
+// checkRemote confirms the project's repository has a remote that answers
+func checkRemote(root string) error {
+	result, err := runner.Run(context.Background(), runner.Command{
+		Name:    "git",
+		Args:    []string{"-C", root, "ls-remote", "--heads", "origin"},
+		Env:     append(os.Environ(), "GIT_TERMINAL_PROMPT=0"),
+		Timeout: remoteTimeout,
+	})
+	if err != nil {
+		message := strings.TrimSpace(result.Combined)
+		if message == "" {
+			message = err.Error()
+		}
+		return fmt.Errorf("remote origin is not reachable: %s", message)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"ue-git-plugin-manager/internal/issues"
+	"ue-git-plugin-manager/internal/logging"
+	"ue-git-plugin-manager/internal/timing"
+	"ue-git-plugin-manager/internal/utils"
+)
+
+// Operations whose outcome is reported
+const (
+	OpSetup  = "setup"
+	OpUpdate = "update"
+	OpRepair = "repair"
+)
+
+// Outcomes of an operation
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+)
+
+// maxQueued bounds the queue while the endpoint can't be reached; the oldest events are dropped
+const maxQueued = 500
+
+// Several instances of the tool may report at once; the queue file is locked while it changes
+const (
+	queueLockWait  = 5 * time.Second
+	queueLockStale = 30 * time.Second
+)
+
+var (
+	// sending is held by the background send in progress, so events are posted once
+	sending sync.Mutex
+	// inFlight counts background sends, so the process can give them a moment before exiting
+	inFlight sync.WaitGroup
+)
+
+// Event is the outcome of one operation on one engine. It holds nothing that identifies the
+// machine, its user, paths or the plugin repository, only what the tool's maintainers need to
+// see which failures are common.
+type Event struct {
+	Operation     string             `json:"operation"`
+	EngineVersion string             `json:"engine_version"`
+	Outcome       string             `json:"outcome"`
+	Failure       string             `json:"failure,omitempty"` // Category, e.g. "build/timeout"; never the error text
+	StepSeconds   map[string]float64 `json:"step_seconds,omitempty"`
+	Day           string             `json:"day"` // UTC date, without the time of day
+}
+
+// NewEvent describes an operation from its recorded steps and the error it ended with
+func NewEvent(operation, engineVersion string, steps []timing.StepDuration, err error) Event {
+	event := Event{
+		Operation:     operation,
+		EngineVersion: engineVersion,
+		Outcome:       OutcomeSucceeded,
+		StepSeconds:   map[string]float64{},
+		Day:           time.Now().UTC().Format("2006-01-02"),
+	}
+	for _, step := range steps {
+		event.StepSeconds[step.Name] += step.Duration.Round(time.Second).Seconds()
+	}
+	if err != nil {
+		event.Outcome = OutcomeFailed
+		event.Failure = issues.Category(steps, err)
+	}
+	return event
+}
+
+// Queue adds an event to the file of events not sent yet
+func Queue(path string, event Event) error {
+	unlock, err := utils.AcquireLock(path, queueLockWait, queueLockStale)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	events, err := loadQueue(path)
+	if err != nil {
+		// A damaged queue is only lost metrics; start over rather than failing the operation
+		events = nil
+	}
+	events = append(events, event)
+	if len(events) > maxQueued {
+		events = events[len(events)-maxQueued:]
+	}
+	return saveQueue(path, events)
+}
+
+// SendInBackground sends the queue without holding up the operation that just reported. Failing
+// to send is only logged; the events are sent with the next report. A send already in progress
+// picks up the queue, so none is started then.
+func SendInBackground(endpoint, path string) {
+	if !sending.TryLock() {
+		return
+	}
+	inFlight.Add(1)
+	go func() {
+		defer inFlight.Done()
+		defer sending.Unlock()
+		if err := Send(endpoint, path); err != nil {
+			logging.Printf("telemetry: %d event(s) kept for later: %v", Pending(path), err)
+		}
+	}()
+}
+
+// Wait gives background sends up to timeout to finish before the process exits. Events of a send
+// cut short stay queued.
+func Wait(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+// Send posts the queued events to the endpoint as {"events": [...]} and removes them from the
+// queue once the endpoint accepted them. Events stay queued for the next attempt otherwise.
+func Send(endpoint, path string) error {
+	events, err := lockedLoad(path)
+	if err != nil || len(events) == 0 {
+		return err
+	}
+	body, err := json.Marshal(struct {
+		Events []Event `json:"events"`
+	}{events})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Kept short: metrics must never hold up the tool noticeably
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint returned %s", resp.Status)
+	}
+	return removeSent(path, len(events))
+}
+
+// Pending returns the number of events waiting to be sent
+func Pending(path string) int {
+	events, _ := lockedLoad(path)
+	return len(events)
+}
+
+// Discard deletes the queued events, e.g. when telemetry is turned off
+func Discard(path string) error {
+	unlock, err := utils.AcquireLock(path, queueLockWait, queueLockStale)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// removeSent drops the sent events from the front of the queue, keeping those queued since
+func removeSent(path string, sent int) error {
+	unlock, err := utils.AcquireLock(path, queueLockWait, queueLockStale)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	events, err := loadQueue(path)
+	if err != nil {
+		return err
+	}
+	if sent >= len(events) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return saveQueue(path, events[sent:])
+}
+
+// lockedLoad reads the queued events while no other instance is changing them
+func lockedLoad(path string) ([]Event, error) {
+	unlock, err := utils.AcquireLock(path, queueLockWait, queueLockStale)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return loadQueue(path)
+}
+
+// loadQueue reads the queued events, returning none if the file doesn't exist
+func loadQueue(path string) ([]Event, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var events []Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return events, nil
+}
+
+// saveQueue writes the queued events
+func saveQueue(path string, events []Event) error {
+	data, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,129 @@
+package theme
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+
+	"ue-git-plugin-manager/internal/console"
+)
+
+// Palette names accepted in the color_theme config field
+const (
+	Default    = "default"
+	ColorBlind = "colorblind"
+	NoColor    = "none"
+)
+
+// Names lists the palettes in the order they are offered in Settings
+var Names = []string{Default, ColorBlind, NoColor}
+
+// Kind is the meaning of a status line, which decides its color and symbol
+type Kind int
+
+const (
+	OK Kind = iota
+	Info
+	Warning
+	Failure
+	Unknown
+)
+
+type palette map[Kind]color.Attribute
+
+var palettes = map[string]palette{
+	Default: {OK: color.FgGreen, Info: color.FgBlue, Warning: color.FgYellow, Failure: color.FgRed, Unknown: color.FgWhite},
+	// Blue/orange/magenta stay distinct for red-green color blindness; yellow stands in for orange on 16-color consoles
+	ColorBlind: {OK: color.FgHiBlue, Info: color.FgHiCyan, Warning: color.FgHiYellow, Failure: color.FgHiMagenta, Unknown: color.FgWhite},
+}
+
+var emojiSymbols = map[Kind]string{OK: "✅", Info: "ℹ️ ", Warning: "⚠️ ", Failure: "❌", Unknown: "❓"}
+
+// Text symbols are all the same width and readable without any color at all
+var textSymbols = map[Kind]string{OK: "[OK]", Info: "[--]", Warning: "[!!]", Failure: "[XX]", Unknown: "[??]"}
+
+var (
+	current      = Default
+	textOnly     bool
+	savedNoColor = color.NoColor
+)
+
+// Apply selects the palette and whether status symbols are shown as text instead of emoji
+func Apply(name string, textStatusSymbols bool) {
+	current = Normalize(name)
+	textOnly = textStatusSymbols
+	color.NoColor = savedNoColor || current == NoColor
+}
+
+// Normalize returns a known palette name, falling back to the default
+func Normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, known := range Names {
+		if name == known {
+			return known
+		}
+	}
+	return Default
+}
+
+// Current returns the active palette name
+func Current() string {
+	return current
+}
+
+// TextSymbols reports whether status symbols are shown as text
+func TextSymbols() bool {
+	return textOnly
+}
+
+// Describe returns a short human readable description of a palette
+func Describe(name string) string {
+	switch Normalize(name) {
+	case ColorBlind:
+		return "Color-blind safe (blue / orange / magenta)"
+	case NoColor:
+		return "No color"
+	default:
+		return "Default (green / yellow / red)"
+	}
+}
+
+// Symbol returns the status symbol for kind
+func Symbol(kind Kind) string {
+	if textOnly || !console.UTF8() {
+		return textSymbols[kind]
+	}
+	return emojiSymbols[kind]
+}
+
+// Label returns text prefixed with the status symbol for kind, without color
+func Label(kind Kind, text string) string {
+	return Symbol(kind) + " " + text
+}
+
+// Colorize returns text in the palette's color for kind
+func Colorize(kind Kind, text string) string {
+	attr, ok := palettes[current][kind]
+	if !ok {
+		return text
+	}
+	return color.New(attr).Sprint(text)
+}
+
+// Subdued returns text dimmed, for notes that should not draw attention
+func Subdued(text string) string {
+	return color.New(color.Faint).Sprint(text)
+}
+
+// Status returns text prefixed with the status symbol and colored for kind
+func Status(kind Kind, text string) string {
+	return Colorize(kind, Label(kind, text))
+}
+
+// YesNo returns a Yes/No status label for a check result
+func YesNo(ok bool) string {
+	if ok {
+		return Label(OK, "Yes")
+	}
+	return Label(Failure, "No")
+}
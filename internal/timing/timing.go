@@ -0,0 +1,184 @@
+package timing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"ue-git-plugin-manager/internal/events"
+)
+
+// Step names shared by setup, update and repair
+const (
+	StepClone    = "clone"
+	StepWorktree = "worktree"
+	StepJunction = "junction"
+	StepBuild    = "build"
+)
+
+// StepDuration is how long a single step took during one operation
+type StepDuration struct {
+	Name     string
+	Duration time.Duration
+	Failed   bool
+	Err      error // Why the step failed, as returned by it
+}
+
+// Recorder measures the steps of one operation
+type Recorder struct {
+	steps  []StepDuration
+	all    []StepDuration
+	report events.Reporter // Emits the step events, with the operation's scope when it has one
+}
+
+// NewRecorder creates an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// SetScope names what the operation is about in its step events, e.g. "UE 5.4" while several
+// engines build at the same time
+func (r *Recorder) SetScope(scope string) {
+	r.report = events.Reporter{Scope: scope}
+}
+
+// Time runs fn and records its duration under the given step name. The step's start and end are
+// also emitted as events.
+func (r *Recorder) Time(step string, fn func() error) error {
+	r.report.StepStarted(step, "")
+	start := time.Now()
+	err := fn()
+	duration := StepDuration{Name: step, Duration: time.Since(start), Failed: err != nil, Err: err}
+	r.steps = append(r.steps, duration)
+	r.all = append(r.all, duration)
+	r.report.StepFinished(step, time.Since(start), err)
+	return err
+}
+
+// Steps returns the recorded steps in the order they ran
+func (r *Recorder) Steps() []StepDuration {
+	return r.steps
+}
+
+// All returns every step recorded, including those cleared by Reset
+func (r *Recorder) All() []StepDuration {
+	return r.all
+}
+
+// Merge adds the steps another recorder measured, e.g. for an engine built on its own goroutine
+func (r *Recorder) Merge(other *Recorder) {
+	r.steps = append(r.steps, other.steps...)
+	r.all = append(r.all, other.all...)
+}
+
+// Reset clears the recorded steps once they have been reported
+func (r *Recorder) Reset() {
+	r.steps = nil
+}
+
+// PrintSummary prints how long each step took
+func (r *Recorder) PrintSummary() {
+	if len(r.steps) == 0 {
+		return
+	}
+	var total time.Duration
+	fmt.Println("⏱️  Step timings:")
+	for _, step := range r.steps {
+		suffix := ""
+		if step.Failed {
+			suffix = " (failed)"
+		}
+		fmt.Printf("   %-10s %s%s\n", step.Name, FormatDuration(step.Duration), suffix)
+		total += step.Duration
+	}
+	fmt.Printf("   %-10s %s\n", "total", FormatDuration(total))
+}
+
+// Stats aggregates the successful runs of one step on this machine
+type Stats struct {
+	Count        int     `json:"count"`
+	TotalSeconds float64 `json:"total_seconds"`
+	MaxSeconds   float64 `json:"max_seconds"`
+	LastSeconds  float64 `json:"last_seconds"`
+	LastUTC      string  `json:"last_utc"`
+}
+
+// AverageSeconds returns the mean duration of the step
+func (s Stats) AverageSeconds() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalSeconds / float64(s.Count)
+}
+
+// Store holds step aggregates keyed by step name
+type Store map[string]Stats
+
+// Add folds the successful steps of a recorder into the aggregates
+func (s Store) Add(r *Recorder) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, step := range r.steps {
+		if step.Failed {
+			continue
+		}
+		seconds := step.Duration.Seconds()
+		stats := s[step.Name]
+		stats.Count++
+		stats.TotalSeconds += seconds
+		stats.LastSeconds = seconds
+		stats.LastUTC = now
+		if seconds > stats.MaxSeconds {
+			stats.MaxSeconds = seconds
+		}
+		s[step.Name] = stats
+	}
+}
+
+// Names returns the step names in alphabetical order
+func (s Store) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load reads the aggregates file, returning an empty store if it doesn't exist yet
+func Load(path string) (Store, error) {
+	store := Store{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return store, nil
+}
+
+// Save writes the aggregates file
+func Save(path string, store Store) error {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// FormatDuration formats a duration rounded for display, e.g. "4m12s"
+func FormatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,79 @@
+package utils
+
+import "fmt"
+
+// Confirmation policies, set in Settings or with --yes for one run
+const (
+	// ConfirmAlways asks before every step of an operation
+	ConfirmAlways = "always"
+	// ConfirmDestructiveOnly only asks before something is deleted or discarded
+	ConfirmDestructiveOnly = "destructive"
+	// ConfirmNever answers every confirmation with yes; it is only set by --yes
+	ConfirmNever = "never"
+)
+
+var (
+	confirmPolicy = ConfirmAlways
+	assumeYes     bool
+)
+
+// SetConfirmPolicy applies the configured policy; an empty or unknown value asks every time
+func SetConfirmPolicy(policy string) {
+	if policy != ConfirmDestructiveOnly {
+		policy = ConfirmAlways
+	}
+	confirmPolicy = policy
+}
+
+// SetAssumeYes answers every confirmation with yes for this run, whatever the configured policy
+func SetAssumeYes(enabled bool) {
+	assumeYes = enabled
+}
+
+// ConfirmPolicy returns the policy in effect
+func ConfirmPolicy() string {
+	if assumeYes {
+		return ConfirmNever
+	}
+	return confirmPolicy
+}
+
+// SkipsRoutineConfirmations reports whether routine steps go ahead without asking
+func SkipsRoutineConfirmations() bool {
+	return ConfirmPolicy() != ConfirmAlways
+}
+
+// ConfirmStep asks before a routine step of an update, repair or setup, such as re-linking an
+// engine. Under the destructive-only policy it goes ahead and says so.
+func ConfirmStep(message string) bool {
+	if SkipsRoutineConfirmations() {
+		fmt.Printf("%s yes (%s)\n", message, describePolicy())
+		return true
+	}
+	return Confirm(message)
+}
+
+// ConfirmDestructive asks before deleting or discarding something that can't be rebuilt, such
+// as uncommitted edits or the tool's own data. Only --yes skips it; prompt_defaults never answer
+// it, so Enter always means no.
+func ConfirmDestructive(message string) bool {
+	if assumeYes {
+		fmt.Printf("%s yes (--yes)\n", message)
+		return true
+	}
+	return confirm(message, false)
+}
+
+// ConfirmConsent asks for the user's own agreement, such as to sending data off the machine.
+// Neither --yes nor prompt_defaults answer it, and Enter means no.
+func ConfirmConsent(message string) bool {
+	return confirm(message, false)
+}
+
+// describePolicy says why a question was answered without asking
+func describePolicy() string {
+	if assumeYes {
+		return "--yes"
+	}
+	return "only destructive steps are confirmed"
+}
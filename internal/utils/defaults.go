@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+// promptDefault is a configured answer and the prompt texts it applies to
+type promptDefault struct {
+	key     string
+	pattern *regexp.Regexp
+	answer  string
+}
+
+var promptDefaults []promptDefault
+
+// SetPromptDefaults applies the answers preselected for prompts, keyed by the prompt's text as
+// shown, where * stands for any text, e.g. "Register this project*". Matching ignores case;
+// a key without * wins over patterns, and a longer pattern over a shorter one.
+func SetPromptDefaults(defaults map[string]string) {
+	promptDefaults = nil
+	for key, answer := range defaults {
+		expr := "(?is)^" + strings.ReplaceAll(regexp.QuoteMeta(strings.TrimSpace(key)), `\*`, ".*") + "$"
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			continue
+		}
+		promptDefaults = append(promptDefaults, promptDefault{key: key, pattern: pattern, answer: strings.TrimSpace(answer)})
+	}
+	sort.SliceStable(promptDefaults, func(i, j int) bool {
+		iWild, jWild := strings.Contains(promptDefaults[i].key, "*"), strings.Contains(promptDefaults[j].key, "*")
+		if iWild != jWild {
+			return !iWild
+		}
+		if len(promptDefaults[i].key) != len(promptDefaults[j].key) {
+			return len(promptDefaults[i].key) > len(promptDefaults[j].key)
+		}
+		return promptDefaults[i].key < promptDefaults[j].key
+	})
+}
+
+// PromptDefault returns the answer configured for a prompt text
+func PromptDefault(label string) (string, bool) {
+	label = strings.TrimSpace(label)
+	for _, def := range promptDefaults {
+		if def.pattern.MatchString(label) {
+			return def.answer, true
+		}
+	}
+	return "", false
+}
+
+// PreselectDefault moves a choice prompt's cursor to the configured answer for its label, so
+// Enter accepts it. The answer matches an item by its full text or by how it starts.
+func PreselectDefault(prompt *promptui.Select) {
+	label, _ := prompt.Label.(string)
+	items, _ := prompt.Items.([]string)
+	answer, ok := PromptDefault(label)
+	if !ok || answer == "" {
+		return
+	}
+	if index := matchItem(items, answer); index >= 0 {
+		prompt.CursorPos = index
+	}
+}
+
+// matchItem returns the item an answer names, preferring a full match over a prefix
+func matchItem(items []string, answer string) int {
+	for i, item := range items {
+		if strings.EqualFold(strings.TrimSpace(item), answer) {
+			return i
+		}
+	}
+	lower := strings.ToLower(answer)
+	for i, item := range items {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(item)), lower) {
+			return i
+		}
+	}
+	return -1
+}
+
+// confirmDefault returns whether a yes/no question is answered yes when Enter is pressed
+func confirmDefault(message string) bool {
+	answer, ok := PromptDefault(message)
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes", "true":
+		return true
+	}
+	return false
+}
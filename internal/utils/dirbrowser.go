@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+const (
+	browseUse   = "✅ Use this folder"
+	browseUp    = "⬆️  Up one level"
+	browseTyped = "⌨️  Type or paste a path"
+)
+
+// BrowseDirectory lets the user pick a folder by walking from the drives down with the arrow
+// keys, typing to filter the folders listed. "Type or paste a path" switches to the typed
+// prompt, so a path copied from Explorer still works. start opens the browser in that folder
+// instead of the drive list. An empty path is returned when the user backs out.
+func BrowseDirectory(typed PathPrompt, start string) (string, error) {
+	current := ""
+	if start != "" && ValidateDirectory(start) == nil {
+		current = filepath.Clean(start)
+	}
+
+	for {
+		var items []string
+		if current != "" {
+			items = append(items, browseUse, browseUp)
+		}
+		items = append(items, browseTyped)
+		fixed := len(items)
+
+		var folders []string
+		if current == "" {
+			folders = Drives()
+		} else {
+			var err error
+			folders, err = subfolders(current)
+			if err != nil {
+				fmt.Printf("⚠️  Could not list %s: %v\n", current, err)
+			}
+		}
+		for _, folder := range folders {
+			items = append(items, "📁 "+folder)
+		}
+
+		label := "Select a drive"
+		if current != "" {
+			label = current
+		}
+		prompt := promptui.Select{
+			Label:    label,
+			Items:    items,
+			Size:     15,
+			HideHelp: true,
+			Searcher: func(input string, index int) bool {
+				return strings.Contains(strings.ToLower(items[index]), strings.ToLower(strings.TrimSpace(input)))
+			},
+			Stdout: &BellSkipper{},
+		}
+		index, choice, err := RunSelect(&prompt)
+		if err != nil {
+			if err == promptui.ErrInterrupt {
+				return "", nil
+			}
+			return "", err
+		}
+
+		switch {
+		case choice == browseUse:
+			return current, nil
+		case choice == browseUp:
+			current = parentFolder(current)
+		case choice == browseTyped:
+			return typed.Run()
+		default:
+			folder := folders[index-fixed]
+			if current == "" {
+				current = folder
+			} else {
+				current = filepath.Join(current, folder)
+			}
+		}
+	}
+}
+
+// parentFolder returns the folder above path, or "" for the drive list when path is a drive root
+func parentFolder(path string) string {
+	parent := filepath.Dir(path)
+	if parent == path {
+		return ""
+	}
+	return parent
+}
+
+// subfolders returns the names of the folders in dir, leaving out hidden and system ones such
+// as $Recycle.Bin
+func subfolders(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || strings.HasPrefix(name, "$") || strings.HasPrefix(name, ".") || name == "System Volume Information" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+	return names, nil
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// lockRetryWait is how often a held lock is tried again
+const lockRetryWait = 100 * time.Millisecond
+
+// AcquireLock takes an exclusive lock file next to path, shared by every instance of the tool,
+// and returns the function releasing it. A lock older than staleAge was left behind by a crashed
+// process and is taken over; after waiting wait for a live one, AcquireLock gives up.
+func AcquireLock(path string, wait, staleAge time.Duration) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(wait)
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleAge {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s (another instance may be using it)", lockPath)
+		}
+		time.Sleep(lockRetryWait)
+	}
+}
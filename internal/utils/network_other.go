@@ -0,0 +1,15 @@
+//go:build !windows
+
+package utils
+
+import "strings"
+
+// IsNetworkPath reports whether a path is a UNC share; mapped drives only exist on Windows
+func IsNetworkPath(path string) bool {
+	return strings.HasPrefix(path, `\\`) || strings.HasPrefix(path, "//")
+}
+
+// ResolveNetworkPath returns the path unchanged; mapped drives only exist on Windows
+func ResolveNetworkPath(path string) string {
+	return path
+}
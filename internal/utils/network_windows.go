@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	mpr                   = windows.NewLazySystemDLL("mpr.dll")
+	procWNetGetConnection = mpr.NewProc("WNetGetConnectionW")
+)
+
+// IsNetworkPath reports whether a path is a UNC share or on a mapped network drive
+func IsNetworkPath(path string) bool {
+	if strings.HasPrefix(path, `\\`) || strings.HasPrefix(path, "//") {
+		return true
+	}
+	volume := filepath.VolumeName(path)
+	if volume == "" {
+		return false
+	}
+	root, err := windows.UTF16PtrFromString(volume + `\`)
+	if err != nil {
+		return false
+	}
+	return windows.GetDriveType(root) == windows.DRIVE_REMOTE
+}
+
+// ResolveNetworkPath rewrites a path on a mapped network drive to its UNC form. Drive letters
+// differ between users and aren't visible to elevated processes, so engines on shares are
+// always recorded by UNC path. Other paths are returned unchanged.
+func ResolveNetworkPath(path string) string {
+	volume := filepath.VolumeName(path)
+	if len(volume) != 2 || volume[1] != ':' || !IsNetworkPath(path) {
+		return path
+	}
+	local, err := windows.UTF16PtrFromString(volume)
+	if err != nil {
+		return path
+	}
+	buf := make([]uint16, windows.MAX_PATH)
+	size := uint32(len(buf))
+	if ret, _, _ := procWNetGetConnection.Call(uintptr(unsafe.Pointer(local)), uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size))); ret != 0 {
+		return path
+	}
+	return filepath.Join(windows.UTF16ToString(buf), strings.TrimPrefix(path, volume))
+}
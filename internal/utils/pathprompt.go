@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/manifoldco/promptui"
+)
+
+// PathPrompt reads a filesystem path with history, Tab completion and validation
+type PathPrompt struct {
+	Label       string             // Prompt text, e.g. "Enter path to scan: "
+	HistoryFile string             // File holding previously accepted paths; empty disables history
+	Default     string             // Used when the input is left empty; without one, empty input cancels
+	Validate    func(string) error // Re-prompts with the error message until it returns nil
+}
+
+// Run prompts until a valid path is entered and returns it cleaned. Empty input without a
+// Default returns promptui.ErrAbort, and the end of input its error. In plain prompt mode the
+// path is read as a plain line, without history or completion.
+func (p PathPrompt) Run() (string, error) {
+	var rl *readline.Instance
+	if !plainPrompts {
+		var err error
+		rl, err = readline.NewEx(&readline.Config{
+			Prompt:                 p.Label,
+			HistoryFile:            p.HistoryFile,
+			DisableAutoSaveHistory: true,
+			HistorySearchFold:      true,
+			AutoComplete:           pathCompleter{},
+			Stdout:                 &BellSkipper{},
+		})
+		if err != nil {
+			return "", err
+		}
+		defer rl.Close()
+	}
+
+	for {
+		var input string
+		var err error
+		if rl != nil {
+			input, err = rl.Readline()
+		} else {
+			fmt.Print(p.Label)
+			input, err = stdin.ReadString('\n')
+			if err != nil && strings.TrimSpace(input) != "" {
+				err = nil
+			}
+		}
+		if err != nil {
+			return "", err
+		}
+
+		path := strings.Trim(strings.TrimSpace(input), "\"")
+		if path == "" {
+			if p.Default == "" {
+				return "", promptui.ErrAbort
+			}
+			fmt.Printf("No path entered, using: %s\n", p.Default)
+			path = p.Default
+		}
+		if path == "." {
+			if cwd, err := os.Getwd(); err == nil {
+				path = cwd
+			}
+		}
+		path = filepath.Clean(path)
+
+		if p.Validate != nil {
+			if err := p.Validate(path); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				continue
+			}
+		}
+
+		// Only accepted paths go into history so typos aren't suggested again
+		if p.HistoryFile != "" && rl != nil {
+			rl.SaveHistory(path)
+		}
+		return path, nil
+	}
+}
+
+// ValidateDirectory returns an error unless path is an existing directory
+func ValidateDirectory(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("path does not exist: %s", path)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("path is not a directory: %s", path)
+	}
+	return nil
+}
+
+// pathCompleter completes directory names for the path being typed
+type pathCompleter struct{}
+
+func (pathCompleter) Do(line []rune, pos int) ([][]rune, int) {
+	typed := strings.TrimLeft(string(line[:pos]), "\"")
+
+	// Split into the directory to list and the partial name being completed
+	cut := strings.LastIndexAny(typed, `\/`)
+	dir, partial := typed[:cut+1], typed[cut+1:]
+	listDir := dir
+	if listDir == "" {
+		listDir = "."
+	}
+
+	entries, err := os.ReadDir(listDir)
+	if err != nil {
+		return nil, 0
+	}
+
+	var candidates [][]rune
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || len(name) < len(partial) || !strings.EqualFold(name[:len(partial)], partial) {
+			continue
+		}
+		candidates = append(candidates, []rune(name[len(partial):]+string(filepath.Separator)))
+	}
+	return candidates, len([]rune(partial))
+}
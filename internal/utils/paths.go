@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NormalizePath returns a path in a canonical form for comparison: absolute, cleaned, without
+// trailing separators or extended-length prefixes, and with 8.3 short names expanded when the
+// path exists. Case is kept; use SamePath or PathKey to compare.
+func NormalizePath(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), "\"")
+	if path == "" {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(path, `\\?\UNC\`):
+		path = `\\` + path[len(`\\?\UNC\`):]
+	case strings.HasPrefix(path, `\\?\`), strings.HasPrefix(path, `\??\`):
+		path = path[len(`\\?\`):]
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return filepath.Clean(longPathName(path))
+}
+
+// PathKey returns a key that is equal for all spellings of the same path, for use in maps
+func PathKey(path string) string {
+	return strings.ToLower(NormalizePath(path))
+}
+
+// SamePath reports whether two paths name the same location, ignoring case as Windows does
+func SamePath(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	return PathKey(a) == PathKey(b)
+}
+
+// IsWritableDir reports whether files can be created in dir. A temporary file is created and
+// removed right away; installers put the exe under Program Files, where this fails for users.
+func IsWritableDir(dir string) bool {
+	file, err := os.CreateTemp(dir, ".ue-git-plugin-manager-*")
+	if err != nil {
+		return false
+	}
+	file.Close()
+	os.Remove(file.Name())
+	return true
+}
+
+// IsPackagedApp reports whether the exe runs from an MSIX package. Its folder is read-only, and
+// writes next to it would be redirected to a per-package location the user never sees. Packages
+// live in Program Files\WindowsApps, or in WindowsApps at the root of another drive.
+func IsPackagedApp(exeDir string) bool {
+	dir := strings.ToLower(filepath.Clean(exeDir)) + `\`
+	roots := []string{filepath.VolumeName(exeDir) + `\WindowsApps\`}
+	if programFiles := os.Getenv("ProgramFiles"); programFiles != "" {
+		roots = append(roots, filepath.Join(programFiles, "WindowsApps")+`\`)
+	}
+	for _, root := range roots {
+		if strings.HasPrefix(dir, strings.ToLower(root)) {
+			return true
+		}
+	}
+	return false
+}
+
+// DirSize returns the total size of the files under dir; unreadable entries are skipped
+func DirSize(dir string) int64 {
+	var size int64
+	filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		// Junctions and symlinks point elsewhere, e.g. into another worktree or an engine
+		if d.Type()&os.ModeSymlink != 0 || d.Type()&os.ModeIrregular != 0 {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info, err := d.Info(); err == nil && !d.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// FormatSize formats a size in bytes as KB, MB or GB
+func FormatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	}
+	return fmt.Sprintf("%d KB", bytes/(1<<10))
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package utils
+
+// longPathName returns the path unchanged; short names only exist on Windows
+func longPathName(path string) string {
+	return path
+}
+
+// Drives returns the filesystem root; only Windows has drive letters
+func Drives() []string {
+	return []string{"/"}
+}
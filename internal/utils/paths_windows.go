@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// longPathName expands 8.3 short names such as PROGRA~1; paths that don't exist are returned unchanged
+func longPathName(path string) string {
+	// 8.3 short names always contain a tilde
+	if !strings.Contains(path, "~") {
+		return path
+	}
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return path
+	}
+	buf := make([]uint16, windows.MAX_PATH)
+	for {
+		n, err := windows.GetLongPathName(p, &buf[0], uint32(len(buf)))
+		if err != nil || n == 0 {
+			return path
+		}
+		if int(n) < len(buf) {
+			return windows.UTF16ToString(buf[:n])
+		}
+		buf = make([]uint16, n)
+	}
+}
+
+// Drives returns the root of every drive letter, e.g. C:\
+func Drives() []string {
+	mask, err := windows.GetLogicalDrives()
+	if err != nil {
+		return nil
+	}
+	var drives []string
+	for i := 0; i < 26; i++ {
+		if mask&(1<<uint(i)) != 0 {
+			drives = append(drives, string(rune('A'+i))+`:\`)
+		}
+	}
+	return drives
+}
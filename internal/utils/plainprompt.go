@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+var plainPrompts bool
+
+// stdin is shared by the line-based prompts, so input typed ahead or piped in isn't lost in the
+// buffer of a reader that was thrown away
+var stdin = bufio.NewReader(os.Stdin)
+
+// ReadLine reads the next line typed at a prompt, without surrounding spaces. It returns an empty
+// string once the input has ended.
+func ReadLine() string {
+	line, _ := stdin.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// SetPlainPrompts turns the screen reader friendly prompt mode on or off. Lists become numbered
+// text read as a line, and nothing on the screen is redrawn or cleared.
+func SetPlainPrompts(enabled bool) {
+	plainPrompts = enabled
+}
+
+// PlainPrompts reports whether plain prompt mode is on
+func PlainPrompts() bool {
+	return plainPrompts
+}
+
+// RunSelect runs a choice prompt. In plain prompt mode the items are printed as a numbered list
+// and a line is read instead, since screen readers can't follow promptui redrawing the list.
+func RunSelect(prompt *promptui.Select) (int, string, error) {
+	// promptui misbehaves on a list without items; there is nothing to choose, so it counts as backing out
+	if len(selectItems(prompt.Items)) == 0 {
+		return -1, "", promptui.ErrAbort
+	}
+	if !plainPrompts {
+		return prompt.Run()
+	}
+	return runPlainSelect(prompt)
+}
+
+// runPlainSelect asks for a choice as a line of text: the item's number, 0 to go back, Enter for
+// the preselected item, or text that filters the items the way the list's search would
+func runPlainSelect(prompt *promptui.Select) (int, string, error) {
+	items := selectItems(prompt.Items)
+	preselected := prompt.CursorPos
+	if preselected < 0 || preselected >= len(items) {
+		preselected = 0
+	}
+	matches := func(input string, index int) bool {
+		if prompt.Searcher != nil {
+			return prompt.Searcher(input, index)
+		}
+		return strings.Contains(strings.ToLower(items[index]), strings.ToLower(input))
+	}
+
+	shown := allIndexes(len(items))
+	for {
+		fmt.Println()
+		fmt.Println(fmt.Sprint(prompt.Label))
+		for n, index := range shown {
+			fmt.Printf("%d. %s\n", n+1, strings.TrimSpace(items[index]))
+		}
+		fmt.Printf("Enter a number, text to filter, 0 to go back, or nothing for %s: ", strings.TrimSpace(items[preselected]))
+
+		line, err := stdin.ReadString('\n')
+		input := strings.TrimSpace(line)
+		if err != nil && input == "" {
+			return -1, "", promptui.ErrInterrupt
+		}
+
+		if input == "" {
+			return preselected, items[preselected], nil
+		}
+		if input == "0" {
+			return -1, "", promptui.ErrInterrupt
+		}
+		if n, err := strconv.Atoi(input); err == nil {
+			if n >= 1 && n <= len(shown) {
+				return shown[n-1], items[shown[n-1]], nil
+			}
+			fmt.Printf("There is no item %d.\n", n)
+			continue
+		}
+
+		var filtered []int
+		for index := range items {
+			if matches(input, index) {
+				filtered = append(filtered, index)
+			}
+		}
+		switch len(filtered) {
+		case 0:
+			fmt.Printf("Nothing matches %q.\n", input)
+			shown = allIndexes(len(items))
+		case 1:
+			return filtered[0], items[filtered[0]], nil
+		default:
+			fmt.Printf("%d items match %q.\n", len(filtered), input)
+			shown = filtered
+		}
+	}
+}
+
+// selectItems returns a prompt's items as the text promptui shows for them
+func selectItems(items interface{}) []string {
+	if texts, ok := items.([]string); ok {
+		return texts
+	}
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice {
+		return nil
+	}
+	texts := make([]string, value.Len())
+	for i := range texts {
+		texts[i] = fmt.Sprintf("%v", value.Index(i).Interface())
+	}
+	return texts
+}
+
+// allIndexes returns 0 to n-1
+func allIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
@@ -1,15 +1,17 @@
 package utils
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"runtime"
 	"strings"
 	"unicode"
 
 	"github.com/fatih/color"
+
+	"ue-git-plugin-manager/internal/console"
+	"ue-git-plugin-manager/internal/runner"
 )
 
 // BellSkipper is an io.WriteCloser that skips the bell character (ASCII 7)
@@ -23,7 +25,10 @@ func (b *BellSkipper) Write(p []byte) (n int, err error) {
 			filtered = append(filtered, b)
 		}
 	}
-	return os.Stdout.Write(filtered)
+	if _, err := os.Stdout.Write(filtered); err != nil {
+		return 0, err
+	}
+	return len(p), nil
 }
 
 func (b *BellSkipper) Close() error {
@@ -48,12 +53,28 @@ func New() *Manager {
 	return &Manager{}
 }
 
-// Confirm asks the user for confirmation
+// Confirm asks the user for confirmation. Enter answers no, or the answer configured for the
+// question in prompt_defaults. The end of input, as when stdin is closed or piped, answers no.
 func Confirm(message string) bool {
-	fmt.Printf("%s (y/N): ", message)
-	reader := bufio.NewReader(os.Stdin)
-	response, _ := reader.ReadString('\n')
+	return confirm(message, confirmDefault(message))
+}
+
+// confirm asks a yes/no question whose answer on Enter is yes
+func confirm(message string, yes bool) bool {
+	if yes {
+		fmt.Printf("%s (Y/n): ", message)
+	} else {
+		fmt.Printf("%s (y/N): ", message)
+	}
+	response, err := stdin.ReadString('\n')
 	response = strings.ToLower(strings.TrimSpace(response))
+	if response == "" {
+		if err != nil {
+			fmt.Println()
+			return false
+		}
+		return yes
+	}
 	return response == "y" || response == "yes"
 }
 
@@ -64,8 +85,9 @@ func OpenURL(url string) error {
 
 	switch runtime.GOOS {
 	case "windows":
-		cmd = "cmd"
-		args = []string{"/c", "start", url}
+		// cmd's start would cut the URL at the first '&' of a query string
+		cmd = "rundll32"
+		args = []string{"url.dll,FileProtocolHandler", url}
 	case "darwin":
 		cmd = "open"
 		args = []string{url}
@@ -76,7 +98,7 @@ func OpenURL(url string) error {
 		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
 	}
 
-	return exec.Command(cmd, args...).Start()
+	return runner.Start("", cmd, args...)
 }
 
 // IsWindows checks if running on Windows
@@ -90,26 +112,20 @@ func FormatPath(path string) string {
 	return strings.ReplaceAll(path, "\\", "/")
 }
 
-// TruncateString truncates a string to the specified length
+// TruncateString truncates a string to the specified display width
 func TruncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return console.Truncate(s, maxLen)
 }
 
-// PadString pads a string to the specified width
+// PadString pads a string to the specified display width
 func PadString(s string, width int) string {
-	if len(s) >= width {
-		return s
-	}
-	return s + strings.Repeat(" ", width-len(s))
+	return console.Pad(s, width)
 }
 
 // Pause waits for user input
 func Pause() {
 	fmt.Print("Press Enter to continue...")
-	bufio.NewReader(os.Stdin).ReadLine()
+	stdin.ReadString('\n')
 }
 
 // IsRunningAsAdmin checks if the application is running with administrator privileges
@@ -148,6 +164,7 @@ func RequestAdminElevation() {
 	fmt.Println("4. Try again")
 	fmt.Println()
 	Pause()
+	console.Reset()
 	os.Exit(1)
 }
 
@@ -156,16 +173,18 @@ func (m *Manager) ClearScreen() {
 	ClearScreen()
 }
 
-// ClearScreen clears the terminal screen
+// ClearScreen clears the terminal screen, except in plain prompt mode where a screen reader
+// would lose what was read out
 func ClearScreen() {
-	var cmd *exec.Cmd
+	if plainPrompts {
+		fmt.Println()
+		return
+	}
+	cmd := runner.Command{Name: "clear", Stdout: os.Stdout}
 	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/c", "cls")
-	} else {
-		cmd = exec.Command("clear")
+		cmd = runner.Command{Name: "cmd", Args: []string{"/c", "cls"}, Stdout: os.Stdout}
 	}
-	cmd.Stdout = os.Stdout
-	cmd.Run()
+	runner.Run(context.Background(), cmd)
 }
 
 // HasNonASCIICharacters checks if a path contains any non-ASCII characters
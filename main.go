@@ -4,43 +4,99 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"ue-git-plugin-manager/internal/cli"
 	"ue-git-plugin-manager/internal/config"
+	"ue-git-plugin-manager/internal/console"
+	"ue-git-plugin-manager/internal/crash"
 	"ue-git-plugin-manager/internal/detection"
 	"ue-git-plugin-manager/internal/engine"
+	"ue-git-plugin-manager/internal/events"
 	"ue-git-plugin-manager/internal/git"
+	"ue-git-plugin-manager/internal/logging"
 	"ue-git-plugin-manager/internal/menu"
 	"ue-git-plugin-manager/internal/plugin"
+	"ue-git-plugin-manager/internal/projectconfig"
+	"ue-git-plugin-manager/internal/runner"
+	"ue-git-plugin-manager/internal/selfupdate"
+	"ue-git-plugin-manager/internal/telemetry"
 	"ue-git-plugin-manager/internal/utils"
 )
 
 func main() {
+	// A panic writes a crash report and waits for the user instead of closing the console window.
+	// Subcommands may run from Task Scheduler with no one at the console, so they never wait.
+	defer crash.Recover()
+	crash.SetInteractive(!hasCommand(os.Args[1:]))
+
+	// Emoji and box characters need a UTF-8 console; legacy code pages fall back to text symbols.
+	// The console's code page is put back on exit for the shell that started the tool.
+	console.Init()
+	defer console.Reset()
+
+	// Ctrl+C stops the git command or build running at the time, with everything it started,
+	// rather than the tool
+	runner.HandleInterrupts()
+
+	// Progress the managers report is printed for the user, or with --json-events written to
+	// stderr as JSON lines for a front end, so stdout stays free for a command's own output
+	renderer := events.Console(os.Stdout)
+	for _, arg := range os.Args[1:] {
+		switch arg {
+		case "--json-events":
+			renderer = events.JSONLines(os.Stderr)
+		case "--verbose":
+			events.SetVerbose(true)
+		case "--quiet":
+			events.SetQuiet(true)
+		}
+	}
+	events.Subscribe(renderer)
+
 	// Get the directory where the executable is located
 	exePath, err := os.Executable()
 	if err != nil {
 		fmt.Printf("Error getting executable path: %v\n", err)
-		os.Exit(1)
+		pauseIfOwnWindow()
+		exit(1)
 	}
 	exeDir := filepath.Dir(exePath)
 
-	// Change to the executable directory to ensure all relative paths work correctly
+	// The exe a self-update replaced is only deleted once the new one runs
+	selfupdate.CleanUp(exePath)
+
+	// Initialize the application
+	configMgr := config.New(exeDir)
+	baseDir := configMgr.GetBaseDir()
+
+	// A portable exe works from its own folder; an installed one (Program Files, MSIX) may not
+	// write there, so it stays in the directory it was started from and writes to baseDir only
 	originalDir, err := os.Getwd()
 	if err != nil {
 		fmt.Printf("Warning: Could not get current directory: %v\n", err)
-	} else {
-		// Only change directory if we're not already in the executable directory
-		if originalDir != exeDir {
-			if err := os.Chdir(exeDir); err != nil {
-				fmt.Printf("Warning: Could not change to executable directory: %v\n", err)
-				fmt.Printf("Current directory: %s\n", originalDir)
-				fmt.Printf("Executable directory: %s\n", exeDir)
-			}
+	} else if configMgr.IsPortable() && originalDir != exeDir {
+		if err := os.Chdir(exeDir); err != nil {
+			fmt.Printf("Warning: Could not change to executable directory: %v\n", err)
+			fmt.Printf("Current directory: %s\n", originalDir)
+			fmt.Printf("Executable directory: %s\n", exeDir)
 		}
 	}
+	projectconfig.SetLogsDir(configMgr.GetLogsDir())
 
-	// Initialize the application
-	configMgr := config.New(exeDir)
-	baseDir := configMgr.GetBaseDir()
+	// Git transcripts go to the log so failures can be diagnosed after the fact
+	configMgr.MoveLegacyLog()
+	if err := logging.Open(configMgr.GetLogFile()); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	if _, err := logging.OpenRun(configMgr.GetRunLogsDir()); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	defer logging.Close()
+	logging.Printf("Started %s %s", filepath.Base(exePath), logging.Redact(strings.Join(os.Args[1:], " ")))
+	events.Subscribe(events.Log())
+	crash.Configure(configMgr.GetLogsDir(), configMgr.GetLogFile(), configMgr.Snapshot)
 
 	app := &Application{
 		ExeDir:    exeDir,
@@ -52,16 +108,117 @@ func main() {
 		Detection: detection.NewWithBaseDir(exeDir, baseDir),
 	}
 
+	// Machines without Git for Windows use the portable MinGit when it has been installed
+	app.Git.UseBundledGit()
+	app.Git.UseBundledGitLFS()
+
 	// Note: Admin privileges are not required for junction creation on modern Windows
 
 	// Note: No relocation check needed since we now use a fixed base directory
 	// based on the user's config directory, which doesn't change with executable location
 
+	// --viewer opens the read-only menu, --yes answers every confirmation, --plain uses numbered
+	// prompts and the output flags were handled above; strip them so the remaining arguments are
+	// handled as usual
+	args := []string{os.Args[0]}
+	viewer := false
+	for _, arg := range os.Args[1:] {
+		if arg == "--viewer" {
+			viewer = true
+			menu.SetReadOnly(true)
+			cli.SetReadOnly(true)
+			continue
+		}
+		if arg == "--yes" {
+			utils.SetAssumeYes(true)
+			continue
+		}
+		if arg == "--plain" {
+			utils.SetPlainPrompts(true)
+			continue
+		}
+		if arg == "--json-events" || arg == "--verbose" || arg == "--quiet" {
+			continue
+		}
+		args = append(args, arg)
+	}
+
+	// Releases before the per-user data directory kept the repository and worktrees next to the
+	// exe; they are moved over once and the engines' links follow them
+	if !viewer {
+		if err := menu.MigrateLegacyLayout(app); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+
+	// A project or engine folder passed as the only argument (e.g. dropped onto the exe)
+	// opens its flow directly before continuing to the main menu
+	if path, ok := argumentPath(originalDir, args); ok {
+		if err := menu.RunForPath(app, path); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			utils.Pause()
+		}
+		app.GetUtils().ClearScreen()
+	} else if len(args) > 1 {
+		// Subcommands run non-interactively and report their result through the exit code. A
+		// scheduled task owns its console too, so they never wait for Enter on an error.
+		code := cli.Run(app, args[1:])
+		telemetry.Wait(telemetryExitWait)
+		exit(code)
+	}
+
 	// Run the main menu
-	if err := menu.Run(app); err != nil {
+	err = menu.Run(app)
+	telemetry.Wait(telemetryExitWait)
+	if err != nil {
 		fmt.Printf("Error running application: %v\n", err)
-		os.Exit(1)
+		pauseIfOwnWindow()
+		exit(1)
+	}
+}
+
+// exit ends the tool with code; os.Exit skips deferred calls, so the console is reset first
+func exit(code int) {
+	console.Reset()
+	os.Exit(code)
+}
+
+// telemetryExitWait is how long an outcome still being sent may delay exiting; it stays queued
+// for the next run otherwise
+const telemetryExitWait = 3 * time.Second
+
+// pauseIfOwnWindow waits for Enter when the menu was started from Explorer, whose console window
+// closes with the process and would take an error message with it. Subcommands never wait.
+func pauseIfOwnWindow() {
+	if console.OwnWindow() && !hasCommand(os.Args[1:]) {
+		utils.Pause()
+	}
+}
+
+// hasCommand reports whether the arguments name a subcommand rather than opening the menu
+func hasCommand(args []string) bool {
+	for _, arg := range args {
+		if cli.IsCommand(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// argumentPath returns the existing path passed as the only argument, resolved against
+// the directory the program was started from
+func argumentPath(workingDir string, args []string) (string, bool) {
+	if len(args) != 2 || cli.IsCommand(args[1]) {
+		return "", false
+	}
+	path := strings.Trim(args[1], "\"")
+	if !filepath.IsAbs(path) && workingDir != "" {
+		path = filepath.Join(workingDir, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", false
 	}
+	return filepath.Clean(path), true
 }
 
 // Application holds all the components